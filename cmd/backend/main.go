@@ -5,7 +5,6 @@ import (
 	"log/slog"
 	"os"
 	"os/signal"
-	"path"
 	"runtime/pprof"
 
 	"github.com/Pineapple217/cvrs/pkg/config"
@@ -46,20 +45,23 @@ func main() {
 
 			conf, err := config.Load()
 			util.MaybeDie(err, "Failed to laod config")
+			err = conf.Auth.EnsureSecret(conf.Server.Debug)
+			util.MaybeDie(err, "Refusing to start")
 
-			db, err := database.NewDatabase(conf.Database)
+			db, err := database.NewDatabase(conf.Database, conf.Storage)
 			util.MaybeDieErr(err)
 
-			wf := worker.NewWorkforce(conf.Workforce, db)
+			wf := worker.NewWorkforce(conf.Workforce, conf.Backup, conf.Images, db)
 			err = wf.Start()
 			util.MaybeDie(err, "Failed to start workforce")
 			defer wf.Stop()
 
-			h := handler.NewHandler(db)
+			h, err := handler.NewHandler(db, conf)
+			util.MaybeDieErr(err)
 
-			server := server.NewServer()
-			server.RegisterRoutes(h, path.Join(conf.Database.DataLocation, database.IMG_DIR))
-			server.ApplyMiddleware(true)
+			server := server.NewServer(conf.Server, conf.Auth)
+			server.RegisterRoutes(h)
+			server.ApplyMiddleware(db)
 			server.Start()
 			defer server.Stop()
 
@@ -97,6 +99,9 @@ func main() {
 	rootCmd.AddCommand(cmdRun)
 	rootCmd.AddCommand(users.GetCmd())
 	rootCmd.AddCommand(database.GetBackupCmd())
+	rootCmd.AddCommand(database.GetRestoreCmd())
+	rootCmd.AddCommand(database.GetSearchCmd())
+	rootCmd.AddCommand(database.GetImagesCmd())
 	if err := rootCmd.Execute(); err != nil {
 		slog.Error("Command execution failed", "error", err)
 		os.Exit(1)
@@ -1,2 +1,3 @@
 declare const __BACKEND_URL__: string;
 declare const __JWT_LOCALSTORAGE__: string;
+declare const __REFRESH_LOCALSTORAGE__: string;
@@ -19,17 +19,14 @@ export function ArtistsList() {
     queryKey: ["artists"],
     staleTime: 10 * 60 * 1000,
     queryFn: async ({ pageParam }) =>
-      getArtists(token, FETCH_COUNT * pageParam, FETCH_COUNT),
-    initialPageParam: 0,
-    getNextPageParam: (lastPage, pages, lastPageParam) => {
-      if (lastPage.length === 0) return null;
-      return lastPageParam + 1;
-    },
+      getArtists(token, pageParam, FETCH_COUNT),
+    initialPageParam: null,
+    getNextPageParam: (lastPage) => lastPage.next,
   });
 
   let len = 50;
   if (data) {
-    len -= data.pages.reduce((sum, inner) => sum + inner.length, 0);
+    len -= data.pages.reduce((sum, inner) => sum + inner.artists.length, 0);
   }
   const placeholders = Array.from({ length: len }, (_, i) => i + 1);
 
@@ -63,25 +60,29 @@ export function ArtistsList() {
   return (
     <div class="artist-grid">
       {status === "success" &&
-        data.pages.map((artists, i) => (
+        data.pages.map((page, i) => (
           <>
-            {artists.map((artist) => {
+            {page.artists.map((artist) => {
               let processedImage =
                 artist.edges.image.edges.proccesed_image.find(
-                  (a) => a.dimentions === 265
+                  (a) => a.variant === "small"
                 );
               if (!processedImage) {
                 processedImage = artist.edges.image.edges.proccesed_image[0];
               }
+              const imageData = artist.edges.image.edges.data;
               return (
                 <div key={artist.id}>
                   <a href={`/artist/${artist.id}`}>
                     <span>{artist.name}</span>
                     <img
                       loading="lazy"
-                      src={__BACKEND_URL__ + "/i/" + processedImage.id}
+                      src={__BACKEND_URL__ + "/i/" + processedImage.file}
                       style={{
-                        backgroundImage: `url(${processedImage.thumb})`,
+                        backgroundImage:
+                          imageData?.placeholder &&
+                          `url(${imageData.placeholder})`,
+                        backgroundColor: imageData?.placeholder_color,
                       }}
                       alt={`picture of ${artist.name}`}
                     />
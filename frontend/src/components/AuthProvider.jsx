@@ -15,7 +15,7 @@ import { useLocation } from "preact-iso";
 /**
  * @typedef {Object} AuthContextType
  * @property {string | null} token - Current JWT token
- * @property {(token: string) => void} setToken - Function to update the JWT token
+ * @property {(token: string, refreshToken?: string) => void} setToken - Function to update the JWT token and the refresh token of its session
  * @property {JwtPayload | null} payload - Decoded payload of the token
  */
 
@@ -47,9 +47,13 @@ export function AuthProvider({ children }) {
   /**
    * Update token in state and sessionStorage
    * @param {string} newToken - JWT token string
+   * @param {string} [refreshToken] - Refresh token of the session, kept to get a new JWT once it expires
    */
-  const setToken = (newToken) => {
+  const setToken = (newToken, refreshToken) => {
     localStorage.setItem(__JWT_LOCALSTORAGE__, newToken);
+    if (refreshToken) {
+      localStorage.setItem(__REFRESH_LOCALSTORAGE__, refreshToken);
+    }
     setTokenState(newToken);
   };
 
@@ -58,8 +62,18 @@ export function AuthProvider({ children }) {
     if (stored && isTokenValid(stored)) {
       setTokenState(stored);
       setPayload(decodePayload(stored));
+      setLoading(false);
+      return;
     }
-    setLoading(false);
+    // The JWT expired, the session can still be alive
+    refreshSession()
+      .then((newToken) => {
+        if (newToken) {
+          setTokenState(newToken);
+          setPayload(decodePayload(newToken));
+        }
+      })
+      .finally(() => setLoading(false));
   }, []);
 
   useEffect(() => {
@@ -89,6 +103,35 @@ export function useAuth() {
   return useContext(AuthContext);
 }
 
+/**
+ * Exchange the stored refresh token for a new JWT and refresh token. A refresh
+ * token only works once, so the new one replaces it right away.
+ * @returns {Promise<string | null>} The new JWT, null when the session ended
+ */
+async function refreshSession() {
+  const refreshToken = localStorage.getItem(__REFRESH_LOCALSTORAGE__);
+  if (!refreshToken) {
+    return null;
+  }
+  try {
+    const response = await fetch(__BACKEND_URL__ + "/auth/refresh", {
+      method: "POST",
+      headers: { "Content-Type": "application/json" },
+      body: JSON.stringify({ refreshToken }),
+    });
+    if (!response.ok) {
+      localStorage.removeItem(__REFRESH_LOCALSTORAGE__);
+      return null;
+    }
+    const { token, refreshToken: next } = await response.json();
+    localStorage.setItem(__JWT_LOCALSTORAGE__, token);
+    localStorage.setItem(__REFRESH_LOCALSTORAGE__, next);
+    return token;
+  } catch {
+    return null;
+  }
+}
+
 /**
  * Decode JWT and check if it's expired
  * @param {string} token
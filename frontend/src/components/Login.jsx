@@ -1,4 +1,4 @@
-import { useState } from "preact/hooks";
+import { useEffect, useState } from "preact/hooks";
 import { useAuth } from "./AuthProvider";
 
 export function Login() {
@@ -6,6 +6,32 @@ export function Login() {
   const [username, setUsername] = useState("");
   const [password, setPassword] = useState("");
   const [error, setError] = useState(null);
+  const [methods, setMethods] = useState({ password: true, oidc: false });
+
+  const redirect = new URLSearchParams(window.location.search).get("r") || "/";
+
+  useEffect(() => {
+    // Single sign-on comes back here with the tokens or an error in the hash
+    const hash = new URLSearchParams(window.location.hash.slice(1));
+    if (hash.has("token")) {
+      setToken(hash.get("token"), hash.get("refreshToken"));
+      window.location.replace(redirect);
+      return;
+    }
+    if (hash.has("error")) {
+      setError(hash.get("error"));
+      history.replaceState(
+        null,
+        "",
+        window.location.pathname + window.location.search
+      );
+    }
+
+    fetch(__BACKEND_URL__ + "/auth/methods")
+      .then((r) => r.json())
+      .then(setMethods)
+      .catch(() => {});
+  }, []);
 
   /**
    * Handle form submission
@@ -21,11 +47,8 @@ export function Login() {
         body: JSON.stringify({ username, password }),
       });
       if (!response.ok) throw new Error("Invalid credentials");
-      const { token } = await response.json();
-      setToken(token);
-
-      const redirect =
-        new URLSearchParams(window.location.search).get("r") || "/";
+      const { token, refreshToken } = await response.json();
+      setToken(token, refreshToken);
       window.location.replace(redirect);
     } catch (err) {
       setError(err.message);
@@ -51,32 +74,45 @@ export function Login() {
   return (
     <div class="container" style="max-width: 600px;">
       <div class="box" style="margin-top: 5rem;">
-        <form onSubmit={handleSubmit}>
-          <h2>Login</h2>
-
-          <label htmlFor="username">Username</label>
-          <input
-            id="username"
-            name="username"
-            type="text"
-            value={username}
-            autoFocus
-            onInput={handleUsernameInput}
-            required
-          />
+        <h2>Login</h2>
+        {methods.password && (
+          <form onSubmit={handleSubmit}>
+            <label htmlFor="username">Username</label>
+            <input
+              id="username"
+              name="username"
+              type="text"
+              value={username}
+              autoFocus
+              onInput={handleUsernameInput}
+              required
+            />
 
-          <label htmlFor="password">Password</label>
-          <input
-            id="password"
-            name="password"
-            type="password"
-            value={password}
-            onInput={handlePasswordInput}
-            required
-          />
+            <label htmlFor="password">Password</label>
+            <input
+              id="password"
+              name="password"
+              type="password"
+              value={password}
+              onInput={handlePasswordInput}
+              required
+            />
 
-          <button type="submit">Log In</button>
-        </form>
+            <button type="submit">Log In</button>
+          </form>
+        )}
+        {methods.oidc && (
+          <a
+            class="button"
+            href={
+              __BACKEND_URL__ +
+              "/auth/oidc/login?r=" +
+              encodeURIComponent(redirect)
+            }
+          >
+            Log in with single sign-on
+          </a>
+        )}
       </div>
       {error && <div class="alert alert-danger">{error}</div>}
     </div>
@@ -1,5 +1,3 @@
-import { thumbHashToDataURL } from "thumbhash";
-
 /**
  * @typedef {Object} ArtistsAddData
  * @property {string} name
@@ -48,19 +46,27 @@ export async function ArtistsAdd(d, loading, error, token) {
 /**
  * @typedef {Object} ProcessedImage
  * @property {string} id
+ * @property {string} file
+ * @property {string} variant
  * @property {string} type
- * @property {number} dimentions
+ * @property {number} width
+ * @property {number} height
  * @property {number} size_bits
- * @property {string} thumb
  * @property {Date} created_at
  * @property {Date} updated_at
  * @property {Object} edges
  */
 
+/**
+ * @typedef {Object} ImageData
+ * @property {string} [placeholder] PNG data URI to show while the image loads
+ * @property {string} [placeholder_color] average color as #rrggbb
+ */
+
 /**
  * @typedef {Object} ImageEdge
  * @property {string} id
- * @property {{ proccesed_image: ProcessedImage[] }} edges
+ * @property {{ proccesed_image: ProcessedImage[], data?: ImageData }} edges
  */
 
 /**
@@ -78,23 +84,33 @@ export async function ArtistsAdd(d, loading, error, token) {
  */
 
 /**
- * @returns {Promise<Artist[]>}
+ * @typedef {Object} ArtistsPage
+ * @property {Artist[]} artists
+ * @property {string|null} next
  */
-export const getArtists = async (token, offset, limit) => {
-  const response = await fetch(
-    __BACKEND_URL__ + `/artists?offset=${offset}&limit=${limit}`,
-    {
-      headers: {
-        Authorization: `Bearer ${token}`,
-      },
-    }
-  );
 
-  /** @type {{ limit: number, offset: number, Artist: Array<Omit<Artist, "created_at" | "updated_at" | "edges"> & { created_at: string, updated_at: string, edges: { image: Omit<ImageEdge, "edges"> & { edges: { proccesed_image: Array<Omit<ProcessedImage, "created_at" | "updated_at"> & { created_at: string, updated_at: string }> } } } }> }} */
+/**
+ * @param {string} token
+ * @param {string|null} after cursor returned as `next` by the previous page
+ * @param {number} limit
+ * @returns {Promise<ArtistsPage>}
+ */
+export const getArtists = async (token, after, limit) => {
+  const params = new URLSearchParams({ limit: String(limit) });
+  if (after) {
+    params.set("after", after);
+  }
+  const response = await fetch(__BACKEND_URL__ + `/artists?${params}`, {
+    headers: {
+      Authorization: `Bearer ${token}`,
+    },
+  });
+
+  /** @type {{ limit: number, next: string|null, prev: string|null, artists: Array<Omit<Artist, "created_at" | "updated_at" | "edges"> & { created_at: string, updated_at: string, edges: { image: Omit<ImageEdge, "edges"> & { edges: { proccesed_image: Array<Omit<ProcessedImage, "created_at" | "updated_at"> & { created_at: string, updated_at: string }>, data?: ImageData } } } }> }} */
   const raw = await response.json();
 
   /** @type {Artist[]} */
-  const artists = raw.Artist.map((artist) => ({
+  const artists = raw.artists.map((artist) => ({
     ...artist,
     created_at: new Date(artist.created_at),
     updated_at: new Date(artist.updated_at),
@@ -105,18 +121,18 @@ export const getArtists = async (token, offset, limit) => {
           proccesed_image: artist.edges.image.edges.proccesed_image.map(
             (img) => ({
               ...img,
-              thumb: thumbHashToDataURL(base64ToArrayLike(img.thumb)),
               created_at: new Date(img.created_at),
               updated_at: new Date(img.updated_at),
               edges: img.edges,
             })
           ),
+          data: artist.edges.image.edges.data,
         },
       },
     },
   }));
 
-  return artists;
+  return { artists, next: raw.next };
 };
 
 /**
@@ -148,18 +164,9 @@ export const getArtist = async (token, id) => {
               edges: img.edges,
             })
           ),
+          data: artist.edges.image.edges.data,
         },
       },
     },
   };
 };
-
-function base64ToArrayLike(base64) {
-  const binaryString = atob(base64);
-  const len = binaryString.length;
-  const bytes = new Uint8Array(len);
-  for (let i = 0; i < len; i++) {
-    bytes[i] = binaryString.charCodeAt(i);
-  }
-  return bytes;
-}
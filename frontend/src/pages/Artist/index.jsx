@@ -39,9 +39,11 @@ export function Artist() {
             src={
               __BACKEND_URL__ +
               "/i/" +
-              artist.edges.image.edges.proccesed_image.find(
-                (a) => a.dimentions === 1024
-              ).id
+              (
+                artist.edges.image.edges.proccesed_image.find(
+                  (a) => a.variant === "large"
+                ) ?? artist.edges.image.edges.proccesed_image[0]
+              ).file
             }
             alt=""
           />
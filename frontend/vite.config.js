@@ -8,5 +8,6 @@ export default defineConfig(({ command }) => ({
       command === "serve" ? "http://localhost:3000/api" : "/api"
     ),
     __JWT_LOCALSTORAGE__: JSON.stringify("cvrs_auth_token"),
+    __REFRESH_LOCALSTORAGE__: JSON.stringify("cvrs_refresh_token"),
   },
 }));
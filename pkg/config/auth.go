@@ -0,0 +1,111 @@
+package config
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+)
+
+const minSecretLen = 16
+
+type Auth struct {
+	Secret string `yaml:"secret"`
+	// File with the current secret on the first line and previous secrets on
+	// the lines after it, used instead of secret and previousSecrets
+	SecretFile    string        `yaml:"secretFile"`
+	TokenLifetime time.Duration `yaml:"tokenLifetime"`
+	// How long a session can be kept alive with its refresh token
+	RefreshLifetime time.Duration `yaml:"refreshLifetime"`
+	// Old secrets that are still accepted to verify tokens, but never used to
+	// sign new ones. Drop them once tokenLifetime has passed after a rotation.
+	PreviousSecrets []string `yaml:"previousSecrets"`
+	// Failed logins allowed for a username or IP before it gets locked out
+	MaxLoginAttempts int `yaml:"maxLoginAttempts"`
+	// First lockout, it doubles with every failure after that
+	LockoutDuration    time.Duration `yaml:"lockoutDuration"`
+	MaxLockoutDuration time.Duration `yaml:"maxLockoutDuration"`
+	// Turn off to only allow single sign-on through oidc
+	PasswordLogin bool `yaml:"passwordLogin"`
+}
+
+func (c *Auth) SetDefault() {
+	c.TokenLifetime = 24 * time.Hour
+	c.RefreshLifetime = 30 * 24 * time.Hour
+	c.PreviousSecrets = []string{}
+	c.MaxLoginAttempts = 5
+	c.LockoutDuration = time.Minute
+	c.MaxLockoutDuration = time.Hour
+	c.PasswordLogin = true
+}
+
+func (c *Auth) Validate() error {
+	if c.SecretFile != "" {
+		data, err := os.ReadFile(c.SecretFile)
+		if err != nil {
+			return fmt.Errorf("auth.secretFile: %w", err)
+		}
+		lines := []string{}
+		for _, line := range strings.Split(string(data), "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				lines = append(lines, line)
+			}
+		}
+		if len(lines) == 0 {
+			return fmt.Errorf("auth.secretFile: %s is empty", c.SecretFile)
+		}
+		c.Secret = lines[0]
+		c.PreviousSecrets = lines[1:]
+	}
+	if c.Secret != "" && len(c.Secret) < minSecretLen {
+		return fmt.Errorf("auth.secret: must be at least %d characters", minSecretLen)
+	}
+	if c.TokenLifetime <= 0 {
+		return fmt.Errorf("auth.tokenLifetime: must be positive")
+	}
+	if c.RefreshLifetime < c.TokenLifetime {
+		return fmt.Errorf("auth.refreshLifetime: can not be shorter than auth.tokenLifetime")
+	}
+	if c.MaxLoginAttempts < 1 {
+		return fmt.Errorf("auth.maxLoginAttempts: must be at least 1")
+	}
+	if c.LockoutDuration <= 0 {
+		return fmt.Errorf("auth.lockoutDuration: must be positive")
+	}
+	if c.MaxLockoutDuration < c.LockoutDuration {
+		return fmt.Errorf("auth.maxLockoutDuration: can not be shorter than auth.lockoutDuration")
+	}
+	return nil
+}
+
+// Makes sure there is a secret to sign tokens with. In dev mode a random one is
+// generated, which invalidates all tokens on every restart.
+func (c *Auth) EnsureSecret(dev bool) error {
+	if c.Secret != "" {
+		return nil
+	}
+	if !dev {
+		return errors.New("no auth secret configured, set auth.secret or auth.secretFile")
+	}
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	if err != nil {
+		return err
+	}
+	c.Secret = base64.RawURLEncoding.EncodeToString(b)
+	slog.Warn("No auth secret configured, using a random one")
+	return nil
+}
+
+// Keys that are accepted to verify a token, the current one first
+func (c *Auth) VerificationKeys() [][]byte {
+	keys := [][]byte{[]byte(c.Secret)}
+	for _, s := range c.PreviousSecrets {
+		keys = append(keys, []byte(s))
+	}
+	return keys
+}
@@ -0,0 +1,75 @@
+package config
+
+import (
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAuthValidate(t *testing.T) {
+	dir := t.TempDir()
+	secretFile := func(name, content string) string {
+		p := path.Join(dir, name)
+		err := os.WriteFile(p, []byte(content), 0600)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+	rotated := secretFile("rotated", "current-secret-123\n\n  previous-secret-12  \nolder-secret-1234\n")
+	empty := secretFile("empty", "\n \n")
+	short := secretFile("short", "short\n")
+
+	tests := []struct {
+		name   string
+		update func(*Auth)
+		// Start of the error, empty when the config is valid
+		err      string
+		secret   string
+		previous []string
+	}{
+		{"defaults", func(c *Auth) {}, "", "", []string{}},
+		{"secret", func(c *Auth) { c.Secret = "long-enough-secret" }, "", "long-enough-secret", []string{}},
+		{"short secret", func(c *Auth) { c.Secret = "short" }, "auth.secret", "", nil},
+		{"secret file", func(c *Auth) { c.SecretFile = rotated }, "", "current-secret-123", []string{"previous-secret-12", "older-secret-1234"}},
+		// The file wins over the secrets in the config
+		{"secret file and secret", func(c *Auth) {
+			c.Secret = "long-enough-secret"
+			c.PreviousSecrets = []string{"unused-secret-1234"}
+			c.SecretFile = rotated
+		}, "", "current-secret-123", []string{"previous-secret-12", "older-secret-1234"}},
+		{"empty secret file", func(c *Auth) { c.SecretFile = empty }, "auth.secretFile", "", nil},
+		{"missing secret file", func(c *Auth) { c.SecretFile = path.Join(dir, "missing") }, "auth.secretFile", "", nil},
+		{"short secret in file", func(c *Auth) { c.SecretFile = short }, "auth.secret", "", nil},
+		{"zero token lifetime", func(c *Auth) { c.TokenLifetime = 0 }, "auth.tokenLifetime", "", nil},
+		{"negative token lifetime", func(c *Auth) { c.TokenLifetime = -time.Hour }, "auth.tokenLifetime", "", nil},
+	}
+	for _, tt := range tests {
+		var c Auth
+		c.SetDefault()
+		tt.update(&c)
+		err := c.Validate()
+		switch {
+		case tt.err == "" && err != nil:
+			t.Errorf("%s: unexpected error %v", tt.name, err)
+		case tt.err != "" && (err == nil || !strings.HasPrefix(err.Error(), tt.err)):
+			t.Errorf("%s: error = %v, want %s", tt.name, err, tt.err)
+		case tt.err == "" && (c.Secret != tt.secret || !slices.Equal(c.PreviousSecrets, tt.previous)):
+			t.Errorf("%s: secrets %q %q, want %q %q", tt.name, c.Secret, c.PreviousSecrets, tt.secret, tt.previous)
+		}
+	}
+}
+
+func TestVerificationKeys(t *testing.T) {
+	c := Auth{Secret: "current", PreviousSecrets: []string{"previous", "older"}}
+	keys := []string{}
+	for _, k := range c.VerificationKeys() {
+		keys = append(keys, string(k))
+	}
+	if !slices.Equal(keys, []string{"current", "previous", "older"}) {
+		t.Errorf("keys %q, the current one should come first", keys)
+	}
+}
@@ -0,0 +1,47 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// Scheduled backups made by cvrs run. The backup command is not affected, its
+// archives are never pruned.
+type Backup struct {
+	// Time between backups, 0 turns scheduled backups off
+	Interval time.Duration `yaml:"interval"`
+	// Only add the images that are new since the previous backup
+	Incremental bool `yaml:"incremental"`
+	// Incremental backups after which a full one is made again
+	FullEvery int `yaml:"fullEvery"`
+	// Scheduled backups to keep, the newest one of every day, week and month.
+	// A backup is kept if any of them wants it.
+	KeepDaily   int `yaml:"keepDaily"`
+	KeepWeekly  int `yaml:"keepWeekly"`
+	KeepMonthly int `yaml:"keepMonthly"`
+}
+
+func (c *Backup) SetDefault() {
+	c.Interval = 0
+	c.Incremental = false
+	c.FullEvery = 6
+	c.KeepDaily = 7
+	c.KeepWeekly = 4
+	c.KeepMonthly = 6
+}
+
+func (c *Backup) Validate() error {
+	if c.Interval < 0 {
+		return fmt.Errorf("backup.interval: can not be negative")
+	}
+	if c.Interval > 0 && c.Interval < time.Minute {
+		return fmt.Errorf("backup.interval: must be at least a minute")
+	}
+	if c.FullEvery < 0 {
+		return fmt.Errorf("backup.fullEvery: can not be negative")
+	}
+	if c.KeepDaily < 0 || c.KeepWeekly < 0 || c.KeepMonthly < 0 {
+		return fmt.Errorf("backup: keep counts can not be negative")
+	}
+	return nil
+}
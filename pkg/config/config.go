@@ -20,15 +20,55 @@ import (
 type Config struct {
 	Workforce Workforce `yaml:"workforce"`
 	Database  Database  `yaml:"database"`
+	Server    Server    `yaml:"server"`
+	Auth      Auth      `yaml:"auth"`
+	Oidc      Oidc      `yaml:"oidc"`
+	Backup    Backup    `yaml:"backup"`
+	Storage   Storage   `yaml:"storage"`
+	Images    Images    `yaml:"images"`
 }
 
 func (c *Config) SetDefault() {
 	c.Workforce.SetDefault()
 	c.Database.SetDefault()
+	c.Server.SetDefault()
+	c.Auth.SetDefault()
+	c.Oidc.SetDefault()
+	c.Backup.SetDefault()
+	c.Storage.SetDefault()
+	c.Images.SetDefault()
 }
 
-func (c *Config) Validate() {
+func (c *Config) Validate() error {
 	c.Database.Validate()
+	err := c.Server.Validate()
+	if err != nil {
+		return err
+	}
+	err = c.Auth.Validate()
+	if err != nil {
+		return err
+	}
+	err = c.Oidc.Validate()
+	if err != nil {
+		return err
+	}
+	err = c.Backup.Validate()
+	if err != nil {
+		return err
+	}
+	err = c.Storage.Validate()
+	if err != nil {
+		return err
+	}
+	err = c.Images.Validate()
+	if err != nil {
+		return err
+	}
+	if !c.Auth.PasswordLogin && !c.Oidc.Enabled() {
+		return errors.New("auth.passwordLogin: can only be turned off when oidc is configured")
+	}
+	return nil
 }
 
 func Load() (Config, error) {
@@ -37,7 +77,6 @@ func Load() (Config, error) {
 
 	var conf Config
 	conf.SetDefault()
-	conf.Validate()
 
 	err := k.Load(file.Provider("./config.yaml"), yaml.Parser())
 	if err != nil {
@@ -98,5 +137,10 @@ func Load() (Config, error) {
 		return Config{}, err
 	}
 
+	err = conf.Validate()
+	if err != nil {
+		return Config{}, fmt.Errorf("invalid config: %w", err)
+	}
+
 	return conf, nil
 }
@@ -1,6 +1,9 @@
 package config
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 type Database struct {
 	SqliteOptions string `yaml:"sqliteOptions"`
@@ -13,5 +16,7 @@ func (c *Database) SetDefault() {
 }
 
 func (c *Database) Validate() {
-	c.SqliteOptions = fmt.Sprintf(c.SqliteOptions, c.DataLocation)
+	if strings.Contains(c.SqliteOptions, "%s") {
+		c.SqliteOptions = fmt.Sprintf(c.SqliteOptions, c.DataLocation)
+	}
 }
@@ -0,0 +1,133 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Variants the worker makes of every uploaded image, run
+// cvrs images regenerate after changing them
+type Images struct {
+	Variants []ImageVariant `yaml:"variants"`
+	Resize   ImageResize    `yaml:"resize"`
+}
+
+// Resizing on request with /api/i/:id?w=&h=
+type ImageResize struct {
+	// Requested sizes are rounded up to one of these
+	Sizes []int `yaml:"sizes"`
+	// Megabytes of resized images that are kept on disk
+	CacheSize int `yaml:"cacheSize"`
+}
+
+type ImageVariant struct {
+	Name string `yaml:"name"`
+	// Sets both the width and the height
+	Size   int `yaml:"size"`
+	Width  int `yaml:"width"`
+	Height int `yaml:"height"`
+	// WEBP, PNG or JPG
+	Format string `yaml:"format"`
+	// From 1 to 100, not used for PNG
+	Quality int `yaml:"quality"`
+	// cover-crop fills width by height and crops the rest around the center,
+	// smart-crop does the same but keeps the busiest part of the image and
+	// fit scales the image to fit within width by height without cropping
+	Mode string `yaml:"mode"`
+}
+
+var imageFormats = []string{"WEBP", "PNG", "JPG"}
+var imageModes = []string{"cover-crop", "fit", "smart-crop"}
+
+func (c *Images) SetDefault() {
+	c.Variants = []ImageVariant{
+		{Name: "thumb", Size: 64, Format: "WEBP", Quality: 90, Mode: "cover-crop"},
+		{Name: "small", Size: 256, Format: "WEBP", Quality: 90, Mode: "cover-crop"},
+		{Name: "large", Size: 1024, Format: "WEBP", Quality: 90, Mode: "cover-crop"},
+	}
+	c.Resize.Sizes = []int{32, 64, 128, 256, 384, 512, 768, 1024, 1536, 2048}
+	c.Resize.CacheSize = 512
+}
+
+func (c *Images) Validate() error {
+	names := map[string]bool{}
+	for i := range c.Variants {
+		v := &c.Variants[i]
+		if v.Name == "" {
+			return fmt.Errorf("images.variants[%d].name: can not be empty", i)
+		}
+		if names[v.Name] {
+			return fmt.Errorf("images.variants[%d].name: %s is used twice", i, v.Name)
+		}
+		names[v.Name] = true
+		if v.Width == 0 {
+			v.Width = v.Size
+		}
+		if v.Height == 0 {
+			v.Height = v.Size
+		}
+		if v.Width < 16 || v.Width > 3000 || v.Height < 16 || v.Height > 3000 {
+			return fmt.Errorf("images.variants[%d]: width and height must be between 16 and 3000", i)
+		}
+		if v.Format == "" {
+			v.Format = "WEBP"
+		}
+		if v.Format == "AVIF" {
+			return fmt.Errorf("images.variants[%d].format: AVIF is not supported yet", i)
+		}
+		if !slices.Contains(imageFormats, v.Format) {
+			return fmt.Errorf("images.variants[%d].format: must be one of %v", i, imageFormats)
+		}
+		if v.Quality == 0 {
+			v.Quality = 90
+		}
+		if v.Quality < 1 || v.Quality > 100 {
+			return fmt.Errorf("images.variants[%d].quality: must be between 1 and 100", i)
+		}
+		if v.Mode == "" {
+			v.Mode = "cover-crop"
+		}
+		if !slices.Contains(imageModes, v.Mode) {
+			return fmt.Errorf("images.variants[%d].mode: must be one of %v", i, imageModes)
+		}
+	}
+
+	if len(c.Resize.Sizes) == 0 {
+		return fmt.Errorf("images.resize.sizes: can not be empty")
+	}
+	for _, size := range c.Resize.Sizes {
+		if size < 16 || size > 3000 {
+			return fmt.Errorf("images.resize.sizes: %d is not between 16 and 3000", size)
+		}
+	}
+	slices.Sort(c.Resize.Sizes)
+	if c.Resize.CacheSize < 0 {
+		return fmt.Errorf("images.resize.cacheSize: can not be negative")
+	}
+	return nil
+}
+
+// Rounds the size up to the nearest allowed size, or down to the largest
+func (c *ImageResize) Clamp(size int) int {
+	for _, s := range c.Sizes {
+		if s >= size {
+			return s
+		}
+	}
+	return c.Max()
+}
+
+// Largest allowed size
+func (c *ImageResize) Max() int {
+	return c.Sizes[len(c.Sizes)-1]
+}
+
+func (c *Images) Variant(name string) (ImageVariant, bool) {
+	i := slices.IndexFunc(c.Variants, func(v ImageVariant) bool {
+		return v.Name == name
+	})
+	if i < 0 {
+		return ImageVariant{}, false
+	}
+	return c.Variants[i], true
+}
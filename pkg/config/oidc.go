@@ -0,0 +1,59 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// Single sign-on with an OpenID Connect provider, enabled by setting an issuer
+type Oidc struct {
+	Issuer       string `yaml:"issuer"`
+	ClientId     string `yaml:"clientId"`
+	ClientSecret string `yaml:"clientSecret"`
+	// File containing the client secret, used instead of clientSecret
+	ClientSecretFile string `yaml:"clientSecretFile"`
+	// Public URL of /api/auth/oidc/callback, as registered with the provider
+	RedirectUrl string   `yaml:"redirectUrl"`
+	Scopes      []string `yaml:"scopes"`
+	// Claim used as username for users that are created on their first login
+	UsernameClaim string `yaml:"usernameClaim"`
+	// Users get is_admin when this claim is true, equals adminValue or is a
+	// list containing adminValue. Left empty, is_admin is never touched.
+	AdminClaim string `yaml:"adminClaim"`
+	AdminValue string `yaml:"adminValue"`
+}
+
+func (c *Oidc) SetDefault() {
+	c.Scopes = []string{"openid", "profile", "email"}
+	c.UsernameClaim = "preferred_username"
+}
+
+func (c *Oidc) Enabled() bool {
+	return c.Issuer != ""
+}
+
+func (c *Oidc) Validate() error {
+	if !c.Enabled() {
+		return nil
+	}
+	if c.ClientSecretFile != "" {
+		data, err := os.ReadFile(c.ClientSecretFile)
+		if err != nil {
+			return fmt.Errorf("oidc.clientSecretFile: %w", err)
+		}
+		c.ClientSecret = strings.TrimSpace(string(data))
+	}
+	if c.ClientId == "" {
+		return fmt.Errorf("oidc.clientId: required when oidc.issuer is set")
+	}
+	u, err := url.Parse(c.RedirectUrl)
+	if err != nil || !u.IsAbs() {
+		return fmt.Errorf("oidc.redirectUrl: must be an absolute URL")
+	}
+	if c.UsernameClaim == "" {
+		return fmt.Errorf("oidc.usernameClaim: can not be empty")
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package config
+
+import (
+	"compress/gzip"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/labstack/gommon/bytes"
+)
+
+type Server struct {
+	Listen      string    `yaml:"listen"`
+	Port        int       `yaml:"port"`
+	Debug       bool      `yaml:"debug"`
+	CorsOrigins []string  `yaml:"corsOrigins"`
+	RateLimit   RateLimit `yaml:"rateLimit"`
+	GzipLevel   int       `yaml:"gzipLevel"`
+	// Max request body size for the whole api, e.g. "10M"
+	BodyLimit    string        `yaml:"bodyLimit"`
+	ReadTimeout  time.Duration `yaml:"readTimeout"`
+	WriteTimeout time.Duration `yaml:"writeTimeout"`
+	IdleTimeout  time.Duration `yaml:"idleTimeout"`
+	// IPs or CIDR ranges of reverse proxies whose X-Forwarded-For header is
+	// trusted. When empty the client IP is the address of the connection.
+	TrustedProxies []string `yaml:"trustedProxies"`
+}
+
+type RateLimit struct {
+	// Requests per second, per ip
+	Rate      float64       `yaml:"rate"`
+	Burst     int           `yaml:"burst"`
+	ExpiresIn time.Duration `yaml:"expiresIn"`
+}
+
+func (c *Server) SetDefault() {
+	c.Listen = "0.0.0.0"
+	c.Port = 3000
+	c.Debug = false
+	c.CorsOrigins = []string{}
+	c.RateLimit.Rate = 30
+	c.RateLimit.Burst = 200
+	c.RateLimit.ExpiresIn = 3 * time.Minute
+	c.GzipLevel = 5
+	c.BodyLimit = "10M"
+	c.ReadTimeout = 30 * time.Second
+	c.WriteTimeout = 60 * time.Second
+	c.IdleTimeout = 2 * time.Minute
+	c.TrustedProxies = []string{}
+}
+
+func (c *Server) Validate() error {
+	if c.Listen != "" && net.ParseIP(c.Listen) == nil {
+		if _, err := net.LookupHost(c.Listen); err != nil {
+			return fmt.Errorf("server.listen: %q is not a valid address", c.Listen)
+		}
+	}
+	if c.Port < 1 || c.Port > 65535 {
+		return fmt.Errorf("server.port: %d is not a valid port", c.Port)
+	}
+	for _, origin := range c.CorsOrigins {
+		if origin == "*" {
+			continue
+		}
+		u, err := url.Parse(origin)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("server.corsOrigins: %q is not a valid origin", origin)
+		}
+	}
+	if c.RateLimit.Rate <= 0 {
+		return fmt.Errorf("server.rateLimit.rate: must be positive")
+	}
+	if c.RateLimit.Burst < 0 {
+		return fmt.Errorf("server.rateLimit.burst: can not be negative")
+	}
+	if c.RateLimit.ExpiresIn <= 0 {
+		return fmt.Errorf("server.rateLimit.expiresIn: must be positive")
+	}
+	if c.GzipLevel < gzip.HuffmanOnly || c.GzipLevel > gzip.BestCompression {
+		return fmt.Errorf("server.gzipLevel: %d is not between %d and %d", c.GzipLevel, gzip.HuffmanOnly, gzip.BestCompression)
+	}
+	if _, err := bytes.Parse(c.BodyLimit); err != nil {
+		return fmt.Errorf("server.bodyLimit: %w", err)
+	}
+	if c.ReadTimeout < 0 || c.WriteTimeout < 0 || c.IdleTimeout < 0 {
+		return fmt.Errorf("server: timeouts can not be negative")
+	}
+	for _, proxy := range c.TrustedProxies {
+		if _, err := parseProxy(proxy); err != nil {
+			return fmt.Errorf("server.trustedProxies: %q is not a valid IP or CIDR", proxy)
+		}
+	}
+	return nil
+}
+
+// Ranges of the trusted proxies, a plain IP is a range of one address
+func (c *Server) TrustedProxyRanges() []*net.IPNet {
+	ranges := []*net.IPNet{}
+	for _, proxy := range c.TrustedProxies {
+		ipNet, err := parseProxy(proxy)
+		if err == nil {
+			ranges = append(ranges, ipNet)
+		}
+	}
+	return ranges
+}
+
+func parseProxy(proxy string) (*net.IPNet, error) {
+	if ip := net.ParseIP(proxy); ip != nil {
+		bits := 8 * net.IPv4len
+		if ip.To4() == nil {
+			bits = 8 * net.IPv6len
+		}
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+	}
+	_, ipNet, err := net.ParseCIDR(proxy)
+	return ipNet, err
+}
+
+func (c *Server) Address() string {
+	return net.JoinHostPort(c.Listen, strconv.Itoa(c.Port))
+}
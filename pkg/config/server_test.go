@@ -0,0 +1,77 @@
+package config
+
+import (
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestServerValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		update func(*Server)
+		// Start of the error, empty when the config is valid
+		err string
+	}{
+		{"defaults", func(c *Server) {}, ""},
+		{"ipv6 listen", func(c *Server) { c.Listen = "::1" }, ""},
+		{"empty listen", func(c *Server) { c.Listen = "" }, ""},
+		{"bad listen", func(c *Server) { c.Listen = "not a host" }, "server.listen"},
+		{"port zero", func(c *Server) { c.Port = 0 }, "server.port"},
+		{"port too high", func(c *Server) { c.Port = 65536 }, "server.port"},
+		{"cors wildcard", func(c *Server) { c.CorsOrigins = []string{"*"} }, ""},
+		{"cors origin", func(c *Server) { c.CorsOrigins = []string{"https://example.com"} }, ""},
+		{"cors without scheme", func(c *Server) { c.CorsOrigins = []string{"example.com"} }, "server.corsOrigins"},
+		{"zero rate", func(c *Server) { c.RateLimit.Rate = 0 }, "server.rateLimit.rate"},
+		{"negative burst", func(c *Server) { c.RateLimit.Burst = -1 }, "server.rateLimit.burst"},
+		{"zero expiry", func(c *Server) { c.RateLimit.ExpiresIn = 0 }, "server.rateLimit.expiresIn"},
+		{"huffman only", func(c *Server) { c.GzipLevel = -2 }, ""},
+		{"gzip too low", func(c *Server) { c.GzipLevel = -3 }, "server.gzipLevel"},
+		{"gzip too high", func(c *Server) { c.GzipLevel = 10 }, "server.gzipLevel"},
+		{"bad body limit", func(c *Server) { c.BodyLimit = "lots" }, "server.bodyLimit"},
+		{"no timeouts", func(c *Server) { c.ReadTimeout, c.WriteTimeout, c.IdleTimeout = 0, 0, 0 }, ""},
+		{"negative timeout", func(c *Server) { c.IdleTimeout = -time.Second }, "server: timeouts"},
+		{"proxies", func(c *Server) { c.TrustedProxies = []string{"10.0.0.1", "fd00::/8"} }, ""},
+		{"bad proxy", func(c *Server) { c.TrustedProxies = []string{"10.0.0.0/33"} }, "server.trustedProxies"},
+	}
+	for _, tt := range tests {
+		var c Server
+		c.SetDefault()
+		tt.update(&c)
+		err := c.Validate()
+		switch {
+		case tt.err == "" && err != nil:
+			t.Errorf("%s: unexpected error %v", tt.name, err)
+		case tt.err != "" && (err == nil || !strings.HasPrefix(err.Error(), tt.err)):
+			t.Errorf("%s: error = %v, want %s", tt.name, err, tt.err)
+		}
+	}
+}
+
+func TestTrustedProxyRanges(t *testing.T) {
+	c := Server{TrustedProxies: []string{"10.0.0.1", "192.168.0.0/16", "::1"}}
+	ranges := c.TrustedProxyRanges()
+	if len(ranges) != 3 {
+		t.Fatalf("got %d ranges, want 3", len(ranges))
+	}
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{"10.0.0.1", true},
+		{"10.0.0.2", false},
+		{"192.168.4.20", true},
+		{"::1", true},
+		{"::2", false},
+	}
+	for _, tt := range tests {
+		got := false
+		for _, r := range ranges {
+			got = got || r.Contains(net.ParseIP(tt.ip))
+		}
+		if got != tt.want {
+			t.Errorf("%s trusted = %v, want %v", tt.ip, got, tt.want)
+		}
+	}
+}
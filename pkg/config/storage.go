@@ -0,0 +1,79 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// Where image files are kept
+type Storage struct {
+	// fs keeps them in the data location, s3 in an S3-compatible bucket
+	Backend string `yaml:"backend"`
+	// Redirect /api/i to presigned URLs, so the bucket serves the images
+	Presign       bool          `yaml:"presign"`
+	PresignExpiry time.Duration `yaml:"presignExpiry"`
+	S3            S3            `yaml:"s3"`
+}
+
+type S3 struct {
+	// Like https://s3.eu-west-1.amazonaws.com or http://localhost:9000
+	Endpoint  string `yaml:"endpoint"`
+	Region    string `yaml:"region"`
+	Bucket    string `yaml:"bucket"`
+	Prefix    string `yaml:"prefix"`
+	AccessKey string `yaml:"accessKey"`
+	SecretKey string `yaml:"secretKey"`
+	// File containing the secret key, used instead of secretKey
+	SecretKeyFile string `yaml:"secretKeyFile"`
+	// Address the bucket as a subdomain instead of as the first part of the
+	// path, required by some providers
+	VirtualHost bool `yaml:"virtualHost"`
+}
+
+func (c *Storage) SetDefault() {
+	c.Backend = "fs"
+	c.Presign = false
+	c.PresignExpiry = time.Hour
+	c.S3.Region = "us-east-1"
+}
+
+func (c *Storage) Validate() error {
+	switch c.Backend {
+	case "fs":
+		if c.Presign {
+			return fmt.Errorf("storage.presign: not supported by the fs backend")
+		}
+		return nil
+	case "s3":
+	default:
+		return fmt.Errorf("storage.backend: %q is not fs or s3", c.Backend)
+	}
+	if c.Presign && (c.PresignExpiry < time.Second || c.PresignExpiry > 7*24*time.Hour) {
+		return fmt.Errorf("storage.presignExpiry: must be between a second and 7 days")
+	}
+
+	if c.S3.SecretKeyFile != "" {
+		data, err := os.ReadFile(c.S3.SecretKeyFile)
+		if err != nil {
+			return fmt.Errorf("storage.s3.secretKeyFile: %w", err)
+		}
+		c.S3.SecretKey = strings.TrimSpace(string(data))
+	}
+	u, err := url.Parse(c.S3.Endpoint)
+	if err != nil || !u.IsAbs() || u.Host == "" {
+		return fmt.Errorf("storage.s3.endpoint: must be an absolute URL")
+	}
+	if c.S3.Bucket == "" {
+		return fmt.Errorf("storage.s3.bucket: required for the s3 backend")
+	}
+	if c.S3.Region == "" {
+		return fmt.Errorf("storage.s3.region: can not be empty")
+	}
+	if c.S3.AccessKey == "" || c.S3.SecretKey == "" {
+		return fmt.Errorf("storage.s3: accessKey and secretKey are required")
+	}
+	return nil
+}
@@ -0,0 +1,218 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"math"
+	"slices"
+	"sort"
+
+	"github.com/Pineapple217/cvrs/pkg/ent"
+	entImage "github.com/Pineapple217/cvrs/pkg/ent/image"
+	"github.com/Pineapple217/cvrs/pkg/ent/imagedata"
+)
+
+const (
+	// Samples per axis, the statistics do not need every pixel
+	analyzeSamples = 128
+	paletteSize    = 5
+	kmeansRounds   = 10
+)
+
+// ImageStats is what gets stored in ImageData, colors are from 0 to 255
+type ImageStats struct {
+	R, G, B    int
+	Brightness int
+	Saturation int
+	Palette    []string
+	Phash      uint64
+	Thumbhash  []byte
+}
+
+type rgb [3]float64
+
+func (c rgb) distance(o rgb) float64 {
+	dr, dg, db := c[0]-o[0], c[1]-o[1], c[2]-o[2]
+	return dr*dr + dg*dg + db*db
+}
+
+func (c rgb) luma() float64 {
+	return 0.299*c[0] + 0.587*c[1] + 0.114*c[2]
+}
+
+func (c rgb) hex() string {
+	return fmt.Sprintf("#%02x%02x%02x", uint8(math.Round(c[0])), uint8(math.Round(c[1])), uint8(math.Round(c[2])))
+}
+
+func AnalyzeImage(img image.Image) ImageStats {
+	bounds := img.Bounds()
+	stepX := max(bounds.Dx()/analyzeSamples, 1)
+	stepY := max(bounds.Dy()/analyzeSamples, 1)
+
+	samples := []rgb{}
+	var sum rgb
+	var brightness, saturation float64
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += stepY {
+		for x := bounds.Min.X; x < bounds.Max.X; x += stepX {
+			r, g, b, _ := img.At(x, y).RGBA()
+			c := rgb{float64(r >> 8), float64(g >> 8), float64(b >> 8)}
+			samples = append(samples, c)
+			sum[0] += c[0]
+			sum[1] += c[1]
+			sum[2] += c[2]
+
+			// Value and saturation as in HSV
+			high := max(c[0], c[1], c[2])
+			low := min(c[0], c[1], c[2])
+			brightness += high
+			if high > 0 {
+				saturation += (high - low) / high * 255
+			}
+		}
+	}
+	n := float64(len(samples))
+	return ImageStats{
+		R:          int(math.Round(sum[0] / n)),
+		G:          int(math.Round(sum[1] / n)),
+		B:          int(math.Round(sum[2] / n)),
+		Brightness: int(math.Round(brightness / n)),
+		Saturation: int(math.Round(saturation / n)),
+		Palette:    palette(samples, paletteSize),
+		Thumbhash:  Thumbhash(img),
+		Phash:      DHash(img),
+	}
+}
+
+// Clusters the colors with k-means and returns the centers, the largest
+// cluster first. The centers start at evenly spaced brightness quantiles, so
+// the result does not depend on chance.
+func palette(samples []rgb, k int) []string {
+	if len(samples) == 0 {
+		return []string{}
+	}
+	sorted := slices.Clone(samples)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].luma() < sorted[j].luma()
+	})
+	centers := make([]rgb, k)
+	for i := range centers {
+		centers[i] = sorted[(2*i+1)*len(sorted)/(2*k)]
+	}
+
+	assigned := make([]int, len(samples))
+	counts := make([]int, k)
+	for range kmeansRounds {
+		for i, s := range samples {
+			best := 0
+			for c := range centers {
+				if s.distance(centers[c]) < s.distance(centers[best]) {
+					best = c
+				}
+			}
+			assigned[i] = best
+		}
+
+		sums := make([]rgb, k)
+		clear(counts)
+		for i, s := range samples {
+			c := assigned[i]
+			sums[c][0] += s[0]
+			sums[c][1] += s[1]
+			sums[c][2] += s[2]
+			counts[c]++
+		}
+		for c := range centers {
+			// An empty cluster keeps its center
+			if counts[c] == 0 {
+				continue
+			}
+			n := float64(counts[c])
+			centers[c] = rgb{sums[c][0] / n, sums[c][1] / n, sums[c][2] / n}
+		}
+	}
+
+	order := make([]int, k)
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return counts[order[i]] > counts[order[j]]
+	})
+	colors := []string{}
+	for _, c := range order {
+		hex := centers[c].hex()
+		// Flat images end up with the same center more than once
+		if counts[c] == 0 || slices.Contains(colors, hex) {
+			continue
+		}
+		colors = append(colors, hex)
+	}
+	return colors
+}
+
+// Analyzes the stored file of the image and saves the result in its data,
+// which is created when the image has none yet
+func (d *Database) AnalyzeImg(ctx context.Context, img *ent.Image) error {
+	r, _, err := d.Blobs.Get(ctx, img.File)
+	if err != nil {
+		return err
+	}
+	decoded, _, err := image.Decode(r)
+	r.Close()
+	if err != nil {
+		return err
+	}
+	stats := AnalyzeImage(decoded)
+	placeholder, err := PlaceholderFromHash(stats.Thumbhash)
+	if err != nil {
+		return err
+	}
+
+	data, err := img.QueryData().Only(ctx)
+	if ent.IsNotFound(err) {
+		return d.Client.ImageData.Create().
+			SetAvrR(stats.R).
+			SetAvrG(stats.G).
+			SetAvrB(stats.B).
+			SetAvgBrightness(stats.Brightness).
+			SetAvgSaturation(stats.Saturation).
+			SetPalette(stats.Palette).
+			SetPhash(int64(stats.Phash)).
+			SetThumbhash(stats.Thumbhash).
+			SetPlaceholder(placeholder.DataURI).
+			SetPlaceholderColor(placeholder.Color).
+			AddImageIDs(img.ID).
+			Exec(ctx)
+	}
+	if err != nil {
+		return err
+	}
+	return d.Client.ImageData.UpdateOne(data).
+		SetAvrR(stats.R).
+		SetAvrG(stats.G).
+		SetAvrB(stats.B).
+		SetAvgBrightness(stats.Brightness).
+		SetAvgSaturation(stats.Saturation).
+		SetPalette(stats.Palette).
+		SetPhash(int64(stats.Phash)).
+		SetThumbhash(stats.Thumbhash).
+		SetPlaceholder(placeholder.DataURI).
+		SetPlaceholderColor(placeholder.Color).
+		Exec(ctx)
+}
+
+// Images without data, or with data from before every field was calculated
+func (d *Database) ImagesWithoutAnalysis(ctx context.Context) ([]*ent.Image, error) {
+	return d.Client.Image.Query().
+		Where(entImage.Or(
+			entImage.Not(entImage.HasData()),
+			entImage.HasDataWith(imagedata.Or(
+				imagedata.AvrRIsNil(),
+				imagedata.PhashIsNil(),
+				imagedata.PaletteIsNil(),
+				imagedata.ThumbhashIsNil(),
+			)),
+		)).
+		All(ctx)
+}
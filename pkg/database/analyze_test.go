@@ -0,0 +1,27 @@
+package database
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"slices"
+	"testing"
+)
+
+func TestAnalyzeImage(t *testing.T) {
+	// Three quarters red, one quarter blue
+	img := image.NewRGBA(image.Rect(0, 0, 512, 512))
+	draw.Draw(img, img.Bounds(), &image.Uniform{color.RGBA{255, 0, 0, 255}}, image.Point{}, draw.Src)
+	draw.Draw(img, image.Rect(384, 0, 512, 512), &image.Uniform{color.RGBA{0, 0, 255, 255}}, image.Point{}, draw.Src)
+
+	stats := AnalyzeImage(img)
+	if stats.R != 191 || stats.G != 0 || stats.B != 64 {
+		t.Errorf("unexpected average color %d %d %d", stats.R, stats.G, stats.B)
+	}
+	if stats.Brightness != 255 || stats.Saturation != 255 {
+		t.Errorf("unexpected brightness %d or saturation %d", stats.Brightness, stats.Saturation)
+	}
+	if !slices.Equal(stats.Palette, []string{"#ff0000", "#0000ff"}) {
+		t.Errorf("unexpected palette %v", stats.Palette)
+	}
+}
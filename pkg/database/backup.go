@@ -2,121 +2,424 @@ package database
 
 import (
 	"archive/tar"
+	"compress/gzip"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"os"
 	"path"
 	"path/filepath"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/Pineapple217/cvrs/pkg/config"
+	"github.com/Pineapple217/cvrs/pkg/storage"
 	"github.com/spf13/cobra"
 )
 
-const BACKUP_DIR = "/backups"
+const BACKUP_DIR = "backups"
 
-func (db *Database) CreateFullBackup(ctx context.Context) error {
+// Names of the entries in a backup archive
+const (
+	BackupDatabase = "database.db"
+	BackupManifest = "manifest.json"
+)
+
+// Version 2 added incremental backups
+const manifestVersion = 2
+
+// Manifest is the last entry of every backup, it lists the checksum of every
+// other entry so a restore can verify the archive.
+type Manifest struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	// Archive in the same directory an incremental backup builds on, empty for
+	// a full backup
+	Parent string `json:"parent,omitempty"`
+	// Incremental backups since the last full one
+	Chain int             `json:"chain,omitempty"`
+	Files []ManifestEntry `json:"files"`
+	// Image files removed since the parent
+	Removed []string `json:"removed,omitempty"`
+	// Every image file at the time of the backup, relative to img/
+	Images []string `json:"images"`
+}
+
+type ManifestEntry struct {
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// Writes a gzip-compressed tarball with the database, all images and a
+// manifest to w. The manifest comes last, so the archive can be streamed
+// without knowing the checksums up front.
+func (db *Database) WriteBackup(ctx context.Context, w io.Writer) (Manifest, error) {
+	return db.writeBackup(ctx, w, nil, "")
+}
+
+// With a parent only the images that are not in it are added. Images are
+// immutable, so the name is enough to know it did not change.
+func (db *Database) writeBackup(ctx context.Context, w io.Writer, parent *Manifest, parentName string) (Manifest, error) {
 	sqliteFilePath, err := db.CreateSQLiteBackup(ctx)
 	if err != nil {
-		return err
+		return Manifest{}, err
 	}
 	defer os.Remove(sqliteFilePath)
 
-	timeString := time.Now().Format("2006-01-02_15-04-05")
-	tarballName := path.Join(db.Conf.DataLocation, BACKUP_DIR, fmt.Sprintf("backup_%s.tar.gz", timeString))
-	tarballFile, err := os.Create(tarballName)
-	if err != nil {
-		return err
+	gzipWriter := gzip.NewWriter(w)
+	tarWriter := tar.NewWriter(gzipWriter)
+	manifest := Manifest{
+		Version:   manifestVersion,
+		CreatedAt: time.Now(),
+		Files:     []ManifestEntry{},
+		Images:    []string{},
+	}
+	inParent := map[string]bool{}
+	if parent != nil {
+		manifest.Parent = parentName
+		manifest.Chain = parent.Chain + 1
+		for _, name := range parent.Images {
+			inParent[name] = true
+		}
 	}
-	defer tarballFile.Close()
-
-	gzipWriter := tar.NewWriter(tarballFile)
-	defer gzipWriter.Close()
 
-	err = addFileToTar(gzipWriter, sqliteFilePath, "database.db")
+	entry, err := addFileToTar(tarWriter, sqliteFilePath, BackupDatabase)
 	if err != nil {
-		return err
+		return Manifest{}, err
 	}
+	manifest.Files = append(manifest.Files, entry)
 
-	folderPath := path.Join(db.Conf.DataLocation, IMG_DIR)
-	err = filepath.Walk(folderPath, func(filePath string, info os.FileInfo, err error) error {
+	blobs, err := db.Blobs.List(ctx, "")
+	if err != nil {
+		return Manifest{}, err
+	}
+	slices.SortFunc(blobs, func(a, b storage.BlobInfo) int {
+		return strings.Compare(a.Key, b.Key)
+	})
+	for _, blob := range blobs {
+		if err := ctx.Err(); err != nil {
+			return Manifest{}, err
+		}
+		manifest.Images = append(manifest.Images, blob.Key)
+		if inParent[blob.Key] {
+			delete(inParent, blob.Key)
+			continue
+		}
+		entry, err := db.addBlobToTar(ctx, tarWriter, blob.Key, path.Join(IMG_DIR, blob.Key))
 		if err != nil {
-			return err
+			return Manifest{}, err
 		}
+		manifest.Files = append(manifest.Files, entry)
+	}
+	// What is left of the parent is gone now
+	for name := range inParent {
+		manifest.Removed = append(manifest.Removed, name)
+	}
+	slices.Sort(manifest.Removed)
 
-		if filePath == folderPath {
-			return nil
-		}
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return Manifest{}, err
+	}
+	err = tarWriter.WriteHeader(&tar.Header{
+		Name:    BackupManifest,
+		Mode:    0644,
+		Size:    int64(len(data)),
+		ModTime: manifest.CreatedAt,
+	})
+	if err != nil {
+		return Manifest{}, err
+	}
+	_, err = tarWriter.Write(data)
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	err = tarWriter.Close()
+	if err != nil {
+		return Manifest{}, err
+	}
+	return manifest, gzipWriter.Close()
+}
+
+// Writes a backup to a temporary file next to dest and only renames it to
+// dest once it is complete, so dest is never a half-written archive.
+func (db *Database) WriteBackupFile(ctx context.Context, dest string) (Manifest, error) {
+	return db.writeBackupFile(ctx, dest, nil, "")
+}
+
+func (db *Database) writeBackupFile(ctx context.Context, dest string, parent *Manifest, parentName string) (Manifest, error) {
+	tempFile, err := os.CreateTemp(filepath.Dir(dest), ".backup_*.tmp")
+	if err != nil {
+		return Manifest{}, err
+	}
+	defer os.Remove(tempFile.Name())
+	defer tempFile.Close()
+
+	manifest, err := db.writeBackup(ctx, tempFile, parent, parentName)
+	if err != nil {
+		return Manifest{}, err
+	}
+	err = tempFile.Sync()
+	if err != nil {
+		return Manifest{}, err
+	}
+	err = tempFile.Close()
+	if err != nil {
+		return Manifest{}, err
+	}
+	return manifest, os.Rename(tempFile.Name(), dest)
+}
+
+// Creates a backup in the backups directory and returns its path
+func (db *Database) CreateFullBackup(ctx context.Context) (string, error) {
+	return db.createBackup(ctx, backupNameLayout, nil, "")
+}
+
+// Creates a backup in the backups directory that only holds the images added
+// since the newest backup there. A full backup is made instead when there is
+// nothing to build on or when the chain already has fullEvery increments.
+func (db *Database) CreateIncrementalBackup(ctx context.Context, fullEvery int) (string, error) {
+	return db.createIncrementalBackup(ctx, backupNameLayout, fullEvery)
+}
+
+// Like CreateFullBackup and CreateIncrementalBackup, but the archive is named
+// as a scheduled one. Scheduled increments only build on scheduled backups,
+// so pruning them never breaks a chain of manual ones.
+func (db *Database) CreateScheduledBackup(ctx context.Context, incremental bool, fullEvery int) (string, error) {
+	if !incremental {
+		return db.createBackup(ctx, scheduledBackupNameLayout, nil, "")
+	}
+	return db.createIncrementalBackup(ctx, scheduledBackupNameLayout, fullEvery)
+}
 
-		relPath, err := filepath.Rel(folderPath, filePath)
+func (db *Database) createIncrementalBackup(ctx context.Context, layout string, fullEvery int) (string, error) {
+	parent, parentName, err := db.latestBackup(layout)
+	if err != nil {
+		return "", err
+	}
+	if parent != nil && parent.Chain >= fullEvery {
+		parent = nil
+	}
+	return db.createBackup(ctx, layout, parent, parentName)
+}
+
+func (db *Database) createBackup(ctx context.Context, layout string, parent *Manifest, parentName string) (string, error) {
+	tarballName, err := reserveBackupName(path.Join(db.Conf.DataLocation, BACKUP_DIR), layout)
+	if err != nil {
+		return "", err
+	}
+	// Only ever replaces the empty file reserveBackupName created
+	manifest, err := db.writeBackupFile(ctx, tarballName, parent, parentName)
+	if err != nil {
+		os.Remove(tarballName)
+		return "", err
+	}
+	// Increments are based on the index, reading the manifest from the end of
+	// a large archive would mean decompressing all of it
+	err = writeBackupIndex(tarballName, manifest)
+	if err != nil {
+		return "", err
+	}
+	return tarballName, nil
+}
+
+// Creates an empty archive in dir named after layout, with milliseconds added
+// to the seconds so backups made in the same second get their own file. The
+// layouts still parse these names, a fraction after the seconds is accepted.
+// A name that is already taken is never reused.
+func reserveBackupName(dir, layout string) (string, error) {
+	const ext = ".tar.gz"
+	layout = strings.TrimSuffix(layout, ext) + ".000" + ext
+	for range 10 {
+		name := path.Join(dir, time.Now().Format(layout))
+		f, err := os.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if errors.Is(err, os.ErrExist) {
+			time.Sleep(time.Millisecond)
+			continue
+		}
 		if err != nil {
-			return err
+			return "", err
 		}
-		headerName := filepath.Join(IMG_DIR, filepath.ToSlash(relPath))
-		return addFileToTar(gzipWriter, filePath, headerName)
-	})
+		return name, f.Close()
+	}
+	return "", fmt.Errorf("no free backup name in %s", dir)
+}
+
+// Path of the copy of the manifest that is kept next to an archive
+func backupIndexPath(archive string) string {
+	return strings.TrimSuffix(archive, ".tar.gz") + ".json"
+}
+
+func writeBackupIndex(archive string, manifest Manifest) error {
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(backupIndexPath(archive), data, 0644)
+}
 
-	return err
+func readBackupIndex(archive string) (Manifest, error) {
+	data, err := os.ReadFile(backupIndexPath(archive))
+	if err != nil {
+		return Manifest{}, err
+	}
+	var manifest Manifest
+	err = json.Unmarshal(data, &manifest)
+	return manifest, err
 }
 
+// Returns the manifest and name of the newest backup in the backups
+// directory named after layout, nil when there is none to build on
+func (db *Database) latestBackup(layout string) (*Manifest, string, error) {
+	backups, err := db.listBackups(layout)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(backups) == 0 {
+		return nil, "", nil
+	}
+	newest := slices.MaxFunc(backups, func(a, b backupFile) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	manifest, err := readBackupIndex(path.Join(db.Conf.DataLocation, BACKUP_DIR, newest.Name))
+	if errors.Is(err, os.ErrNotExist) {
+		// Made before incremental backups existed
+		return nil, "", nil
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	return &manifest, newest.Name, nil
+}
+
+// Copies the database to a new file in the temp directory and returns its
+// path. VACUUM INTO accepts an existing file as long as it is empty, so a
+// unique temp file is created for it.
 func (db *Database) CreateSQLiteBackup(ctx context.Context) (string, error) {
-	timeString := time.Now().Format("2006-01-02_15-04-05")
-	path := path.Join(db.Conf.DataLocation, TEMP_DIR, fmt.Sprintf("%s.db", timeString))
-	_, err := db.Client.ExecContext(ctx, fmt.Sprintf("vacuum into \"%s\"", path))
-	return path, err
+	f, err := os.CreateTemp(path.Join(db.Conf.DataLocation, TEMP_DIR), "backup_*.db")
+	if err != nil {
+		return "", err
+	}
+	path := f.Name()
+	err = f.Close()
+	if err == nil {
+		_, err = db.Client.ExecContext(ctx, fmt.Sprintf("vacuum into \"%s\"", path))
+	}
+	if err != nil {
+		os.Remove(path)
+		return "", err
+	}
+	return path, nil
 }
 
-func addFileToTar(gzipWriter *tar.Writer, filePath, headerName string) error {
+// Adds the file to the tarball and returns its manifest entry
+func addFileToTar(tarWriter *tar.Writer, filePath, headerName string) (ManifestEntry, error) {
 	file, err := os.Open(filePath)
 	if err != nil {
-		return err
+		return ManifestEntry{}, err
 	}
 	defer file.Close()
 
 	fileInfo, err := file.Stat()
 	if err != nil {
-		return err
+		return ManifestEntry{}, err
 	}
+	return addToTar(tarWriter, file, headerName, fileInfo.Size(), fileInfo.ModTime())
+}
 
-	header, err := tar.FileInfoHeader(fileInfo, "")
+// Adds the blob to the tarball and returns its manifest entry
+func (db *Database) addBlobToTar(ctx context.Context, tarWriter *tar.Writer, key, headerName string) (ManifestEntry, error) {
+	r, info, err := db.Blobs.Get(ctx, key)
 	if err != nil {
-		return err
+		return ManifestEntry{}, err
 	}
+	defer r.Close()
+	return addToTar(tarWriter, r, headerName, info.Size, info.ModTime)
+}
 
-	if headerName != "" {
-		header.Name = headerName
-	} else {
-		header.Name = fileInfo.Name()
+// The checksum is calculated while copying, so every file is only read once
+func addToTar(tarWriter *tar.Writer, r io.Reader, headerName string, size int64, modTime time.Time) (ManifestEntry, error) {
+	err := tarWriter.WriteHeader(&tar.Header{
+		Name:    headerName,
+		Mode:    0644,
+		Size:    size,
+		ModTime: modTime,
+	})
+	if err != nil {
+		return ManifestEntry{}, err
 	}
 
-	if err := gzipWriter.WriteHeader(header); err != nil {
-		return err
+	hash := sha256.New()
+	n, err := io.Copy(io.MultiWriter(tarWriter, hash), r)
+	if err != nil {
+		return ManifestEntry{}, err
 	}
-
-	_, err = io.Copy(gzipWriter, file)
-	return err
+	return ManifestEntry{
+		Path:   headerName,
+		Size:   n,
+		SHA256: hex.EncodeToString(hash.Sum(nil)),
+	}, nil
 }
 
 func GetBackupCmd() *cobra.Command {
+	var output string
+	var incremental bool
 	cmd := &cobra.Command{
 		Use:   "backup",
 		Short: "create full instance backup",
+		Long: "Creates a full instance backup. Archives written to the backups directory by\n" +
+			"this command are never pruned, only the scheduled backups of cvrs run are.",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			conf, err := config.Load()
 			if err != nil {
 				return err
 			}
-			db, err := NewDatabase(conf.Database)
+			db, err := NewDatabase(conf.Database, conf.Storage)
 			if err != nil {
 				return err
 			}
-			err = db.CreateFullBackup(cmd.Context())
-			if err != nil {
-				return err
+
+			if incremental && output != "" {
+				return errors.New("incremental backups can only be written to the backups directory")
+			}
+
+			switch output {
+			case "":
+				if incremental {
+					output, err = db.CreateIncrementalBackup(cmd.Context(), conf.Backup.FullEvery)
+				} else {
+					output, err = db.CreateFullBackup(cmd.Context())
+				}
+				if err != nil {
+					return err
+				}
+				slog.Info("Created backup", "file", output)
+			case "-":
+				// Logs go to stderr, so stdout only carries the archive
+				_, err = db.WriteBackup(cmd.Context(), os.Stdout)
+				if err != nil {
+					return err
+				}
+			default:
+				manifest, err := db.WriteBackupFile(cmd.Context(), output)
+				if err != nil {
+					return err
+				}
+				slog.Info("Created backup", "file", output, "files", len(manifest.Files))
 			}
 			return nil
 		},
 	}
+	cmd.Flags().StringVarP(&output, "output", "o", "", "File to write the backup to, - for stdout (default: the backups directory)")
+	cmd.Flags().BoolVarP(&incremental, "incremental", "i", false, "Only add the images that are new since the last backup")
+	cmd.SilenceUsage = true
 	return cmd
 }
@@ -0,0 +1,153 @@
+package database
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+)
+
+type backupEntry struct {
+	name string
+	data []byte
+}
+
+// Reads every entry of a gzip-compressed backup, in the order of the archive
+func readBackup(t *testing.T, archive []byte) []backupEntry {
+	t.Helper()
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		t.Fatalf("backup is not gzip-compressed: %v", err)
+	}
+	tr := tar.NewReader(gz)
+	entries := []backupEntry{}
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		entries = append(entries, backupEntry{h.Name, data})
+	}
+	return entries
+}
+
+func TestWriteBackupManifest(t *testing.T) {
+	db := newTestDatabase(t)
+	ctx := context.Background()
+	for _, key := range []string{"b", "a", "c"} {
+		putBlob(t, db.Blobs, key, bytes.Repeat([]byte(key), 1024))
+	}
+
+	var buf bytes.Buffer
+	manifest, err := db.WriteBackup(ctx, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries := readBackup(t, buf.Bytes())
+
+	names := []string{}
+	for _, e := range entries {
+		names = append(names, e.name)
+	}
+	// Database first and the manifest last, so it can be written while streaming
+	want := []string{BackupDatabase, "img/a", "img/b", "img/c", BackupManifest}
+	if !slices.Equal(names, want) {
+		t.Fatalf("entries %v, want %v", names, want)
+	}
+	var written Manifest
+	err = json.Unmarshal(entries[len(entries)-1].data, &written)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if written.Version != manifestVersion || written.Parent != "" || written.Chain != 0 || len(written.Removed) != 0 {
+		t.Errorf("manifest %+v", written)
+	}
+	if !slices.Equal(written.Images, []string{"a", "b", "c"}) {
+		t.Errorf("images %v", written.Images)
+	}
+	if len(written.Files) != len(entries)-1 || len(manifest.Files) != len(written.Files) {
+		t.Fatalf("manifest lists %d files for %d entries", len(written.Files), len(entries)-1)
+	}
+	for i, f := range written.Files {
+		sum := sha256.Sum256(entries[i].data)
+		if f.Path != entries[i].name || f.Size != int64(len(entries[i].data)) || f.SHA256 != hex.EncodeToString(sum[:]) {
+			t.Errorf("manifest entry %+v does not match %s", f, entries[i].name)
+		}
+		if f != manifest.Files[i] {
+			t.Errorf("returned manifest entry %+v, written %+v", manifest.Files[i], f)
+		}
+	}
+	size := 0
+	for _, e := range entries {
+		size += len(e.data)
+	}
+	if buf.Len() >= size/2 {
+		t.Errorf("backup of %d bytes for %d bytes of entries is not compressed", buf.Len(), size)
+	}
+}
+
+func TestWriteIncrementalBackup(t *testing.T) {
+	db := newTestDatabase(t)
+	ctx := context.Background()
+	for _, key := range []string{"a", "b", "c"} {
+		putBlob(t, db.Blobs, key, []byte(key))
+	}
+
+	tests := []struct {
+		name   string
+		parent *Manifest
+		// Images added to the archive and the ones listed as removed
+		files   []string
+		removed []string
+	}{
+		{"full", nil, []string{"a", "b", "c"}, nil},
+		{"nothing changed", &Manifest{Chain: 2, Images: []string{"a", "b", "c"}}, nil, nil},
+		{"added and removed", &Manifest{Images: []string{"a", "x"}}, []string{"b", "c"}, []string{"x"}},
+		{"all replaced", &Manifest{Images: []string{"y", "x"}}, []string{"a", "b", "c"}, []string{"x", "y"}},
+		{"empty parent", &Manifest{Images: []string{}}, []string{"a", "b", "c"}, nil},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		manifest, err := db.writeBackup(ctx, &buf, tt.parent, "parent.tar.gz")
+		if err != nil {
+			t.Fatal(err)
+		}
+		files := []string{}
+		for _, e := range readBackup(t, buf.Bytes()) {
+			if name, ok := strings.CutPrefix(e.name, IMG_DIR+"/"); ok {
+				files = append(files, name)
+			}
+		}
+		if !slices.Equal(files, tt.files) {
+			t.Errorf("%s: archived %v, want %v", tt.name, files, tt.files)
+		}
+		if !slices.Equal(manifest.Removed, tt.removed) {
+			t.Errorf("%s: removed %v, want %v", tt.name, manifest.Removed, tt.removed)
+		}
+		// Every backup lists all images, the next increment builds on it
+		if !slices.Equal(manifest.Images, []string{"a", "b", "c"}) {
+			t.Errorf("%s: images %v", tt.name, manifest.Images)
+		}
+		wantChain, wantParent := 0, ""
+		if tt.parent != nil {
+			wantChain, wantParent = tt.parent.Chain+1, "parent.tar.gz"
+		}
+		if manifest.Chain != wantChain || manifest.Parent != wantParent {
+			t.Errorf("%s: chain %d on %q, want %d on %q", tt.name, manifest.Chain, manifest.Parent, wantChain, wantParent)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package database
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"log/slog"
 	"os"
@@ -10,21 +11,27 @@ import (
 	"github.com/Pineapple217/cvrs/pkg/config"
 	"github.com/Pineapple217/cvrs/pkg/ent"
 	_ "github.com/Pineapple217/cvrs/pkg/ent/runtime"
+	"github.com/Pineapple217/cvrs/pkg/pid"
+	"github.com/Pineapple217/cvrs/pkg/storage"
 	_ "github.com/mattn/go-sqlite3"
 )
 
 type Database struct {
 	Client *ent.Client
 	Conf   config.Database
+	// Image files, both originals and processed ones
+	Blobs  storage.BlobStore
+	search bool
 }
 
-func NewDatabase(conf config.Database) (*Database, error) {
+func NewDatabase(conf config.Database, storageConf config.Storage) (*Database, error) {
 	var err error
 	for _, p := range []string{
 		conf.DataLocation,
 		path.Join(conf.DataLocation, TEMP_DIR),
 		path.Join(conf.DataLocation, IMG_DIR),
 		path.Join(conf.DataLocation, BACKUP_DIR),
+		path.Join(conf.DataLocation, CACHE_DIR),
 	} {
 		err = CreateDir(p)
 		if err != nil {
@@ -32,21 +39,172 @@ func NewDatabase(conf config.Database) (*Database, error) {
 		}
 	}
 
+	blobs, err := newBlobStore(conf, storageConf)
+	if err != nil {
+		return nil, err
+	}
+
 	client, err := ent.Open("sqlite3", conf.SqliteOptions)
 	if err != nil {
 		return nil, fmt.Errorf("failed opening connection to sqlite: %v", err)
 	}
 
+	err = migrateDimentions(context.Background(), client)
+	if err != nil {
+		return nil, fmt.Errorf("failed migrating processed images: %v", err)
+	}
 	if err := client.Schema.Create(context.Background()); err != nil {
 		return nil, fmt.Errorf("failed creating schema resources: %v", err)
 	}
 	db := &Database{
 		Client: client,
 		Conf:   conf,
+		Blobs:  blobs,
+	}
+	err = db.initSearch(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	err = db.backfillProcessedFiles(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	err = db.migrateProcessedThumbs(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed migrating thumbhashes: %v", err)
 	}
 	return db, nil
 }
 
+func newBlobStore(conf config.Database, storageConf config.Storage) (storage.BlobStore, error) {
+	switch storageConf.Backend {
+	case "s3":
+		return storage.NewS3Store(storageConf.S3)
+	default:
+		return storage.NewFsStore(path.Join(conf.DataLocation, IMG_DIR)), nil
+	}
+}
+
+// Variants used to have a single dimentions column, it becomes the width and
+// the height. Variants that were not square get the right height once they
+// are regenerated.
+func migrateDimentions(ctx context.Context, client *ent.Client) error {
+	found, err := hasColumn(ctx, client, "processed_images", "dimentions")
+	if err != nil || !found {
+		return err
+	}
+	slog.Info("Migrating processed image dimentions to width and height")
+	for _, stmt := range []string{
+		"ALTER TABLE processed_images RENAME COLUMN dimentions TO width",
+		"ALTER TABLE processed_images ADD COLUMN height integer NOT NULL DEFAULT 0",
+		"UPDATE processed_images SET height = width",
+	} {
+		_, err = client.ExecContext(ctx, stmt)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func hasColumn(ctx context.Context, client *ent.Client, table, column string) (bool, error) {
+	rows, err := client.QueryContext(ctx,
+		"SELECT count(*) FROM pragma_table_info(?) WHERE name = ?", table, column)
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+	var found int
+	if rows.Next() {
+		err = rows.Scan(&found)
+	}
+	return found > 0, err
+}
+
+// Every variant used to store the same thumbhash, now the image data holds
+// it once. The hash of one of the variants is kept for data without one, and
+// images that were never analyzed get new data holding only the hash, so no
+// hash is lost when the column is dropped.
+func (d *Database) migrateProcessedThumbs(ctx context.Context) error {
+	found, err := hasColumn(ctx, d.Client, "processed_images", "thumb")
+	if err != nil || !found {
+		return err
+	}
+	slog.Info("Moving thumbhashes from processed images to image data")
+	rows, err := d.Client.QueryContext(ctx, `
+		SELECT i.id, i.image_data, p.thumb FROM processed_images p
+		JOIN images i ON i.id = p.image_proccesed_image
+		LEFT JOIN image_data d ON d.id = i.image_data
+		WHERE d.thumbhash IS NULL AND p.thumb IS NOT NULL
+		GROUP BY i.id`)
+	if err != nil {
+		return err
+	}
+	type thumb struct {
+		image pid.ID
+		data  sql.NullInt64
+		hash  []byte
+	}
+	thumbs := []thumb{}
+	for rows.Next() {
+		var t thumb
+		err = rows.Scan(&t.image, &t.data, &t.hash)
+		if err != nil {
+			rows.Close()
+			return err
+		}
+		thumbs = append(thumbs, t)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	tx, err := d.Client.Tx(ctx)
+	if err != nil {
+		return err
+	}
+	// Images can share their data, it only has to be filled once
+	done := map[int64]bool{}
+	for _, t := range thumbs {
+		if t.data.Valid && done[t.data.Int64] {
+			continue
+		}
+		placeholder, perr := PlaceholderFromHash(t.hash)
+		if perr != nil {
+			slog.Warn("Skipping invalid thumbhash", "image", t.image, "error", perr)
+			continue
+		}
+		if t.data.Valid {
+			done[t.data.Int64] = true
+			err = tx.ImageData.UpdateOneID(int(t.data.Int64)).
+				SetThumbhash(t.hash).
+				SetPlaceholder(placeholder.DataURI).
+				SetPlaceholderColor(placeholder.Color).
+				Exec(ctx)
+		} else {
+			err = tx.ImageData.Create().
+				SetThumbhash(t.hash).
+				SetPlaceholder(placeholder.DataURI).
+				SetPlaceholderColor(placeholder.Color).
+				AddImageIDs(t.image).
+				Exec(ctx)
+		}
+		if err != nil {
+			break
+		}
+	}
+	if err == nil {
+		_, err = tx.Client().ExecContext(ctx, "ALTER TABLE processed_images DROP COLUMN thumb")
+	}
+	if err == nil {
+		err = tx.Commit()
+	} else if rerr := tx.Rollback(); rerr != nil {
+		err = fmt.Errorf("%w: %v", err, rerr)
+	}
+	return err
+}
+
 func CreateDir(p string) error {
 	_, err := os.Stat(p)
 	if os.IsNotExist(err) {
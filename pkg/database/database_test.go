@@ -0,0 +1,24 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Pineapple217/cvrs/pkg/config"
+)
+
+// Database on an in-memory sqlite, with the fs blob store in a temp dir
+func newTestDatabase(t *testing.T) *Database {
+	t.Helper()
+	name := strings.NewReplacer("/", "_", " ", "_").Replace(t.Name())
+	db, err := NewDatabase(config.Database{
+		DataLocation:  t.TempDir(),
+		SqliteOptions: fmt.Sprintf("file:%s?mode=memory&cache=shared&_fk=1", name),
+	}, config.Storage{Backend: "fs"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Client.Close() })
+	return db
+}
@@ -0,0 +1,202 @@
+package database
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/Pineapple217/cvrs/pkg/ent"
+	entImage "github.com/Pineapple217/cvrs/pkg/ent/image"
+	"github.com/Pineapple217/cvrs/pkg/ent/processedimage"
+	"github.com/Pineapple217/cvrs/pkg/ent/schema"
+	"github.com/Pineapple217/cvrs/pkg/pid"
+)
+
+type DedupeReport struct {
+	// Images that got their hash calculated
+	Hashed int
+	// Images that now share the file of an older image with the same hash
+	Merged int
+	// Files that were no longer used after merging
+	Deleted int
+}
+
+// Returns the oldest image with the hash, with its variants and data. Nil
+// when there is none.
+func findByHash(ctx context.Context, client *ent.Client, hash string) (*ent.Image, error) {
+	img, err := client.Image.Query().
+		Where(entImage.HashEQ(hash)).
+		WithProccesedImage().
+		WithData().
+		Order(ent.Asc(entImage.FieldID)).
+		First(ctx)
+	if ent.IsNotFound(err) {
+		return nil, nil
+	}
+	return img, err
+}
+
+// Gives source its own rows for the variants, sharing their files
+func copyProcessedImgs(ctx context.Context, tx *ent.Tx, procImgs []*ent.ProcessedImage, source pid.ID) error {
+	creates := make([]*ent.ProcessedImageCreate, len(procImgs))
+	for i, p := range procImgs {
+		creates[i] = tx.ProcessedImage.Create().
+			SetID(pid.New()).
+			SetFile(p.File).
+			SetVariant(p.Variant).
+			SetType(p.Type).
+			SetWidth(p.Width).
+			SetHeight(p.Height).
+			SetSizeBits(p.SizeBits).
+			SetSourceID(source)
+	}
+	return tx.ProcessedImage.CreateBulk(creates...).Exec(ctx)
+}
+
+// Deletes the blob once no image or variant uses it anymore. Reports if it
+// was deleted.
+func (d *Database) deleteUnusedBlob(ctx context.Context, client *ent.Client, key string) (bool, error) {
+	ctx = schema.SkipSoftDelete(ctx)
+	used, err := client.Image.Query().Where(entImage.FileEQ(key)).Exist(ctx)
+	if err != nil || used {
+		return false, err
+	}
+	used, err = client.ProcessedImage.Query().Where(processedimage.FileEQ(key)).Exist(ctx)
+	if err != nil || used {
+		return false, err
+	}
+	return true, d.Blobs.Delete(ctx, key)
+}
+
+// Variants made before they had a file field are stored under their ID
+func (d *Database) backfillProcessedFiles(ctx context.Context) error {
+	ctx = schema.SkipSoftDelete(ctx)
+	ids, err := d.Client.ProcessedImage.Query().
+		Where(processedimage.Or(processedimage.FileIsNil(), processedimage.FileEQ(""))).
+		IDs(ctx)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		err = d.Client.ProcessedImage.UpdateOneID(id).SetFile(id.String()).Exec(ctx)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *Database) hashBlob(ctx context.Context, key string) (string, error) {
+	r, _, err := d.Blobs.Get(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	defer r.Close()
+	hash := sha256.New()
+	_, err = io.Copy(hash, r)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// Calculates the missing hashes, then points every image at the file of the
+// oldest image with the same hash. Variants of the same size are merged the
+// same way. Files that are no longer used are deleted.
+func (d *Database) DedupeImages(ctx context.Context) (DedupeReport, error) {
+	report := DedupeReport{}
+	// Soft-deleted images can be restored, so they are deduplicated as well
+	ctx = schema.SkipSoftDelete(ctx)
+
+	unhashed, err := d.Client.Image.Query().
+		Where(entImage.Or(entImage.HashIsNil(), entImage.HashEQ(""))).
+		All(ctx)
+	if err != nil {
+		return report, err
+	}
+	for _, img := range unhashed {
+		hash, err := d.hashBlob(ctx, img.File)
+		if err != nil {
+			slog.Warn("Failed to hash image", "image", img.ID.String(), "error", err)
+			continue
+		}
+		err = d.Client.Image.UpdateOne(img).SetHash(hash).Exec(ctx)
+		if err != nil {
+			return report, err
+		}
+		report.Hashed++
+	}
+
+	imgs, err := d.Client.Image.Query().
+		Where(entImage.HashNEQ("")).
+		WithProccesedImage().
+		Order(ent.Asc(entImage.FieldID)).
+		All(ctx)
+	if err != nil {
+		return report, err
+	}
+	oldest := map[string]*ent.Image{}
+	for _, img := range imgs {
+		canonical, ok := oldest[img.Hash]
+		if !ok {
+			oldest[img.Hash] = img
+			continue
+		}
+		files, err := d.mergeImage(ctx, canonical, img)
+		if err != nil {
+			return report, err
+		}
+		if len(files) == 0 {
+			continue
+		}
+		report.Merged++
+		for _, f := range files {
+			deleted, err := d.deleteUnusedBlob(ctx, d.Client, f)
+			if err != nil {
+				return report, err
+			}
+			if deleted {
+				report.Deleted++
+			}
+		}
+	}
+	return report, nil
+}
+
+// Points img and its variants at the files of canonical and returns the files
+// they used before
+func (d *Database) mergeImage(ctx context.Context, canonical, img *ent.Image) ([]string, error) {
+	old := []string{}
+	tx, err := d.Client.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if img.File != canonical.File {
+		err = tx.Image.UpdateOne(img).SetFile(canonical.File).Exec(ctx)
+		old = append(old, img.File)
+	}
+	for _, p := range img.Edges.ProccesedImage {
+		if err != nil {
+			break
+		}
+		for _, c := range canonical.Edges.ProccesedImage {
+			if c.Variant != p.Variant || c.Width != p.Width || c.Height != p.Height ||
+				c.Type != p.Type || c.File == p.File {
+				continue
+			}
+			err = tx.ProcessedImage.UpdateOne(p).SetFile(c.File).Exec(ctx)
+			old = append(old, p.File)
+			break
+		}
+	}
+	if err == nil {
+		err = tx.Commit()
+	} else if rerr := tx.Rollback(); rerr != nil {
+		err = fmt.Errorf("%w: %v", err, rerr)
+	}
+	return old, err
+}
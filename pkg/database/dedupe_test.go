@@ -0,0 +1,357 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"mime/multipart"
+	"net/textproto"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/Pineapple217/cvrs/pkg/config"
+	"github.com/Pineapple217/cvrs/pkg/ent"
+	entImage "github.com/Pineapple217/cvrs/pkg/ent/image"
+	"github.com/Pineapple217/cvrs/pkg/ent/processedimage"
+	"github.com/Pineapple217/cvrs/pkg/ent/schema"
+	"github.com/Pineapple217/cvrs/pkg/ent/task"
+	"github.com/Pineapple217/cvrs/pkg/storage"
+)
+
+func testPNG(t *testing.T, c color.Color) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, 32, 32))
+	draw.Draw(img, img.Bounds(), &image.Uniform{c}, image.Point{}, draw.Src)
+	var buf bytes.Buffer
+	err := png.Encode(&buf, img)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// Header of an uploaded file, like the handlers get from the form
+func testUpload(t *testing.T, name string, data []byte) *multipart.FileHeader {
+	t.Helper()
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	h := textproto.MIMEHeader{}
+	h.Set("Content-Disposition", `form-data; name="file"; filename="`+name+`"`)
+	h.Set("Content-Type", "image/png")
+	part, err := w.CreatePart(h)
+	if err == nil {
+		_, err = part.Write(data)
+	}
+	if err == nil {
+		err = w.Close()
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	form, err := multipart.NewReader(&body, w.Boundary()).ReadForm(1 << 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { form.RemoveAll() })
+	return form.File["file"][0]
+}
+
+func TestSaveImgReuse(t *testing.T) {
+	db := newTestDatabase(t)
+	ctx := context.Background()
+	u, err := db.Client.User.Create().SetUsername("bob").Save(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := testPNG(t, color.RGBA{200, 40, 40, 255})
+
+	first, err := db.SaveImg(ctx, testUpload(t, "a.png", data), u.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Processed and analyzed, like the workers would
+	variant, err := db.Client.ProcessedImage.Create().
+		SetFile("variant").
+		SetVariant("small").
+		SetType(processedimage.TypeWEBP).
+		SetWidth(16).
+		SetHeight(16).
+		SetSizeBits(1).
+		SetSourceID(first.ID).
+		Save(ctx)
+	if err == nil {
+		_, err = db.Client.ImageData.Create().AddImage(first).Save(ctx)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	tasks, err := db.Client.Task.Query().Count(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := db.SaveImg(ctx, testUpload(t, "b.png", data), u.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second.ID == first.ID || second.File != first.File || second.Hash != first.Hash {
+		t.Errorf("duplicate does not share the file: %s and %s", first.File, second.File)
+	}
+	if second.OriginalName != "b.png" {
+		t.Errorf("original name is %s", second.OriginalName)
+	}
+	procs, err := db.Client.Image.QueryProccesedImage(second).All(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(procs) != 1 || procs[0].File != variant.File || procs[0].ID == variant.ID {
+		t.Errorf("variants were not copied: %v", procs)
+	}
+	firstData, _ := db.Client.Image.QueryData(first).OnlyID(ctx)
+	secondData, err := db.Client.Image.QueryData(second).OnlyID(ctx)
+	if err != nil || secondData != firstData {
+		t.Errorf("image data not shared: %d and %d, %v", firstData, secondData, err)
+	}
+	// Nothing left to process or analyze
+	n, err := db.Client.Task.Query().Count(ctx)
+	if err != nil || n != tasks {
+		t.Errorf("duplicate queued %d tasks", n-tasks)
+	}
+
+	temps, err := os.ReadDir(path.Join(db.Conf.DataLocation, TEMP_DIR))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(temps) != 0 {
+		t.Errorf("%d temp files left behind", len(temps))
+	}
+	blobs, err := db.Blobs.List(ctx, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blobs) != 1 {
+		t.Errorf("stored %d blobs, want 1", len(blobs))
+	}
+}
+
+func TestSaveImgDuplicateOfUnprocessed(t *testing.T) {
+	db := newTestDatabase(t)
+	ctx := context.Background()
+	u, err := db.Client.User.Create().SetUsername("bob").Save(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := testPNG(t, color.RGBA{40, 40, 200, 255})
+
+	for _, name := range []string{"a.png", "b.png"} {
+		_, err = db.SaveImg(ctx, testUpload(t, name, data), u.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The first one is not processed yet, so the duplicate needs its own tasks
+	for _, typ := range []task.Type{task.TypeScaleImg, task.TypeAnalyzeImg} {
+		n, err := db.Client.Task.Query().Where(task.TypeEQ(typ)).Count(ctx)
+		if err != nil || n != 2 {
+			t.Errorf("%d %s tasks, want 2", n, typ)
+		}
+	}
+}
+
+func putBlob(t *testing.T, store storage.BlobStore, key string, data []byte) {
+	t.Helper()
+	err := store.Put(context.Background(), key, bytes.NewReader(data), int64(len(data)), "image/png")
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDedupeImages(t *testing.T) {
+	db := newTestDatabase(t)
+	ctx := context.Background()
+	u, err := db.Client.User.Create().SetUsername("bob").Save(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := testPNG(t, color.RGBA{40, 200, 40, 255})
+	other := testPNG(t, color.RGBA{40, 40, 40, 255})
+
+	// Uploaded before images had a hash, every copy got its own files
+	newImage := func(file string, data []byte) *ent.Image {
+		putBlob(t, db.Blobs, file, data)
+		putBlob(t, db.Blobs, file+"_small", []byte(file))
+		img, err := db.Client.Image.Create().
+			SetFile(file).
+			SetOriginalName(file + ".png").
+			SetType(entImage.TypePNG).
+			SetDimentionWidth(32).
+			SetDimentionHeight(32).
+			SetSizeBits(uint32(len(data))).
+			SetUploader(u).
+			Save(ctx)
+		if err == nil {
+			_, err = db.Client.ProcessedImage.Create().
+				SetFile(file + "_small").
+				SetVariant("small").
+				SetType(processedimage.TypeWEBP).
+				SetWidth(16).
+				SetHeight(16).
+				SetSizeBits(1).
+				SetSource(img).
+				Save(ctx)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		return img
+	}
+	first := newImage("first", data)
+	second := newImage("second", data)
+	unique := newImage("unique", other)
+	// IDs made in the same millisecond are not ordered, the lowest one is
+	// the oldest
+	if second.ID < first.ID {
+		first, second = second, first
+	}
+
+	report, err := db.DedupeImages(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := DedupeReport{Hashed: 3, Merged: 1, Deleted: 2}
+	if report != want {
+		t.Errorf("report = %+v, want %+v", report, want)
+	}
+
+	merged, err := db.Client.Image.Get(ctx, second.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if merged.File != first.File {
+		t.Errorf("duplicate uses %s, want %s", merged.File, first.File)
+	}
+	p, err := db.Client.Image.QueryProccesedImage(merged).Only(ctx)
+	if err != nil || p.File != first.File+"_small" {
+		t.Errorf("variant of the duplicate not merged: %v", err)
+	}
+	for _, key := range []string{second.File, second.File + "_small"} {
+		if _, err := db.Blobs.Stat(ctx, key); err == nil {
+			t.Errorf("unused blob %s was kept", key)
+		}
+	}
+	for _, key := range []string{first.File, first.File + "_small", unique.File, unique.File + "_small"} {
+		if _, err := db.Blobs.Stat(ctx, key); err != nil {
+			t.Errorf("blob %s was deleted: %v", key, err)
+		}
+	}
+
+	// Running it again has nothing left to do
+	report, err = db.DedupeImages(ctx)
+	if err != nil || report != (DedupeReport{}) {
+		t.Errorf("second run = %+v, %v", report, err)
+	}
+}
+
+func TestHardDeleteImgSharedData(t *testing.T) {
+	db := newTestDatabase(t)
+	ctx := context.Background()
+	u, err := db.Client.User.Create().SetUsername("bob").Save(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := testPNG(t, color.RGBA{200, 200, 40, 255})
+	first, err := db.SaveImg(ctx, testUpload(t, "a.png", data), u.ID)
+	if err == nil {
+		_, err = db.Client.ImageData.Create().AddImage(first).Save(ctx)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := db.SaveImg(ctx, testUpload(t, "b.png", data), u.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Soft-deleted, it still uses the data
+	err = second.Update().SetDeletedAt(time.Now()).Exec(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = db.HardDeleteImg(ctx, first.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, _ := db.Client.ImageData.Query().Count(ctx); n != 1 {
+		t.Errorf("%d image data left while still used, want 1", n)
+	}
+	err = db.HardDeleteImg(ctx, second.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, _ := db.Client.ImageData.Query().Count(ctx); n != 0 {
+		t.Errorf("%d orphaned image data left", n)
+	}
+}
+
+func TestSaveProcessedImgsSoftDeleted(t *testing.T) {
+	db := newTestDatabase(t)
+	ctx := context.Background()
+	u, err := db.Client.User.Create().SetUsername("bob").Save(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := testPNG(t, color.RGBA{40, 200, 200, 255})
+	active, err := db.SaveImg(ctx, testUpload(t, "a.png", data), u.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	deleted, err := db.SaveImg(ctx, testUpload(t, "b.png", data), u.ID)
+	if err == nil {
+		deleted, err = deleted.Update().SetDeletedAt(time.Now()).Save(ctx)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	var conf config.Images
+	conf.SetDefault()
+	v := conf.Variants[0]
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Twice, the first variants are replaced by the second ones
+	for range 2 {
+		_, err = db.SaveProcessedImgs(ctx, []*ent.Image{active, deleted}, []config.ImageVariant{v}, []image.Image{src})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, img := range []*ent.Image{active, deleted} {
+		removed, err := db.RemoveStaleVariants(ctx, img.ID, conf)
+		if err != nil || removed != 1 {
+			t.Errorf("removed %d stale variants of %s, want 1: %v", removed, img.OriginalName, err)
+		}
+	}
+
+	procs, err := db.Client.ProcessedImage.Query().
+		Where(processedimage.HasSourceWith(entImage.IDEQ(deleted.ID))).
+		All(schema.SkipSoftDelete(ctx))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(procs) != 1 || procs[0].DeletedAt == nil {
+		t.Errorf("variants of the soft-deleted image: %v", procs)
+	}
+	n, err := db.Client.ProcessedImage.Query().
+		Where(processedimage.HasSourceWith(entImage.IDEQ(active.ID))).
+		Count(ctx)
+	if err != nil || n != 1 {
+		t.Errorf("%d visible variants of the active image, want 1", n)
+	}
+}
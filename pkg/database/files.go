@@ -2,25 +2,29 @@ package database
 
 import (
 	"context"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"image"
+	"image/jpeg"
+	"image/png"
 	"io"
 	"mime/multipart"
 	"os"
 	"path"
 	"slices"
 
-	_ "image/jpeg"
-	_ "image/png"
-
+	"github.com/Pineapple217/cvrs/pkg/config"
 	"github.com/Pineapple217/cvrs/pkg/ent"
+	entImage "github.com/Pineapple217/cvrs/pkg/ent/image"
+	"github.com/Pineapple217/cvrs/pkg/ent/imagedata"
 	"github.com/Pineapple217/cvrs/pkg/ent/processedimage"
+	"github.com/Pineapple217/cvrs/pkg/ent/schema"
 	"github.com/Pineapple217/cvrs/pkg/ent/task"
 	"github.com/Pineapple217/cvrs/pkg/pid"
 	"github.com/chai2010/webp"
-	thumbhash "github.com/galdor/go-thumbhash"
 )
 
 var AllowedMIME = []string{"image/png", "image/jpeg", "image/webp"}
@@ -28,6 +32,7 @@ var AllowedMIME = []string{"image/png", "image/jpeg", "image/webp"}
 const MAX_IMG_SIZE = 1024 * 1024 * 5 // MB
 const TEMP_DIR = "tmp"
 const IMG_DIR = "img"
+const CACHE_DIR = "cache"
 
 func (d Database) SaveImg(ctx context.Context, f *multipart.FileHeader, uploader pid.ID) (*ent.Image, error) {
 	var err error
@@ -52,11 +57,13 @@ func (d Database) SaveImg(ctx context.Context, f *multipart.FileHeader, uploader
 	if err != nil {
 		return nil, err
 	}
-	_, err = io.Copy(tempFile, sourceFile)
+	hash := sha256.New()
+	_, err = io.Copy(io.MultiWriter(tempFile, hash), sourceFile)
 	if err != nil {
 		return nil, err
 	}
 	defer sourceFile.Close()
+	hashString := hex.EncodeToString(hash.Sum(nil))
 
 	// Get img info
 	_, err = tempFile.Seek(0, io.SeekStart)
@@ -90,29 +97,57 @@ func (d Database) SaveImg(ctx context.Context, f *multipart.FileHeader, uploader
 	if err != nil {
 		return nil, err
 	}
+	analyzeData, err := json.Marshal(TaskAnalyzeImg{
+		ImageId: id,
+	})
+	if err != nil {
+		return nil, err
+	}
 
 	// Start transaction ================================
 	tx, err := d.Client.BeginTx(ctx, &sql.TxOptions{})
 	if err != nil {
 		return nil, err
 	}
-	DBimg, err := tx.Image.Create().
+	// The same file was uploaded before, its file and variants are reused.
+	// It is looked up inside the transaction, so the reused file can not be
+	// hard-deleted before the new image points to it.
+	duplicate, err := findByHash(ctx, tx.Client(), hashString)
+	if err != nil {
+		if rerr := tx.Rollback(); rerr != nil {
+			err = fmt.Errorf("%w: %v", err, rerr)
+		}
+		return nil, err
+	}
+	file := id.String()
+	if duplicate != nil {
+		file = duplicate.File
+	}
+	imgCreate := tx.Image.Create().
 		SetType(imgType).
 		SetDimentionWidth(bounds.Dx()).
 		SetDimentionHeight(bounds.Dy()).
 		SetOriginalName(f.Filename).
 		SetSizeBits(uint32(stats.Size())).
-		SetFile(id.String()).
+		SetFile(file).
+		SetHash(hashString).
 		SetUploaderID(uploader).
-		SetID(id).
-		Save(ctx)
+		SetID(id)
+	if duplicate != nil && duplicate.Edges.Data != nil {
+		imgCreate.SetDataID(duplicate.Edges.Data.ID)
+	}
+	DBimg, err := imgCreate.Save(ctx)
 	if err != nil {
 		if rerr := tx.Rollback(); rerr != nil {
 			err = fmt.Errorf("%w: %v", err, rerr)
 		}
 		return nil, err
 	}
-	err = os.Rename(tempFile.Name(), path.Join(d.Conf.DataLocation, IMG_DIR, id.String()))
+	if duplicate == nil {
+		err = d.putTempFile(ctx, tempFile.Name(), file, mimeType)
+	} else {
+		err = copyProcessedImgs(ctx, tx, duplicate.Edges.ProccesedImage, id)
+	}
 	if err != nil {
 		if rerr := tx.Rollback(); rerr != nil {
 			err = fmt.Errorf("%w: %v", err, rerr)
@@ -120,10 +155,20 @@ func (d Database) SaveImg(ctx context.Context, f *multipart.FileHeader, uploader
 		return nil, err
 	}
 
-	_, err = tx.Task.Create().
-		SetType(task.TypeScaleImg).
-		SetPayload(taskData).
-		Save(ctx)
+	// A duplicate of an image that is still being processed gets processed
+	// itself
+	if duplicate == nil || len(duplicate.Edges.ProccesedImage) == 0 {
+		_, err = tx.Task.Create().
+			SetType(task.TypeScaleImg).
+			SetPayload(taskData).
+			Save(ctx)
+	}
+	if err == nil && (duplicate == nil || duplicate.Edges.Data == nil) {
+		_, err = tx.Task.Create().
+			SetType(task.TypeAnalyzeImg).
+			SetPayload(analyzeData).
+			Save(ctx)
+	}
 	if err != nil {
 		if rerr := tx.Rollback(); rerr != nil {
 			err = fmt.Errorf("%w: %v", err, rerr)
@@ -137,13 +182,19 @@ func (d Database) SaveImg(ctx context.Context, f *multipart.FileHeader, uploader
 	}
 	// End transaction ===================================
 
+	if duplicate != nil {
+		os.Remove(tempFile.Name())
+	}
 	// TODO: Clean up temp file that are stale
 	return DBimg.Unwrap(), nil
 }
 
-func (d Database) SaveProcessedImgs(ctx context.Context, source pid.ID, imgs []image.Image) ([]*ent.ProcessedImage, error) {
+// Encodes the variants and stores them for every source. The sources share
+// the files, they are images with the same original. Variants of a
+// soft-deleted source are soft-deleted with it, restoring it brings them back.
+func (d Database) SaveProcessedImgs(ctx context.Context, sources []*ent.Image, variants []config.ImageVariant, imgs []image.Image) ([]*ent.ProcessedImage, error) {
 	temps := []*os.File{}
-	for _, i := range imgs {
+	for i, img := range imgs {
 		tempFile, err := os.CreateTemp(path.Join(d.Conf.DataLocation, TEMP_DIR), "proc_img*")
 		if err != nil {
 			return nil, fmt.Errorf("failed to create tmp file, %s", err)
@@ -151,9 +202,7 @@ func (d Database) SaveProcessedImgs(ctx context.Context, source pid.ID, imgs []i
 		defer tempFile.Close()
 		temps = append(temps, tempFile)
 
-		err = webp.Encode(tempFile, i, &webp.Options{
-			Quality: 90,
-		})
+		err = EncodeVariant(tempFile, img, variants[i])
 		if err != nil {
 			return nil, err
 		}
@@ -164,9 +213,10 @@ func (d Database) SaveProcessedImgs(ctx context.Context, source pid.ID, imgs []i
 	if err != nil {
 		return nil, err
 	}
+	files := make([]string, len(temps))
 	imgCreates := []*ent.ProcessedImageCreate{}
 	for i, temp := range temps {
-		id := pid.New()
+		files[i] = pid.New().String()
 		info, err := temp.Stat()
 		if err != nil {
 			if rerr := tx.Rollback(); rerr != nil {
@@ -174,14 +224,19 @@ func (d Database) SaveProcessedImgs(ctx context.Context, source pid.ID, imgs []i
 			}
 			return nil, fmt.Errorf("failed to get temp file stats: %s", err)
 		}
-		imgCreate := tx.ProcessedImage.Create().
-			SetDimentions(imgs[i].Bounds().Dx()).
-			SetID(id).
-			SetThumb(thumbhash.EncodeImage(imgs[i])).
-			SetSizeBits(uint32(info.Size())).
-			SetSourceID(source).
-			SetType(processedimage.TypeWEBP)
-		imgCreates = append(imgCreates, imgCreate)
+		for _, source := range sources {
+			imgCreate := tx.ProcessedImage.Create().
+				SetWidth(imgs[i].Bounds().Dx()).
+				SetHeight(imgs[i].Bounds().Dy()).
+				SetID(pid.New()).
+				SetFile(files[i]).
+				SetVariant(variants[i].Name).
+				SetSizeBits(uint32(info.Size())).
+				SetSourceID(source.ID).
+				SetNillableDeletedAt(source.DeletedAt).
+				SetType(processedimage.Type(variants[i].Format))
+			imgCreates = append(imgCreates, imgCreate)
+		}
 		err = temp.Close()
 		if err != nil {
 			if rerr := tx.Rollback(); rerr != nil {
@@ -200,7 +255,7 @@ func (d Database) SaveProcessedImgs(ctx context.Context, source pid.ID, imgs []i
 	}
 
 	for i, temp := range temps {
-		err = os.Rename(temp.Name(), path.Join(d.Conf.DataLocation, IMG_DIR, dbImgs[i].ID.String()))
+		err = d.putTempFile(ctx, temp.Name(), files[i], VariantMIME[variants[i].Format])
 		if err != nil {
 			if rerr := tx.Rollback(); rerr != nil {
 				err = fmt.Errorf("%w: %v", err, rerr)
@@ -220,12 +275,98 @@ func (d Database) SaveProcessedImgs(ctx context.Context, source pid.ID, imgs []i
 	return dbImgs, nil
 }
 
+// Content types of the variant formats
+var VariantMIME = map[string]string{
+	"WEBP": "image/webp",
+	"PNG":  "image/png",
+	"JPG":  "image/jpeg",
+}
+
+// Writes the image in the format and quality of the variant
+func EncodeVariant(w io.Writer, img image.Image, variant config.ImageVariant) error {
+	switch variant.Format {
+	case "WEBP":
+		return webp.Encode(w, img, &webp.Options{
+			Quality: float32(variant.Quality),
+		})
+	case "PNG":
+		return png.Encode(w, img)
+	case "JPG":
+		return jpeg.Encode(w, img, &jpeg.Options{
+			Quality: variant.Quality,
+		})
+	}
+	return fmt.Errorf("unknown variant format %s", variant.Format)
+}
+
+// Moves a finished temp file into the blob store
+func (d Database) putTempFile(ctx context.Context, name, key, contentType string) error {
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(name)
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	return d.Blobs.Put(ctx, key, f, info.Size(), contentType)
+}
+
 func (d *Database) HardDeleteImg(ctx context.Context, id pid.ID) error {
+	// Files can be shared with soft-deleted rows, so those count as well
+	ctx = schema.SkipSoftDelete(ctx)
 	tx, err := d.Client.BeginTx(ctx, &sql.TxOptions{})
 	if err != nil {
 		return err
 	}
 
+	img, err := tx.Image.Query().
+		Where(entImage.IDEQ(id)).
+		WithData(func(q *ent.ImageDataQuery) {
+			q.Select(imagedata.FieldID)
+		}).
+		Only(ctx)
+	if err != nil {
+		if rerr := tx.Rollback(); rerr != nil {
+			err = fmt.Errorf("%w: %v", err, rerr)
+		}
+		return err
+	}
+	// Variants reference their source, so they have to go first
+	procImgs, err := tx.ProcessedImage.Query().
+		Where(processedimage.HasSourceWith(entImage.IDEQ(id))).
+		All(ctx)
+	if err != nil {
+		if rerr := tx.Rollback(); rerr != nil {
+			err = fmt.Errorf("%w: %v", err, rerr)
+		}
+		return err
+	}
+	procIds := make([]pid.ID, len(procImgs))
+	for i, procImg := range procImgs {
+		procIds[i] = procImg.ID
+	}
+	_, err = tx.ProcessedImage.Delete().
+		Where(processedimage.IDIn(procIds...)).
+		Exec(ctx)
+	if err != nil {
+		if rerr := tx.Rollback(); rerr != nil {
+			err = fmt.Errorf("%w: %v", err, rerr)
+		}
+		return err
+	}
+	for _, procImg := range procImgs {
+		_, err = d.deleteUnusedBlob(ctx, tx.Client(), procImg.File)
+		if err != nil {
+			if rerr := tx.Rollback(); rerr != nil {
+				err = fmt.Errorf("%w: %v", err, rerr)
+			}
+			return err
+		}
+	}
+
 	err = tx.Image.DeleteOneID(id).Exec(ctx)
 	if err != nil {
 		if rerr := tx.Rollback(); rerr != nil {
@@ -233,8 +374,23 @@ func (d *Database) HardDeleteImg(ctx context.Context, id pid.ID) error {
 		}
 		return err
 	}
+	// Duplicates share the data, it goes with the last image using it
+	if img.Edges.Data != nil {
+		_, err = tx.ImageData.Delete().
+			Where(
+				imagedata.IDEQ(img.Edges.Data.ID),
+				imagedata.Not(imagedata.HasImage()),
+			).
+			Exec(ctx)
+		if err != nil {
+			if rerr := tx.Rollback(); rerr != nil {
+				err = fmt.Errorf("%w: %v", err, rerr)
+			}
+			return err
+		}
+	}
 
-	err = os.Remove(path.Join(d.Conf.DataLocation, IMG_DIR, id.String()))
+	_, err = d.deleteUnusedBlob(ctx, tx.Client(), img.File)
 	if err != nil {
 		if rerr := tx.Rollback(); rerr != nil {
 			err = fmt.Errorf("%w: %v", err, rerr)
@@ -245,11 +401,19 @@ func (d *Database) HardDeleteImg(ctx context.Context, id pid.ID) error {
 }
 
 func (d *Database) HardDeleteProcessedImg(ctx context.Context, id pid.ID) error {
+	ctx = schema.SkipSoftDelete(ctx)
 	tx, err := d.Client.BeginTx(ctx, &sql.TxOptions{})
 	if err != nil {
 		return err
 	}
 
+	procImg, err := tx.ProcessedImage.Get(ctx, id)
+	if err != nil {
+		if rerr := tx.Rollback(); rerr != nil {
+			err = fmt.Errorf("%w: %v", err, rerr)
+		}
+		return err
+	}
 	err = tx.ProcessedImage.DeleteOneID(id).Exec(ctx)
 	if err != nil {
 		if rerr := tx.Rollback(); rerr != nil {
@@ -258,7 +422,7 @@ func (d *Database) HardDeleteProcessedImg(ctx context.Context, id pid.ID) error
 		return err
 	}
 
-	err = os.Remove(path.Join(d.Conf.DataLocation, IMG_DIR, id.String()))
+	_, err = d.deleteUnusedBlob(ctx, tx.Client(), procImg.File)
 	if err != nil {
 		if rerr := tx.Rollback(); rerr != nil {
 			err = fmt.Errorf("%w: %v", err, rerr)
@@ -0,0 +1,111 @@
+package database
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/Pineapple217/cvrs/pkg/config"
+	"github.com/Pineapple217/cvrs/pkg/ent"
+	"github.com/spf13/cobra"
+)
+
+func GetImagesCmd() *cobra.Command {
+	imagesCmd := &cobra.Command{
+		Use:   "images",
+		Short: "Manage stored images",
+	}
+
+	dedupeCmd := &cobra.Command{
+		Use:   "dedupe",
+		Short: "Hash images that have no hash yet and merge the files of identical images",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			conf, err := config.Load()
+			if err != nil {
+				return err
+			}
+			db, err := NewDatabase(conf.Database, conf.Storage)
+			if err != nil {
+				return err
+			}
+			report, err := db.DedupeImages(cmd.Context())
+			if err != nil {
+				return err
+			}
+			slog.Info("Deduplicated images", "hashed", report.Hashed, "merged", report.Merged, "deleted_files", report.Deleted)
+			return nil
+		},
+	}
+	dedupeCmd.SilenceUsage = true
+
+	var missing bool
+	analyzeCmd := &cobra.Command{
+		Use:   "analyze",
+		Short: "Calculate the colors, palette and perceptual hash of images",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			conf, err := config.Load()
+			if err != nil {
+				return err
+			}
+			db, err := NewDatabase(conf.Database, conf.Storage)
+			if err != nil {
+				return err
+			}
+
+			var imgs []*ent.Image
+			if missing {
+				imgs, err = db.ImagesWithoutAnalysis(cmd.Context())
+			} else {
+				imgs, err = db.Client.Image.Query().All(cmd.Context())
+			}
+			if err != nil {
+				return err
+			}
+			failed := 0
+			for _, img := range imgs {
+				err = db.AnalyzeImg(cmd.Context(), img)
+				if err != nil {
+					slog.Warn("Failed to analyze image", "image", img.ID.String(), "error", err)
+					failed++
+				}
+			}
+			slog.Info("Analyzed images", "count", len(imgs)-failed, "failed", failed)
+			return nil
+		},
+	}
+	analyzeCmd.Flags().BoolVar(&missing, "missing", false, "Only analyze images that have not been analyzed yet")
+	analyzeCmd.SilenceUsage = true
+
+	var variants []string
+	regenerateCmd := &cobra.Command{
+		Use:   "regenerate",
+		Short: "Queue the variants of every image to be made again, run this after changing the configured variants",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			conf, err := config.Load()
+			if err != nil {
+				return err
+			}
+			for _, name := range variants {
+				if _, ok := conf.Images.Variant(name); !ok {
+					return fmt.Errorf("variant %s is not configured", name)
+				}
+			}
+			db, err := NewDatabase(conf.Database, conf.Storage)
+			if err != nil {
+				return err
+			}
+			count, err := db.RegenerateVariants(cmd.Context(), variants)
+			if err != nil {
+				return err
+			}
+			slog.Info("Queued images for regeneration, they are processed by the server", "count", count)
+			return nil
+		},
+	}
+	regenerateCmd.Flags().StringSliceVar(&variants, "variant", nil, "Only make these variants (default: all configured variants)")
+	regenerateCmd.SilenceUsage = true
+
+	imagesCmd.AddCommand(dedupeCmd)
+	imagesCmd.AddCommand(analyzeCmd)
+	imagesCmd.AddCommand(regenerateCmd)
+	return imagesCmd
+}
@@ -0,0 +1,113 @@
+package database
+
+import (
+	"context"
+	"image"
+	"math/bits"
+	"sort"
+
+	"github.com/Pineapple217/cvrs/pkg/ent"
+	"github.com/Pineapple217/cvrs/pkg/ent/imagedata"
+	"github.com/Pineapple217/cvrs/pkg/pid"
+)
+
+// Images within this Hamming distance of each other are considered the same
+// artwork
+const SimilarDistance = 10
+
+// Samples per cell and axis, averaging every pixel of a large image is slow
+// and does not change the hash
+const dhashSamples = 16
+
+// Calculates the difference hash of the image. It is shrunk to 9x8 grey
+// values and every bit tells if a value is brighter than its right
+// neighbour, so scaling and recompression barely change it.
+func DHash(img image.Image) uint64 {
+	const w, h = 9, 8
+	bounds := img.Bounds()
+	var grey [h][w]float64
+	for y := range h {
+		y0 := bounds.Min.Y + y*bounds.Dy()/h
+		y1 := max(bounds.Min.Y+(y+1)*bounds.Dy()/h, y0+1)
+		stepY := max((y1-y0)/dhashSamples, 1)
+		for x := range w {
+			x0 := bounds.Min.X + x*bounds.Dx()/w
+			x1 := max(bounds.Min.X+(x+1)*bounds.Dx()/w, x0+1)
+			stepX := max((x1-x0)/dhashSamples, 1)
+
+			sum, n := 0.0, 0
+			for py := y0; py < y1; py += stepY {
+				for px := x0; px < x1; px += stepX {
+					r, g, b, _ := img.At(px, py).RGBA()
+					sum += 0.299*float64(r) + 0.587*float64(g) + 0.114*float64(b)
+					n++
+				}
+			}
+			grey[y][x] = sum / float64(n)
+		}
+	}
+
+	var hash uint64
+	for y := range h {
+		for x := range w - 1 {
+			hash <<= 1
+			if grey[y][x] > grey[y][x+1] {
+				hash |= 1
+			}
+		}
+	}
+	return hash
+}
+
+func HammingDistance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+type SimilarImage struct {
+	Image    *ent.Image `json:"image"`
+	Distance int        `json:"distance"`
+}
+
+// Returns the images whose perceptual hash is within maxDistance of hash,
+// closest first. The image with the exclude ID is left out.
+func (d *Database) SimilarImages(ctx context.Context, hash uint64, maxDistance int, exclude pid.ID) ([]SimilarImage, error) {
+	datas, err := d.Client.ImageData.Query().
+		Where(imagedata.PhashNotNil()).
+		WithImage().
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	similar := []SimilarImage{}
+	for _, data := range datas {
+		distance := HammingDistance(hash, uint64(*data.Phash))
+		if distance > maxDistance {
+			continue
+		}
+		for _, img := range data.Edges.Image {
+			if img.ID == exclude {
+				continue
+			}
+			similar = append(similar, SimilarImage{Image: img, Distance: distance})
+		}
+	}
+	sort.SliceStable(similar, func(i, j int) bool {
+		return similar[i].Distance < similar[j].Distance
+	})
+	return similar, nil
+}
+
+// Looks for images like the stored file of img, used to warn about uploads
+// of artwork that is already there
+func (d *Database) SimilarToImage(ctx context.Context, img *ent.Image) ([]SimilarImage, error) {
+	r, _, err := d.Blobs.Get(ctx, img.File)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	decoded, _, err := image.Decode(r)
+	if err != nil {
+		return nil, err
+	}
+	return d.SimilarImages(ctx, DHash(decoded), SimilarDistance, img.ID)
+}
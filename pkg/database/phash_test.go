@@ -0,0 +1,36 @@
+package database
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/anthonynsimon/bild/transform"
+)
+
+func testPattern(size int, invert bool) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+	for y := range size {
+		for x := range size {
+			v := uint8((x*x + y*3*size) / (4 * size) % 256)
+			if invert {
+				v = 255 - v
+			}
+			img.Set(x, y, color.RGBA{v, v / 2, 255 - v, 255})
+		}
+	}
+	return img
+}
+
+func TestDHash(t *testing.T) {
+	original := testPattern(600, false)
+	hash := DHash(original)
+
+	scaled := transform.Resize(original, 150, 150, transform.Lanczos)
+	if d := HammingDistance(hash, DHash(scaled)); d > SimilarDistance {
+		t.Errorf("scaled copy has distance %d", d)
+	}
+	if d := HammingDistance(hash, DHash(testPattern(600, true))); d <= SimilarDistance {
+		t.Errorf("inverted image has distance %d", d)
+	}
+}
@@ -0,0 +1,67 @@
+package database
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/png"
+	"math"
+
+	"github.com/anthonynsimon/bild/transform"
+	thumbhash "github.com/galdor/go-thumbhash"
+)
+
+// ThumbHash is meant for images up to 100x100, larger ones only take longer
+const thumbhashSize = 100
+
+// Placeholder is what the frontend shows while an image loads
+type Placeholder struct {
+	DataURI string
+	// As #rrggbb
+	Color string
+}
+
+// ThumbHash of a copy of the image that fits in 100x100
+func Thumbhash(img image.Image) []byte {
+	bounds := img.Bounds()
+	w, h := thumbhashSize, thumbhashSize
+	if bounds.Dx() > bounds.Dy() {
+		h = max(1, bounds.Dy()*thumbhashSize/bounds.Dx())
+	} else {
+		w = max(1, bounds.Dx()*thumbhashSize/bounds.Dy())
+	}
+	return thumbhash.EncodeImage(transform.Resize(img, w, h, transform.Linear))
+}
+
+// Decodes the hash into a tiny PNG and takes the average color from its
+// header
+func PlaceholderFromHash(hash []byte) (Placeholder, error) {
+	img, err := thumbhash.DecodeImage(hash)
+	if err != nil {
+		return Placeholder{}, err
+	}
+	buf := bytes.Buffer{}
+	err = png.Encode(&buf, img)
+	if err != nil {
+		return Placeholder{}, err
+	}
+
+	var h thumbhash.Hash
+	err = h.Decode(hash, &thumbhash.DecodingCfg{})
+	if err != nil {
+		return Placeholder{}, err
+	}
+	// Back from the LPQ color space, as in the reference implementation
+	b := h.LDC - 2.0/3.0*h.PDC
+	r := (3*h.LDC - b + h.QDC) / 2
+	g := r - h.QDC
+	channel := func(v float64) uint8 {
+		return uint8(math.Round(255 * min(1, max(0, v))))
+	}
+
+	return Placeholder{
+		DataURI: "data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes()),
+		Color:   fmt.Sprintf("#%02x%02x%02x", channel(r), channel(g), channel(b)),
+	}, nil
+}
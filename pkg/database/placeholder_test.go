@@ -0,0 +1,128 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/Pineapple217/cvrs/pkg/ent"
+	entImage "github.com/Pineapple217/cvrs/pkg/ent/image"
+	"github.com/Pineapple217/cvrs/pkg/ent/processedimage"
+)
+
+func TestPlaceholder(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 640, 320))
+	draw.Draw(img, img.Bounds(), &image.Uniform{color.RGBA{200, 40, 40, 255}}, image.Point{}, draw.Src)
+
+	p, err := PlaceholderFromHash(Thumbhash(img))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, ok := strings.CutPrefix(p.DataURI, "data:image/png;base64,")
+	if !ok {
+		t.Fatalf("unexpected data URI %.40s", p.DataURI)
+	}
+	raw, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	preview, err := png.Decode(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b := preview.Bounds(); b.Dx() <= b.Dy() {
+		t.Errorf("preview of a landscape image should be landscape, got %v", b.Size())
+	}
+
+	want := []int64{200, 40, 40}
+	for i := range want {
+		got, err := strconv.ParseInt(p.Color[1+2*i:3+2*i], 16, 64)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got < want[i]-12 || got > want[i]+12 {
+			t.Errorf("unexpected color %s", p.Color)
+			break
+		}
+	}
+}
+
+func TestMigrateProcessedThumbs(t *testing.T) {
+	db := newTestDatabase(t)
+	ctx := context.Background()
+	img := image.NewRGBA(image.Rect(0, 0, 64, 32))
+	draw.Draw(img, img.Bounds(), &image.Uniform{color.RGBA{40, 200, 40, 255}}, image.Point{}, draw.Src)
+	hash := Thumbhash(img)
+
+	u, err := db.Client.User.Create().SetUsername("bob").Save(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newImage := func() *ent.Image {
+		c := db.Client.Image.Create().
+			SetFile("f").
+			SetOriginalName("f.png").
+			SetType(entImage.TypePNG).
+			SetDimentionWidth(64).
+			SetDimentionHeight(32).
+			SetSizeBits(1).
+			SetUploader(u)
+		i, err := c.Save(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = db.Client.ProcessedImage.Create().
+			SetSource(i).
+			SetType(processedimage.TypeWEBP).
+			SetWidth(32).
+			SetHeight(16).
+			SetSizeBits(1).
+			Save(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return i
+	}
+	analyzed := newImage()
+	_, err = db.Client.ImageData.Create().AddImage(analyzed).Save(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Uploaded before image data existed
+	bare := newImage()
+
+	// Put back the column of old installs
+	_, err = db.Client.ExecContext(ctx, "ALTER TABLE processed_images ADD COLUMN thumb blob")
+	if err == nil {
+		_, err = db.Client.ExecContext(ctx, "UPDATE processed_images SET thumb = ?", hash)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = db.migrateProcessedThumbs(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	found, err := hasColumn(ctx, db.Client, "processed_images", "thumb")
+	if err != nil || found {
+		t.Fatalf("thumb column still there: %v", err)
+	}
+	for _, i := range []*ent.Image{analyzed, bare} {
+		d, err := db.Client.Image.QueryData(i).Only(ctx)
+		if err != nil {
+			t.Fatalf("image %d: %v", i.ID, err)
+		}
+		if !bytes.Equal(d.Thumbhash, hash) || d.Placeholder == "" || d.PlaceholderColor == "" {
+			t.Errorf("image %d: thumbhash not migrated", i.ID)
+		}
+	}
+}
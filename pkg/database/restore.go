@@ -0,0 +1,444 @@
+package database
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/Pineapple217/cvrs/pkg/config"
+	"github.com/Pineapple217/cvrs/pkg/ent/schema"
+	"github.com/spf13/cobra"
+)
+
+// Files that make up the live database, the WAL has to go together with it
+var databaseFiles = []string{BackupDatabase, BackupDatabase + "-wal", BackupDatabase + "-shm"}
+
+// RestoreReport lists inconsistencies between the restored database and the
+// restored image files
+type RestoreReport struct {
+	// Rows whose file is missing, as "<entity> <id>"
+	Missing []string
+	// Blobs that no row references
+	Orphans []string
+}
+
+// Only database.db and flat files in img/ are accepted, so a crafted archive
+// can not write outside of the data location.
+func checkEntryName(name string) error {
+	if name == BackupDatabase || name == BackupManifest {
+		return nil
+	}
+	dir, file := path.Split(name)
+	if dir == IMG_DIR+"/" && file != "" && file != "." && file != ".." && !strings.ContainsAny(file, `/\`) {
+		return nil
+	}
+	return fmt.Errorf("unexpected entry %q in archive", name)
+}
+
+// Extracts the archive into dir and verifies every entry against the manifest
+func extractBackup(r io.Reader, dir string) (Manifest, error) {
+	gzipReader, err := gzip.NewReader(r)
+	if err != nil {
+		return Manifest{}, fmt.Errorf("not a gzip archive: %w", err)
+	}
+	defer gzipReader.Close()
+	tarReader := tar.NewReader(gzipReader)
+
+	err = os.Mkdir(path.Join(dir, IMG_DIR), 0755)
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	extracted := map[string]ManifestEntry{}
+	var manifest *Manifest
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return Manifest{}, fmt.Errorf("corrupt archive: %w", err)
+		}
+		// Archives repacked by hand may contain the directory itself
+		if header.Typeflag == tar.TypeDir && path.Clean(header.Name) == IMG_DIR {
+			continue
+		}
+		if header.Typeflag != tar.TypeReg {
+			return Manifest{}, fmt.Errorf("unexpected entry %q in archive", header.Name)
+		}
+		err = checkEntryName(header.Name)
+		if err != nil {
+			return Manifest{}, err
+		}
+		if manifest != nil {
+			return Manifest{}, fmt.Errorf("entry %q after the manifest", header.Name)
+		}
+
+		if header.Name == BackupManifest {
+			manifest = &Manifest{}
+			err = json.NewDecoder(tarReader).Decode(manifest)
+			if err != nil {
+				return Manifest{}, fmt.Errorf("invalid manifest: %w", err)
+			}
+			continue
+		}
+		if _, ok := extracted[header.Name]; ok {
+			return Manifest{}, fmt.Errorf("duplicate entry %q in archive", header.Name)
+		}
+		entry, err := extractFile(tarReader, path.Join(dir, header.Name))
+		if err != nil {
+			return Manifest{}, err
+		}
+		entry.Path = header.Name
+		extracted[header.Name] = entry
+	}
+
+	if manifest == nil {
+		return Manifest{}, errors.New("archive has no manifest")
+	}
+	if manifest.Version < 1 || manifest.Version > manifestVersion {
+		return Manifest{}, fmt.Errorf("unsupported manifest version %d", manifest.Version)
+	}
+	if _, ok := extracted[BackupDatabase]; !ok {
+		return Manifest{}, errors.New("archive has no database")
+	}
+	if len(manifest.Files) != len(extracted) {
+		return Manifest{}, fmt.Errorf("manifest lists %d files, archive has %d", len(manifest.Files), len(extracted))
+	}
+	for _, want := range manifest.Files {
+		got, ok := extracted[want.Path]
+		if !ok {
+			return Manifest{}, fmt.Errorf("%s is missing from the archive", want.Path)
+		}
+		if got.Size != want.Size || got.SHA256 != want.SHA256 {
+			return Manifest{}, fmt.Errorf("%s does not match its checksum", want.Path)
+		}
+	}
+	return *manifest, nil
+}
+
+func extractFile(r io.Reader, dest string) (ManifestEntry, error) {
+	file, err := os.OpenFile(dest, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return ManifestEntry{}, err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	n, err := io.Copy(io.MultiWriter(file, hash), r)
+	if err != nil {
+		return ManifestEntry{}, err
+	}
+	return ManifestEntry{
+		Size:   n,
+		SHA256: hex.EncodeToString(hash.Sum(nil)),
+	}, file.Close()
+}
+
+// Reports if the data location already holds a database or images
+func dataLocationInUse(conf config.Database) (bool, error) {
+	_, err := os.Stat(path.Join(conf.DataLocation, BackupDatabase))
+	if err == nil {
+		return true, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return false, err
+	}
+	entries, err := os.ReadDir(path.Join(conf.DataLocation, IMG_DIR))
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	return len(entries) > 0, err
+}
+
+// Moves the extracted database and images into place. The current ones are
+// moved aside first and put back when anything goes wrong.
+func swapIn(dataLocation, staging, old string) (err error) {
+	moved := [][2]string{}
+	move := func(from, to string) error {
+		err := os.Rename(from, to)
+		if err == nil {
+			moved = append(moved, [2]string{from, to})
+		}
+		return err
+	}
+	defer func() {
+		if err == nil {
+			return
+		}
+		for i := len(moved) - 1; i >= 0; i-- {
+			if rerr := os.Rename(moved[i][1], moved[i][0]); rerr != nil {
+				err = fmt.Errorf("%w: failed to roll back %s: %v", err, moved[i][0], rerr)
+			}
+		}
+	}()
+
+	for _, name := range append(databaseFiles, IMG_DIR) {
+		err = move(path.Join(dataLocation, name), path.Join(old, name))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	for _, name := range []string{BackupDatabase, IMG_DIR} {
+		err = move(path.Join(staging, name), path.Join(dataLocation, name))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Restores a backup written by WriteBackup into the data location. Nothing in
+// the data location is touched until the whole archive has been verified.
+// The backups an incremental backup builds on are read from chainDir.
+// The server must not be running while restoring.
+func RestoreBackup(ctx context.Context, conf config.Database, storageConf config.Storage, r io.Reader, chainDir string, force bool) (RestoreReport, error) {
+	if storageConf.Backend != "fs" {
+		return RestoreReport{}, fmt.Errorf("restore only supports the fs storage backend")
+	}
+	if path.Join(conf.DataLocation, BackupDatabase) != sqlitePath(conf.SqliteOptions) {
+		return RestoreReport{}, fmt.Errorf("restore only supports a database at %s", path.Join(conf.DataLocation, BackupDatabase))
+	}
+	inUse, err := dataLocationInUse(conf)
+	if err != nil {
+		return RestoreReport{}, err
+	}
+	if inUse && !force {
+		return RestoreReport{}, fmt.Errorf("%s already holds data, use --force to overwrite it", conf.DataLocation)
+	}
+	err = CreateDir(conf.DataLocation)
+	if err != nil {
+		return RestoreReport{}, err
+	}
+
+	// Staying inside the data location keeps the renames on one filesystem
+	staging, err := os.MkdirTemp(conf.DataLocation, ".restore_*")
+	if err != nil {
+		return RestoreReport{}, err
+	}
+	defer os.RemoveAll(staging)
+	restored, err := extractChain(r, chainDir, staging)
+	if err != nil {
+		return RestoreReport{}, err
+	}
+
+	old, err := os.MkdirTemp(conf.DataLocation, ".replaced_*")
+	if err != nil {
+		return RestoreReport{}, err
+	}
+	err = swapIn(conf.DataLocation, restored, old)
+	if err != nil {
+		// Only empty after a complete roll back, otherwise the old data is kept
+		os.Remove(old)
+		return RestoreReport{}, err
+	}
+	err = os.RemoveAll(old)
+	if err != nil {
+		slog.Warn("Failed to clean up the replaced data", "dir", old, "error", err)
+	}
+
+	// Runs the migrations for archives of older versions
+	db, err := NewDatabase(conf, storageConf)
+	if err != nil {
+		return RestoreReport{}, err
+	}
+	defer db.Client.Close()
+	return db.CheckImageFiles(ctx)
+}
+
+// Extracts the archive and, for an incremental backup, every backup it builds
+// on into staging. The images of the increments are applied on top of the
+// full backup, the database always comes from the newest archive. Returns the
+// directory holding the result.
+func extractChain(r io.Reader, chainDir, staging string) (string, error) {
+	dirs := []string{}
+	manifests := []Manifest{}
+	for {
+		dir, err := os.MkdirTemp(staging, "archive_*")
+		if err != nil {
+			return "", err
+		}
+		manifest, err := extractBackup(r, dir)
+		if err != nil {
+			return "", err
+		}
+		slog.Info("Verified backup", "created_at", manifest.CreatedAt, "files", len(manifest.Files))
+		if len(manifests) > 0 && !manifest.CreatedAt.Before(manifests[len(manifests)-1].CreatedAt) {
+			return "", fmt.Errorf("%s is not older than the backup built on it", manifests[len(manifests)-1].Parent)
+		}
+		dirs = append(dirs, dir)
+		manifests = append(manifests, manifest)
+		if manifest.Parent == "" {
+			break
+		}
+
+		if chainDir == "" {
+			return "", fmt.Errorf("incremental backup builds on %s, restore it from a file next to that one", manifest.Parent)
+		}
+		if path.Base(manifest.Parent) != manifest.Parent || manifest.Parent == "." || manifest.Parent == ".." {
+			return "", fmt.Errorf("invalid parent %q in manifest", manifest.Parent)
+		}
+		f, err := os.Open(path.Join(chainDir, manifest.Parent))
+		if err != nil {
+			return "", err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	// The full backup is the last one, apply the increments from old to new
+	base := dirs[len(dirs)-1]
+	for i := len(dirs) - 2; i >= 0; i-- {
+		for _, name := range manifests[i].Removed {
+			err := os.Remove(path.Join(base, IMG_DIR, name))
+			if err != nil {
+				return "", fmt.Errorf("broken backup chain: %w", err)
+			}
+		}
+		for _, entry := range manifests[i].Files {
+			if entry.Path == BackupDatabase {
+				continue
+			}
+			dest := path.Join(base, entry.Path)
+			if _, err := os.Stat(dest); err == nil {
+				return "", fmt.Errorf("broken backup chain: %s is added twice", entry.Path)
+			}
+			err := os.Rename(path.Join(dirs[i], entry.Path), dest)
+			if err != nil {
+				return "", err
+			}
+		}
+	}
+	err := os.Rename(path.Join(dirs[0], BackupDatabase), path.Join(base, BackupDatabase))
+	if err != nil {
+		return "", err
+	}
+
+	// Version 1 manifests do not list the images
+	newest := manifests[0]
+	if newest.Version < 2 {
+		return base, nil
+	}
+	entries, err := os.ReadDir(path.Join(base, IMG_DIR))
+	if err != nil {
+		return "", err
+	}
+	images := make([]string, len(entries))
+	for i, e := range entries {
+		images[i] = e.Name()
+	}
+	slices.Sort(images)
+	want := slices.Clone(newest.Images)
+	slices.Sort(want)
+	if !slices.Equal(images, want) {
+		return "", errors.New("broken backup chain: restored images do not match the manifest")
+	}
+	return base, nil
+}
+
+// Extracts the file path from sqlite options like file:./data/database.db?_fk=1
+func sqlitePath(options string) string {
+	p, _, _ := strings.Cut(strings.TrimPrefix(options, "file:"), "?")
+	return path.Clean(p)
+}
+
+// Compares the image rows with the blobs in the store
+func (db *Database) CheckImageFiles(ctx context.Context) (RestoreReport, error) {
+	report := RestoreReport{Missing: []string{}, Orphans: []string{}}
+	blobs, err := db.Blobs.List(ctx, "")
+	if err != nil {
+		return report, err
+	}
+	files := map[string]bool{}
+	for _, b := range blobs {
+		files[b.Key] = false
+	}
+
+	// Soft-deleted rows can be restored, so their files still count
+	ctx = schema.SkipSoftDelete(ctx)
+	imgs, err := db.Client.Image.Query().All(ctx)
+	if err != nil {
+		return report, err
+	}
+	for _, img := range imgs {
+		if _, ok := files[img.File]; !ok {
+			report.Missing = append(report.Missing, "image "+img.ID.String())
+		}
+		files[img.File] = true
+	}
+	procImgs, err := db.Client.ProcessedImage.Query().All(ctx)
+	if err != nil {
+		return report, err
+	}
+	for _, procImg := range procImgs {
+		if _, ok := files[procImg.File]; !ok {
+			report.Missing = append(report.Missing, "processed_image "+procImg.ID.String())
+		}
+		files[procImg.File] = true
+	}
+
+	for _, b := range blobs {
+		if !files[b.Key] {
+			report.Orphans = append(report.Orphans, b.Key)
+		}
+	}
+	return report, nil
+}
+
+func GetRestoreCmd() *cobra.Command {
+	var force bool
+	cmd := &cobra.Command{
+		Use:   "restore <archive>",
+		Short: "restore a full instance backup, - reads it from stdin",
+		Long: "Restores a backup made with the backup command. The archive is verified against its\n" +
+			"manifest before anything is replaced. An incremental backup needs the backups it builds\n" +
+			"on in the same directory. Stop the server before restoring.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			conf, err := config.Load()
+			if err != nil {
+				return err
+			}
+
+			var r io.Reader = os.Stdin
+			chainDir := ""
+			if args[0] != "-" {
+				f, err := os.Open(args[0])
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				r = f
+				chainDir = filepath.Dir(args[0])
+			}
+
+			report, err := RestoreBackup(cmd.Context(), conf.Database, conf.Storage, r, chainDir, force)
+			if err != nil {
+				return err
+			}
+			for _, m := range report.Missing {
+				slog.Warn("File of row is missing", "row", m)
+			}
+			for _, o := range report.Orphans {
+				slog.Warn("File is not referenced by any row", "file", o)
+			}
+			slog.Info("Restored backup", "missing", len(report.Missing), "orphans", len(report.Orphans))
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&force, "force", false, "Overwrite the data location when it already holds data")
+	cmd.SilenceUsage = true
+	return cmd
+}
@@ -0,0 +1,205 @@
+package database
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"image/color"
+	"io"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Pineapple217/cvrs/pkg/config"
+	"github.com/Pineapple217/cvrs/pkg/ent"
+)
+
+func TestCheckEntryName(t *testing.T) {
+	tests := []struct {
+		name string
+		ok   bool
+	}{
+		{"database.db", true},
+		{"manifest.json", true},
+		{"img/1M56PQDXRQ03H", true},
+		{"img/", false},
+		{"img/..", false},
+		{"img/a/b", false},
+		{"../database.db", false},
+		{"/img/x", false},
+		{"img/..\\x", false},
+		{"config.yaml", false},
+	}
+	for _, tt := range tests {
+		if err := checkEntryName(tt.name); (err == nil) != tt.ok {
+			t.Errorf("checkEntryName(%q) = %v, want ok %t", tt.name, err, tt.ok)
+		}
+	}
+}
+
+// Data location that RestoreBackup accepts, with the database as a file in it
+func restoreTarget(t *testing.T) config.Database {
+	dir := t.TempDir()
+	return config.Database{
+		DataLocation:  dir,
+		SqliteOptions: "file:" + path.Join(dir, BackupDatabase) + "?_fk=1",
+	}
+}
+
+func blobKeys(t *testing.T, db *Database) []string {
+	t.Helper()
+	blobs, err := db.Blobs.List(context.Background(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := []string{}
+	for _, b := range blobs {
+		keys = append(keys, b.Key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func TestRestoreBackupChain(t *testing.T) {
+	db := newTestDatabase(t)
+	ctx := context.Background()
+	u, err := db.Client.User.Create().SetUsername("bob").Save(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	upload := func(name string, c color.Color) *ent.Image {
+		img, err := db.SaveImg(ctx, testUpload(t, name, testPNG(t, c)), u.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return img
+	}
+	gone := upload("gone.png", color.RGBA{255, 0, 0, 255})
+	upload("kept.png", color.RGBA{0, 255, 0, 255})
+
+	chainDir := t.TempDir()
+	full, err := db.writeBackupFile(ctx, path.Join(chainDir, "full.tar.gz"), nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = db.HardDeleteImg(ctx, gone.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	upload("new.png", color.RGBA{0, 0, 255, 255})
+	inc, err := db.writeBackupFile(ctx, path.Join(chainDir, "inc.tar.gz"), &full, "full.tar.gz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if inc.Chain != 1 || len(inc.Removed) != 1 || len(inc.Files) != 2 {
+		t.Errorf("increment has chain %d, %d removed and %d files", inc.Chain, len(inc.Removed), len(inc.Files))
+	}
+	want := blobKeys(t, db)
+
+	conf := restoreTarget(t)
+	f, err := os.Open(path.Join(chainDir, "inc.tar.gz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	report, err := RestoreBackup(ctx, conf, config.Storage{Backend: "fs"}, f, chainDir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Missing) != 0 || len(report.Orphans) != 0 {
+		t.Errorf("restore report %+v", report)
+	}
+
+	restored, err := NewDatabase(conf, config.Storage{Backend: "fs"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer restored.Client.Close()
+	if got := blobKeys(t, restored); !slices.Equal(got, want) {
+		t.Errorf("restored images %v, want %v", got, want)
+	}
+	names := []string{}
+	for _, img := range restored.Client.Image.Query().AllX(ctx) {
+		names = append(names, img.OriginalName)
+	}
+	slices.Sort(names)
+	if !slices.Equal(names, []string{"kept.png", "new.png"}) {
+		t.Errorf("restored image rows %v", names)
+	}
+}
+
+// Rewrites the archive with the first byte of every image flipped, the
+// manifest is left as it was
+func tamper(t *testing.T, archive []byte) []byte {
+	t.Helper()
+	gzipReader, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tarReader := tar.NewReader(gzipReader)
+	var out bytes.Buffer
+	gzipWriter := gzip.NewWriter(&out)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := io.ReadAll(tarReader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.HasPrefix(header.Name, IMG_DIR+"/") {
+			data[0] ^= 0xff
+		}
+		err = tarWriter.WriteHeader(header)
+		if err == nil {
+			_, err = tarWriter.Write(data)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return out.Bytes()
+}
+
+func TestRestoreBackupTampered(t *testing.T) {
+	db := newTestDatabase(t)
+	ctx := context.Background()
+	u, err := db.Client.User.Create().SetUsername("bob").Save(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = db.SaveImg(ctx, testUpload(t, "a.png", testPNG(t, color.White)), u.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var archive bytes.Buffer
+	_, err = db.WriteBackup(ctx, &archive)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	conf := restoreTarget(t)
+	_, err = RestoreBackup(ctx, conf, config.Storage{Backend: "fs"}, bytes.NewReader(tamper(t, archive.Bytes())), "", false)
+	if err == nil || !strings.Contains(err.Error(), "does not match its checksum") {
+		t.Fatalf("expected a checksum error, got %v", err)
+	}
+	// The data location is only touched once the archive is verified
+	if _, err := os.Stat(path.Join(conf.DataLocation, BackupDatabase)); !os.IsNotExist(err) {
+		t.Errorf("database was restored from a tampered archive: %v", err)
+	}
+}
@@ -0,0 +1,134 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"os"
+	"path"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/Pineapple217/cvrs/pkg/config"
+)
+
+// Archives made with the backup command
+const backupNameLayout = "backup_2006-01-02_15-04-05.tar.gz"
+
+// Archives made on the backup interval, only these are pruned
+const scheduledBackupNameLayout = "scheduled_2006-01-02_15-04-05.tar.gz"
+
+type backupFile struct {
+	Name      string
+	CreatedAt time.Time
+}
+
+// Picks the backups to keep, the newest backup of each of the last KeepDaily
+// days, KeepWeekly weeks and KeepMonthly months. The newest backup is always
+// kept.
+func backupsToKeep(conf config.Backup, backups []backupFile) map[string]bool {
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].CreatedAt.After(backups[j].CreatedAt)
+	})
+	keep := map[string]bool{}
+	if len(backups) > 0 {
+		keep[backups[0].Name] = true
+	}
+
+	bucket := func(n int, period func(time.Time) string) {
+		seen := map[string]bool{}
+		for _, b := range backups {
+			if len(seen) >= n {
+				return
+			}
+			p := period(b.CreatedAt)
+			if seen[p] {
+				continue
+			}
+			seen[p] = true
+			keep[b.Name] = true
+		}
+	}
+	bucket(conf.KeepDaily, func(t time.Time) string {
+		return t.Format(time.DateOnly)
+	})
+	bucket(conf.KeepWeekly, func(t time.Time) string {
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	})
+	bucket(conf.KeepMonthly, func(t time.Time) string {
+		return t.Format("2006-01")
+	})
+	return keep
+}
+
+// Lists the archives in the backups directory that are named after layout,
+// anything else is left alone.
+func (db *Database) listBackups(layout string) ([]backupFile, error) {
+	entries, err := os.ReadDir(path.Join(db.Conf.DataLocation, BACKUP_DIR))
+	if err != nil {
+		return nil, err
+	}
+	backups := []backupFile{}
+	for _, e := range entries {
+		if !e.Type().IsRegular() {
+			continue
+		}
+		t, err := time.ParseInLocation(layout, e.Name(), time.Local)
+		if err != nil {
+			continue
+		}
+		backups = append(backups, backupFile{Name: e.Name(), CreatedAt: t})
+	}
+	return backups, nil
+}
+
+// Deletes the scheduled archives in the backups directory that fall outside of
+// the retention policy. The backups a kept incremental backup builds on are
+// kept as well, it can not be restored without them. Archives made with the
+// backup command are never pruned.
+func (db *Database) PruneBackups(conf config.Backup) ([]string, error) {
+	dir := path.Join(db.Conf.DataLocation, BACKUP_DIR)
+	backups, err := db.listBackups(scheduledBackupNameLayout)
+	if err != nil {
+		return nil, err
+	}
+
+	keep := backupsToKeep(conf, backups)
+	for _, name := range slices.Collect(maps.Keys(keep)) {
+		for {
+			manifest, err := readBackupIndex(path.Join(dir, name))
+			if errors.Is(err, os.ErrNotExist) {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			if manifest.Parent == "" {
+				break
+			}
+			name = manifest.Parent
+			keep[name] = true
+		}
+	}
+
+	pruned := []string{}
+	for _, b := range backups {
+		if keep[b.Name] {
+			continue
+		}
+		err = os.Remove(path.Join(dir, b.Name))
+		if err != nil {
+			return pruned, err
+		}
+		err = os.Remove(backupIndexPath(path.Join(dir, b.Name)))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return pruned, err
+		}
+		slog.Debug("Pruned backup", "file", b.Name)
+		pruned = append(pruned, b.Name)
+	}
+	return pruned, nil
+}
@@ -0,0 +1,115 @@
+package database
+
+import (
+	"os"
+	"path"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Pineapple217/cvrs/pkg/config"
+)
+
+func TestBackupsToKeep(t *testing.T) {
+	// One backup a day at noon, newest first, for 90 days up to a Sunday
+	last := time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC)
+	backups := []backupFile{}
+	for i := range 90 {
+		created := last.AddDate(0, 0, -i)
+		backups = append(backups, backupFile{Name: created.Format(scheduledBackupNameLayout), CreatedAt: created})
+	}
+	name := func(y int, m time.Month, d int) string {
+		return time.Date(y, m, d, 12, 0, 0, 0, time.UTC).Format(scheduledBackupNameLayout)
+	}
+
+	keep := backupsToKeep(config.Backup{KeepDaily: 3, KeepWeekly: 2, KeepMonthly: 3}, backups)
+	want := []string{
+		// Daily
+		name(2024, 6, 30), name(2024, 6, 29), name(2024, 6, 28),
+		// Weekly, the 30th also covers this week
+		name(2024, 6, 23),
+		// Monthly, the 30th also covers June
+		name(2024, 5, 31), name(2024, 4, 30),
+	}
+	if len(keep) != len(want) {
+		t.Errorf("expected %d backups to be kept, got %d: %v", len(want), len(keep), keep)
+	}
+	for _, w := range want {
+		if !keep[w] {
+			t.Errorf("expected %s to be kept", w)
+		}
+	}
+
+	keep = backupsToKeep(config.Backup{}, backups)
+	if len(keep) != 1 || !keep[name(2024, 6, 30)] {
+		t.Errorf("expected only the newest backup to be kept, got %v", keep)
+	}
+}
+
+func TestPruneBackupsKeepsManual(t *testing.T) {
+	db := newTestDatabase(t)
+	dir := path.Join(db.Conf.DataLocation, BACKUP_DIR)
+	last := time.Date(2024, 6, 30, 12, 0, 0, 0, time.Local)
+	names := []string{
+		last.Format(scheduledBackupNameLayout),
+		last.AddDate(0, 0, -1).Format(scheduledBackupNameLayout),
+		last.AddDate(0, 0, -2).Format(scheduledBackupNameLayout),
+		// Older than every scheduled one, but made by hand
+		last.AddDate(0, -3, 0).Format(backupNameLayout),
+		"notes.txt",
+	}
+	for _, name := range names {
+		err := os.WriteFile(path.Join(dir, name), nil, 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	pruned, err := db.PruneBackups(config.Backup{KeepDaily: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(pruned, []string{names[2]}) {
+		t.Errorf("pruned %v, want %v", pruned, names[2:3])
+	}
+	for _, name := range []string{names[0], names[1], names[3], names[4]} {
+		if _, err := os.Stat(path.Join(dir, name)); err != nil {
+			t.Errorf("%s was removed: %v", name, err)
+		}
+	}
+}
+
+func TestReserveBackupName(t *testing.T) {
+	db := newTestDatabase(t)
+	dir := path.Join(db.Conf.DataLocation, BACKUP_DIR)
+	// Named before backups had milliseconds, it still counts
+	old := time.Now().Add(-time.Hour).Format(scheduledBackupNameLayout)
+	err := os.WriteFile(path.Join(dir, old), []byte("archive"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Made within the same second, every one gets its own file
+	names := map[string]bool{}
+	for range 5 {
+		name, err := reserveBackupName(dir, scheduledBackupNameLayout)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if names[name] {
+			t.Errorf("%s reserved twice", name)
+		}
+		names[name] = true
+	}
+	backups, err := db.listBackups(scheduledBackupNameLayout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backups) != 6 {
+		t.Errorf("listed %d backups, want 6", len(backups))
+	}
+	data, err := os.ReadFile(path.Join(dir, old))
+	if err != nil || string(data) != "archive" {
+		t.Errorf("existing archive was overwritten: %q, %v", data, err)
+	}
+}
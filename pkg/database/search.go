@@ -0,0 +1,305 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/Pineapple217/cvrs/pkg/config"
+	"github.com/Pineapple217/cvrs/pkg/ent"
+	"github.com/Pineapple217/cvrs/pkg/ent/hook"
+	"github.com/Pineapple217/cvrs/pkg/pid"
+	"github.com/spf13/cobra"
+)
+
+type SearchKind string
+
+const (
+	SearchArtist  SearchKind = "artist"
+	SearchRelease SearchKind = "release"
+	SearchTrack   SearchKind = "track"
+)
+
+var ErrSearchDisabled = errors.New("search is disabled, sqlite was built without FTS5")
+
+type SearchHit struct {
+	Kind SearchKind
+	Ref  pid.ID
+	Rank float64
+}
+
+// Every searchable entity gets one row in search_docs, ref is the ID of the
+// entity itself. The FTS5 index reads its content from there and is kept in
+// sync by the triggers, so a row is found through the (kind, ref) index
+// instead of a scan of the FTS5 table.
+var createSearchTables = []string{
+	`CREATE TABLE IF NOT EXISTS search_docs (
+		id INTEGER PRIMARY KEY,
+		kind TEXT NOT NULL,
+		ref INTEGER NOT NULL,
+		text TEXT NOT NULL,
+		UNIQUE (kind, ref)
+	)`,
+	`CREATE VIRTUAL TABLE IF NOT EXISTS search USING fts5(
+		kind UNINDEXED,
+		ref UNINDEXED,
+		text,
+		content = 'search_docs',
+		content_rowid = 'id',
+		tokenize = 'unicode61 remove_diacritics 2',
+		prefix = '2 3'
+	)`,
+	`CREATE TRIGGER IF NOT EXISTS search_docs_insert AFTER INSERT ON search_docs BEGIN
+		INSERT INTO search (rowid, kind, ref, text) VALUES (new.id, new.kind, new.ref, new.text);
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS search_docs_delete AFTER DELETE ON search_docs BEGIN
+		INSERT INTO search (search, rowid, kind, ref, text) VALUES ('delete', old.id, old.kind, old.ref, old.text);
+	END`,
+}
+
+// Creates the FTS5 index and registers the hooks that keep it in sync.
+// mattn/go-sqlite3 only includes FTS5 when built with -tags sqlite_fts5, without
+// it the rest of the app keeps working and search is turned off.
+func (d *Database) initSearch(ctx context.Context) error {
+	ok, err := d.createSearch(ctx)
+	if err != nil || !ok {
+		return err
+	}
+	d.Client.Artist.Use(searchHook(SearchArtist, "name"))
+	d.Client.Release.Use(searchHook(SearchRelease, "name"))
+	d.Client.Track.Use(searchHook(SearchTrack, "title"))
+	return nil
+}
+
+// Reports if FTS5 is available. The index used to be a plain FTS5 table, it
+// is replaced and rebuilt.
+func (d *Database) createSearch(ctx context.Context) (bool, error) {
+	rows, err := d.Client.QueryContext(ctx, "SELECT sql FROM sqlite_master WHERE name = 'search'")
+	if err != nil {
+		return false, err
+	}
+	var sql string
+	if rows.Next() {
+		err = rows.Scan(&sql)
+	}
+	rows.Close()
+	if err != nil {
+		return false, err
+	}
+	outdated := sql != "" && !strings.Contains(sql, "search_docs")
+	if outdated {
+		slog.Info("Moving the search index to an external content table")
+		_, err = d.Client.ExecContext(ctx, "DROP TABLE search")
+		if err != nil {
+			if strings.Contains(err.Error(), "no such module: fts5") {
+				slog.Warn("Search disabled, build with -tags sqlite_fts5 to enable it")
+				return false, nil
+			}
+			return false, err
+		}
+	}
+
+	for _, stmt := range createSearchTables {
+		_, err = d.Client.ExecContext(ctx, stmt)
+		if err != nil {
+			if strings.Contains(err.Error(), "no such module: fts5") {
+				slog.Warn("Search disabled, build with -tags sqlite_fts5 to enable it")
+				return false, nil
+			}
+			return false, fmt.Errorf("failed to create search index: %w", err)
+		}
+	}
+	d.search = true
+	if outdated {
+		return true, d.ReindexSearch(ctx)
+	}
+	return true, nil
+}
+
+type searchMutation interface {
+	ent.Mutation
+	ID() (pid.ID, bool)
+	IDs(ctx context.Context) ([]pid.ID, error)
+	Client() *ent.Client
+}
+
+// Mirrors creates, renames and deletes of an entity into the search index,
+// inside the same transaction as the mutation itself.
+func searchHook(kind SearchKind, textField string) ent.Hook {
+	return hook.On(func(next ent.Mutator) ent.Mutator {
+		return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+			sm, ok := m.(searchMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+
+			// IDs have to be collected before the rows are gone
+			var ids []pid.ID
+			var err error
+			if m.Op().Is(ent.OpDelete | ent.OpDeleteOne | ent.OpUpdate) {
+				ids, err = sm.IDs(ctx)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			v, err := next.Mutate(ctx, m)
+			if err != nil {
+				return nil, err
+			}
+			c := sm.Client()
+
+			if m.Op().Is(ent.OpDelete | ent.OpDeleteOne) {
+				return v, removeFromSearch(ctx, c, kind, ids...)
+			}
+			text, ok := m.Field(textField)
+			if !ok {
+				return v, nil
+			}
+			if m.Op().Is(ent.OpCreate | ent.OpUpdateOne) {
+				id, _ := sm.ID()
+				ids = []pid.ID{id}
+			}
+			err = removeFromSearch(ctx, c, kind, ids...)
+			if err != nil {
+				return nil, err
+			}
+			for _, id := range ids {
+				_, err = c.ExecContext(ctx,
+					"INSERT INTO search_docs (kind, ref, text) VALUES (?, ?, ?)",
+					kind, int64(id), text)
+				if err != nil {
+					return nil, err
+				}
+			}
+			return v, nil
+		})
+	}, ent.OpCreate|ent.OpUpdate|ent.OpUpdateOne|ent.OpDelete|ent.OpDeleteOne)
+}
+
+func removeFromSearch(ctx context.Context, c *ent.Client, kind SearchKind, ids ...pid.ID) error {
+	for _, id := range ids {
+		_, err := c.ExecContext(ctx,
+			"DELETE FROM search_docs WHERE kind = ? AND ref = ?",
+			kind, int64(id))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Turns user input into an FTS5 query where every word is matched as a
+// prefix, so FTS5 syntax in the input can never cause a query error.
+func searchQuery(q string) string {
+	terms := []string{}
+	for _, word := range strings.Fields(q) {
+		word = strings.ReplaceAll(word, `"`, `""`)
+		terms = append(terms, fmt.Sprintf(`"%s"*`, word))
+	}
+	return strings.Join(terms, " ")
+}
+
+// Returns the best matching entities, best match first. An empty kinds
+// list searches everything.
+func (d *Database) Search(ctx context.Context, q string, limit int, kinds ...SearchKind) ([]SearchHit, error) {
+	if !d.search {
+		return nil, ErrSearchDisabled
+	}
+	match := searchQuery(q)
+	if match == "" {
+		return []SearchHit{}, nil
+	}
+
+	query := "SELECT kind, ref, rank FROM search WHERE search MATCH ?"
+	args := []any{match}
+	if len(kinds) > 0 {
+		query += " AND kind IN (?" + strings.Repeat(", ?", len(kinds)-1) + ")"
+		for _, k := range kinds {
+			args = append(args, k)
+		}
+	}
+	query += " ORDER BY rank LIMIT ?"
+	args = append(args, limit)
+
+	rows, err := d.Client.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	hits := []SearchHit{}
+	for rows.Next() {
+		var hit SearchHit
+		var ref int64
+		err = rows.Scan(&hit.Kind, &ref, &hit.Rank)
+		if err != nil {
+			return nil, err
+		}
+		hit.Ref = pid.ID(ref)
+		hits = append(hits, hit)
+	}
+	return hits, rows.Err()
+}
+
+// Rebuilds the whole search index from the entity tables
+func (d *Database) ReindexSearch(ctx context.Context) error {
+	if !d.search {
+		return ErrSearchDisabled
+	}
+	tx, err := d.Client.Tx(ctx)
+	if err != nil {
+		return err
+	}
+	for _, stmt := range []string{
+		"DELETE FROM search_docs",
+		"INSERT INTO search_docs (kind, ref, text) SELECT '" + string(SearchArtist) + "', id, name FROM artists",
+		"INSERT INTO search_docs (kind, ref, text) SELECT '" + string(SearchRelease) + "', id, name FROM releases",
+		"INSERT INTO search_docs (kind, ref, text) SELECT '" + string(SearchTrack) + "', id, title FROM tracks",
+		// The index may be out of sync, that is why it is rebuilt
+		"INSERT INTO search (search) VALUES ('rebuild')",
+		"INSERT INTO search (search) VALUES ('optimize')",
+	} {
+		_, err = tx.Client().ExecContext(ctx, stmt)
+		if err != nil {
+			if rerr := tx.Rollback(); rerr != nil {
+				err = fmt.Errorf("%w: %v", err, rerr)
+			}
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func GetSearchCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "search",
+		Short: "Manage the search index",
+	}
+	reindexCmd := &cobra.Command{
+		Use:   "reindex",
+		Short: "Rebuild the search index from scratch",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			conf, err := config.Load()
+			if err != nil {
+				return err
+			}
+			db, err := NewDatabase(conf.Database, conf.Storage)
+			if err != nil {
+				return err
+			}
+			err = db.ReindexSearch(cmd.Context())
+			if err != nil {
+				return err
+			}
+			slog.Info("Rebuilt search index")
+			return nil
+		},
+	}
+	reindexCmd.SilenceUsage = true
+
+	cmd.AddCommand(reindexCmd)
+	return cmd
+}
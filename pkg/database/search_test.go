@@ -0,0 +1,191 @@
+package database
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Pineapple217/cvrs/pkg/ent/release"
+	"github.com/Pineapple217/cvrs/pkg/ent/track"
+	"github.com/Pineapple217/cvrs/pkg/pid"
+)
+
+func TestSearchQuery(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"", ""},
+		{"   ", ""},
+		{"daft", `"daft"*`},
+		{"daft  punk", `"daft"* "punk"*`},
+		{`say "hi"`, `"say"* """hi"""*`},
+		{"a OR b", `"a"* "OR"* "b"*`},
+		{"NEAR(x y)", `"NEAR(x"* "y)"*`},
+	}
+	for _, tt := range tests {
+		if got := searchQuery(tt.in); got != tt.want {
+			t.Errorf("searchQuery(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestSearchHooks(t *testing.T) {
+	db := newTestDatabase(t)
+	if !db.search {
+		t.Skip("sqlite was built without FTS5, run with -tags sqlite_fts5")
+	}
+	ctx := context.Background()
+
+	indexed := func(kind SearchKind, id pid.ID) string {
+		t.Helper()
+		rows, err := db.Client.QueryContext(ctx,
+			"SELECT text FROM search WHERE kind = ? AND ref = ?", kind, int64(id))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer rows.Close()
+		texts := []string{}
+		for rows.Next() {
+			var text string
+			if err := rows.Scan(&text); err != nil {
+				t.Fatal(err)
+			}
+			texts = append(texts, text)
+		}
+		if len(texts) > 1 {
+			t.Errorf("%s %s is indexed %d times", kind, id, len(texts))
+		}
+		return strings.Join(texts, ",")
+	}
+
+	a, err := db.Client.Artist.Create().SetName("Daft Punk").Save(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := db.Client.Release.Create().
+		SetName("Discovery").
+		SetType(release.TypeAlbum).
+		SetReleaseDate(time.Date(2001, 3, 12, 0, 0, 0, 0, time.UTC)).
+		Save(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr, err := db.Client.Track.Create().
+		SetTitle("One More Time").
+		SetPosition(1).
+		SetRelease(r).
+		Save(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := indexed(SearchArtist, a.ID); got != "Daft Punk" {
+		t.Errorf("artist indexed as %q", got)
+	}
+	if got := indexed(SearchRelease, r.ID); got != "Discovery" {
+		t.Errorf("release indexed as %q", got)
+	}
+	if got := indexed(SearchTrack, tr.ID); got != "One More Time" {
+		t.Errorf("track indexed as %q", got)
+	}
+
+	err = db.Client.Artist.UpdateOne(a).SetName("Thomas Bangalter").Exec(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := indexed(SearchArtist, a.ID); got != "Thomas Bangalter" {
+		t.Errorf("renamed artist indexed as %q", got)
+	}
+	_, err = db.Client.Track.Update().
+		Where(track.IDEQ(tr.ID)).
+		SetTitle("Aerodynamic").
+		Save(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := indexed(SearchTrack, tr.ID); got != "Aerodynamic" {
+		t.Errorf("bulk renamed track indexed as %q", got)
+	}
+
+	err = db.Client.Track.DeleteOne(tr).Exec(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := indexed(SearchTrack, tr.ID); got != "" {
+		t.Errorf("deleted track still indexed as %q", got)
+	}
+
+	hits, err := db.Search(ctx, "bang", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hits) != 1 || hits[0].Kind != SearchArtist || hits[0].Ref != a.ID {
+		t.Errorf("unexpected hits %+v", hits)
+	}
+}
+
+func TestSearchRemovalUsesIndex(t *testing.T) {
+	db := newTestDatabase(t)
+	if !db.search {
+		t.Skip("sqlite was built without FTS5, run with -tags sqlite_fts5")
+	}
+	rows, err := db.Client.QueryContext(context.Background(),
+		"EXPLAIN QUERY PLAN DELETE FROM search_docs WHERE kind = ? AND ref = ?", SearchArtist, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	plan := []string{}
+	for rows.Next() {
+		var id, parent, unused int
+		var detail string
+		if err := rows.Scan(&id, &parent, &unused, &detail); err != nil {
+			t.Fatal(err)
+		}
+		plan = append(plan, detail)
+	}
+	if !strings.Contains(strings.Join(plan, "\n"), "USING INDEX") {
+		t.Errorf("removing from the search index scans the table: %v", plan)
+	}
+}
+
+func TestSearchMigratesOldIndex(t *testing.T) {
+	db := newTestDatabase(t)
+	if !db.search {
+		t.Skip("sqlite was built without FTS5, run with -tags sqlite_fts5")
+	}
+	ctx := context.Background()
+	a, err := db.Client.Artist.Create().SetName("Daft Punk").Save(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The index as it was before search_docs
+	for _, stmt := range []string{
+		"DROP TABLE search",
+		"DROP TABLE search_docs",
+		`CREATE VIRTUAL TABLE search USING fts5(kind UNINDEXED, ref UNINDEXED, text)`,
+		"INSERT INTO search (kind, ref, text) VALUES ('artist', 1, 'stale')",
+	} {
+		if _, err := db.Client.ExecContext(ctx, stmt); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ok, err := db.createSearch(ctx)
+	if err != nil || !ok {
+		t.Fatalf("createSearch = %v, %v", ok, err)
+	}
+	hits, err := db.Search(ctx, "daft", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hits) != 1 || hits[0].Ref != a.ID {
+		t.Errorf("unexpected hits after the migration %+v", hits)
+	}
+	hits, err = db.Search(ctx, "stale", 10)
+	if err != nil || len(hits) != 0 {
+		t.Errorf("old rows survived the migration: %+v, %v", hits, err)
+	}
+}
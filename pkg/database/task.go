@@ -4,4 +4,17 @@ import "github.com/Pineapple217/cvrs/pkg/pid"
 
 type TaskScaleImg struct {
 	ImageId pid.ID `json:"imageId"`
+	// Names of the variants to make, all configured variants when empty
+	Variants []string `json:"variants,omitempty"`
+}
+
+type TaskAnalyzeImg struct {
+	ImageId pid.ID `json:"imageId"`
+}
+
+type TaskBackup struct {
+	// Prune old archives with the retention policy afterwards
+	Prune bool `json:"prune"`
+	// Make an incremental backup, every FullEvery-th one is full
+	Incremental bool `json:"incremental"`
 }
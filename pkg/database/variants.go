@@ -0,0 +1,101 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Pineapple217/cvrs/pkg/config"
+	"github.com/Pineapple217/cvrs/pkg/ent"
+	entImage "github.com/Pineapple217/cvrs/pkg/ent/image"
+	"github.com/Pineapple217/cvrs/pkg/ent/processedimage"
+	"github.com/Pineapple217/cvrs/pkg/ent/schema"
+	"github.com/Pineapple217/cvrs/pkg/ent/task"
+	"github.com/Pineapple217/cvrs/pkg/pid"
+)
+
+// Queues a scale task for every file, the worker gives all images with that
+// file the new variants. Variants limits the tasks to those names, all
+// configured variants are made when it is empty. Returns how many tasks were
+// queued. Files only used by soft-deleted images are included, those images
+// can still be restored.
+func (d *Database) RegenerateVariants(ctx context.Context, variants []string) (int, error) {
+	ctx = schema.SkipSoftDelete(ctx)
+	imgs, err := d.Client.Image.Query().
+		Order(ent.Asc(entImage.FieldID)).
+		All(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	// Start transaction ================================
+	tx, err := d.Client.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return 0, err
+	}
+	seen := map[string]bool{}
+	creates := []*ent.TaskCreate{}
+	for _, img := range imgs {
+		if seen[img.File] {
+			continue
+		}
+		seen[img.File] = true
+		payload, err := json.Marshal(TaskScaleImg{
+			ImageId:  img.ID,
+			Variants: variants,
+		})
+		if err != nil {
+			if rerr := tx.Rollback(); rerr != nil {
+				err = fmt.Errorf("%w: %v", err, rerr)
+			}
+			return 0, err
+		}
+		creates = append(creates, tx.Task.Create().
+			SetType(task.TypeScaleImg).
+			SetPayload(payload))
+	}
+	err = tx.Task.CreateBulk(creates...).Exec(ctx)
+	if err != nil {
+		if rerr := tx.Rollback(); rerr != nil {
+			err = fmt.Errorf("%w: %v", err, rerr)
+		}
+		return 0, err
+	}
+	err = tx.Commit()
+	if err != nil {
+		return 0, err
+	}
+	// End transaction ===================================
+	return len(creates), nil
+}
+
+// Removes the variants of an image that are not configured anymore, or that
+// were replaced by a newer one with the same name. Returns how many were
+// removed. Soft-deleted variants count as well, they come back with their
+// source.
+func (d *Database) RemoveStaleVariants(ctx context.Context, source pid.ID, conf config.Images) (int, error) {
+	ctx = schema.SkipSoftDelete(ctx)
+	procImgs, err := d.Client.ProcessedImage.Query().
+		Where(processedimage.HasSourceWith(entImage.IDEQ(source))).
+		Order(ent.Desc(processedimage.FieldCreatedAt)).
+		All(ctx)
+	if err != nil {
+		return 0, err
+	}
+	seen := map[string]bool{}
+	removed := 0
+	for _, procImg := range procImgs {
+		_, configured := conf.Variant(procImg.Variant)
+		if configured && !seen[procImg.Variant] {
+			seen[procImg.Variant] = true
+			continue
+		}
+		err = d.HardDeleteProcessedImg(ctx, procImg.ID)
+		if err != nil {
+			return removed, err
+		}
+		removed++
+	}
+	return removed, nil
+}
@@ -0,0 +1,102 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Pineapple217/cvrs/pkg/config"
+	entImage "github.com/Pineapple217/cvrs/pkg/ent/image"
+	"github.com/Pineapple217/cvrs/pkg/ent/processedimage"
+)
+
+func TestRemoveStaleVariants(t *testing.T) {
+	db := newTestDatabase(t)
+	ctx := context.Background()
+	u, err := db.Client.User.Create().SetUsername("bob").Save(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conf := config.Images{Variants: []config.ImageVariant{{Name: "small"}, {Name: "large"}}}
+
+	type variant struct {
+		name string
+		// Minutes ago the variant was made
+		age int
+	}
+	tests := []struct {
+		name     string
+		variants []variant
+		// Indexes of the variants that are kept
+		kept []int
+	}{
+		{"none", nil, nil},
+		{"configured", []variant{{"small", 0}, {"large", 0}}, []int{0, 1}},
+		{"not configured", []variant{{"small", 0}, {"huge", 0}}, []int{0}},
+		{"replaced", []variant{{"small", 10}, {"small", 0}, {"large", 5}}, []int{1, 2}},
+		{"replaced twice", []variant{{"large", 0}, {"large", 20}, {"large", 10}}, []int{0}},
+		{"all stale", []variant{{"medium", 0}, {"huge", 1}}, nil},
+	}
+	for i, tt := range tests {
+		file := fmt.Sprintf("original%d", i)
+		img, err := db.Client.Image.Create().
+			SetFile(file).
+			SetOriginalName(file + ".png").
+			SetType(entImage.TypePNG).
+			SetDimentionWidth(32).
+			SetDimentionHeight(32).
+			SetSizeBits(1).
+			SetUploader(u).
+			Save(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files := []string{}
+		for j, v := range tt.variants {
+			files = append(files, fmt.Sprintf("%s_%d", file, j))
+			putBlob(t, db.Blobs, files[j], []byte(files[j]))
+			_, err = db.Client.ProcessedImage.Create().
+				SetFile(files[j]).
+				SetVariant(v.name).
+				SetType(processedimage.TypeWEBP).
+				SetWidth(16).
+				SetHeight(16).
+				SetSizeBits(1).
+				SetCreatedAt(time.Now().Add(-time.Duration(v.age) * time.Minute)).
+				SetSource(img).
+				Save(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		removed, err := db.RemoveStaleVariants(ctx, img.ID, conf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if removed != len(tt.variants)-len(tt.kept) {
+			t.Errorf("%s: removed %d, want %d", tt.name, removed, len(tt.variants)-len(tt.kept))
+		}
+		kept := []string{}
+		for _, p := range db.Client.Image.QueryProccesedImage(img).AllX(ctx) {
+			kept = append(kept, p.File)
+		}
+		slices.Sort(kept)
+		want := []string{}
+		for _, j := range tt.kept {
+			want = append(want, files[j])
+		}
+		if !slices.Equal(kept, want) {
+			t.Errorf("%s: kept %v, want %v", tt.name, kept, want)
+		}
+		// The files of removed variants are deleted with them
+		for j, f := range files {
+			_, err := db.Blobs.Stat(ctx, f)
+			if slices.Contains(tt.kept, j) != (err == nil) {
+				t.Errorf("%s: blob %s kept %v, want %v", tt.name, f, err == nil, slices.Contains(tt.kept, j))
+			}
+		}
+	}
+}
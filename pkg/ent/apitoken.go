@@ -0,0 +1,211 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/Pineapple217/cvrs/pkg/ent/apitoken"
+	"github.com/Pineapple217/cvrs/pkg/ent/user"
+	"github.com/Pineapple217/cvrs/pkg/pid"
+)
+
+// ApiToken is the model entity for the ApiToken schema.
+type ApiToken struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID pid.ID `json:"id,omitempty"`
+	// Name holds the value of the "name" field.
+	Name string `json:"name,omitempty"`
+	// TokenHash holds the value of the "token_hash" field.
+	TokenHash []byte `json:"-"`
+	// Scopes holds the value of the "scopes" field.
+	Scopes []string `json:"scopes,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at,omitzero"`
+	// LastUsedAt holds the value of the "last_used_at" field.
+	LastUsedAt *time.Time `json:"last_used_at,omitzero"`
+	// ExpiresAt holds the value of the "expires_at" field.
+	ExpiresAt *time.Time `json:"expires_at,omitzero"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the ApiTokenQuery when eager-loading is set.
+	Edges           ApiTokenEdges `json:"edges"`
+	user_api_tokens *pid.ID
+	selectValues    sql.SelectValues
+}
+
+// ApiTokenEdges holds the relations/edges for other nodes in the graph.
+type ApiTokenEdges struct {
+	// User holds the value of the user edge.
+	User *User `json:"user,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [1]bool
+}
+
+// UserOrErr returns the User value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e ApiTokenEdges) UserOrErr() (*User, error) {
+	if e.User != nil {
+		return e.User, nil
+	} else if e.loadedTypes[0] {
+		return nil, &NotFoundError{label: user.Label}
+	}
+	return nil, &NotLoadedError{edge: "user"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*ApiToken) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case apitoken.FieldTokenHash, apitoken.FieldScopes:
+			values[i] = new([]byte)
+		case apitoken.FieldID:
+			values[i] = new(sql.NullInt64)
+		case apitoken.FieldName:
+			values[i] = new(sql.NullString)
+		case apitoken.FieldCreatedAt, apitoken.FieldLastUsedAt, apitoken.FieldExpiresAt:
+			values[i] = new(sql.NullTime)
+		case apitoken.ForeignKeys[0]: // user_api_tokens
+			values[i] = new(sql.NullInt64)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the ApiToken fields.
+func (at *ApiToken) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case apitoken.FieldID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[i])
+			} else if value.Valid {
+				at.ID = pid.ID(value.Int64)
+			}
+		case apitoken.FieldName:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field name", values[i])
+			} else if value.Valid {
+				at.Name = value.String
+			}
+		case apitoken.FieldTokenHash:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field token_hash", values[i])
+			} else if value != nil {
+				at.TokenHash = *value
+			}
+		case apitoken.FieldScopes:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field scopes", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &at.Scopes); err != nil {
+					return fmt.Errorf("unmarshal field scopes: %w", err)
+				}
+			}
+		case apitoken.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				at.CreatedAt = value.Time
+			}
+		case apitoken.FieldLastUsedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field last_used_at", values[i])
+			} else if value.Valid {
+				at.LastUsedAt = new(time.Time)
+				*at.LastUsedAt = value.Time
+			}
+		case apitoken.FieldExpiresAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field expires_at", values[i])
+			} else if value.Valid {
+				at.ExpiresAt = new(time.Time)
+				*at.ExpiresAt = value.Time
+			}
+		case apitoken.ForeignKeys[0]:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field user_api_tokens", values[i])
+			} else if value.Valid {
+				at.user_api_tokens = new(pid.ID)
+				*at.user_api_tokens = pid.ID(value.Int64)
+			}
+		default:
+			at.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the ApiToken.
+// This includes values selected through modifiers, order, etc.
+func (at *ApiToken) Value(name string) (ent.Value, error) {
+	return at.selectValues.Get(name)
+}
+
+// QueryUser queries the "user" edge of the ApiToken entity.
+func (at *ApiToken) QueryUser() *UserQuery {
+	return NewApiTokenClient(at.config).QueryUser(at)
+}
+
+// Update returns a builder for updating this ApiToken.
+// Note that you need to call ApiToken.Unwrap() before calling this method if this ApiToken
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (at *ApiToken) Update() *ApiTokenUpdateOne {
+	return NewApiTokenClient(at.config).UpdateOne(at)
+}
+
+// Unwrap unwraps the ApiToken entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (at *ApiToken) Unwrap() *ApiToken {
+	_tx, ok := at.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: ApiToken is not a transactional entity")
+	}
+	at.config.driver = _tx.drv
+	return at
+}
+
+// String implements the fmt.Stringer.
+func (at *ApiToken) String() string {
+	var builder strings.Builder
+	builder.WriteString("ApiToken(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", at.ID))
+	builder.WriteString("name=")
+	builder.WriteString(at.Name)
+	builder.WriteString(", ")
+	builder.WriteString("token_hash=<sensitive>")
+	builder.WriteString(", ")
+	builder.WriteString("scopes=")
+	builder.WriteString(fmt.Sprintf("%v", at.Scopes))
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(at.CreatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	if v := at.LastUsedAt; v != nil {
+		builder.WriteString("last_used_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	if v := at.ExpiresAt; v != nil {
+		builder.WriteString("expires_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// ApiTokens is a parsable slice of ApiToken.
+type ApiTokens []*ApiToken
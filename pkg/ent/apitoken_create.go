@@ -0,0 +1,344 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/Pineapple217/cvrs/pkg/ent/apitoken"
+	"github.com/Pineapple217/cvrs/pkg/ent/user"
+	"github.com/Pineapple217/cvrs/pkg/pid"
+)
+
+// ApiTokenCreate is the builder for creating a ApiToken entity.
+type ApiTokenCreate struct {
+	config
+	mutation *ApiTokenMutation
+	hooks    []Hook
+}
+
+// SetName sets the "name" field.
+func (atc *ApiTokenCreate) SetName(s string) *ApiTokenCreate {
+	atc.mutation.SetName(s)
+	return atc
+}
+
+// SetTokenHash sets the "token_hash" field.
+func (atc *ApiTokenCreate) SetTokenHash(b []byte) *ApiTokenCreate {
+	atc.mutation.SetTokenHash(b)
+	return atc
+}
+
+// SetScopes sets the "scopes" field.
+func (atc *ApiTokenCreate) SetScopes(s []string) *ApiTokenCreate {
+	atc.mutation.SetScopes(s)
+	return atc
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (atc *ApiTokenCreate) SetCreatedAt(t time.Time) *ApiTokenCreate {
+	atc.mutation.SetCreatedAt(t)
+	return atc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (atc *ApiTokenCreate) SetNillableCreatedAt(t *time.Time) *ApiTokenCreate {
+	if t != nil {
+		atc.SetCreatedAt(*t)
+	}
+	return atc
+}
+
+// SetLastUsedAt sets the "last_used_at" field.
+func (atc *ApiTokenCreate) SetLastUsedAt(t time.Time) *ApiTokenCreate {
+	atc.mutation.SetLastUsedAt(t)
+	return atc
+}
+
+// SetNillableLastUsedAt sets the "last_used_at" field if the given value is not nil.
+func (atc *ApiTokenCreate) SetNillableLastUsedAt(t *time.Time) *ApiTokenCreate {
+	if t != nil {
+		atc.SetLastUsedAt(*t)
+	}
+	return atc
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (atc *ApiTokenCreate) SetExpiresAt(t time.Time) *ApiTokenCreate {
+	atc.mutation.SetExpiresAt(t)
+	return atc
+}
+
+// SetNillableExpiresAt sets the "expires_at" field if the given value is not nil.
+func (atc *ApiTokenCreate) SetNillableExpiresAt(t *time.Time) *ApiTokenCreate {
+	if t != nil {
+		atc.SetExpiresAt(*t)
+	}
+	return atc
+}
+
+// SetID sets the "id" field.
+func (atc *ApiTokenCreate) SetID(pi pid.ID) *ApiTokenCreate {
+	atc.mutation.SetID(pi)
+	return atc
+}
+
+// SetNillableID sets the "id" field if the given value is not nil.
+func (atc *ApiTokenCreate) SetNillableID(pi *pid.ID) *ApiTokenCreate {
+	if pi != nil {
+		atc.SetID(*pi)
+	}
+	return atc
+}
+
+// SetUserID sets the "user" edge to the User entity by ID.
+func (atc *ApiTokenCreate) SetUserID(id pid.ID) *ApiTokenCreate {
+	atc.mutation.SetUserID(id)
+	return atc
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (atc *ApiTokenCreate) SetUser(u *User) *ApiTokenCreate {
+	return atc.SetUserID(u.ID)
+}
+
+// Mutation returns the ApiTokenMutation object of the builder.
+func (atc *ApiTokenCreate) Mutation() *ApiTokenMutation {
+	return atc.mutation
+}
+
+// Save creates the ApiToken in the database.
+func (atc *ApiTokenCreate) Save(ctx context.Context) (*ApiToken, error) {
+	atc.defaults()
+	return withHooks(ctx, atc.sqlSave, atc.mutation, atc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (atc *ApiTokenCreate) SaveX(ctx context.Context) *ApiToken {
+	v, err := atc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (atc *ApiTokenCreate) Exec(ctx context.Context) error {
+	_, err := atc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (atc *ApiTokenCreate) ExecX(ctx context.Context) {
+	if err := atc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (atc *ApiTokenCreate) defaults() {
+	if _, ok := atc.mutation.CreatedAt(); !ok {
+		v := apitoken.DefaultCreatedAt()
+		atc.mutation.SetCreatedAt(v)
+	}
+	if _, ok := atc.mutation.ID(); !ok {
+		v := apitoken.DefaultID()
+		atc.mutation.SetID(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (atc *ApiTokenCreate) check() error {
+	if _, ok := atc.mutation.Name(); !ok {
+		return &ValidationError{Name: "name", err: errors.New(`ent: missing required field "ApiToken.name"`)}
+	}
+	if v, ok := atc.mutation.Name(); ok {
+		if err := apitoken.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "ApiToken.name": %w`, err)}
+		}
+	}
+	if _, ok := atc.mutation.TokenHash(); !ok {
+		return &ValidationError{Name: "token_hash", err: errors.New(`ent: missing required field "ApiToken.token_hash"`)}
+	}
+	if v, ok := atc.mutation.TokenHash(); ok {
+		if err := apitoken.TokenHashValidator(v); err != nil {
+			return &ValidationError{Name: "token_hash", err: fmt.Errorf(`ent: validator failed for field "ApiToken.token_hash": %w`, err)}
+		}
+	}
+	if _, ok := atc.mutation.Scopes(); !ok {
+		return &ValidationError{Name: "scopes", err: errors.New(`ent: missing required field "ApiToken.scopes"`)}
+	}
+	if _, ok := atc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "ApiToken.created_at"`)}
+	}
+	if len(atc.mutation.UserIDs()) == 0 {
+		return &ValidationError{Name: "user", err: errors.New(`ent: missing required edge "ApiToken.user"`)}
+	}
+	return nil
+}
+
+func (atc *ApiTokenCreate) sqlSave(ctx context.Context) (*ApiToken, error) {
+	if err := atc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := atc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, atc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != _node.ID {
+		id := _spec.ID.Value.(int64)
+		_node.ID = pid.ID(id)
+	}
+	atc.mutation.id = &_node.ID
+	atc.mutation.done = true
+	return _node, nil
+}
+
+func (atc *ApiTokenCreate) createSpec() (*ApiToken, *sqlgraph.CreateSpec) {
+	var (
+		_node = &ApiToken{config: atc.config}
+		_spec = sqlgraph.NewCreateSpec(apitoken.Table, sqlgraph.NewFieldSpec(apitoken.FieldID, field.TypeInt64))
+	)
+	if id, ok := atc.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = id
+	}
+	if value, ok := atc.mutation.Name(); ok {
+		_spec.SetField(apitoken.FieldName, field.TypeString, value)
+		_node.Name = value
+	}
+	if value, ok := atc.mutation.TokenHash(); ok {
+		_spec.SetField(apitoken.FieldTokenHash, field.TypeBytes, value)
+		_node.TokenHash = value
+	}
+	if value, ok := atc.mutation.Scopes(); ok {
+		_spec.SetField(apitoken.FieldScopes, field.TypeJSON, value)
+		_node.Scopes = value
+	}
+	if value, ok := atc.mutation.CreatedAt(); ok {
+		_spec.SetField(apitoken.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if value, ok := atc.mutation.LastUsedAt(); ok {
+		_spec.SetField(apitoken.FieldLastUsedAt, field.TypeTime, value)
+		_node.LastUsedAt = &value
+	}
+	if value, ok := atc.mutation.ExpiresAt(); ok {
+		_spec.SetField(apitoken.FieldExpiresAt, field.TypeTime, value)
+		_node.ExpiresAt = &value
+	}
+	if nodes := atc.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   apitoken.UserTable,
+			Columns: []string{apitoken.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.user_api_tokens = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// ApiTokenCreateBulk is the builder for creating many ApiToken entities in bulk.
+type ApiTokenCreateBulk struct {
+	config
+	err      error
+	builders []*ApiTokenCreate
+}
+
+// Save creates the ApiToken entities in the database.
+func (atcb *ApiTokenCreateBulk) Save(ctx context.Context) ([]*ApiToken, error) {
+	if atcb.err != nil {
+		return nil, atcb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(atcb.builders))
+	nodes := make([]*ApiToken, len(atcb.builders))
+	mutators := make([]Mutator, len(atcb.builders))
+	for i := range atcb.builders {
+		func(i int, root context.Context) {
+			builder := atcb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*ApiTokenMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, atcb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, atcb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil && nodes[i].ID == 0 {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = pid.ID(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, atcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (atcb *ApiTokenCreateBulk) SaveX(ctx context.Context) []*ApiToken {
+	v, err := atcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (atcb *ApiTokenCreateBulk) Exec(ctx context.Context) error {
+	_, err := atcb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (atcb *ApiTokenCreateBulk) ExecX(ctx context.Context) {
+	if err := atcb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
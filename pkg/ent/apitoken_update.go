@@ -0,0 +1,509 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/dialect/sql/sqljson"
+	"entgo.io/ent/schema/field"
+	"github.com/Pineapple217/cvrs/pkg/ent/apitoken"
+	"github.com/Pineapple217/cvrs/pkg/ent/predicate"
+	"github.com/Pineapple217/cvrs/pkg/ent/user"
+	"github.com/Pineapple217/cvrs/pkg/pid"
+)
+
+// ApiTokenUpdate is the builder for updating ApiToken entities.
+type ApiTokenUpdate struct {
+	config
+	hooks    []Hook
+	mutation *ApiTokenMutation
+}
+
+// Where appends a list predicates to the ApiTokenUpdate builder.
+func (atu *ApiTokenUpdate) Where(ps ...predicate.ApiToken) *ApiTokenUpdate {
+	atu.mutation.Where(ps...)
+	return atu
+}
+
+// SetName sets the "name" field.
+func (atu *ApiTokenUpdate) SetName(s string) *ApiTokenUpdate {
+	atu.mutation.SetName(s)
+	return atu
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (atu *ApiTokenUpdate) SetNillableName(s *string) *ApiTokenUpdate {
+	if s != nil {
+		atu.SetName(*s)
+	}
+	return atu
+}
+
+// SetTokenHash sets the "token_hash" field.
+func (atu *ApiTokenUpdate) SetTokenHash(b []byte) *ApiTokenUpdate {
+	atu.mutation.SetTokenHash(b)
+	return atu
+}
+
+// SetScopes sets the "scopes" field.
+func (atu *ApiTokenUpdate) SetScopes(s []string) *ApiTokenUpdate {
+	atu.mutation.SetScopes(s)
+	return atu
+}
+
+// AppendScopes appends s to the "scopes" field.
+func (atu *ApiTokenUpdate) AppendScopes(s []string) *ApiTokenUpdate {
+	atu.mutation.AppendScopes(s)
+	return atu
+}
+
+// SetLastUsedAt sets the "last_used_at" field.
+func (atu *ApiTokenUpdate) SetLastUsedAt(t time.Time) *ApiTokenUpdate {
+	atu.mutation.SetLastUsedAt(t)
+	return atu
+}
+
+// SetNillableLastUsedAt sets the "last_used_at" field if the given value is not nil.
+func (atu *ApiTokenUpdate) SetNillableLastUsedAt(t *time.Time) *ApiTokenUpdate {
+	if t != nil {
+		atu.SetLastUsedAt(*t)
+	}
+	return atu
+}
+
+// ClearLastUsedAt clears the value of the "last_used_at" field.
+func (atu *ApiTokenUpdate) ClearLastUsedAt() *ApiTokenUpdate {
+	atu.mutation.ClearLastUsedAt()
+	return atu
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (atu *ApiTokenUpdate) SetExpiresAt(t time.Time) *ApiTokenUpdate {
+	atu.mutation.SetExpiresAt(t)
+	return atu
+}
+
+// SetNillableExpiresAt sets the "expires_at" field if the given value is not nil.
+func (atu *ApiTokenUpdate) SetNillableExpiresAt(t *time.Time) *ApiTokenUpdate {
+	if t != nil {
+		atu.SetExpiresAt(*t)
+	}
+	return atu
+}
+
+// ClearExpiresAt clears the value of the "expires_at" field.
+func (atu *ApiTokenUpdate) ClearExpiresAt() *ApiTokenUpdate {
+	atu.mutation.ClearExpiresAt()
+	return atu
+}
+
+// SetUserID sets the "user" edge to the User entity by ID.
+func (atu *ApiTokenUpdate) SetUserID(id pid.ID) *ApiTokenUpdate {
+	atu.mutation.SetUserID(id)
+	return atu
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (atu *ApiTokenUpdate) SetUser(u *User) *ApiTokenUpdate {
+	return atu.SetUserID(u.ID)
+}
+
+// Mutation returns the ApiTokenMutation object of the builder.
+func (atu *ApiTokenUpdate) Mutation() *ApiTokenMutation {
+	return atu.mutation
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (atu *ApiTokenUpdate) ClearUser() *ApiTokenUpdate {
+	atu.mutation.ClearUser()
+	return atu
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (atu *ApiTokenUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, atu.sqlSave, atu.mutation, atu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (atu *ApiTokenUpdate) SaveX(ctx context.Context) int {
+	affected, err := atu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (atu *ApiTokenUpdate) Exec(ctx context.Context) error {
+	_, err := atu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (atu *ApiTokenUpdate) ExecX(ctx context.Context) {
+	if err := atu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (atu *ApiTokenUpdate) check() error {
+	if v, ok := atu.mutation.Name(); ok {
+		if err := apitoken.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "ApiToken.name": %w`, err)}
+		}
+	}
+	if v, ok := atu.mutation.TokenHash(); ok {
+		if err := apitoken.TokenHashValidator(v); err != nil {
+			return &ValidationError{Name: "token_hash", err: fmt.Errorf(`ent: validator failed for field "ApiToken.token_hash": %w`, err)}
+		}
+	}
+	if atu.mutation.UserCleared() && len(atu.mutation.UserIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "ApiToken.user"`)
+	}
+	return nil
+}
+
+func (atu *ApiTokenUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := atu.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(apitoken.Table, apitoken.Columns, sqlgraph.NewFieldSpec(apitoken.FieldID, field.TypeInt64))
+	if ps := atu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := atu.mutation.Name(); ok {
+		_spec.SetField(apitoken.FieldName, field.TypeString, value)
+	}
+	if value, ok := atu.mutation.TokenHash(); ok {
+		_spec.SetField(apitoken.FieldTokenHash, field.TypeBytes, value)
+	}
+	if value, ok := atu.mutation.Scopes(); ok {
+		_spec.SetField(apitoken.FieldScopes, field.TypeJSON, value)
+	}
+	if value, ok := atu.mutation.AppendedScopes(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, apitoken.FieldScopes, value)
+		})
+	}
+	if value, ok := atu.mutation.LastUsedAt(); ok {
+		_spec.SetField(apitoken.FieldLastUsedAt, field.TypeTime, value)
+	}
+	if atu.mutation.LastUsedAtCleared() {
+		_spec.ClearField(apitoken.FieldLastUsedAt, field.TypeTime)
+	}
+	if value, ok := atu.mutation.ExpiresAt(); ok {
+		_spec.SetField(apitoken.FieldExpiresAt, field.TypeTime, value)
+	}
+	if atu.mutation.ExpiresAtCleared() {
+		_spec.ClearField(apitoken.FieldExpiresAt, field.TypeTime)
+	}
+	if atu.mutation.UserCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   apitoken.UserTable,
+			Columns: []string{apitoken.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := atu.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   apitoken.UserTable,
+			Columns: []string{apitoken.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, atu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{apitoken.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	atu.mutation.done = true
+	return n, nil
+}
+
+// ApiTokenUpdateOne is the builder for updating a single ApiToken entity.
+type ApiTokenUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *ApiTokenMutation
+}
+
+// SetName sets the "name" field.
+func (atuo *ApiTokenUpdateOne) SetName(s string) *ApiTokenUpdateOne {
+	atuo.mutation.SetName(s)
+	return atuo
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (atuo *ApiTokenUpdateOne) SetNillableName(s *string) *ApiTokenUpdateOne {
+	if s != nil {
+		atuo.SetName(*s)
+	}
+	return atuo
+}
+
+// SetTokenHash sets the "token_hash" field.
+func (atuo *ApiTokenUpdateOne) SetTokenHash(b []byte) *ApiTokenUpdateOne {
+	atuo.mutation.SetTokenHash(b)
+	return atuo
+}
+
+// SetScopes sets the "scopes" field.
+func (atuo *ApiTokenUpdateOne) SetScopes(s []string) *ApiTokenUpdateOne {
+	atuo.mutation.SetScopes(s)
+	return atuo
+}
+
+// AppendScopes appends s to the "scopes" field.
+func (atuo *ApiTokenUpdateOne) AppendScopes(s []string) *ApiTokenUpdateOne {
+	atuo.mutation.AppendScopes(s)
+	return atuo
+}
+
+// SetLastUsedAt sets the "last_used_at" field.
+func (atuo *ApiTokenUpdateOne) SetLastUsedAt(t time.Time) *ApiTokenUpdateOne {
+	atuo.mutation.SetLastUsedAt(t)
+	return atuo
+}
+
+// SetNillableLastUsedAt sets the "last_used_at" field if the given value is not nil.
+func (atuo *ApiTokenUpdateOne) SetNillableLastUsedAt(t *time.Time) *ApiTokenUpdateOne {
+	if t != nil {
+		atuo.SetLastUsedAt(*t)
+	}
+	return atuo
+}
+
+// ClearLastUsedAt clears the value of the "last_used_at" field.
+func (atuo *ApiTokenUpdateOne) ClearLastUsedAt() *ApiTokenUpdateOne {
+	atuo.mutation.ClearLastUsedAt()
+	return atuo
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (atuo *ApiTokenUpdateOne) SetExpiresAt(t time.Time) *ApiTokenUpdateOne {
+	atuo.mutation.SetExpiresAt(t)
+	return atuo
+}
+
+// SetNillableExpiresAt sets the "expires_at" field if the given value is not nil.
+func (atuo *ApiTokenUpdateOne) SetNillableExpiresAt(t *time.Time) *ApiTokenUpdateOne {
+	if t != nil {
+		atuo.SetExpiresAt(*t)
+	}
+	return atuo
+}
+
+// ClearExpiresAt clears the value of the "expires_at" field.
+func (atuo *ApiTokenUpdateOne) ClearExpiresAt() *ApiTokenUpdateOne {
+	atuo.mutation.ClearExpiresAt()
+	return atuo
+}
+
+// SetUserID sets the "user" edge to the User entity by ID.
+func (atuo *ApiTokenUpdateOne) SetUserID(id pid.ID) *ApiTokenUpdateOne {
+	atuo.mutation.SetUserID(id)
+	return atuo
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (atuo *ApiTokenUpdateOne) SetUser(u *User) *ApiTokenUpdateOne {
+	return atuo.SetUserID(u.ID)
+}
+
+// Mutation returns the ApiTokenMutation object of the builder.
+func (atuo *ApiTokenUpdateOne) Mutation() *ApiTokenMutation {
+	return atuo.mutation
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (atuo *ApiTokenUpdateOne) ClearUser() *ApiTokenUpdateOne {
+	atuo.mutation.ClearUser()
+	return atuo
+}
+
+// Where appends a list predicates to the ApiTokenUpdate builder.
+func (atuo *ApiTokenUpdateOne) Where(ps ...predicate.ApiToken) *ApiTokenUpdateOne {
+	atuo.mutation.Where(ps...)
+	return atuo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (atuo *ApiTokenUpdateOne) Select(field string, fields ...string) *ApiTokenUpdateOne {
+	atuo.fields = append([]string{field}, fields...)
+	return atuo
+}
+
+// Save executes the query and returns the updated ApiToken entity.
+func (atuo *ApiTokenUpdateOne) Save(ctx context.Context) (*ApiToken, error) {
+	return withHooks(ctx, atuo.sqlSave, atuo.mutation, atuo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (atuo *ApiTokenUpdateOne) SaveX(ctx context.Context) *ApiToken {
+	node, err := atuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (atuo *ApiTokenUpdateOne) Exec(ctx context.Context) error {
+	_, err := atuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (atuo *ApiTokenUpdateOne) ExecX(ctx context.Context) {
+	if err := atuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (atuo *ApiTokenUpdateOne) check() error {
+	if v, ok := atuo.mutation.Name(); ok {
+		if err := apitoken.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "ApiToken.name": %w`, err)}
+		}
+	}
+	if v, ok := atuo.mutation.TokenHash(); ok {
+		if err := apitoken.TokenHashValidator(v); err != nil {
+			return &ValidationError{Name: "token_hash", err: fmt.Errorf(`ent: validator failed for field "ApiToken.token_hash": %w`, err)}
+		}
+	}
+	if atuo.mutation.UserCleared() && len(atuo.mutation.UserIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "ApiToken.user"`)
+	}
+	return nil
+}
+
+func (atuo *ApiTokenUpdateOne) sqlSave(ctx context.Context) (_node *ApiToken, err error) {
+	if err := atuo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(apitoken.Table, apitoken.Columns, sqlgraph.NewFieldSpec(apitoken.FieldID, field.TypeInt64))
+	id, ok := atuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "ApiToken.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := atuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, apitoken.FieldID)
+		for _, f := range fields {
+			if !apitoken.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != apitoken.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := atuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := atuo.mutation.Name(); ok {
+		_spec.SetField(apitoken.FieldName, field.TypeString, value)
+	}
+	if value, ok := atuo.mutation.TokenHash(); ok {
+		_spec.SetField(apitoken.FieldTokenHash, field.TypeBytes, value)
+	}
+	if value, ok := atuo.mutation.Scopes(); ok {
+		_spec.SetField(apitoken.FieldScopes, field.TypeJSON, value)
+	}
+	if value, ok := atuo.mutation.AppendedScopes(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, apitoken.FieldScopes, value)
+		})
+	}
+	if value, ok := atuo.mutation.LastUsedAt(); ok {
+		_spec.SetField(apitoken.FieldLastUsedAt, field.TypeTime, value)
+	}
+	if atuo.mutation.LastUsedAtCleared() {
+		_spec.ClearField(apitoken.FieldLastUsedAt, field.TypeTime)
+	}
+	if value, ok := atuo.mutation.ExpiresAt(); ok {
+		_spec.SetField(apitoken.FieldExpiresAt, field.TypeTime, value)
+	}
+	if atuo.mutation.ExpiresAtCleared() {
+		_spec.ClearField(apitoken.FieldExpiresAt, field.TypeTime)
+	}
+	if atuo.mutation.UserCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   apitoken.UserTable,
+			Columns: []string{apitoken.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := atuo.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   apitoken.UserTable,
+			Columns: []string{apitoken.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &ApiToken{config: atuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, atuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{apitoken.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	atuo.mutation.done = true
+	return _node, nil
+}
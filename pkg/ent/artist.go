@@ -19,14 +19,14 @@ type Artist struct {
 	config `json:"-"`
 	// ID of the ent.
 	ID pid.ID `json:"id,omitempty"`
+	// DeletedAt holds the value of the "deleted_at" field.
+	DeletedAt *time.Time `json:"deleted_at,omitzero"`
 	// Name holds the value of the "name" field.
 	Name string `json:"name,omitempty"`
 	// CreatedAt holds the value of the "created_at" field.
 	CreatedAt time.Time `json:"created_at,omitzero"`
 	// UpdatedAt holds the value of the "updated_at" field.
 	UpdatedAt time.Time `json:"updated_at,omitzero"`
-	// DeletedAt holds the value of the "deleted_at" field.
-	DeletedAt *time.Time `json:"deleted_at,omitzero"`
 	// Edges holds the relations/edges for other nodes in the graph.
 	// The values are being populated by the ArtistQuery when eager-loading is set.
 	Edges        ArtistEdges `json:"edges"`
@@ -106,7 +106,7 @@ func (*Artist) scanValues(columns []string) ([]any, error) {
 			values[i] = new(sql.NullInt64)
 		case artist.FieldName:
 			values[i] = new(sql.NullString)
-		case artist.FieldCreatedAt, artist.FieldUpdatedAt, artist.FieldDeletedAt:
+		case artist.FieldDeletedAt, artist.FieldCreatedAt, artist.FieldUpdatedAt:
 			values[i] = new(sql.NullTime)
 		default:
 			values[i] = new(sql.UnknownType)
@@ -129,6 +129,13 @@ func (a *Artist) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				a.ID = pid.ID(value.Int64)
 			}
+		case artist.FieldDeletedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field deleted_at", values[i])
+			} else if value.Valid {
+				a.DeletedAt = new(time.Time)
+				*a.DeletedAt = value.Time
+			}
 		case artist.FieldName:
 			if value, ok := values[i].(*sql.NullString); !ok {
 				return fmt.Errorf("unexpected type %T for field name", values[i])
@@ -147,13 +154,6 @@ func (a *Artist) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				a.UpdatedAt = value.Time
 			}
-		case artist.FieldDeletedAt:
-			if value, ok := values[i].(*sql.NullTime); !ok {
-				return fmt.Errorf("unexpected type %T for field deleted_at", values[i])
-			} else if value.Valid {
-				a.DeletedAt = new(time.Time)
-				*a.DeletedAt = value.Time
-			}
 		default:
 			a.selectValues.Set(columns[i], values[i])
 		}
@@ -215,6 +215,11 @@ func (a *Artist) String() string {
 	var builder strings.Builder
 	builder.WriteString("Artist(")
 	builder.WriteString(fmt.Sprintf("id=%v, ", a.ID))
+	if v := a.DeletedAt; v != nil {
+		builder.WriteString("deleted_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
 	builder.WriteString("name=")
 	builder.WriteString(a.Name)
 	builder.WriteString(", ")
@@ -223,11 +228,6 @@ func (a *Artist) String() string {
 	builder.WriteString(", ")
 	builder.WriteString("updated_at=")
 	builder.WriteString(a.UpdatedAt.Format(time.ANSIC))
-	builder.WriteString(", ")
-	if v := a.DeletedAt; v != nil {
-		builder.WriteString("deleted_at=")
-		builder.WriteString(v.Format(time.ANSIC))
-	}
 	builder.WriteByte(')')
 	return builder.String()
 }
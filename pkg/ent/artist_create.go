@@ -24,6 +24,20 @@ type ArtistCreate struct {
 	hooks    []Hook
 }
 
+// SetDeletedAt sets the "deleted_at" field.
+func (ac *ArtistCreate) SetDeletedAt(t time.Time) *ArtistCreate {
+	ac.mutation.SetDeletedAt(t)
+	return ac
+}
+
+// SetNillableDeletedAt sets the "deleted_at" field if the given value is not nil.
+func (ac *ArtistCreate) SetNillableDeletedAt(t *time.Time) *ArtistCreate {
+	if t != nil {
+		ac.SetDeletedAt(*t)
+	}
+	return ac
+}
+
 // SetName sets the "name" field.
 func (ac *ArtistCreate) SetName(s string) *ArtistCreate {
 	ac.mutation.SetName(s)
@@ -58,20 +72,6 @@ func (ac *ArtistCreate) SetNillableUpdatedAt(t *time.Time) *ArtistCreate {
 	return ac
 }
 
-// SetDeletedAt sets the "deleted_at" field.
-func (ac *ArtistCreate) SetDeletedAt(t time.Time) *ArtistCreate {
-	ac.mutation.SetDeletedAt(t)
-	return ac
-}
-
-// SetNillableDeletedAt sets the "deleted_at" field if the given value is not nil.
-func (ac *ArtistCreate) SetNillableDeletedAt(t *time.Time) *ArtistCreate {
-	if t != nil {
-		ac.SetDeletedAt(*t)
-	}
-	return ac
-}
-
 // SetID sets the "id" field.
 func (ac *ArtistCreate) SetID(pi pid.ID) *ArtistCreate {
 	ac.mutation.SetID(pi)
@@ -232,6 +232,10 @@ func (ac *ArtistCreate) createSpec() (*Artist, *sqlgraph.CreateSpec) {
 		_node.ID = id
 		_spec.ID.Value = id
 	}
+	if value, ok := ac.mutation.DeletedAt(); ok {
+		_spec.SetField(artist.FieldDeletedAt, field.TypeTime, value)
+		_node.DeletedAt = &value
+	}
 	if value, ok := ac.mutation.Name(); ok {
 		_spec.SetField(artist.FieldName, field.TypeString, value)
 		_node.Name = value
@@ -244,10 +248,6 @@ func (ac *ArtistCreate) createSpec() (*Artist, *sqlgraph.CreateSpec) {
 		_spec.SetField(artist.FieldUpdatedAt, field.TypeTime, value)
 		_node.UpdatedAt = value
 	}
-	if value, ok := ac.mutation.DeletedAt(); ok {
-		_spec.SetField(artist.FieldDeletedAt, field.TypeTime, value)
-		_node.DeletedAt = &value
-	}
 	if nodes := ac.mutation.AppearingTracksIDs(); len(nodes) > 0 {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2M,
@@ -444,12 +444,12 @@ func (aq *ArtistQuery) WithReleaseAppearance(opts ...func(*ReleaseAppearanceQuer
 // Example:
 //
 //	var v []struct {
-//		Name string `json:"name,omitempty"`
+//		DeletedAt time.Time `json:"deleted_at,omitzero"`
 //		Count int `json:"count,omitempty"`
 //	}
 //
 //	client.Artist.Query().
-//		GroupBy(artist.FieldName).
+//		GroupBy(artist.FieldDeletedAt).
 //		Aggregate(ent.Count()).
 //		Scan(ctx, &v)
 func (aq *ArtistQuery) GroupBy(field string, fields ...string) *ArtistGroupBy {
@@ -467,11 +467,11 @@ func (aq *ArtistQuery) GroupBy(field string, fields ...string) *ArtistGroupBy {
 // Example:
 //
 //	var v []struct {
-//		Name string `json:"name,omitempty"`
+//		DeletedAt time.Time `json:"deleted_at,omitzero"`
 //	}
 //
 //	client.Artist.Query().
-//		Select(artist.FieldName).
+//		Select(artist.FieldDeletedAt).
 //		Scan(ctx, &v)
 func (aq *ArtistQuery) Select(fields ...string) *ArtistSelect {
 	aq.ctx.Fields = append(aq.ctx.Fields, fields...)
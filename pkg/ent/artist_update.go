@@ -32,26 +32,6 @@ func (au *ArtistUpdate) Where(ps ...predicate.Artist) *ArtistUpdate {
 	return au
 }
 
-// SetName sets the "name" field.
-func (au *ArtistUpdate) SetName(s string) *ArtistUpdate {
-	au.mutation.SetName(s)
-	return au
-}
-
-// SetNillableName sets the "name" field if the given value is not nil.
-func (au *ArtistUpdate) SetNillableName(s *string) *ArtistUpdate {
-	if s != nil {
-		au.SetName(*s)
-	}
-	return au
-}
-
-// SetUpdatedAt sets the "updated_at" field.
-func (au *ArtistUpdate) SetUpdatedAt(t time.Time) *ArtistUpdate {
-	au.mutation.SetUpdatedAt(t)
-	return au
-}
-
 // SetDeletedAt sets the "deleted_at" field.
 func (au *ArtistUpdate) SetDeletedAt(t time.Time) *ArtistUpdate {
 	au.mutation.SetDeletedAt(t)
@@ -72,6 +52,26 @@ func (au *ArtistUpdate) ClearDeletedAt() *ArtistUpdate {
 	return au
 }
 
+// SetName sets the "name" field.
+func (au *ArtistUpdate) SetName(s string) *ArtistUpdate {
+	au.mutation.SetName(s)
+	return au
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (au *ArtistUpdate) SetNillableName(s *string) *ArtistUpdate {
+	if s != nil {
+		au.SetName(*s)
+	}
+	return au
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (au *ArtistUpdate) SetUpdatedAt(t time.Time) *ArtistUpdate {
+	au.mutation.SetUpdatedAt(t)
+	return au
+}
+
 // AddAppearingTrackIDs adds the "appearing_tracks" edge to the Track entity by IDs.
 func (au *ArtistUpdate) AddAppearingTrackIDs(ids ...pid.ID) *ArtistUpdate {
 	au.mutation.AddAppearingTrackIDs(ids...)
@@ -232,18 +232,18 @@ func (au *ArtistUpdate) sqlSave(ctx context.Context) (n int, err error) {
 			}
 		}
 	}
-	if value, ok := au.mutation.Name(); ok {
-		_spec.SetField(artist.FieldName, field.TypeString, value)
-	}
-	if value, ok := au.mutation.UpdatedAt(); ok {
-		_spec.SetField(artist.FieldUpdatedAt, field.TypeTime, value)
-	}
 	if value, ok := au.mutation.DeletedAt(); ok {
 		_spec.SetField(artist.FieldDeletedAt, field.TypeTime, value)
 	}
 	if au.mutation.DeletedAtCleared() {
 		_spec.ClearField(artist.FieldDeletedAt, field.TypeTime)
 	}
+	if value, ok := au.mutation.Name(); ok {
+		_spec.SetField(artist.FieldName, field.TypeString, value)
+	}
+	if value, ok := au.mutation.UpdatedAt(); ok {
+		_spec.SetField(artist.FieldUpdatedAt, field.TypeTime, value)
+	}
 	if au.mutation.AppearingTracksCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2M,
@@ -407,26 +407,6 @@ type ArtistUpdateOne struct {
 	mutation *ArtistMutation
 }
 
-// SetName sets the "name" field.
-func (auo *ArtistUpdateOne) SetName(s string) *ArtistUpdateOne {
-	auo.mutation.SetName(s)
-	return auo
-}
-
-// SetNillableName sets the "name" field if the given value is not nil.
-func (auo *ArtistUpdateOne) SetNillableName(s *string) *ArtistUpdateOne {
-	if s != nil {
-		auo.SetName(*s)
-	}
-	return auo
-}
-
-// SetUpdatedAt sets the "updated_at" field.
-func (auo *ArtistUpdateOne) SetUpdatedAt(t time.Time) *ArtistUpdateOne {
-	auo.mutation.SetUpdatedAt(t)
-	return auo
-}
-
 // SetDeletedAt sets the "deleted_at" field.
 func (auo *ArtistUpdateOne) SetDeletedAt(t time.Time) *ArtistUpdateOne {
 	auo.mutation.SetDeletedAt(t)
@@ -447,6 +427,26 @@ func (auo *ArtistUpdateOne) ClearDeletedAt() *ArtistUpdateOne {
 	return auo
 }
 
+// SetName sets the "name" field.
+func (auo *ArtistUpdateOne) SetName(s string) *ArtistUpdateOne {
+	auo.mutation.SetName(s)
+	return auo
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (auo *ArtistUpdateOne) SetNillableName(s *string) *ArtistUpdateOne {
+	if s != nil {
+		auo.SetName(*s)
+	}
+	return auo
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (auo *ArtistUpdateOne) SetUpdatedAt(t time.Time) *ArtistUpdateOne {
+	auo.mutation.SetUpdatedAt(t)
+	return auo
+}
+
 // AddAppearingTrackIDs adds the "appearing_tracks" edge to the Track entity by IDs.
 func (auo *ArtistUpdateOne) AddAppearingTrackIDs(ids ...pid.ID) *ArtistUpdateOne {
 	auo.mutation.AddAppearingTrackIDs(ids...)
@@ -637,18 +637,18 @@ func (auo *ArtistUpdateOne) sqlSave(ctx context.Context) (_node *Artist, err err
 			}
 		}
 	}
-	if value, ok := auo.mutation.Name(); ok {
-		_spec.SetField(artist.FieldName, field.TypeString, value)
-	}
-	if value, ok := auo.mutation.UpdatedAt(); ok {
-		_spec.SetField(artist.FieldUpdatedAt, field.TypeTime, value)
-	}
 	if value, ok := auo.mutation.DeletedAt(); ok {
 		_spec.SetField(artist.FieldDeletedAt, field.TypeTime, value)
 	}
 	if auo.mutation.DeletedAtCleared() {
 		_spec.ClearField(artist.FieldDeletedAt, field.TypeTime)
 	}
+	if value, ok := auo.mutation.Name(); ok {
+		_spec.SetField(artist.FieldName, field.TypeString, value)
+	}
+	if value, ok := auo.mutation.UpdatedAt(); ok {
+		_spec.SetField(artist.FieldUpdatedAt, field.TypeTime, value)
+	}
 	if auo.mutation.AppearingTracksCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2M,
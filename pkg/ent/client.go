@@ -16,12 +16,15 @@ import (
 	"entgo.io/ent/dialect"
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/Pineapple217/cvrs/pkg/ent/apitoken"
 	"github.com/Pineapple217/cvrs/pkg/ent/artist"
 	"github.com/Pineapple217/cvrs/pkg/ent/image"
 	"github.com/Pineapple217/cvrs/pkg/ent/imagedata"
+	"github.com/Pineapple217/cvrs/pkg/ent/lockout"
 	"github.com/Pineapple217/cvrs/pkg/ent/processedimage"
 	"github.com/Pineapple217/cvrs/pkg/ent/release"
 	"github.com/Pineapple217/cvrs/pkg/ent/releaseappearance"
+	"github.com/Pineapple217/cvrs/pkg/ent/session"
 	"github.com/Pineapple217/cvrs/pkg/ent/task"
 	"github.com/Pineapple217/cvrs/pkg/ent/track"
 	"github.com/Pineapple217/cvrs/pkg/ent/trackappearance"
@@ -35,18 +38,24 @@ type Client struct {
 	config
 	// Schema is the client for creating, migrating and dropping schema.
 	Schema *migrate.Schema
+	// ApiToken is the client for interacting with the ApiToken builders.
+	ApiToken *ApiTokenClient
 	// Artist is the client for interacting with the Artist builders.
 	Artist *ArtistClient
 	// Image is the client for interacting with the Image builders.
 	Image *ImageClient
 	// ImageData is the client for interacting with the ImageData builders.
 	ImageData *ImageDataClient
+	// Lockout is the client for interacting with the Lockout builders.
+	Lockout *LockoutClient
 	// ProcessedImage is the client for interacting with the ProcessedImage builders.
 	ProcessedImage *ProcessedImageClient
 	// Release is the client for interacting with the Release builders.
 	Release *ReleaseClient
 	// ReleaseAppearance is the client for interacting with the ReleaseAppearance builders.
 	ReleaseAppearance *ReleaseAppearanceClient
+	// Session is the client for interacting with the Session builders.
+	Session *SessionClient
 	// Task is the client for interacting with the Task builders.
 	Task *TaskClient
 	// Track is the client for interacting with the Track builders.
@@ -66,12 +75,15 @@ func NewClient(opts ...Option) *Client {
 
 func (c *Client) init() {
 	c.Schema = migrate.NewSchema(c.driver)
+	c.ApiToken = NewApiTokenClient(c.config)
 	c.Artist = NewArtistClient(c.config)
 	c.Image = NewImageClient(c.config)
 	c.ImageData = NewImageDataClient(c.config)
+	c.Lockout = NewLockoutClient(c.config)
 	c.ProcessedImage = NewProcessedImageClient(c.config)
 	c.Release = NewReleaseClient(c.config)
 	c.ReleaseAppearance = NewReleaseAppearanceClient(c.config)
+	c.Session = NewSessionClient(c.config)
 	c.Task = NewTaskClient(c.config)
 	c.Track = NewTrackClient(c.config)
 	c.TrackAppearance = NewTrackAppearanceClient(c.config)
@@ -168,12 +180,15 @@ func (c *Client) Tx(ctx context.Context) (*Tx, error) {
 	return &Tx{
 		ctx:               ctx,
 		config:            cfg,
+		ApiToken:          NewApiTokenClient(cfg),
 		Artist:            NewArtistClient(cfg),
 		Image:             NewImageClient(cfg),
 		ImageData:         NewImageDataClient(cfg),
+		Lockout:           NewLockoutClient(cfg),
 		ProcessedImage:    NewProcessedImageClient(cfg),
 		Release:           NewReleaseClient(cfg),
 		ReleaseAppearance: NewReleaseAppearanceClient(cfg),
+		Session:           NewSessionClient(cfg),
 		Task:              NewTaskClient(cfg),
 		Track:             NewTrackClient(cfg),
 		TrackAppearance:   NewTrackAppearanceClient(cfg),
@@ -197,12 +212,15 @@ func (c *Client) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error)
 	return &Tx{
 		ctx:               ctx,
 		config:            cfg,
+		ApiToken:          NewApiTokenClient(cfg),
 		Artist:            NewArtistClient(cfg),
 		Image:             NewImageClient(cfg),
 		ImageData:         NewImageDataClient(cfg),
+		Lockout:           NewLockoutClient(cfg),
 		ProcessedImage:    NewProcessedImageClient(cfg),
 		Release:           NewReleaseClient(cfg),
 		ReleaseAppearance: NewReleaseAppearanceClient(cfg),
+		Session:           NewSessionClient(cfg),
 		Task:              NewTaskClient(cfg),
 		Track:             NewTrackClient(cfg),
 		TrackAppearance:   NewTrackAppearanceClient(cfg),
@@ -213,7 +231,7 @@ func (c *Client) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error)
 // Debug returns a new debug-client. It's used to get verbose logging on specific operations.
 //
 //	client.Debug().
-//		Artist.
+//		ApiToken.
 //		Query().
 //		Count(ctx)
 func (c *Client) Debug() *Client {
@@ -236,8 +254,9 @@ func (c *Client) Close() error {
 // In order to add hooks to a specific client, call: `client.Node.Use(...)`.
 func (c *Client) Use(hooks ...Hook) {
 	for _, n := range []interface{ Use(...Hook) }{
-		c.Artist, c.Image, c.ImageData, c.ProcessedImage, c.Release,
-		c.ReleaseAppearance, c.Task, c.Track, c.TrackAppearance, c.User,
+		c.ApiToken, c.Artist, c.Image, c.ImageData, c.Lockout, c.ProcessedImage,
+		c.Release, c.ReleaseAppearance, c.Session, c.Task, c.Track, c.TrackAppearance,
+		c.User,
 	} {
 		n.Use(hooks...)
 	}
@@ -247,8 +266,9 @@ func (c *Client) Use(hooks ...Hook) {
 // In order to add interceptors to a specific client, call: `client.Node.Intercept(...)`.
 func (c *Client) Intercept(interceptors ...Interceptor) {
 	for _, n := range []interface{ Intercept(...Interceptor) }{
-		c.Artist, c.Image, c.ImageData, c.ProcessedImage, c.Release,
-		c.ReleaseAppearance, c.Task, c.Track, c.TrackAppearance, c.User,
+		c.ApiToken, c.Artist, c.Image, c.ImageData, c.Lockout, c.ProcessedImage,
+		c.Release, c.ReleaseAppearance, c.Session, c.Task, c.Track, c.TrackAppearance,
+		c.User,
 	} {
 		n.Intercept(interceptors...)
 	}
@@ -257,18 +277,24 @@ func (c *Client) Intercept(interceptors ...Interceptor) {
 // Mutate implements the ent.Mutator interface.
 func (c *Client) Mutate(ctx context.Context, m Mutation) (Value, error) {
 	switch m := m.(type) {
+	case *ApiTokenMutation:
+		return c.ApiToken.mutate(ctx, m)
 	case *ArtistMutation:
 		return c.Artist.mutate(ctx, m)
 	case *ImageMutation:
 		return c.Image.mutate(ctx, m)
 	case *ImageDataMutation:
 		return c.ImageData.mutate(ctx, m)
+	case *LockoutMutation:
+		return c.Lockout.mutate(ctx, m)
 	case *ProcessedImageMutation:
 		return c.ProcessedImage.mutate(ctx, m)
 	case *ReleaseMutation:
 		return c.Release.mutate(ctx, m)
 	case *ReleaseAppearanceMutation:
 		return c.ReleaseAppearance.mutate(ctx, m)
+	case *SessionMutation:
+		return c.Session.mutate(ctx, m)
 	case *TaskMutation:
 		return c.Task.mutate(ctx, m)
 	case *TrackMutation:
@@ -282,6 +308,155 @@ func (c *Client) Mutate(ctx context.Context, m Mutation) (Value, error) {
 	}
 }
 
+// ApiTokenClient is a client for the ApiToken schema.
+type ApiTokenClient struct {
+	config
+}
+
+// NewApiTokenClient returns a client for the ApiToken from the given config.
+func NewApiTokenClient(c config) *ApiTokenClient {
+	return &ApiTokenClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `apitoken.Hooks(f(g(h())))`.
+func (c *ApiTokenClient) Use(hooks ...Hook) {
+	c.hooks.ApiToken = append(c.hooks.ApiToken, hooks...)
+}
+
+// Intercept adds a list of query interceptors to the interceptors stack.
+// A call to `Intercept(f, g, h)` equals to `apitoken.Intercept(f(g(h())))`.
+func (c *ApiTokenClient) Intercept(interceptors ...Interceptor) {
+	c.inters.ApiToken = append(c.inters.ApiToken, interceptors...)
+}
+
+// Create returns a builder for creating a ApiToken entity.
+func (c *ApiTokenClient) Create() *ApiTokenCreate {
+	mutation := newApiTokenMutation(c.config, OpCreate)
+	return &ApiTokenCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of ApiToken entities.
+func (c *ApiTokenClient) CreateBulk(builders ...*ApiTokenCreate) *ApiTokenCreateBulk {
+	return &ApiTokenCreateBulk{config: c.config, builders: builders}
+}
+
+// MapCreateBulk creates a bulk creation builder from the given slice. For each item in the slice, the function creates
+// a builder and applies setFunc on it.
+func (c *ApiTokenClient) MapCreateBulk(slice any, setFunc func(*ApiTokenCreate, int)) *ApiTokenCreateBulk {
+	rv := reflect.ValueOf(slice)
+	if rv.Kind() != reflect.Slice {
+		return &ApiTokenCreateBulk{err: fmt.Errorf("calling to ApiTokenClient.MapCreateBulk with wrong type %T, need slice", slice)}
+	}
+	builders := make([]*ApiTokenCreate, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		builders[i] = c.Create()
+		setFunc(builders[i], i)
+	}
+	return &ApiTokenCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for ApiToken.
+func (c *ApiTokenClient) Update() *ApiTokenUpdate {
+	mutation := newApiTokenMutation(c.config, OpUpdate)
+	return &ApiTokenUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *ApiTokenClient) UpdateOne(at *ApiToken) *ApiTokenUpdateOne {
+	mutation := newApiTokenMutation(c.config, OpUpdateOne, withApiToken(at))
+	return &ApiTokenUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *ApiTokenClient) UpdateOneID(id pid.ID) *ApiTokenUpdateOne {
+	mutation := newApiTokenMutation(c.config, OpUpdateOne, withApiTokenID(id))
+	return &ApiTokenUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for ApiToken.
+func (c *ApiTokenClient) Delete() *ApiTokenDelete {
+	mutation := newApiTokenMutation(c.config, OpDelete)
+	return &ApiTokenDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *ApiTokenClient) DeleteOne(at *ApiToken) *ApiTokenDeleteOne {
+	return c.DeleteOneID(at.ID)
+}
+
+// DeleteOneID returns a builder for deleting the given entity by its id.
+func (c *ApiTokenClient) DeleteOneID(id pid.ID) *ApiTokenDeleteOne {
+	builder := c.Delete().Where(apitoken.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &ApiTokenDeleteOne{builder}
+}
+
+// Query returns a query builder for ApiToken.
+func (c *ApiTokenClient) Query() *ApiTokenQuery {
+	return &ApiTokenQuery{
+		config: c.config,
+		ctx:    &QueryContext{Type: TypeApiToken},
+		inters: c.Interceptors(),
+	}
+}
+
+// Get returns a ApiToken entity by its id.
+func (c *ApiTokenClient) Get(ctx context.Context, id pid.ID) (*ApiToken, error) {
+	return c.Query().Where(apitoken.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *ApiTokenClient) GetX(ctx context.Context, id pid.ID) *ApiToken {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// QueryUser queries the user edge of a ApiToken.
+func (c *ApiTokenClient) QueryUser(at *ApiToken) *UserQuery {
+	query := (&UserClient{config: c.config}).Query()
+	query.path = func(context.Context) (fromV *sql.Selector, _ error) {
+		id := at.ID
+		step := sqlgraph.NewStep(
+			sqlgraph.From(apitoken.Table, apitoken.FieldID, id),
+			sqlgraph.To(user.Table, user.FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, apitoken.UserTable, apitoken.UserColumn),
+		)
+		fromV = sqlgraph.Neighbors(at.driver.Dialect(), step)
+		return fromV, nil
+	}
+	return query
+}
+
+// Hooks returns the client hooks.
+func (c *ApiTokenClient) Hooks() []Hook {
+	return c.hooks.ApiToken
+}
+
+// Interceptors returns the client interceptors.
+func (c *ApiTokenClient) Interceptors() []Interceptor {
+	return c.inters.ApiToken
+}
+
+func (c *ApiTokenClient) mutate(ctx context.Context, m *ApiTokenMutation) (Value, error) {
+	switch m.Op() {
+	case OpCreate:
+		return (&ApiTokenCreate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdate:
+		return (&ApiTokenUpdate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdateOne:
+		return (&ApiTokenUpdateOne{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpDelete, OpDeleteOne:
+		return (&ApiTokenDelete{config: c.config, hooks: c.Hooks(), mutation: m}).Exec(ctx)
+	default:
+		return nil, fmt.Errorf("ent: unknown ApiToken mutation op: %q", m.Op())
+	}
+}
+
 // ArtistClient is a client for the Artist schema.
 type ArtistClient struct {
 	config
@@ -477,7 +652,8 @@ func (c *ArtistClient) Hooks() []Hook {
 
 // Interceptors returns the client interceptors.
 func (c *ArtistClient) Interceptors() []Interceptor {
-	return c.inters.Artist
+	inters := c.inters.Artist
+	return append(inters[:len(inters):len(inters)], artist.Interceptors[:]...)
 }
 
 func (c *ArtistClient) mutate(ctx context.Context, m *ArtistMutation) (Value, error) {
@@ -691,7 +867,8 @@ func (c *ImageClient) Hooks() []Hook {
 
 // Interceptors returns the client interceptors.
 func (c *ImageClient) Interceptors() []Interceptor {
-	return c.inters.Image
+	inters := c.inters.Image
+	return append(inters[:len(inters):len(inters)], image.Interceptors[:]...)
 }
 
 func (c *ImageClient) mutate(ctx context.Context, m *ImageMutation) (Value, error) {
@@ -858,6 +1035,139 @@ func (c *ImageDataClient) mutate(ctx context.Context, m *ImageDataMutation) (Val
 	}
 }
 
+// LockoutClient is a client for the Lockout schema.
+type LockoutClient struct {
+	config
+}
+
+// NewLockoutClient returns a client for the Lockout from the given config.
+func NewLockoutClient(c config) *LockoutClient {
+	return &LockoutClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `lockout.Hooks(f(g(h())))`.
+func (c *LockoutClient) Use(hooks ...Hook) {
+	c.hooks.Lockout = append(c.hooks.Lockout, hooks...)
+}
+
+// Intercept adds a list of query interceptors to the interceptors stack.
+// A call to `Intercept(f, g, h)` equals to `lockout.Intercept(f(g(h())))`.
+func (c *LockoutClient) Intercept(interceptors ...Interceptor) {
+	c.inters.Lockout = append(c.inters.Lockout, interceptors...)
+}
+
+// Create returns a builder for creating a Lockout entity.
+func (c *LockoutClient) Create() *LockoutCreate {
+	mutation := newLockoutMutation(c.config, OpCreate)
+	return &LockoutCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of Lockout entities.
+func (c *LockoutClient) CreateBulk(builders ...*LockoutCreate) *LockoutCreateBulk {
+	return &LockoutCreateBulk{config: c.config, builders: builders}
+}
+
+// MapCreateBulk creates a bulk creation builder from the given slice. For each item in the slice, the function creates
+// a builder and applies setFunc on it.
+func (c *LockoutClient) MapCreateBulk(slice any, setFunc func(*LockoutCreate, int)) *LockoutCreateBulk {
+	rv := reflect.ValueOf(slice)
+	if rv.Kind() != reflect.Slice {
+		return &LockoutCreateBulk{err: fmt.Errorf("calling to LockoutClient.MapCreateBulk with wrong type %T, need slice", slice)}
+	}
+	builders := make([]*LockoutCreate, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		builders[i] = c.Create()
+		setFunc(builders[i], i)
+	}
+	return &LockoutCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for Lockout.
+func (c *LockoutClient) Update() *LockoutUpdate {
+	mutation := newLockoutMutation(c.config, OpUpdate)
+	return &LockoutUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *LockoutClient) UpdateOne(l *Lockout) *LockoutUpdateOne {
+	mutation := newLockoutMutation(c.config, OpUpdateOne, withLockout(l))
+	return &LockoutUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *LockoutClient) UpdateOneID(id pid.ID) *LockoutUpdateOne {
+	mutation := newLockoutMutation(c.config, OpUpdateOne, withLockoutID(id))
+	return &LockoutUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for Lockout.
+func (c *LockoutClient) Delete() *LockoutDelete {
+	mutation := newLockoutMutation(c.config, OpDelete)
+	return &LockoutDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *LockoutClient) DeleteOne(l *Lockout) *LockoutDeleteOne {
+	return c.DeleteOneID(l.ID)
+}
+
+// DeleteOneID returns a builder for deleting the given entity by its id.
+func (c *LockoutClient) DeleteOneID(id pid.ID) *LockoutDeleteOne {
+	builder := c.Delete().Where(lockout.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &LockoutDeleteOne{builder}
+}
+
+// Query returns a query builder for Lockout.
+func (c *LockoutClient) Query() *LockoutQuery {
+	return &LockoutQuery{
+		config: c.config,
+		ctx:    &QueryContext{Type: TypeLockout},
+		inters: c.Interceptors(),
+	}
+}
+
+// Get returns a Lockout entity by its id.
+func (c *LockoutClient) Get(ctx context.Context, id pid.ID) (*Lockout, error) {
+	return c.Query().Where(lockout.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *LockoutClient) GetX(ctx context.Context, id pid.ID) *Lockout {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// Hooks returns the client hooks.
+func (c *LockoutClient) Hooks() []Hook {
+	return c.hooks.Lockout
+}
+
+// Interceptors returns the client interceptors.
+func (c *LockoutClient) Interceptors() []Interceptor {
+	return c.inters.Lockout
+}
+
+func (c *LockoutClient) mutate(ctx context.Context, m *LockoutMutation) (Value, error) {
+	switch m.Op() {
+	case OpCreate:
+		return (&LockoutCreate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdate:
+		return (&LockoutUpdate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdateOne:
+		return (&LockoutUpdateOne{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpDelete, OpDeleteOne:
+		return (&LockoutDelete{config: c.config, hooks: c.Hooks(), mutation: m}).Exec(ctx)
+	default:
+		return nil, fmt.Errorf("ent: unknown Lockout mutation op: %q", m.Op())
+	}
+}
+
 // ProcessedImageClient is a client for the ProcessedImage schema.
 type ProcessedImageClient struct {
 	config
@@ -989,7 +1299,8 @@ func (c *ProcessedImageClient) Hooks() []Hook {
 
 // Interceptors returns the client interceptors.
 func (c *ProcessedImageClient) Interceptors() []Interceptor {
-	return c.inters.ProcessedImage
+	inters := c.inters.ProcessedImage
+	return append(inters[:len(inters):len(inters)], processedimage.Interceptors[:]...)
 }
 
 func (c *ProcessedImageClient) mutate(ctx context.Context, m *ProcessedImageMutation) (Value, error) {
@@ -1320,6 +1631,155 @@ func (c *ReleaseAppearanceClient) mutate(ctx context.Context, m *ReleaseAppearan
 	}
 }
 
+// SessionClient is a client for the Session schema.
+type SessionClient struct {
+	config
+}
+
+// NewSessionClient returns a client for the Session from the given config.
+func NewSessionClient(c config) *SessionClient {
+	return &SessionClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `session.Hooks(f(g(h())))`.
+func (c *SessionClient) Use(hooks ...Hook) {
+	c.hooks.Session = append(c.hooks.Session, hooks...)
+}
+
+// Intercept adds a list of query interceptors to the interceptors stack.
+// A call to `Intercept(f, g, h)` equals to `session.Intercept(f(g(h())))`.
+func (c *SessionClient) Intercept(interceptors ...Interceptor) {
+	c.inters.Session = append(c.inters.Session, interceptors...)
+}
+
+// Create returns a builder for creating a Session entity.
+func (c *SessionClient) Create() *SessionCreate {
+	mutation := newSessionMutation(c.config, OpCreate)
+	return &SessionCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of Session entities.
+func (c *SessionClient) CreateBulk(builders ...*SessionCreate) *SessionCreateBulk {
+	return &SessionCreateBulk{config: c.config, builders: builders}
+}
+
+// MapCreateBulk creates a bulk creation builder from the given slice. For each item in the slice, the function creates
+// a builder and applies setFunc on it.
+func (c *SessionClient) MapCreateBulk(slice any, setFunc func(*SessionCreate, int)) *SessionCreateBulk {
+	rv := reflect.ValueOf(slice)
+	if rv.Kind() != reflect.Slice {
+		return &SessionCreateBulk{err: fmt.Errorf("calling to SessionClient.MapCreateBulk with wrong type %T, need slice", slice)}
+	}
+	builders := make([]*SessionCreate, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		builders[i] = c.Create()
+		setFunc(builders[i], i)
+	}
+	return &SessionCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for Session.
+func (c *SessionClient) Update() *SessionUpdate {
+	mutation := newSessionMutation(c.config, OpUpdate)
+	return &SessionUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *SessionClient) UpdateOne(s *Session) *SessionUpdateOne {
+	mutation := newSessionMutation(c.config, OpUpdateOne, withSession(s))
+	return &SessionUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *SessionClient) UpdateOneID(id pid.ID) *SessionUpdateOne {
+	mutation := newSessionMutation(c.config, OpUpdateOne, withSessionID(id))
+	return &SessionUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for Session.
+func (c *SessionClient) Delete() *SessionDelete {
+	mutation := newSessionMutation(c.config, OpDelete)
+	return &SessionDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *SessionClient) DeleteOne(s *Session) *SessionDeleteOne {
+	return c.DeleteOneID(s.ID)
+}
+
+// DeleteOneID returns a builder for deleting the given entity by its id.
+func (c *SessionClient) DeleteOneID(id pid.ID) *SessionDeleteOne {
+	builder := c.Delete().Where(session.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &SessionDeleteOne{builder}
+}
+
+// Query returns a query builder for Session.
+func (c *SessionClient) Query() *SessionQuery {
+	return &SessionQuery{
+		config: c.config,
+		ctx:    &QueryContext{Type: TypeSession},
+		inters: c.Interceptors(),
+	}
+}
+
+// Get returns a Session entity by its id.
+func (c *SessionClient) Get(ctx context.Context, id pid.ID) (*Session, error) {
+	return c.Query().Where(session.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *SessionClient) GetX(ctx context.Context, id pid.ID) *Session {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// QueryUser queries the user edge of a Session.
+func (c *SessionClient) QueryUser(s *Session) *UserQuery {
+	query := (&UserClient{config: c.config}).Query()
+	query.path = func(context.Context) (fromV *sql.Selector, _ error) {
+		id := s.ID
+		step := sqlgraph.NewStep(
+			sqlgraph.From(session.Table, session.FieldID, id),
+			sqlgraph.To(user.Table, user.FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, session.UserTable, session.UserColumn),
+		)
+		fromV = sqlgraph.Neighbors(s.driver.Dialect(), step)
+		return fromV, nil
+	}
+	return query
+}
+
+// Hooks returns the client hooks.
+func (c *SessionClient) Hooks() []Hook {
+	return c.hooks.Session
+}
+
+// Interceptors returns the client interceptors.
+func (c *SessionClient) Interceptors() []Interceptor {
+	return c.inters.Session
+}
+
+func (c *SessionClient) mutate(ctx context.Context, m *SessionMutation) (Value, error) {
+	switch m.Op() {
+	case OpCreate:
+		return (&SessionCreate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdate:
+		return (&SessionUpdate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdateOne:
+		return (&SessionUpdateOne{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpDelete, OpDeleteOne:
+		return (&SessionDelete{config: c.config, hooks: c.Hooks(), mutation: m}).Exec(ctx)
+	default:
+		return nil, fmt.Errorf("ent: unknown Session mutation op: %q", m.Op())
+	}
+}
+
 // TaskClient is a client for the Task schema.
 type TaskClient struct {
 	config
@@ -1874,6 +2334,38 @@ func (c *UserClient) QueryImages(u *User) *ImageQuery {
 	return query
 }
 
+// QuerySessions queries the sessions edge of a User.
+func (c *UserClient) QuerySessions(u *User) *SessionQuery {
+	query := (&SessionClient{config: c.config}).Query()
+	query.path = func(context.Context) (fromV *sql.Selector, _ error) {
+		id := u.ID
+		step := sqlgraph.NewStep(
+			sqlgraph.From(user.Table, user.FieldID, id),
+			sqlgraph.To(session.Table, session.FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, user.SessionsTable, user.SessionsColumn),
+		)
+		fromV = sqlgraph.Neighbors(u.driver.Dialect(), step)
+		return fromV, nil
+	}
+	return query
+}
+
+// QueryAPITokens queries the api_tokens edge of a User.
+func (c *UserClient) QueryAPITokens(u *User) *ApiTokenQuery {
+	query := (&ApiTokenClient{config: c.config}).Query()
+	query.path = func(context.Context) (fromV *sql.Selector, _ error) {
+		id := u.ID
+		step := sqlgraph.NewStep(
+			sqlgraph.From(user.Table, user.FieldID, id),
+			sqlgraph.To(apitoken.Table, apitoken.FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, user.APITokensTable, user.APITokensColumn),
+		)
+		fromV = sqlgraph.Neighbors(u.driver.Dialect(), step)
+		return fromV, nil
+	}
+	return query
+}
+
 // Hooks returns the client hooks.
 func (c *UserClient) Hooks() []Hook {
 	return c.hooks.User
@@ -1902,12 +2394,13 @@ func (c *UserClient) mutate(ctx context.Context, m *UserMutation) (Value, error)
 // hooks and interceptors per client, for fast access.
 type (
 	hooks struct {
-		Artist, Image, ImageData, ProcessedImage, Release, ReleaseAppearance, Task,
-		Track, TrackAppearance, User []ent.Hook
+		ApiToken, Artist, Image, ImageData, Lockout, ProcessedImage, Release,
+		ReleaseAppearance, Session, Task, Track, TrackAppearance, User []ent.Hook
 	}
 	inters struct {
-		Artist, Image, ImageData, ProcessedImage, Release, ReleaseAppearance, Task,
-		Track, TrackAppearance, User []ent.Interceptor
+		ApiToken, Artist, Image, ImageData, Lockout, ProcessedImage, Release,
+		ReleaseAppearance, Session, Task, Track, TrackAppearance,
+		User []ent.Interceptor
 	}
 )
 
@@ -12,12 +12,15 @@ import (
 	"entgo.io/ent"
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/Pineapple217/cvrs/pkg/ent/apitoken"
 	"github.com/Pineapple217/cvrs/pkg/ent/artist"
 	"github.com/Pineapple217/cvrs/pkg/ent/image"
 	"github.com/Pineapple217/cvrs/pkg/ent/imagedata"
+	"github.com/Pineapple217/cvrs/pkg/ent/lockout"
 	"github.com/Pineapple217/cvrs/pkg/ent/processedimage"
 	"github.com/Pineapple217/cvrs/pkg/ent/release"
 	"github.com/Pineapple217/cvrs/pkg/ent/releaseappearance"
+	"github.com/Pineapple217/cvrs/pkg/ent/session"
 	"github.com/Pineapple217/cvrs/pkg/ent/task"
 	"github.com/Pineapple217/cvrs/pkg/ent/track"
 	"github.com/Pineapple217/cvrs/pkg/ent/trackappearance"
@@ -82,12 +85,15 @@ var (
 func checkColumn(table, column string) error {
 	initCheck.Do(func() {
 		columnCheck = sql.NewColumnCheck(map[string]func(string) bool{
+			apitoken.Table:          apitoken.ValidColumn,
 			artist.Table:            artist.ValidColumn,
 			image.Table:             image.ValidColumn,
 			imagedata.Table:         imagedata.ValidColumn,
+			lockout.Table:           lockout.ValidColumn,
 			processedimage.Table:    processedimage.ValidColumn,
 			release.Table:           release.ValidColumn,
 			releaseappearance.Table: releaseappearance.ValidColumn,
+			session.Table:           session.ValidColumn,
 			task.Table:              task.ValidColumn,
 			track.Table:             track.ValidColumn,
 			trackappearance.Table:   trackappearance.ValidColumn,
@@ -17,6 +17,7 @@ func main() {
 		},
 		Features: []gen.Feature{
 			gen.FeatureExecQuery,
+			gen.FeatureIntercept,
 		},
 	}); err != nil {
 		log.Fatalf("running ent codegen: %v", err)
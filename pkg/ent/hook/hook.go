@@ -9,6 +9,18 @@ import (
 	"github.com/Pineapple217/cvrs/pkg/ent"
 )
 
+// The ApiTokenFunc type is an adapter to allow the use of ordinary
+// function as ApiToken mutator.
+type ApiTokenFunc func(context.Context, *ent.ApiTokenMutation) (ent.Value, error)
+
+// Mutate calls f(ctx, m).
+func (f ApiTokenFunc) Mutate(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+	if mv, ok := m.(*ent.ApiTokenMutation); ok {
+		return f(ctx, mv)
+	}
+	return nil, fmt.Errorf("unexpected mutation type %T. expect *ent.ApiTokenMutation", m)
+}
+
 // The ArtistFunc type is an adapter to allow the use of ordinary
 // function as Artist mutator.
 type ArtistFunc func(context.Context, *ent.ArtistMutation) (ent.Value, error)
@@ -45,6 +57,18 @@ func (f ImageDataFunc) Mutate(ctx context.Context, m ent.Mutation) (ent.Value, e
 	return nil, fmt.Errorf("unexpected mutation type %T. expect *ent.ImageDataMutation", m)
 }
 
+// The LockoutFunc type is an adapter to allow the use of ordinary
+// function as Lockout mutator.
+type LockoutFunc func(context.Context, *ent.LockoutMutation) (ent.Value, error)
+
+// Mutate calls f(ctx, m).
+func (f LockoutFunc) Mutate(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+	if mv, ok := m.(*ent.LockoutMutation); ok {
+		return f(ctx, mv)
+	}
+	return nil, fmt.Errorf("unexpected mutation type %T. expect *ent.LockoutMutation", m)
+}
+
 // The ProcessedImageFunc type is an adapter to allow the use of ordinary
 // function as ProcessedImage mutator.
 type ProcessedImageFunc func(context.Context, *ent.ProcessedImageMutation) (ent.Value, error)
@@ -81,6 +105,18 @@ func (f ReleaseAppearanceFunc) Mutate(ctx context.Context, m ent.Mutation) (ent.
 	return nil, fmt.Errorf("unexpected mutation type %T. expect *ent.ReleaseAppearanceMutation", m)
 }
 
+// The SessionFunc type is an adapter to allow the use of ordinary
+// function as Session mutator.
+type SessionFunc func(context.Context, *ent.SessionMutation) (ent.Value, error)
+
+// Mutate calls f(ctx, m).
+func (f SessionFunc) Mutate(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+	if mv, ok := m.(*ent.SessionMutation); ok {
+		return f(ctx, mv)
+	}
+	return nil, fmt.Errorf("unexpected mutation type %T. expect *ent.SessionMutation", m)
+}
+
 // The TaskFunc type is an adapter to allow the use of ordinary
 // function as Task mutator.
 type TaskFunc func(context.Context, *ent.TaskMutation) (ent.Value, error)
@@ -22,6 +22,8 @@ type Image struct {
 	config `json:"-"`
 	// ID of the ent.
 	ID pid.ID `json:"id,omitempty"`
+	// DeletedAt holds the value of the "deleted_at" field.
+	DeletedAt *time.Time `json:"deleted_at,omitzero"`
 	// File holds the value of the "file" field.
 	File string `json:"file,omitempty"`
 	// OriginalName holds the value of the "original_name" field.
@@ -36,12 +38,12 @@ type Image struct {
 	DimentionHeight int `json:"dimention_height,omitempty"`
 	// SizeBits holds the value of the "size_bits" field.
 	SizeBits uint32 `json:"size_bits,omitempty"`
+	// Hash holds the value of the "hash" field.
+	Hash string `json:"hash,omitempty"`
 	// CreatedAt holds the value of the "created_at" field.
 	CreatedAt time.Time `json:"created_at,omitzero"`
 	// UpdatedAt holds the value of the "updated_at" field.
 	UpdatedAt time.Time `json:"updated_at,omitzero"`
-	// DeletedAt holds the value of the "deleted_at" field.
-	DeletedAt *time.Time `json:"deleted_at,omitzero"`
 	// Edges holds the relations/edges for other nodes in the graph.
 	// The values are being populated by the ImageQuery when eager-loading is set.
 	Edges         ImageEdges `json:"edges"`
@@ -129,9 +131,9 @@ func (*Image) scanValues(columns []string) ([]any, error) {
 		switch columns[i] {
 		case image.FieldID, image.FieldDimentionWidth, image.FieldDimentionHeight, image.FieldSizeBits:
 			values[i] = new(sql.NullInt64)
-		case image.FieldFile, image.FieldOriginalName, image.FieldType, image.FieldNote:
+		case image.FieldFile, image.FieldOriginalName, image.FieldType, image.FieldNote, image.FieldHash:
 			values[i] = new(sql.NullString)
-		case image.FieldCreatedAt, image.FieldUpdatedAt, image.FieldDeletedAt:
+		case image.FieldDeletedAt, image.FieldCreatedAt, image.FieldUpdatedAt:
 			values[i] = new(sql.NullTime)
 		case image.ForeignKeys[0]: // artist_image
 			values[i] = new(sql.NullInt64)
@@ -162,6 +164,13 @@ func (i *Image) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				i.ID = pid.ID(value.Int64)
 			}
+		case image.FieldDeletedAt:
+			if value, ok := values[j].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field deleted_at", values[j])
+			} else if value.Valid {
+				i.DeletedAt = new(time.Time)
+				*i.DeletedAt = value.Time
+			}
 		case image.FieldFile:
 			if value, ok := values[j].(*sql.NullString); !ok {
 				return fmt.Errorf("unexpected type %T for field file", values[j])
@@ -205,6 +214,12 @@ func (i *Image) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				i.SizeBits = uint32(value.Int64)
 			}
+		case image.FieldHash:
+			if value, ok := values[j].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field hash", values[j])
+			} else if value.Valid {
+				i.Hash = value.String
+			}
 		case image.FieldCreatedAt:
 			if value, ok := values[j].(*sql.NullTime); !ok {
 				return fmt.Errorf("unexpected type %T for field created_at", values[j])
@@ -217,13 +232,6 @@ func (i *Image) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				i.UpdatedAt = value.Time
 			}
-		case image.FieldDeletedAt:
-			if value, ok := values[j].(*sql.NullTime); !ok {
-				return fmt.Errorf("unexpected type %T for field deleted_at", values[j])
-			} else if value.Valid {
-				i.DeletedAt = new(time.Time)
-				*i.DeletedAt = value.Time
-			}
 		case image.ForeignKeys[0]:
 			if value, ok := values[j].(*sql.NullInt64); !ok {
 				return fmt.Errorf("unexpected type %T for field artist_image", values[j])
@@ -313,6 +321,11 @@ func (i *Image) String() string {
 	var builder strings.Builder
 	builder.WriteString("Image(")
 	builder.WriteString(fmt.Sprintf("id=%v, ", i.ID))
+	if v := i.DeletedAt; v != nil {
+		builder.WriteString("deleted_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
 	builder.WriteString("file=")
 	builder.WriteString(i.File)
 	builder.WriteString(", ")
@@ -336,16 +349,14 @@ func (i *Image) String() string {
 	builder.WriteString("size_bits=")
 	builder.WriteString(fmt.Sprintf("%v", i.SizeBits))
 	builder.WriteString(", ")
+	builder.WriteString("hash=")
+	builder.WriteString(i.Hash)
+	builder.WriteString(", ")
 	builder.WriteString("created_at=")
 	builder.WriteString(i.CreatedAt.Format(time.ANSIC))
 	builder.WriteString(", ")
 	builder.WriteString("updated_at=")
 	builder.WriteString(i.UpdatedAt.Format(time.ANSIC))
-	builder.WriteString(", ")
-	if v := i.DeletedAt; v != nil {
-		builder.WriteString("deleted_at=")
-		builder.WriteString(v.Format(time.ANSIC))
-	}
 	builder.WriteByte(')')
 	return builder.String()
 }
@@ -17,6 +17,8 @@ const (
 	Label = "image"
 	// FieldID holds the string denoting the id field in the database.
 	FieldID = "id"
+	// FieldDeletedAt holds the string denoting the deleted_at field in the database.
+	FieldDeletedAt = "deleted_at"
 	// FieldFile holds the string denoting the file field in the database.
 	FieldFile = "file"
 	// FieldOriginalName holds the string denoting the original_name field in the database.
@@ -31,12 +33,12 @@ const (
 	FieldDimentionHeight = "dimention_height"
 	// FieldSizeBits holds the string denoting the size_bits field in the database.
 	FieldSizeBits = "size_bits"
+	// FieldHash holds the string denoting the hash field in the database.
+	FieldHash = "hash"
 	// FieldCreatedAt holds the string denoting the created_at field in the database.
 	FieldCreatedAt = "created_at"
 	// FieldUpdatedAt holds the string denoting the updated_at field in the database.
 	FieldUpdatedAt = "updated_at"
-	// FieldDeletedAt holds the string denoting the deleted_at field in the database.
-	FieldDeletedAt = "deleted_at"
 	// EdgeRelease holds the string denoting the release edge name in mutations.
 	EdgeRelease = "release"
 	// EdgeArtist holds the string denoting the artist edge name in mutations.
@@ -89,6 +91,7 @@ const (
 // Columns holds all SQL columns for image fields.
 var Columns = []string{
 	FieldID,
+	FieldDeletedAt,
 	FieldFile,
 	FieldOriginalName,
 	FieldType,
@@ -96,9 +99,9 @@ var Columns = []string{
 	FieldDimentionWidth,
 	FieldDimentionHeight,
 	FieldSizeBits,
+	FieldHash,
 	FieldCreatedAt,
 	FieldUpdatedAt,
-	FieldDeletedAt,
 }
 
 // ForeignKeys holds the SQL foreign-keys that are owned by the "images"
@@ -131,7 +134,8 @@ func ValidColumn(column string) bool {
 //
 //	import _ "github.com/Pineapple217/cvrs/pkg/ent/runtime"
 var (
-	Hooks [1]ent.Hook
+	Hooks        [1]ent.Hook
+	Interceptors [1]ent.Interceptor
 	// FileValidator is a validator for the "file" field. It is called by the builders before save.
 	FileValidator func(string) error
 	// OriginalNameValidator is a validator for the "original_name" field. It is called by the builders before save.
@@ -182,6 +186,11 @@ func ByID(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldID, opts...).ToFunc()
 }
 
+// ByDeletedAt orders the results by the deleted_at field.
+func ByDeletedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldDeletedAt, opts...).ToFunc()
+}
+
 // ByFile orders the results by the file field.
 func ByFile(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldFile, opts...).ToFunc()
@@ -217,6 +226,11 @@ func BySizeBits(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldSizeBits, opts...).ToFunc()
 }
 
+// ByHash orders the results by the hash field.
+func ByHash(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldHash, opts...).ToFunc()
+}
+
 // ByCreatedAt orders the results by the created_at field.
 func ByCreatedAt(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldCreatedAt, opts...).ToFunc()
@@ -227,11 +241,6 @@ func ByUpdatedAt(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldUpdatedAt, opts...).ToFunc()
 }
 
-// ByDeletedAt orders the results by the deleted_at field.
-func ByDeletedAt(opts ...sql.OrderTermOption) OrderOption {
-	return sql.OrderByField(FieldDeletedAt, opts...).ToFunc()
-}
-
 // ByReleaseField orders the results by release field.
 func ByReleaseField(field string, opts ...sql.OrderTermOption) OrderOption {
 	return func(s *sql.Selector) {
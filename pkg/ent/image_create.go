@@ -26,6 +26,20 @@ type ImageCreate struct {
 	hooks    []Hook
 }
 
+// SetDeletedAt sets the "deleted_at" field.
+func (ic *ImageCreate) SetDeletedAt(t time.Time) *ImageCreate {
+	ic.mutation.SetDeletedAt(t)
+	return ic
+}
+
+// SetNillableDeletedAt sets the "deleted_at" field if the given value is not nil.
+func (ic *ImageCreate) SetNillableDeletedAt(t *time.Time) *ImageCreate {
+	if t != nil {
+		ic.SetDeletedAt(*t)
+	}
+	return ic
+}
+
 // SetFile sets the "file" field.
 func (ic *ImageCreate) SetFile(s string) *ImageCreate {
 	ic.mutation.SetFile(s)
@@ -76,6 +90,20 @@ func (ic *ImageCreate) SetSizeBits(u uint32) *ImageCreate {
 	return ic
 }
 
+// SetHash sets the "hash" field.
+func (ic *ImageCreate) SetHash(s string) *ImageCreate {
+	ic.mutation.SetHash(s)
+	return ic
+}
+
+// SetNillableHash sets the "hash" field if the given value is not nil.
+func (ic *ImageCreate) SetNillableHash(s *string) *ImageCreate {
+	if s != nil {
+		ic.SetHash(*s)
+	}
+	return ic
+}
+
 // SetCreatedAt sets the "created_at" field.
 func (ic *ImageCreate) SetCreatedAt(t time.Time) *ImageCreate {
 	ic.mutation.SetCreatedAt(t)
@@ -104,20 +132,6 @@ func (ic *ImageCreate) SetNillableUpdatedAt(t *time.Time) *ImageCreate {
 	return ic
 }
 
-// SetDeletedAt sets the "deleted_at" field.
-func (ic *ImageCreate) SetDeletedAt(t time.Time) *ImageCreate {
-	ic.mutation.SetDeletedAt(t)
-	return ic
-}
-
-// SetNillableDeletedAt sets the "deleted_at" field if the given value is not nil.
-func (ic *ImageCreate) SetNillableDeletedAt(t *time.Time) *ImageCreate {
-	if t != nil {
-		ic.SetDeletedAt(*t)
-	}
-	return ic
-}
-
 // SetID sets the "id" field.
 func (ic *ImageCreate) SetID(pi pid.ID) *ImageCreate {
 	ic.mutation.SetID(pi)
@@ -362,6 +376,10 @@ func (ic *ImageCreate) createSpec() (*Image, *sqlgraph.CreateSpec) {
 		_node.ID = id
 		_spec.ID.Value = id
 	}
+	if value, ok := ic.mutation.DeletedAt(); ok {
+		_spec.SetField(image.FieldDeletedAt, field.TypeTime, value)
+		_node.DeletedAt = &value
+	}
 	if value, ok := ic.mutation.File(); ok {
 		_spec.SetField(image.FieldFile, field.TypeString, value)
 		_node.File = value
@@ -390,6 +408,10 @@ func (ic *ImageCreate) createSpec() (*Image, *sqlgraph.CreateSpec) {
 		_spec.SetField(image.FieldSizeBits, field.TypeUint32, value)
 		_node.SizeBits = value
 	}
+	if value, ok := ic.mutation.Hash(); ok {
+		_spec.SetField(image.FieldHash, field.TypeString, value)
+		_node.Hash = value
+	}
 	if value, ok := ic.mutation.CreatedAt(); ok {
 		_spec.SetField(image.FieldCreatedAt, field.TypeTime, value)
 		_node.CreatedAt = value
@@ -398,10 +420,6 @@ func (ic *ImageCreate) createSpec() (*Image, *sqlgraph.CreateSpec) {
 		_spec.SetField(image.FieldUpdatedAt, field.TypeTime, value)
 		_node.UpdatedAt = value
 	}
-	if value, ok := ic.mutation.DeletedAt(); ok {
-		_spec.SetField(image.FieldDeletedAt, field.TypeTime, value)
-		_node.DeletedAt = &value
-	}
 	if nodes := ic.mutation.ReleaseIDs(); len(nodes) > 0 {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.O2O,
@@ -445,12 +445,12 @@ func (iq *ImageQuery) WithData(opts ...func(*ImageDataQuery)) *ImageQuery {
 // Example:
 //
 //	var v []struct {
-//		File string `json:"file,omitempty"`
+//		DeletedAt time.Time `json:"deleted_at,omitzero"`
 //		Count int `json:"count,omitempty"`
 //	}
 //
 //	client.Image.Query().
-//		GroupBy(image.FieldFile).
+//		GroupBy(image.FieldDeletedAt).
 //		Aggregate(ent.Count()).
 //		Scan(ctx, &v)
 func (iq *ImageQuery) GroupBy(field string, fields ...string) *ImageGroupBy {
@@ -468,11 +468,11 @@ func (iq *ImageQuery) GroupBy(field string, fields ...string) *ImageGroupBy {
 // Example:
 //
 //	var v []struct {
-//		File string `json:"file,omitempty"`
+//		DeletedAt time.Time `json:"deleted_at,omitzero"`
 //	}
 //
 //	client.Image.Query().
-//		Select(image.FieldFile).
+//		Select(image.FieldDeletedAt).
 //		Scan(ctx, &v)
 func (iq *ImageQuery) Select(fields ...string) *ImageSelect {
 	iq.ctx.Fields = append(iq.ctx.Fields, fields...)
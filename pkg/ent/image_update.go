@@ -34,6 +34,26 @@ func (iu *ImageUpdate) Where(ps ...predicate.Image) *ImageUpdate {
 	return iu
 }
 
+// SetDeletedAt sets the "deleted_at" field.
+func (iu *ImageUpdate) SetDeletedAt(t time.Time) *ImageUpdate {
+	iu.mutation.SetDeletedAt(t)
+	return iu
+}
+
+// SetNillableDeletedAt sets the "deleted_at" field if the given value is not nil.
+func (iu *ImageUpdate) SetNillableDeletedAt(t *time.Time) *ImageUpdate {
+	if t != nil {
+		iu.SetDeletedAt(*t)
+	}
+	return iu
+}
+
+// ClearDeletedAt clears the value of the "deleted_at" field.
+func (iu *ImageUpdate) ClearDeletedAt() *ImageUpdate {
+	iu.mutation.ClearDeletedAt()
+	return iu
+}
+
 // SetFile sets the "file" field.
 func (iu *ImageUpdate) SetFile(s string) *ImageUpdate {
 	iu.mutation.SetFile(s)
@@ -159,29 +179,29 @@ func (iu *ImageUpdate) AddSizeBits(u int32) *ImageUpdate {
 	return iu
 }
 
-// SetUpdatedAt sets the "updated_at" field.
-func (iu *ImageUpdate) SetUpdatedAt(t time.Time) *ImageUpdate {
-	iu.mutation.SetUpdatedAt(t)
+// SetHash sets the "hash" field.
+func (iu *ImageUpdate) SetHash(s string) *ImageUpdate {
+	iu.mutation.SetHash(s)
 	return iu
 }
 
-// SetDeletedAt sets the "deleted_at" field.
-func (iu *ImageUpdate) SetDeletedAt(t time.Time) *ImageUpdate {
-	iu.mutation.SetDeletedAt(t)
+// SetNillableHash sets the "hash" field if the given value is not nil.
+func (iu *ImageUpdate) SetNillableHash(s *string) *ImageUpdate {
+	if s != nil {
+		iu.SetHash(*s)
+	}
 	return iu
 }
 
-// SetNillableDeletedAt sets the "deleted_at" field if the given value is not nil.
-func (iu *ImageUpdate) SetNillableDeletedAt(t *time.Time) *ImageUpdate {
-	if t != nil {
-		iu.SetDeletedAt(*t)
-	}
+// ClearHash clears the value of the "hash" field.
+func (iu *ImageUpdate) ClearHash() *ImageUpdate {
+	iu.mutation.ClearHash()
 	return iu
 }
 
-// ClearDeletedAt clears the value of the "deleted_at" field.
-func (iu *ImageUpdate) ClearDeletedAt() *ImageUpdate {
-	iu.mutation.ClearDeletedAt()
+// SetUpdatedAt sets the "updated_at" field.
+func (iu *ImageUpdate) SetUpdatedAt(t time.Time) *ImageUpdate {
+	iu.mutation.SetUpdatedAt(t)
 	return iu
 }
 
@@ -405,6 +425,12 @@ func (iu *ImageUpdate) sqlSave(ctx context.Context) (n int, err error) {
 			}
 		}
 	}
+	if value, ok := iu.mutation.DeletedAt(); ok {
+		_spec.SetField(image.FieldDeletedAt, field.TypeTime, value)
+	}
+	if iu.mutation.DeletedAtCleared() {
+		_spec.ClearField(image.FieldDeletedAt, field.TypeTime)
+	}
 	if value, ok := iu.mutation.File(); ok {
 		_spec.SetField(image.FieldFile, field.TypeString, value)
 	}
@@ -438,14 +464,14 @@ func (iu *ImageUpdate) sqlSave(ctx context.Context) (n int, err error) {
 	if value, ok := iu.mutation.AddedSizeBits(); ok {
 		_spec.AddField(image.FieldSizeBits, field.TypeUint32, value)
 	}
-	if value, ok := iu.mutation.UpdatedAt(); ok {
-		_spec.SetField(image.FieldUpdatedAt, field.TypeTime, value)
+	if value, ok := iu.mutation.Hash(); ok {
+		_spec.SetField(image.FieldHash, field.TypeString, value)
 	}
-	if value, ok := iu.mutation.DeletedAt(); ok {
-		_spec.SetField(image.FieldDeletedAt, field.TypeTime, value)
+	if iu.mutation.HashCleared() {
+		_spec.ClearField(image.FieldHash, field.TypeString)
 	}
-	if iu.mutation.DeletedAtCleared() {
-		_spec.ClearField(image.FieldDeletedAt, field.TypeTime)
+	if value, ok := iu.mutation.UpdatedAt(); ok {
+		_spec.SetField(image.FieldUpdatedAt, field.TypeTime, value)
 	}
 	if iu.mutation.ReleaseCleared() {
 		edge := &sqlgraph.EdgeSpec{
@@ -628,6 +654,26 @@ type ImageUpdateOne struct {
 	mutation *ImageMutation
 }
 
+// SetDeletedAt sets the "deleted_at" field.
+func (iuo *ImageUpdateOne) SetDeletedAt(t time.Time) *ImageUpdateOne {
+	iuo.mutation.SetDeletedAt(t)
+	return iuo
+}
+
+// SetNillableDeletedAt sets the "deleted_at" field if the given value is not nil.
+func (iuo *ImageUpdateOne) SetNillableDeletedAt(t *time.Time) *ImageUpdateOne {
+	if t != nil {
+		iuo.SetDeletedAt(*t)
+	}
+	return iuo
+}
+
+// ClearDeletedAt clears the value of the "deleted_at" field.
+func (iuo *ImageUpdateOne) ClearDeletedAt() *ImageUpdateOne {
+	iuo.mutation.ClearDeletedAt()
+	return iuo
+}
+
 // SetFile sets the "file" field.
 func (iuo *ImageUpdateOne) SetFile(s string) *ImageUpdateOne {
 	iuo.mutation.SetFile(s)
@@ -753,29 +799,29 @@ func (iuo *ImageUpdateOne) AddSizeBits(u int32) *ImageUpdateOne {
 	return iuo
 }
 
-// SetUpdatedAt sets the "updated_at" field.
-func (iuo *ImageUpdateOne) SetUpdatedAt(t time.Time) *ImageUpdateOne {
-	iuo.mutation.SetUpdatedAt(t)
+// SetHash sets the "hash" field.
+func (iuo *ImageUpdateOne) SetHash(s string) *ImageUpdateOne {
+	iuo.mutation.SetHash(s)
 	return iuo
 }
 
-// SetDeletedAt sets the "deleted_at" field.
-func (iuo *ImageUpdateOne) SetDeletedAt(t time.Time) *ImageUpdateOne {
-	iuo.mutation.SetDeletedAt(t)
+// SetNillableHash sets the "hash" field if the given value is not nil.
+func (iuo *ImageUpdateOne) SetNillableHash(s *string) *ImageUpdateOne {
+	if s != nil {
+		iuo.SetHash(*s)
+	}
 	return iuo
 }
 
-// SetNillableDeletedAt sets the "deleted_at" field if the given value is not nil.
-func (iuo *ImageUpdateOne) SetNillableDeletedAt(t *time.Time) *ImageUpdateOne {
-	if t != nil {
-		iuo.SetDeletedAt(*t)
-	}
+// ClearHash clears the value of the "hash" field.
+func (iuo *ImageUpdateOne) ClearHash() *ImageUpdateOne {
+	iuo.mutation.ClearHash()
 	return iuo
 }
 
-// ClearDeletedAt clears the value of the "deleted_at" field.
-func (iuo *ImageUpdateOne) ClearDeletedAt() *ImageUpdateOne {
-	iuo.mutation.ClearDeletedAt()
+// SetUpdatedAt sets the "updated_at" field.
+func (iuo *ImageUpdateOne) SetUpdatedAt(t time.Time) *ImageUpdateOne {
+	iuo.mutation.SetUpdatedAt(t)
 	return iuo
 }
 
@@ -1029,6 +1075,12 @@ func (iuo *ImageUpdateOne) sqlSave(ctx context.Context) (_node *Image, err error
 			}
 		}
 	}
+	if value, ok := iuo.mutation.DeletedAt(); ok {
+		_spec.SetField(image.FieldDeletedAt, field.TypeTime, value)
+	}
+	if iuo.mutation.DeletedAtCleared() {
+		_spec.ClearField(image.FieldDeletedAt, field.TypeTime)
+	}
 	if value, ok := iuo.mutation.File(); ok {
 		_spec.SetField(image.FieldFile, field.TypeString, value)
 	}
@@ -1062,14 +1114,14 @@ func (iuo *ImageUpdateOne) sqlSave(ctx context.Context) (_node *Image, err error
 	if value, ok := iuo.mutation.AddedSizeBits(); ok {
 		_spec.AddField(image.FieldSizeBits, field.TypeUint32, value)
 	}
-	if value, ok := iuo.mutation.UpdatedAt(); ok {
-		_spec.SetField(image.FieldUpdatedAt, field.TypeTime, value)
+	if value, ok := iuo.mutation.Hash(); ok {
+		_spec.SetField(image.FieldHash, field.TypeString, value)
 	}
-	if value, ok := iuo.mutation.DeletedAt(); ok {
-		_spec.SetField(image.FieldDeletedAt, field.TypeTime, value)
+	if iuo.mutation.HashCleared() {
+		_spec.ClearField(image.FieldHash, field.TypeString)
 	}
-	if iuo.mutation.DeletedAtCleared() {
-		_spec.ClearField(image.FieldDeletedAt, field.TypeTime)
+	if value, ok := iuo.mutation.UpdatedAt(); ok {
+		_spec.SetField(image.FieldUpdatedAt, field.TypeTime, value)
 	}
 	if iuo.mutation.ReleaseCleared() {
 		edge := &sqlgraph.EdgeSpec{
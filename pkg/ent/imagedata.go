@@ -3,6 +3,7 @@
 package ent
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -18,15 +19,25 @@ type ImageData struct {
 	// ID of the ent.
 	ID int `json:"id,omitempty"`
 	// AvrR holds the value of the "avr_r" field.
-	AvrR int `json:"avr_r,omitempty"`
+	AvrR *int `json:"avr_r,omitempty"`
 	// AvrG holds the value of the "avr_g" field.
-	AvrG int `json:"avr_g,omitempty"`
+	AvrG *int `json:"avr_g,omitempty"`
 	// AvrB holds the value of the "avr_b" field.
-	AvrB int `json:"avr_b,omitempty"`
+	AvrB *int `json:"avr_b,omitempty"`
 	// AvgBrightness holds the value of the "avg_brightness" field.
-	AvgBrightness int `json:"avg_brightness,omitempty"`
+	AvgBrightness *int `json:"avg_brightness,omitempty"`
 	// AvgSaturation holds the value of the "avg_saturation" field.
-	AvgSaturation int `json:"avg_saturation,omitempty"`
+	AvgSaturation *int `json:"avg_saturation,omitempty"`
+	// Phash holds the value of the "phash" field.
+	Phash *int64 `json:"-"`
+	// Palette holds the value of the "palette" field.
+	Palette []string `json:"palette,omitempty"`
+	// Thumbhash holds the value of the "thumbhash" field.
+	Thumbhash []byte `json:"-"`
+	// Placeholder holds the value of the "placeholder" field.
+	Placeholder string `json:"placeholder,omitempty"`
+	// PlaceholderColor holds the value of the "placeholder_color" field.
+	PlaceholderColor string `json:"placeholder_color,omitempty"`
 	// CreatedAt holds the value of the "created_at" field.
 	CreatedAt time.Time `json:"created_at,omitzero"`
 	// Edges holds the relations/edges for other nodes in the graph.
@@ -58,8 +69,12 @@ func (*ImageData) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
 	for i := range columns {
 		switch columns[i] {
-		case imagedata.FieldID, imagedata.FieldAvrR, imagedata.FieldAvrG, imagedata.FieldAvrB, imagedata.FieldAvgBrightness, imagedata.FieldAvgSaturation:
+		case imagedata.FieldPalette, imagedata.FieldThumbhash:
+			values[i] = new([]byte)
+		case imagedata.FieldID, imagedata.FieldAvrR, imagedata.FieldAvrG, imagedata.FieldAvrB, imagedata.FieldAvgBrightness, imagedata.FieldAvgSaturation, imagedata.FieldPhash:
 			values[i] = new(sql.NullInt64)
+		case imagedata.FieldPlaceholder, imagedata.FieldPlaceholderColor:
+			values[i] = new(sql.NullString)
 		case imagedata.FieldCreatedAt:
 			values[i] = new(sql.NullTime)
 		default:
@@ -87,31 +102,69 @@ func (id *ImageData) assignValues(columns []string, values []any) error {
 			if value, ok := values[i].(*sql.NullInt64); !ok {
 				return fmt.Errorf("unexpected type %T for field avr_r", values[i])
 			} else if value.Valid {
-				id.AvrR = int(value.Int64)
+				id.AvrR = new(int)
+				*id.AvrR = int(value.Int64)
 			}
 		case imagedata.FieldAvrG:
 			if value, ok := values[i].(*sql.NullInt64); !ok {
 				return fmt.Errorf("unexpected type %T for field avr_g", values[i])
 			} else if value.Valid {
-				id.AvrG = int(value.Int64)
+				id.AvrG = new(int)
+				*id.AvrG = int(value.Int64)
 			}
 		case imagedata.FieldAvrB:
 			if value, ok := values[i].(*sql.NullInt64); !ok {
 				return fmt.Errorf("unexpected type %T for field avr_b", values[i])
 			} else if value.Valid {
-				id.AvrB = int(value.Int64)
+				id.AvrB = new(int)
+				*id.AvrB = int(value.Int64)
 			}
 		case imagedata.FieldAvgBrightness:
 			if value, ok := values[i].(*sql.NullInt64); !ok {
 				return fmt.Errorf("unexpected type %T for field avg_brightness", values[i])
 			} else if value.Valid {
-				id.AvgBrightness = int(value.Int64)
+				id.AvgBrightness = new(int)
+				*id.AvgBrightness = int(value.Int64)
 			}
 		case imagedata.FieldAvgSaturation:
 			if value, ok := values[i].(*sql.NullInt64); !ok {
 				return fmt.Errorf("unexpected type %T for field avg_saturation", values[i])
 			} else if value.Valid {
-				id.AvgSaturation = int(value.Int64)
+				id.AvgSaturation = new(int)
+				*id.AvgSaturation = int(value.Int64)
+			}
+		case imagedata.FieldPhash:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field phash", values[i])
+			} else if value.Valid {
+				id.Phash = new(int64)
+				*id.Phash = value.Int64
+			}
+		case imagedata.FieldPalette:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field palette", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &id.Palette); err != nil {
+					return fmt.Errorf("unmarshal field palette: %w", err)
+				}
+			}
+		case imagedata.FieldThumbhash:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field thumbhash", values[i])
+			} else if value != nil {
+				id.Thumbhash = *value
+			}
+		case imagedata.FieldPlaceholder:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field placeholder", values[i])
+			} else if value.Valid {
+				id.Placeholder = value.String
+			}
+		case imagedata.FieldPlaceholderColor:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field placeholder_color", values[i])
+			} else if value.Valid {
+				id.PlaceholderColor = value.String
 			}
 		case imagedata.FieldCreatedAt:
 			if value, ok := values[i].(*sql.NullTime); !ok {
@@ -160,20 +213,47 @@ func (id *ImageData) String() string {
 	var builder strings.Builder
 	builder.WriteString("ImageData(")
 	builder.WriteString(fmt.Sprintf("id=%v, ", id.ID))
-	builder.WriteString("avr_r=")
-	builder.WriteString(fmt.Sprintf("%v", id.AvrR))
+	if v := id.AvrR; v != nil {
+		builder.WriteString("avr_r=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
+	if v := id.AvrG; v != nil {
+		builder.WriteString("avr_g=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
+	if v := id.AvrB; v != nil {
+		builder.WriteString("avr_b=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
+	if v := id.AvgBrightness; v != nil {
+		builder.WriteString("avg_brightness=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
+	if v := id.AvgSaturation; v != nil {
+		builder.WriteString("avg_saturation=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
+	if v := id.Phash; v != nil {
+		builder.WriteString("phash=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
 	builder.WriteString(", ")
-	builder.WriteString("avr_g=")
-	builder.WriteString(fmt.Sprintf("%v", id.AvrG))
+	builder.WriteString("palette=")
+	builder.WriteString(fmt.Sprintf("%v", id.Palette))
 	builder.WriteString(", ")
-	builder.WriteString("avr_b=")
-	builder.WriteString(fmt.Sprintf("%v", id.AvrB))
+	builder.WriteString("thumbhash=")
+	builder.WriteString(fmt.Sprintf("%v", id.Thumbhash))
 	builder.WriteString(", ")
-	builder.WriteString("avg_brightness=")
-	builder.WriteString(fmt.Sprintf("%v", id.AvgBrightness))
+	builder.WriteString("placeholder=")
+	builder.WriteString(id.Placeholder)
 	builder.WriteString(", ")
-	builder.WriteString("avg_saturation=")
-	builder.WriteString(fmt.Sprintf("%v", id.AvgSaturation))
+	builder.WriteString("placeholder_color=")
+	builder.WriteString(id.PlaceholderColor)
 	builder.WriteString(", ")
 	builder.WriteString("created_at=")
 	builder.WriteString(id.CreatedAt.Format(time.ANSIC))
@@ -24,6 +24,16 @@ const (
 	FieldAvgBrightness = "avg_brightness"
 	// FieldAvgSaturation holds the string denoting the avg_saturation field in the database.
 	FieldAvgSaturation = "avg_saturation"
+	// FieldPhash holds the string denoting the phash field in the database.
+	FieldPhash = "phash"
+	// FieldPalette holds the string denoting the palette field in the database.
+	FieldPalette = "palette"
+	// FieldThumbhash holds the string denoting the thumbhash field in the database.
+	FieldThumbhash = "thumbhash"
+	// FieldPlaceholder holds the string denoting the placeholder field in the database.
+	FieldPlaceholder = "placeholder"
+	// FieldPlaceholderColor holds the string denoting the placeholder_color field in the database.
+	FieldPlaceholderColor = "placeholder_color"
 	// FieldCreatedAt holds the string denoting the created_at field in the database.
 	FieldCreatedAt = "created_at"
 	// EdgeImage holds the string denoting the image edge name in mutations.
@@ -47,6 +57,11 @@ var Columns = []string{
 	FieldAvrB,
 	FieldAvgBrightness,
 	FieldAvgSaturation,
+	FieldPhash,
+	FieldPalette,
+	FieldThumbhash,
+	FieldPlaceholder,
+	FieldPlaceholderColor,
 	FieldCreatedAt,
 }
 
@@ -98,6 +113,21 @@ func ByAvgSaturation(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldAvgSaturation, opts...).ToFunc()
 }
 
+// ByPhash orders the results by the phash field.
+func ByPhash(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldPhash, opts...).ToFunc()
+}
+
+// ByPlaceholder orders the results by the placeholder field.
+func ByPlaceholder(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldPlaceholder, opts...).ToFunc()
+}
+
+// ByPlaceholderColor orders the results by the placeholder_color field.
+func ByPlaceholderColor(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldPlaceholderColor, opts...).ToFunc()
+}
+
 // ByCreatedAt orders the results by the created_at field.
 func ByCreatedAt(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldCreatedAt, opts...).ToFunc()
@@ -80,6 +80,26 @@ func AvgSaturation(v int) predicate.ImageData {
 	return predicate.ImageData(sql.FieldEQ(FieldAvgSaturation, v))
 }
 
+// Phash applies equality check predicate on the "phash" field. It's identical to PhashEQ.
+func Phash(v int64) predicate.ImageData {
+	return predicate.ImageData(sql.FieldEQ(FieldPhash, v))
+}
+
+// Thumbhash applies equality check predicate on the "thumbhash" field. It's identical to ThumbhashEQ.
+func Thumbhash(v []byte) predicate.ImageData {
+	return predicate.ImageData(sql.FieldEQ(FieldThumbhash, v))
+}
+
+// Placeholder applies equality check predicate on the "placeholder" field. It's identical to PlaceholderEQ.
+func Placeholder(v string) predicate.ImageData {
+	return predicate.ImageData(sql.FieldEQ(FieldPlaceholder, v))
+}
+
+// PlaceholderColor applies equality check predicate on the "placeholder_color" field. It's identical to PlaceholderColorEQ.
+func PlaceholderColor(v string) predicate.ImageData {
+	return predicate.ImageData(sql.FieldEQ(FieldPlaceholderColor, v))
+}
+
 // CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
 func CreatedAt(v time.Time) predicate.ImageData {
 	return predicate.ImageData(sql.FieldEQ(FieldCreatedAt, v))
@@ -125,6 +145,16 @@ func AvrRLTE(v int) predicate.ImageData {
 	return predicate.ImageData(sql.FieldLTE(FieldAvrR, v))
 }
 
+// AvrRIsNil applies the IsNil predicate on the "avr_r" field.
+func AvrRIsNil() predicate.ImageData {
+	return predicate.ImageData(sql.FieldIsNull(FieldAvrR))
+}
+
+// AvrRNotNil applies the NotNil predicate on the "avr_r" field.
+func AvrRNotNil() predicate.ImageData {
+	return predicate.ImageData(sql.FieldNotNull(FieldAvrR))
+}
+
 // AvrGEQ applies the EQ predicate on the "avr_g" field.
 func AvrGEQ(v int) predicate.ImageData {
 	return predicate.ImageData(sql.FieldEQ(FieldAvrG, v))
@@ -165,6 +195,16 @@ func AvrGLTE(v int) predicate.ImageData {
 	return predicate.ImageData(sql.FieldLTE(FieldAvrG, v))
 }
 
+// AvrGIsNil applies the IsNil predicate on the "avr_g" field.
+func AvrGIsNil() predicate.ImageData {
+	return predicate.ImageData(sql.FieldIsNull(FieldAvrG))
+}
+
+// AvrGNotNil applies the NotNil predicate on the "avr_g" field.
+func AvrGNotNil() predicate.ImageData {
+	return predicate.ImageData(sql.FieldNotNull(FieldAvrG))
+}
+
 // AvrBEQ applies the EQ predicate on the "avr_b" field.
 func AvrBEQ(v int) predicate.ImageData {
 	return predicate.ImageData(sql.FieldEQ(FieldAvrB, v))
@@ -205,6 +245,16 @@ func AvrBLTE(v int) predicate.ImageData {
 	return predicate.ImageData(sql.FieldLTE(FieldAvrB, v))
 }
 
+// AvrBIsNil applies the IsNil predicate on the "avr_b" field.
+func AvrBIsNil() predicate.ImageData {
+	return predicate.ImageData(sql.FieldIsNull(FieldAvrB))
+}
+
+// AvrBNotNil applies the NotNil predicate on the "avr_b" field.
+func AvrBNotNil() predicate.ImageData {
+	return predicate.ImageData(sql.FieldNotNull(FieldAvrB))
+}
+
 // AvgBrightnessEQ applies the EQ predicate on the "avg_brightness" field.
 func AvgBrightnessEQ(v int) predicate.ImageData {
 	return predicate.ImageData(sql.FieldEQ(FieldAvgBrightness, v))
@@ -245,6 +295,16 @@ func AvgBrightnessLTE(v int) predicate.ImageData {
 	return predicate.ImageData(sql.FieldLTE(FieldAvgBrightness, v))
 }
 
+// AvgBrightnessIsNil applies the IsNil predicate on the "avg_brightness" field.
+func AvgBrightnessIsNil() predicate.ImageData {
+	return predicate.ImageData(sql.FieldIsNull(FieldAvgBrightness))
+}
+
+// AvgBrightnessNotNil applies the NotNil predicate on the "avg_brightness" field.
+func AvgBrightnessNotNil() predicate.ImageData {
+	return predicate.ImageData(sql.FieldNotNull(FieldAvgBrightness))
+}
+
 // AvgSaturationEQ applies the EQ predicate on the "avg_saturation" field.
 func AvgSaturationEQ(v int) predicate.ImageData {
 	return predicate.ImageData(sql.FieldEQ(FieldAvgSaturation, v))
@@ -285,6 +345,276 @@ func AvgSaturationLTE(v int) predicate.ImageData {
 	return predicate.ImageData(sql.FieldLTE(FieldAvgSaturation, v))
 }
 
+// AvgSaturationIsNil applies the IsNil predicate on the "avg_saturation" field.
+func AvgSaturationIsNil() predicate.ImageData {
+	return predicate.ImageData(sql.FieldIsNull(FieldAvgSaturation))
+}
+
+// AvgSaturationNotNil applies the NotNil predicate on the "avg_saturation" field.
+func AvgSaturationNotNil() predicate.ImageData {
+	return predicate.ImageData(sql.FieldNotNull(FieldAvgSaturation))
+}
+
+// PhashEQ applies the EQ predicate on the "phash" field.
+func PhashEQ(v int64) predicate.ImageData {
+	return predicate.ImageData(sql.FieldEQ(FieldPhash, v))
+}
+
+// PhashNEQ applies the NEQ predicate on the "phash" field.
+func PhashNEQ(v int64) predicate.ImageData {
+	return predicate.ImageData(sql.FieldNEQ(FieldPhash, v))
+}
+
+// PhashIn applies the In predicate on the "phash" field.
+func PhashIn(vs ...int64) predicate.ImageData {
+	return predicate.ImageData(sql.FieldIn(FieldPhash, vs...))
+}
+
+// PhashNotIn applies the NotIn predicate on the "phash" field.
+func PhashNotIn(vs ...int64) predicate.ImageData {
+	return predicate.ImageData(sql.FieldNotIn(FieldPhash, vs...))
+}
+
+// PhashGT applies the GT predicate on the "phash" field.
+func PhashGT(v int64) predicate.ImageData {
+	return predicate.ImageData(sql.FieldGT(FieldPhash, v))
+}
+
+// PhashGTE applies the GTE predicate on the "phash" field.
+func PhashGTE(v int64) predicate.ImageData {
+	return predicate.ImageData(sql.FieldGTE(FieldPhash, v))
+}
+
+// PhashLT applies the LT predicate on the "phash" field.
+func PhashLT(v int64) predicate.ImageData {
+	return predicate.ImageData(sql.FieldLT(FieldPhash, v))
+}
+
+// PhashLTE applies the LTE predicate on the "phash" field.
+func PhashLTE(v int64) predicate.ImageData {
+	return predicate.ImageData(sql.FieldLTE(FieldPhash, v))
+}
+
+// PhashIsNil applies the IsNil predicate on the "phash" field.
+func PhashIsNil() predicate.ImageData {
+	return predicate.ImageData(sql.FieldIsNull(FieldPhash))
+}
+
+// PhashNotNil applies the NotNil predicate on the "phash" field.
+func PhashNotNil() predicate.ImageData {
+	return predicate.ImageData(sql.FieldNotNull(FieldPhash))
+}
+
+// PaletteIsNil applies the IsNil predicate on the "palette" field.
+func PaletteIsNil() predicate.ImageData {
+	return predicate.ImageData(sql.FieldIsNull(FieldPalette))
+}
+
+// PaletteNotNil applies the NotNil predicate on the "palette" field.
+func PaletteNotNil() predicate.ImageData {
+	return predicate.ImageData(sql.FieldNotNull(FieldPalette))
+}
+
+// ThumbhashEQ applies the EQ predicate on the "thumbhash" field.
+func ThumbhashEQ(v []byte) predicate.ImageData {
+	return predicate.ImageData(sql.FieldEQ(FieldThumbhash, v))
+}
+
+// ThumbhashNEQ applies the NEQ predicate on the "thumbhash" field.
+func ThumbhashNEQ(v []byte) predicate.ImageData {
+	return predicate.ImageData(sql.FieldNEQ(FieldThumbhash, v))
+}
+
+// ThumbhashIn applies the In predicate on the "thumbhash" field.
+func ThumbhashIn(vs ...[]byte) predicate.ImageData {
+	return predicate.ImageData(sql.FieldIn(FieldThumbhash, vs...))
+}
+
+// ThumbhashNotIn applies the NotIn predicate on the "thumbhash" field.
+func ThumbhashNotIn(vs ...[]byte) predicate.ImageData {
+	return predicate.ImageData(sql.FieldNotIn(FieldThumbhash, vs...))
+}
+
+// ThumbhashGT applies the GT predicate on the "thumbhash" field.
+func ThumbhashGT(v []byte) predicate.ImageData {
+	return predicate.ImageData(sql.FieldGT(FieldThumbhash, v))
+}
+
+// ThumbhashGTE applies the GTE predicate on the "thumbhash" field.
+func ThumbhashGTE(v []byte) predicate.ImageData {
+	return predicate.ImageData(sql.FieldGTE(FieldThumbhash, v))
+}
+
+// ThumbhashLT applies the LT predicate on the "thumbhash" field.
+func ThumbhashLT(v []byte) predicate.ImageData {
+	return predicate.ImageData(sql.FieldLT(FieldThumbhash, v))
+}
+
+// ThumbhashLTE applies the LTE predicate on the "thumbhash" field.
+func ThumbhashLTE(v []byte) predicate.ImageData {
+	return predicate.ImageData(sql.FieldLTE(FieldThumbhash, v))
+}
+
+// ThumbhashIsNil applies the IsNil predicate on the "thumbhash" field.
+func ThumbhashIsNil() predicate.ImageData {
+	return predicate.ImageData(sql.FieldIsNull(FieldThumbhash))
+}
+
+// ThumbhashNotNil applies the NotNil predicate on the "thumbhash" field.
+func ThumbhashNotNil() predicate.ImageData {
+	return predicate.ImageData(sql.FieldNotNull(FieldThumbhash))
+}
+
+// PlaceholderEQ applies the EQ predicate on the "placeholder" field.
+func PlaceholderEQ(v string) predicate.ImageData {
+	return predicate.ImageData(sql.FieldEQ(FieldPlaceholder, v))
+}
+
+// PlaceholderNEQ applies the NEQ predicate on the "placeholder" field.
+func PlaceholderNEQ(v string) predicate.ImageData {
+	return predicate.ImageData(sql.FieldNEQ(FieldPlaceholder, v))
+}
+
+// PlaceholderIn applies the In predicate on the "placeholder" field.
+func PlaceholderIn(vs ...string) predicate.ImageData {
+	return predicate.ImageData(sql.FieldIn(FieldPlaceholder, vs...))
+}
+
+// PlaceholderNotIn applies the NotIn predicate on the "placeholder" field.
+func PlaceholderNotIn(vs ...string) predicate.ImageData {
+	return predicate.ImageData(sql.FieldNotIn(FieldPlaceholder, vs...))
+}
+
+// PlaceholderGT applies the GT predicate on the "placeholder" field.
+func PlaceholderGT(v string) predicate.ImageData {
+	return predicate.ImageData(sql.FieldGT(FieldPlaceholder, v))
+}
+
+// PlaceholderGTE applies the GTE predicate on the "placeholder" field.
+func PlaceholderGTE(v string) predicate.ImageData {
+	return predicate.ImageData(sql.FieldGTE(FieldPlaceholder, v))
+}
+
+// PlaceholderLT applies the LT predicate on the "placeholder" field.
+func PlaceholderLT(v string) predicate.ImageData {
+	return predicate.ImageData(sql.FieldLT(FieldPlaceholder, v))
+}
+
+// PlaceholderLTE applies the LTE predicate on the "placeholder" field.
+func PlaceholderLTE(v string) predicate.ImageData {
+	return predicate.ImageData(sql.FieldLTE(FieldPlaceholder, v))
+}
+
+// PlaceholderContains applies the Contains predicate on the "placeholder" field.
+func PlaceholderContains(v string) predicate.ImageData {
+	return predicate.ImageData(sql.FieldContains(FieldPlaceholder, v))
+}
+
+// PlaceholderHasPrefix applies the HasPrefix predicate on the "placeholder" field.
+func PlaceholderHasPrefix(v string) predicate.ImageData {
+	return predicate.ImageData(sql.FieldHasPrefix(FieldPlaceholder, v))
+}
+
+// PlaceholderHasSuffix applies the HasSuffix predicate on the "placeholder" field.
+func PlaceholderHasSuffix(v string) predicate.ImageData {
+	return predicate.ImageData(sql.FieldHasSuffix(FieldPlaceholder, v))
+}
+
+// PlaceholderIsNil applies the IsNil predicate on the "placeholder" field.
+func PlaceholderIsNil() predicate.ImageData {
+	return predicate.ImageData(sql.FieldIsNull(FieldPlaceholder))
+}
+
+// PlaceholderNotNil applies the NotNil predicate on the "placeholder" field.
+func PlaceholderNotNil() predicate.ImageData {
+	return predicate.ImageData(sql.FieldNotNull(FieldPlaceholder))
+}
+
+// PlaceholderEqualFold applies the EqualFold predicate on the "placeholder" field.
+func PlaceholderEqualFold(v string) predicate.ImageData {
+	return predicate.ImageData(sql.FieldEqualFold(FieldPlaceholder, v))
+}
+
+// PlaceholderContainsFold applies the ContainsFold predicate on the "placeholder" field.
+func PlaceholderContainsFold(v string) predicate.ImageData {
+	return predicate.ImageData(sql.FieldContainsFold(FieldPlaceholder, v))
+}
+
+// PlaceholderColorEQ applies the EQ predicate on the "placeholder_color" field.
+func PlaceholderColorEQ(v string) predicate.ImageData {
+	return predicate.ImageData(sql.FieldEQ(FieldPlaceholderColor, v))
+}
+
+// PlaceholderColorNEQ applies the NEQ predicate on the "placeholder_color" field.
+func PlaceholderColorNEQ(v string) predicate.ImageData {
+	return predicate.ImageData(sql.FieldNEQ(FieldPlaceholderColor, v))
+}
+
+// PlaceholderColorIn applies the In predicate on the "placeholder_color" field.
+func PlaceholderColorIn(vs ...string) predicate.ImageData {
+	return predicate.ImageData(sql.FieldIn(FieldPlaceholderColor, vs...))
+}
+
+// PlaceholderColorNotIn applies the NotIn predicate on the "placeholder_color" field.
+func PlaceholderColorNotIn(vs ...string) predicate.ImageData {
+	return predicate.ImageData(sql.FieldNotIn(FieldPlaceholderColor, vs...))
+}
+
+// PlaceholderColorGT applies the GT predicate on the "placeholder_color" field.
+func PlaceholderColorGT(v string) predicate.ImageData {
+	return predicate.ImageData(sql.FieldGT(FieldPlaceholderColor, v))
+}
+
+// PlaceholderColorGTE applies the GTE predicate on the "placeholder_color" field.
+func PlaceholderColorGTE(v string) predicate.ImageData {
+	return predicate.ImageData(sql.FieldGTE(FieldPlaceholderColor, v))
+}
+
+// PlaceholderColorLT applies the LT predicate on the "placeholder_color" field.
+func PlaceholderColorLT(v string) predicate.ImageData {
+	return predicate.ImageData(sql.FieldLT(FieldPlaceholderColor, v))
+}
+
+// PlaceholderColorLTE applies the LTE predicate on the "placeholder_color" field.
+func PlaceholderColorLTE(v string) predicate.ImageData {
+	return predicate.ImageData(sql.FieldLTE(FieldPlaceholderColor, v))
+}
+
+// PlaceholderColorContains applies the Contains predicate on the "placeholder_color" field.
+func PlaceholderColorContains(v string) predicate.ImageData {
+	return predicate.ImageData(sql.FieldContains(FieldPlaceholderColor, v))
+}
+
+// PlaceholderColorHasPrefix applies the HasPrefix predicate on the "placeholder_color" field.
+func PlaceholderColorHasPrefix(v string) predicate.ImageData {
+	return predicate.ImageData(sql.FieldHasPrefix(FieldPlaceholderColor, v))
+}
+
+// PlaceholderColorHasSuffix applies the HasSuffix predicate on the "placeholder_color" field.
+func PlaceholderColorHasSuffix(v string) predicate.ImageData {
+	return predicate.ImageData(sql.FieldHasSuffix(FieldPlaceholderColor, v))
+}
+
+// PlaceholderColorIsNil applies the IsNil predicate on the "placeholder_color" field.
+func PlaceholderColorIsNil() predicate.ImageData {
+	return predicate.ImageData(sql.FieldIsNull(FieldPlaceholderColor))
+}
+
+// PlaceholderColorNotNil applies the NotNil predicate on the "placeholder_color" field.
+func PlaceholderColorNotNil() predicate.ImageData {
+	return predicate.ImageData(sql.FieldNotNull(FieldPlaceholderColor))
+}
+
+// PlaceholderColorEqualFold applies the EqualFold predicate on the "placeholder_color" field.
+func PlaceholderColorEqualFold(v string) predicate.ImageData {
+	return predicate.ImageData(sql.FieldEqualFold(FieldPlaceholderColor, v))
+}
+
+// PlaceholderColorContainsFold applies the ContainsFold predicate on the "placeholder_color" field.
+func PlaceholderColorContainsFold(v string) predicate.ImageData {
+	return predicate.ImageData(sql.FieldContainsFold(FieldPlaceholderColor, v))
+}
+
 // CreatedAtEQ applies the EQ predicate on the "created_at" field.
 func CreatedAtEQ(v time.Time) predicate.ImageData {
 	return predicate.ImageData(sql.FieldEQ(FieldCreatedAt, v))
@@ -28,30 +28,124 @@ func (idc *ImageDataCreate) SetAvrR(i int) *ImageDataCreate {
 	return idc
 }
 
+// SetNillableAvrR sets the "avr_r" field if the given value is not nil.
+func (idc *ImageDataCreate) SetNillableAvrR(i *int) *ImageDataCreate {
+	if i != nil {
+		idc.SetAvrR(*i)
+	}
+	return idc
+}
+
 // SetAvrG sets the "avr_g" field.
 func (idc *ImageDataCreate) SetAvrG(i int) *ImageDataCreate {
 	idc.mutation.SetAvrG(i)
 	return idc
 }
 
+// SetNillableAvrG sets the "avr_g" field if the given value is not nil.
+func (idc *ImageDataCreate) SetNillableAvrG(i *int) *ImageDataCreate {
+	if i != nil {
+		idc.SetAvrG(*i)
+	}
+	return idc
+}
+
 // SetAvrB sets the "avr_b" field.
 func (idc *ImageDataCreate) SetAvrB(i int) *ImageDataCreate {
 	idc.mutation.SetAvrB(i)
 	return idc
 }
 
+// SetNillableAvrB sets the "avr_b" field if the given value is not nil.
+func (idc *ImageDataCreate) SetNillableAvrB(i *int) *ImageDataCreate {
+	if i != nil {
+		idc.SetAvrB(*i)
+	}
+	return idc
+}
+
 // SetAvgBrightness sets the "avg_brightness" field.
 func (idc *ImageDataCreate) SetAvgBrightness(i int) *ImageDataCreate {
 	idc.mutation.SetAvgBrightness(i)
 	return idc
 }
 
+// SetNillableAvgBrightness sets the "avg_brightness" field if the given value is not nil.
+func (idc *ImageDataCreate) SetNillableAvgBrightness(i *int) *ImageDataCreate {
+	if i != nil {
+		idc.SetAvgBrightness(*i)
+	}
+	return idc
+}
+
 // SetAvgSaturation sets the "avg_saturation" field.
 func (idc *ImageDataCreate) SetAvgSaturation(i int) *ImageDataCreate {
 	idc.mutation.SetAvgSaturation(i)
 	return idc
 }
 
+// SetNillableAvgSaturation sets the "avg_saturation" field if the given value is not nil.
+func (idc *ImageDataCreate) SetNillableAvgSaturation(i *int) *ImageDataCreate {
+	if i != nil {
+		idc.SetAvgSaturation(*i)
+	}
+	return idc
+}
+
+// SetPhash sets the "phash" field.
+func (idc *ImageDataCreate) SetPhash(i int64) *ImageDataCreate {
+	idc.mutation.SetPhash(i)
+	return idc
+}
+
+// SetNillablePhash sets the "phash" field if the given value is not nil.
+func (idc *ImageDataCreate) SetNillablePhash(i *int64) *ImageDataCreate {
+	if i != nil {
+		idc.SetPhash(*i)
+	}
+	return idc
+}
+
+// SetPalette sets the "palette" field.
+func (idc *ImageDataCreate) SetPalette(s []string) *ImageDataCreate {
+	idc.mutation.SetPalette(s)
+	return idc
+}
+
+// SetThumbhash sets the "thumbhash" field.
+func (idc *ImageDataCreate) SetThumbhash(b []byte) *ImageDataCreate {
+	idc.mutation.SetThumbhash(b)
+	return idc
+}
+
+// SetPlaceholder sets the "placeholder" field.
+func (idc *ImageDataCreate) SetPlaceholder(s string) *ImageDataCreate {
+	idc.mutation.SetPlaceholder(s)
+	return idc
+}
+
+// SetNillablePlaceholder sets the "placeholder" field if the given value is not nil.
+func (idc *ImageDataCreate) SetNillablePlaceholder(s *string) *ImageDataCreate {
+	if s != nil {
+		idc.SetPlaceholder(*s)
+	}
+	return idc
+}
+
+// SetPlaceholderColor sets the "placeholder_color" field.
+func (idc *ImageDataCreate) SetPlaceholderColor(s string) *ImageDataCreate {
+	idc.mutation.SetPlaceholderColor(s)
+	return idc
+}
+
+// SetNillablePlaceholderColor sets the "placeholder_color" field if the given value is not nil.
+func (idc *ImageDataCreate) SetNillablePlaceholderColor(s *string) *ImageDataCreate {
+	if s != nil {
+		idc.SetPlaceholderColor(*s)
+	}
+	return idc
+}
+
 // SetCreatedAt sets the "created_at" field.
 func (idc *ImageDataCreate) SetCreatedAt(t time.Time) *ImageDataCreate {
 	idc.mutation.SetCreatedAt(t)
@@ -124,21 +218,6 @@ func (idc *ImageDataCreate) defaults() {
 
 // check runs all checks and user-defined validators on the builder.
 func (idc *ImageDataCreate) check() error {
-	if _, ok := idc.mutation.AvrR(); !ok {
-		return &ValidationError{Name: "avr_r", err: errors.New(`ent: missing required field "ImageData.avr_r"`)}
-	}
-	if _, ok := idc.mutation.AvrG(); !ok {
-		return &ValidationError{Name: "avr_g", err: errors.New(`ent: missing required field "ImageData.avr_g"`)}
-	}
-	if _, ok := idc.mutation.AvrB(); !ok {
-		return &ValidationError{Name: "avr_b", err: errors.New(`ent: missing required field "ImageData.avr_b"`)}
-	}
-	if _, ok := idc.mutation.AvgBrightness(); !ok {
-		return &ValidationError{Name: "avg_brightness", err: errors.New(`ent: missing required field "ImageData.avg_brightness"`)}
-	}
-	if _, ok := idc.mutation.AvgSaturation(); !ok {
-		return &ValidationError{Name: "avg_saturation", err: errors.New(`ent: missing required field "ImageData.avg_saturation"`)}
-	}
 	if _, ok := idc.mutation.CreatedAt(); !ok {
 		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "ImageData.created_at"`)}
 	}
@@ -173,23 +252,43 @@ func (idc *ImageDataCreate) createSpec() (*ImageData, *sqlgraph.CreateSpec) {
 	)
 	if value, ok := idc.mutation.AvrR(); ok {
 		_spec.SetField(imagedata.FieldAvrR, field.TypeInt, value)
-		_node.AvrR = value
+		_node.AvrR = &value
 	}
 	if value, ok := idc.mutation.AvrG(); ok {
 		_spec.SetField(imagedata.FieldAvrG, field.TypeInt, value)
-		_node.AvrG = value
+		_node.AvrG = &value
 	}
 	if value, ok := idc.mutation.AvrB(); ok {
 		_spec.SetField(imagedata.FieldAvrB, field.TypeInt, value)
-		_node.AvrB = value
+		_node.AvrB = &value
 	}
 	if value, ok := idc.mutation.AvgBrightness(); ok {
 		_spec.SetField(imagedata.FieldAvgBrightness, field.TypeInt, value)
-		_node.AvgBrightness = value
+		_node.AvgBrightness = &value
 	}
 	if value, ok := idc.mutation.AvgSaturation(); ok {
 		_spec.SetField(imagedata.FieldAvgSaturation, field.TypeInt, value)
-		_node.AvgSaturation = value
+		_node.AvgSaturation = &value
+	}
+	if value, ok := idc.mutation.Phash(); ok {
+		_spec.SetField(imagedata.FieldPhash, field.TypeInt64, value)
+		_node.Phash = &value
+	}
+	if value, ok := idc.mutation.Palette(); ok {
+		_spec.SetField(imagedata.FieldPalette, field.TypeJSON, value)
+		_node.Palette = value
+	}
+	if value, ok := idc.mutation.Thumbhash(); ok {
+		_spec.SetField(imagedata.FieldThumbhash, field.TypeBytes, value)
+		_node.Thumbhash = value
+	}
+	if value, ok := idc.mutation.Placeholder(); ok {
+		_spec.SetField(imagedata.FieldPlaceholder, field.TypeString, value)
+		_node.Placeholder = value
+	}
+	if value, ok := idc.mutation.PlaceholderColor(); ok {
+		_spec.SetField(imagedata.FieldPlaceholderColor, field.TypeString, value)
+		_node.PlaceholderColor = value
 	}
 	if value, ok := idc.mutation.CreatedAt(); ok {
 		_spec.SetField(imagedata.FieldCreatedAt, field.TypeTime, value)
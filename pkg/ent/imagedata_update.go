@@ -9,6 +9,7 @@ import (
 
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/dialect/sql/sqljson"
 	"entgo.io/ent/schema/field"
 	"github.com/Pineapple217/cvrs/pkg/ent/image"
 	"github.com/Pineapple217/cvrs/pkg/ent/imagedata"
@@ -50,6 +51,12 @@ func (idu *ImageDataUpdate) AddAvrR(i int) *ImageDataUpdate {
 	return idu
 }
 
+// ClearAvrR clears the value of the "avr_r" field.
+func (idu *ImageDataUpdate) ClearAvrR() *ImageDataUpdate {
+	idu.mutation.ClearAvrR()
+	return idu
+}
+
 // SetAvrG sets the "avr_g" field.
 func (idu *ImageDataUpdate) SetAvrG(i int) *ImageDataUpdate {
 	idu.mutation.ResetAvrG()
@@ -71,6 +78,12 @@ func (idu *ImageDataUpdate) AddAvrG(i int) *ImageDataUpdate {
 	return idu
 }
 
+// ClearAvrG clears the value of the "avr_g" field.
+func (idu *ImageDataUpdate) ClearAvrG() *ImageDataUpdate {
+	idu.mutation.ClearAvrG()
+	return idu
+}
+
 // SetAvrB sets the "avr_b" field.
 func (idu *ImageDataUpdate) SetAvrB(i int) *ImageDataUpdate {
 	idu.mutation.ResetAvrB()
@@ -92,6 +105,12 @@ func (idu *ImageDataUpdate) AddAvrB(i int) *ImageDataUpdate {
 	return idu
 }
 
+// ClearAvrB clears the value of the "avr_b" field.
+func (idu *ImageDataUpdate) ClearAvrB() *ImageDataUpdate {
+	idu.mutation.ClearAvrB()
+	return idu
+}
+
 // SetAvgBrightness sets the "avg_brightness" field.
 func (idu *ImageDataUpdate) SetAvgBrightness(i int) *ImageDataUpdate {
 	idu.mutation.ResetAvgBrightness()
@@ -113,6 +132,12 @@ func (idu *ImageDataUpdate) AddAvgBrightness(i int) *ImageDataUpdate {
 	return idu
 }
 
+// ClearAvgBrightness clears the value of the "avg_brightness" field.
+func (idu *ImageDataUpdate) ClearAvgBrightness() *ImageDataUpdate {
+	idu.mutation.ClearAvgBrightness()
+	return idu
+}
+
 // SetAvgSaturation sets the "avg_saturation" field.
 func (idu *ImageDataUpdate) SetAvgSaturation(i int) *ImageDataUpdate {
 	idu.mutation.ResetAvgSaturation()
@@ -134,6 +159,109 @@ func (idu *ImageDataUpdate) AddAvgSaturation(i int) *ImageDataUpdate {
 	return idu
 }
 
+// ClearAvgSaturation clears the value of the "avg_saturation" field.
+func (idu *ImageDataUpdate) ClearAvgSaturation() *ImageDataUpdate {
+	idu.mutation.ClearAvgSaturation()
+	return idu
+}
+
+// SetPhash sets the "phash" field.
+func (idu *ImageDataUpdate) SetPhash(i int64) *ImageDataUpdate {
+	idu.mutation.ResetPhash()
+	idu.mutation.SetPhash(i)
+	return idu
+}
+
+// SetNillablePhash sets the "phash" field if the given value is not nil.
+func (idu *ImageDataUpdate) SetNillablePhash(i *int64) *ImageDataUpdate {
+	if i != nil {
+		idu.SetPhash(*i)
+	}
+	return idu
+}
+
+// AddPhash adds i to the "phash" field.
+func (idu *ImageDataUpdate) AddPhash(i int64) *ImageDataUpdate {
+	idu.mutation.AddPhash(i)
+	return idu
+}
+
+// ClearPhash clears the value of the "phash" field.
+func (idu *ImageDataUpdate) ClearPhash() *ImageDataUpdate {
+	idu.mutation.ClearPhash()
+	return idu
+}
+
+// SetPalette sets the "palette" field.
+func (idu *ImageDataUpdate) SetPalette(s []string) *ImageDataUpdate {
+	idu.mutation.SetPalette(s)
+	return idu
+}
+
+// AppendPalette appends s to the "palette" field.
+func (idu *ImageDataUpdate) AppendPalette(s []string) *ImageDataUpdate {
+	idu.mutation.AppendPalette(s)
+	return idu
+}
+
+// ClearPalette clears the value of the "palette" field.
+func (idu *ImageDataUpdate) ClearPalette() *ImageDataUpdate {
+	idu.mutation.ClearPalette()
+	return idu
+}
+
+// SetThumbhash sets the "thumbhash" field.
+func (idu *ImageDataUpdate) SetThumbhash(b []byte) *ImageDataUpdate {
+	idu.mutation.SetThumbhash(b)
+	return idu
+}
+
+// ClearThumbhash clears the value of the "thumbhash" field.
+func (idu *ImageDataUpdate) ClearThumbhash() *ImageDataUpdate {
+	idu.mutation.ClearThumbhash()
+	return idu
+}
+
+// SetPlaceholder sets the "placeholder" field.
+func (idu *ImageDataUpdate) SetPlaceholder(s string) *ImageDataUpdate {
+	idu.mutation.SetPlaceholder(s)
+	return idu
+}
+
+// SetNillablePlaceholder sets the "placeholder" field if the given value is not nil.
+func (idu *ImageDataUpdate) SetNillablePlaceholder(s *string) *ImageDataUpdate {
+	if s != nil {
+		idu.SetPlaceholder(*s)
+	}
+	return idu
+}
+
+// ClearPlaceholder clears the value of the "placeholder" field.
+func (idu *ImageDataUpdate) ClearPlaceholder() *ImageDataUpdate {
+	idu.mutation.ClearPlaceholder()
+	return idu
+}
+
+// SetPlaceholderColor sets the "placeholder_color" field.
+func (idu *ImageDataUpdate) SetPlaceholderColor(s string) *ImageDataUpdate {
+	idu.mutation.SetPlaceholderColor(s)
+	return idu
+}
+
+// SetNillablePlaceholderColor sets the "placeholder_color" field if the given value is not nil.
+func (idu *ImageDataUpdate) SetNillablePlaceholderColor(s *string) *ImageDataUpdate {
+	if s != nil {
+		idu.SetPlaceholderColor(*s)
+	}
+	return idu
+}
+
+// ClearPlaceholderColor clears the value of the "placeholder_color" field.
+func (idu *ImageDataUpdate) ClearPlaceholderColor() *ImageDataUpdate {
+	idu.mutation.ClearPlaceholderColor()
+	return idu
+}
+
 // AddImageIDs adds the "image" edge to the Image entity by IDs.
 func (idu *ImageDataUpdate) AddImageIDs(ids ...pid.ID) *ImageDataUpdate {
 	idu.mutation.AddImageIDs(ids...)
@@ -217,30 +345,83 @@ func (idu *ImageDataUpdate) sqlSave(ctx context.Context) (n int, err error) {
 	if value, ok := idu.mutation.AddedAvrR(); ok {
 		_spec.AddField(imagedata.FieldAvrR, field.TypeInt, value)
 	}
+	if idu.mutation.AvrRCleared() {
+		_spec.ClearField(imagedata.FieldAvrR, field.TypeInt)
+	}
 	if value, ok := idu.mutation.AvrG(); ok {
 		_spec.SetField(imagedata.FieldAvrG, field.TypeInt, value)
 	}
 	if value, ok := idu.mutation.AddedAvrG(); ok {
 		_spec.AddField(imagedata.FieldAvrG, field.TypeInt, value)
 	}
+	if idu.mutation.AvrGCleared() {
+		_spec.ClearField(imagedata.FieldAvrG, field.TypeInt)
+	}
 	if value, ok := idu.mutation.AvrB(); ok {
 		_spec.SetField(imagedata.FieldAvrB, field.TypeInt, value)
 	}
 	if value, ok := idu.mutation.AddedAvrB(); ok {
 		_spec.AddField(imagedata.FieldAvrB, field.TypeInt, value)
 	}
+	if idu.mutation.AvrBCleared() {
+		_spec.ClearField(imagedata.FieldAvrB, field.TypeInt)
+	}
 	if value, ok := idu.mutation.AvgBrightness(); ok {
 		_spec.SetField(imagedata.FieldAvgBrightness, field.TypeInt, value)
 	}
 	if value, ok := idu.mutation.AddedAvgBrightness(); ok {
 		_spec.AddField(imagedata.FieldAvgBrightness, field.TypeInt, value)
 	}
+	if idu.mutation.AvgBrightnessCleared() {
+		_spec.ClearField(imagedata.FieldAvgBrightness, field.TypeInt)
+	}
 	if value, ok := idu.mutation.AvgSaturation(); ok {
 		_spec.SetField(imagedata.FieldAvgSaturation, field.TypeInt, value)
 	}
 	if value, ok := idu.mutation.AddedAvgSaturation(); ok {
 		_spec.AddField(imagedata.FieldAvgSaturation, field.TypeInt, value)
 	}
+	if idu.mutation.AvgSaturationCleared() {
+		_spec.ClearField(imagedata.FieldAvgSaturation, field.TypeInt)
+	}
+	if value, ok := idu.mutation.Phash(); ok {
+		_spec.SetField(imagedata.FieldPhash, field.TypeInt64, value)
+	}
+	if value, ok := idu.mutation.AddedPhash(); ok {
+		_spec.AddField(imagedata.FieldPhash, field.TypeInt64, value)
+	}
+	if idu.mutation.PhashCleared() {
+		_spec.ClearField(imagedata.FieldPhash, field.TypeInt64)
+	}
+	if value, ok := idu.mutation.Palette(); ok {
+		_spec.SetField(imagedata.FieldPalette, field.TypeJSON, value)
+	}
+	if value, ok := idu.mutation.AppendedPalette(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, imagedata.FieldPalette, value)
+		})
+	}
+	if idu.mutation.PaletteCleared() {
+		_spec.ClearField(imagedata.FieldPalette, field.TypeJSON)
+	}
+	if value, ok := idu.mutation.Thumbhash(); ok {
+		_spec.SetField(imagedata.FieldThumbhash, field.TypeBytes, value)
+	}
+	if idu.mutation.ThumbhashCleared() {
+		_spec.ClearField(imagedata.FieldThumbhash, field.TypeBytes)
+	}
+	if value, ok := idu.mutation.Placeholder(); ok {
+		_spec.SetField(imagedata.FieldPlaceholder, field.TypeString, value)
+	}
+	if idu.mutation.PlaceholderCleared() {
+		_spec.ClearField(imagedata.FieldPlaceholder, field.TypeString)
+	}
+	if value, ok := idu.mutation.PlaceholderColor(); ok {
+		_spec.SetField(imagedata.FieldPlaceholderColor, field.TypeString, value)
+	}
+	if idu.mutation.PlaceholderColorCleared() {
+		_spec.ClearField(imagedata.FieldPlaceholderColor, field.TypeString)
+	}
 	if idu.mutation.ImageCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.O2M,
@@ -327,6 +508,12 @@ func (iduo *ImageDataUpdateOne) AddAvrR(i int) *ImageDataUpdateOne {
 	return iduo
 }
 
+// ClearAvrR clears the value of the "avr_r" field.
+func (iduo *ImageDataUpdateOne) ClearAvrR() *ImageDataUpdateOne {
+	iduo.mutation.ClearAvrR()
+	return iduo
+}
+
 // SetAvrG sets the "avr_g" field.
 func (iduo *ImageDataUpdateOne) SetAvrG(i int) *ImageDataUpdateOne {
 	iduo.mutation.ResetAvrG()
@@ -348,6 +535,12 @@ func (iduo *ImageDataUpdateOne) AddAvrG(i int) *ImageDataUpdateOne {
 	return iduo
 }
 
+// ClearAvrG clears the value of the "avr_g" field.
+func (iduo *ImageDataUpdateOne) ClearAvrG() *ImageDataUpdateOne {
+	iduo.mutation.ClearAvrG()
+	return iduo
+}
+
 // SetAvrB sets the "avr_b" field.
 func (iduo *ImageDataUpdateOne) SetAvrB(i int) *ImageDataUpdateOne {
 	iduo.mutation.ResetAvrB()
@@ -369,6 +562,12 @@ func (iduo *ImageDataUpdateOne) AddAvrB(i int) *ImageDataUpdateOne {
 	return iduo
 }
 
+// ClearAvrB clears the value of the "avr_b" field.
+func (iduo *ImageDataUpdateOne) ClearAvrB() *ImageDataUpdateOne {
+	iduo.mutation.ClearAvrB()
+	return iduo
+}
+
 // SetAvgBrightness sets the "avg_brightness" field.
 func (iduo *ImageDataUpdateOne) SetAvgBrightness(i int) *ImageDataUpdateOne {
 	iduo.mutation.ResetAvgBrightness()
@@ -390,6 +589,12 @@ func (iduo *ImageDataUpdateOne) AddAvgBrightness(i int) *ImageDataUpdateOne {
 	return iduo
 }
 
+// ClearAvgBrightness clears the value of the "avg_brightness" field.
+func (iduo *ImageDataUpdateOne) ClearAvgBrightness() *ImageDataUpdateOne {
+	iduo.mutation.ClearAvgBrightness()
+	return iduo
+}
+
 // SetAvgSaturation sets the "avg_saturation" field.
 func (iduo *ImageDataUpdateOne) SetAvgSaturation(i int) *ImageDataUpdateOne {
 	iduo.mutation.ResetAvgSaturation()
@@ -411,6 +616,109 @@ func (iduo *ImageDataUpdateOne) AddAvgSaturation(i int) *ImageDataUpdateOne {
 	return iduo
 }
 
+// ClearAvgSaturation clears the value of the "avg_saturation" field.
+func (iduo *ImageDataUpdateOne) ClearAvgSaturation() *ImageDataUpdateOne {
+	iduo.mutation.ClearAvgSaturation()
+	return iduo
+}
+
+// SetPhash sets the "phash" field.
+func (iduo *ImageDataUpdateOne) SetPhash(i int64) *ImageDataUpdateOne {
+	iduo.mutation.ResetPhash()
+	iduo.mutation.SetPhash(i)
+	return iduo
+}
+
+// SetNillablePhash sets the "phash" field if the given value is not nil.
+func (iduo *ImageDataUpdateOne) SetNillablePhash(i *int64) *ImageDataUpdateOne {
+	if i != nil {
+		iduo.SetPhash(*i)
+	}
+	return iduo
+}
+
+// AddPhash adds i to the "phash" field.
+func (iduo *ImageDataUpdateOne) AddPhash(i int64) *ImageDataUpdateOne {
+	iduo.mutation.AddPhash(i)
+	return iduo
+}
+
+// ClearPhash clears the value of the "phash" field.
+func (iduo *ImageDataUpdateOne) ClearPhash() *ImageDataUpdateOne {
+	iduo.mutation.ClearPhash()
+	return iduo
+}
+
+// SetPalette sets the "palette" field.
+func (iduo *ImageDataUpdateOne) SetPalette(s []string) *ImageDataUpdateOne {
+	iduo.mutation.SetPalette(s)
+	return iduo
+}
+
+// AppendPalette appends s to the "palette" field.
+func (iduo *ImageDataUpdateOne) AppendPalette(s []string) *ImageDataUpdateOne {
+	iduo.mutation.AppendPalette(s)
+	return iduo
+}
+
+// ClearPalette clears the value of the "palette" field.
+func (iduo *ImageDataUpdateOne) ClearPalette() *ImageDataUpdateOne {
+	iduo.mutation.ClearPalette()
+	return iduo
+}
+
+// SetThumbhash sets the "thumbhash" field.
+func (iduo *ImageDataUpdateOne) SetThumbhash(b []byte) *ImageDataUpdateOne {
+	iduo.mutation.SetThumbhash(b)
+	return iduo
+}
+
+// ClearThumbhash clears the value of the "thumbhash" field.
+func (iduo *ImageDataUpdateOne) ClearThumbhash() *ImageDataUpdateOne {
+	iduo.mutation.ClearThumbhash()
+	return iduo
+}
+
+// SetPlaceholder sets the "placeholder" field.
+func (iduo *ImageDataUpdateOne) SetPlaceholder(s string) *ImageDataUpdateOne {
+	iduo.mutation.SetPlaceholder(s)
+	return iduo
+}
+
+// SetNillablePlaceholder sets the "placeholder" field if the given value is not nil.
+func (iduo *ImageDataUpdateOne) SetNillablePlaceholder(s *string) *ImageDataUpdateOne {
+	if s != nil {
+		iduo.SetPlaceholder(*s)
+	}
+	return iduo
+}
+
+// ClearPlaceholder clears the value of the "placeholder" field.
+func (iduo *ImageDataUpdateOne) ClearPlaceholder() *ImageDataUpdateOne {
+	iduo.mutation.ClearPlaceholder()
+	return iduo
+}
+
+// SetPlaceholderColor sets the "placeholder_color" field.
+func (iduo *ImageDataUpdateOne) SetPlaceholderColor(s string) *ImageDataUpdateOne {
+	iduo.mutation.SetPlaceholderColor(s)
+	return iduo
+}
+
+// SetNillablePlaceholderColor sets the "placeholder_color" field if the given value is not nil.
+func (iduo *ImageDataUpdateOne) SetNillablePlaceholderColor(s *string) *ImageDataUpdateOne {
+	if s != nil {
+		iduo.SetPlaceholderColor(*s)
+	}
+	return iduo
+}
+
+// ClearPlaceholderColor clears the value of the "placeholder_color" field.
+func (iduo *ImageDataUpdateOne) ClearPlaceholderColor() *ImageDataUpdateOne {
+	iduo.mutation.ClearPlaceholderColor()
+	return iduo
+}
+
 // AddImageIDs adds the "image" edge to the Image entity by IDs.
 func (iduo *ImageDataUpdateOne) AddImageIDs(ids ...pid.ID) *ImageDataUpdateOne {
 	iduo.mutation.AddImageIDs(ids...)
@@ -524,30 +832,83 @@ func (iduo *ImageDataUpdateOne) sqlSave(ctx context.Context) (_node *ImageData,
 	if value, ok := iduo.mutation.AddedAvrR(); ok {
 		_spec.AddField(imagedata.FieldAvrR, field.TypeInt, value)
 	}
+	if iduo.mutation.AvrRCleared() {
+		_spec.ClearField(imagedata.FieldAvrR, field.TypeInt)
+	}
 	if value, ok := iduo.mutation.AvrG(); ok {
 		_spec.SetField(imagedata.FieldAvrG, field.TypeInt, value)
 	}
 	if value, ok := iduo.mutation.AddedAvrG(); ok {
 		_spec.AddField(imagedata.FieldAvrG, field.TypeInt, value)
 	}
+	if iduo.mutation.AvrGCleared() {
+		_spec.ClearField(imagedata.FieldAvrG, field.TypeInt)
+	}
 	if value, ok := iduo.mutation.AvrB(); ok {
 		_spec.SetField(imagedata.FieldAvrB, field.TypeInt, value)
 	}
 	if value, ok := iduo.mutation.AddedAvrB(); ok {
 		_spec.AddField(imagedata.FieldAvrB, field.TypeInt, value)
 	}
+	if iduo.mutation.AvrBCleared() {
+		_spec.ClearField(imagedata.FieldAvrB, field.TypeInt)
+	}
 	if value, ok := iduo.mutation.AvgBrightness(); ok {
 		_spec.SetField(imagedata.FieldAvgBrightness, field.TypeInt, value)
 	}
 	if value, ok := iduo.mutation.AddedAvgBrightness(); ok {
 		_spec.AddField(imagedata.FieldAvgBrightness, field.TypeInt, value)
 	}
+	if iduo.mutation.AvgBrightnessCleared() {
+		_spec.ClearField(imagedata.FieldAvgBrightness, field.TypeInt)
+	}
 	if value, ok := iduo.mutation.AvgSaturation(); ok {
 		_spec.SetField(imagedata.FieldAvgSaturation, field.TypeInt, value)
 	}
 	if value, ok := iduo.mutation.AddedAvgSaturation(); ok {
 		_spec.AddField(imagedata.FieldAvgSaturation, field.TypeInt, value)
 	}
+	if iduo.mutation.AvgSaturationCleared() {
+		_spec.ClearField(imagedata.FieldAvgSaturation, field.TypeInt)
+	}
+	if value, ok := iduo.mutation.Phash(); ok {
+		_spec.SetField(imagedata.FieldPhash, field.TypeInt64, value)
+	}
+	if value, ok := iduo.mutation.AddedPhash(); ok {
+		_spec.AddField(imagedata.FieldPhash, field.TypeInt64, value)
+	}
+	if iduo.mutation.PhashCleared() {
+		_spec.ClearField(imagedata.FieldPhash, field.TypeInt64)
+	}
+	if value, ok := iduo.mutation.Palette(); ok {
+		_spec.SetField(imagedata.FieldPalette, field.TypeJSON, value)
+	}
+	if value, ok := iduo.mutation.AppendedPalette(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, imagedata.FieldPalette, value)
+		})
+	}
+	if iduo.mutation.PaletteCleared() {
+		_spec.ClearField(imagedata.FieldPalette, field.TypeJSON)
+	}
+	if value, ok := iduo.mutation.Thumbhash(); ok {
+		_spec.SetField(imagedata.FieldThumbhash, field.TypeBytes, value)
+	}
+	if iduo.mutation.ThumbhashCleared() {
+		_spec.ClearField(imagedata.FieldThumbhash, field.TypeBytes)
+	}
+	if value, ok := iduo.mutation.Placeholder(); ok {
+		_spec.SetField(imagedata.FieldPlaceholder, field.TypeString, value)
+	}
+	if iduo.mutation.PlaceholderCleared() {
+		_spec.ClearField(imagedata.FieldPlaceholder, field.TypeString)
+	}
+	if value, ok := iduo.mutation.PlaceholderColor(); ok {
+		_spec.SetField(imagedata.FieldPlaceholderColor, field.TypeString, value)
+	}
+	if iduo.mutation.PlaceholderColorCleared() {
+		_spec.ClearField(imagedata.FieldPlaceholderColor, field.TypeString)
+	}
 	if iduo.mutation.ImageCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.O2M,
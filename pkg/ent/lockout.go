@@ -0,0 +1,143 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/Pineapple217/cvrs/pkg/ent/lockout"
+	"github.com/Pineapple217/cvrs/pkg/pid"
+)
+
+// Lockout is the model entity for the Lockout schema.
+type Lockout struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID pid.ID `json:"id,omitempty"`
+	// Key holds the value of the "key" field.
+	Key string `json:"key,omitempty"`
+	// Failures holds the value of the "failures" field.
+	Failures int `json:"failures,omitempty"`
+	// LastFailureAt holds the value of the "last_failure_at" field.
+	LastFailureAt time.Time `json:"last_failure_at,omitzero"`
+	// LockedUntil holds the value of the "locked_until" field.
+	LockedUntil  *time.Time `json:"locked_until,omitzero"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*Lockout) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case lockout.FieldID, lockout.FieldFailures:
+			values[i] = new(sql.NullInt64)
+		case lockout.FieldKey:
+			values[i] = new(sql.NullString)
+		case lockout.FieldLastFailureAt, lockout.FieldLockedUntil:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the Lockout fields.
+func (l *Lockout) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case lockout.FieldID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[i])
+			} else if value.Valid {
+				l.ID = pid.ID(value.Int64)
+			}
+		case lockout.FieldKey:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field key", values[i])
+			} else if value.Valid {
+				l.Key = value.String
+			}
+		case lockout.FieldFailures:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field failures", values[i])
+			} else if value.Valid {
+				l.Failures = int(value.Int64)
+			}
+		case lockout.FieldLastFailureAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field last_failure_at", values[i])
+			} else if value.Valid {
+				l.LastFailureAt = value.Time
+			}
+		case lockout.FieldLockedUntil:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field locked_until", values[i])
+			} else if value.Valid {
+				l.LockedUntil = new(time.Time)
+				*l.LockedUntil = value.Time
+			}
+		default:
+			l.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the Lockout.
+// This includes values selected through modifiers, order, etc.
+func (l *Lockout) Value(name string) (ent.Value, error) {
+	return l.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this Lockout.
+// Note that you need to call Lockout.Unwrap() before calling this method if this Lockout
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (l *Lockout) Update() *LockoutUpdateOne {
+	return NewLockoutClient(l.config).UpdateOne(l)
+}
+
+// Unwrap unwraps the Lockout entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (l *Lockout) Unwrap() *Lockout {
+	_tx, ok := l.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: Lockout is not a transactional entity")
+	}
+	l.config.driver = _tx.drv
+	return l
+}
+
+// String implements the fmt.Stringer.
+func (l *Lockout) String() string {
+	var builder strings.Builder
+	builder.WriteString("Lockout(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", l.ID))
+	builder.WriteString("key=")
+	builder.WriteString(l.Key)
+	builder.WriteString(", ")
+	builder.WriteString("failures=")
+	builder.WriteString(fmt.Sprintf("%v", l.Failures))
+	builder.WriteString(", ")
+	builder.WriteString("last_failure_at=")
+	builder.WriteString(l.LastFailureAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	if v := l.LockedUntil; v != nil {
+		builder.WriteString("locked_until=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// Lockouts is a parsable slice of Lockout.
+type Lockouts []*Lockout
@@ -8,13 +8,45 @@ import (
 )
 
 var (
+	// APITokensColumns holds the columns for the "api_tokens" table.
+	APITokensColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt64, Increment: true},
+		{Name: "name", Type: field.TypeString, Size: 64},
+		{Name: "token_hash", Type: field.TypeBytes, Unique: true},
+		{Name: "scopes", Type: field.TypeJSON},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "last_used_at", Type: field.TypeTime, Nullable: true},
+		{Name: "expires_at", Type: field.TypeTime, Nullable: true},
+		{Name: "user_api_tokens", Type: field.TypeInt64},
+	}
+	// APITokensTable holds the schema information for the "api_tokens" table.
+	APITokensTable = &schema.Table{
+		Name:       "api_tokens",
+		Columns:    APITokensColumns,
+		PrimaryKey: []*schema.Column{APITokensColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "api_tokens_users_api_tokens",
+				Columns:    []*schema.Column{APITokensColumns[7]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.NoAction,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "apitoken_user_api_tokens",
+				Unique:  false,
+				Columns: []*schema.Column{APITokensColumns[7]},
+			},
+		},
+	}
 	// ArtistsColumns holds the columns for the "artists" table.
 	ArtistsColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeInt64, Increment: true},
+		{Name: "deleted_at", Type: field.TypeTime, Nullable: true},
 		{Name: "name", Type: field.TypeString},
 		{Name: "created_at", Type: field.TypeTime},
 		{Name: "updated_at", Type: field.TypeTime},
-		{Name: "deleted_at", Type: field.TypeTime, Nullable: true},
 	}
 	// ArtistsTable holds the schema information for the "artists" table.
 	ArtistsTable = &schema.Table{
@@ -25,6 +57,7 @@ var (
 	// ImagesColumns holds the columns for the "images" table.
 	ImagesColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeInt64, Increment: true},
+		{Name: "deleted_at", Type: field.TypeTime, Nullable: true},
 		{Name: "file", Type: field.TypeString},
 		{Name: "original_name", Type: field.TypeString},
 		{Name: "type", Type: field.TypeEnum, Enums: []string{"WEBP", "PNG", "JPG"}},
@@ -32,9 +65,9 @@ var (
 		{Name: "dimention_width", Type: field.TypeInt},
 		{Name: "dimention_height", Type: field.TypeInt},
 		{Name: "size_bits", Type: field.TypeUint32},
+		{Name: "hash", Type: field.TypeString, Nullable: true},
 		{Name: "created_at", Type: field.TypeTime},
 		{Name: "updated_at", Type: field.TypeTime},
-		{Name: "deleted_at", Type: field.TypeTime, Nullable: true},
 		{Name: "artist_image", Type: field.TypeInt64, Unique: true, Nullable: true},
 		{Name: "image_data", Type: field.TypeInt, Nullable: true},
 		{Name: "release_image", Type: field.TypeInt64, Unique: true, Nullable: true},
@@ -48,38 +81,55 @@ var (
 		ForeignKeys: []*schema.ForeignKey{
 			{
 				Symbol:     "images_artists_image",
-				Columns:    []*schema.Column{ImagesColumns[11]},
+				Columns:    []*schema.Column{ImagesColumns[12]},
 				RefColumns: []*schema.Column{ArtistsColumns[0]},
 				OnDelete:   schema.SetNull,
 			},
 			{
 				Symbol:     "images_image_data_data",
-				Columns:    []*schema.Column{ImagesColumns[12]},
+				Columns:    []*schema.Column{ImagesColumns[13]},
 				RefColumns: []*schema.Column{ImageDataColumns[0]},
 				OnDelete:   schema.SetNull,
 			},
 			{
 				Symbol:     "images_releases_image",
-				Columns:    []*schema.Column{ImagesColumns[13]},
+				Columns:    []*schema.Column{ImagesColumns[14]},
 				RefColumns: []*schema.Column{ReleasesColumns[0]},
 				OnDelete:   schema.SetNull,
 			},
 			{
 				Symbol:     "images_users_images",
-				Columns:    []*schema.Column{ImagesColumns[14]},
+				Columns:    []*schema.Column{ImagesColumns[15]},
 				RefColumns: []*schema.Column{UsersColumns[0]},
 				OnDelete:   schema.NoAction,
 			},
 		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "image_hash",
+				Unique:  false,
+				Columns: []*schema.Column{ImagesColumns[9]},
+			},
+			{
+				Name:    "image_file",
+				Unique:  false,
+				Columns: []*schema.Column{ImagesColumns[2]},
+			},
+		},
 	}
 	// ImageDataColumns holds the columns for the "image_data" table.
 	ImageDataColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeInt, Increment: true},
-		{Name: "avr_r", Type: field.TypeInt},
-		{Name: "avr_g", Type: field.TypeInt},
-		{Name: "avr_b", Type: field.TypeInt},
-		{Name: "avg_brightness", Type: field.TypeInt},
-		{Name: "avg_saturation", Type: field.TypeInt},
+		{Name: "avr_r", Type: field.TypeInt, Nullable: true},
+		{Name: "avr_g", Type: field.TypeInt, Nullable: true},
+		{Name: "avr_b", Type: field.TypeInt, Nullable: true},
+		{Name: "avg_brightness", Type: field.TypeInt, Nullable: true},
+		{Name: "avg_saturation", Type: field.TypeInt, Nullable: true},
+		{Name: "phash", Type: field.TypeInt64, Nullable: true},
+		{Name: "palette", Type: field.TypeJSON, Nullable: true},
+		{Name: "thumbhash", Type: field.TypeBytes, Nullable: true},
+		{Name: "placeholder", Type: field.TypeString, Nullable: true},
+		{Name: "placeholder_color", Type: field.TypeString, Nullable: true},
 		{Name: "created_at", Type: field.TypeTime},
 	}
 	// ImageDataTable holds the schema information for the "image_data" table.
@@ -88,16 +138,32 @@ var (
 		Columns:    ImageDataColumns,
 		PrimaryKey: []*schema.Column{ImageDataColumns[0]},
 	}
+	// LockoutsColumns holds the columns for the "lockouts" table.
+	LockoutsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt64, Increment: true},
+		{Name: "key", Type: field.TypeString, Unique: true},
+		{Name: "failures", Type: field.TypeInt, Default: 0},
+		{Name: "last_failure_at", Type: field.TypeTime},
+		{Name: "locked_until", Type: field.TypeTime, Nullable: true},
+	}
+	// LockoutsTable holds the schema information for the "lockouts" table.
+	LockoutsTable = &schema.Table{
+		Name:       "lockouts",
+		Columns:    LockoutsColumns,
+		PrimaryKey: []*schema.Column{LockoutsColumns[0]},
+	}
 	// ProcessedImagesColumns holds the columns for the "processed_images" table.
 	ProcessedImagesColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeInt64, Increment: true},
+		{Name: "deleted_at", Type: field.TypeTime, Nullable: true},
+		{Name: "file", Type: field.TypeString, Nullable: true},
+		{Name: "variant", Type: field.TypeString, Nullable: true},
 		{Name: "type", Type: field.TypeEnum, Enums: []string{"WEBP", "PNG", "JPG"}},
-		{Name: "dimentions", Type: field.TypeInt},
+		{Name: "width", Type: field.TypeInt},
+		{Name: "height", Type: field.TypeInt},
 		{Name: "size_bits", Type: field.TypeUint32},
-		{Name: "thumb", Type: field.TypeBytes},
 		{Name: "created_at", Type: field.TypeTime},
 		{Name: "updated_at", Type: field.TypeTime},
-		{Name: "deleted_at", Type: field.TypeTime, Nullable: true},
 		{Name: "image_proccesed_image", Type: field.TypeInt64},
 	}
 	// ProcessedImagesTable holds the schema information for the "processed_images" table.
@@ -108,7 +174,7 @@ var (
 		ForeignKeys: []*schema.ForeignKey{
 			{
 				Symbol:     "processed_images_images_proccesed_image",
-				Columns:    []*schema.Column{ProcessedImagesColumns[8]},
+				Columns:    []*schema.Column{ProcessedImagesColumns[10]},
 				RefColumns: []*schema.Column{ImagesColumns[0]},
 				OnDelete:   schema.NoAction,
 			},
@@ -117,7 +183,12 @@ var (
 			{
 				Name:    "processedimage_image_proccesed_image",
 				Unique:  false,
-				Columns: []*schema.Column{ProcessedImagesColumns[8]},
+				Columns: []*schema.Column{ProcessedImagesColumns[10]},
+			},
+			{
+				Name:    "processedimage_file",
+				Unique:  false,
+				Columns: []*schema.Column{ProcessedImagesColumns[2]},
 			},
 		},
 	}
@@ -160,10 +231,42 @@ var (
 			},
 		},
 	}
+	// SessionsColumns holds the columns for the "sessions" table.
+	SessionsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt64, Increment: true},
+		{Name: "refresh_hash", Type: field.TypeBytes, Unique: true},
+		{Name: "device", Type: field.TypeString, Size: 128, Default: ""},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "last_used_at", Type: field.TypeTime},
+		{Name: "expires_at", Type: field.TypeTime},
+		{Name: "revoked_at", Type: field.TypeTime, Nullable: true},
+		{Name: "user_sessions", Type: field.TypeInt64},
+	}
+	// SessionsTable holds the schema information for the "sessions" table.
+	SessionsTable = &schema.Table{
+		Name:       "sessions",
+		Columns:    SessionsColumns,
+		PrimaryKey: []*schema.Column{SessionsColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "sessions_users_sessions",
+				Columns:    []*schema.Column{SessionsColumns[7]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.NoAction,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "session_user_sessions",
+				Unique:  false,
+				Columns: []*schema.Column{SessionsColumns[7]},
+			},
+		},
+	}
 	// TasksColumns holds the columns for the "tasks" table.
 	TasksColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeInt64, Increment: true},
-		{Name: "type", Type: field.TypeEnum, Enums: []string{"scale_img"}},
+		{Name: "type", Type: field.TypeEnum, Enums: []string{"scale_img", "backup", "analyze_img"}},
 		{Name: "status", Type: field.TypeEnum, Enums: []string{"pending", "working", "error", "done"}, Default: "pending"},
 		{Name: "error", Type: field.TypeString, Nullable: true},
 		{Name: "payload", Type: field.TypeJSON},
@@ -227,7 +330,8 @@ var (
 	UsersColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeInt64, Increment: true},
 		{Name: "username", Type: field.TypeString, Unique: true, Size: 32},
-		{Name: "password", Type: field.TypeBytes},
+		{Name: "password", Type: field.TypeBytes, Nullable: true},
+		{Name: "oidc_subject", Type: field.TypeString, Unique: true, Nullable: true},
 		{Name: "is_admin", Type: field.TypeBool, Default: false},
 		{Name: "created_at", Type: field.TypeTime},
 	}
@@ -239,12 +343,15 @@ var (
 	}
 	// Tables holds all the tables in the schema.
 	Tables = []*schema.Table{
+		APITokensTable,
 		ArtistsTable,
 		ImagesTable,
 		ImageDataTable,
+		LockoutsTable,
 		ProcessedImagesTable,
 		ReleasesTable,
 		ReleaseAppearancesTable,
+		SessionsTable,
 		TasksTable,
 		TracksTable,
 		TrackAppearancesTable,
@@ -253,6 +360,7 @@ var (
 )
 
 func init() {
+	APITokensTable.ForeignKeys[0].RefTable = UsersTable
 	ImagesTable.ForeignKeys[0].RefTable = ArtistsTable
 	ImagesTable.ForeignKeys[1].RefTable = ImageDataTable
 	ImagesTable.ForeignKeys[2].RefTable = ReleasesTable
@@ -260,6 +368,7 @@ func init() {
 	ProcessedImagesTable.ForeignKeys[0].RefTable = ImagesTable
 	ReleaseAppearancesTable.ForeignKeys[0].RefTable = ArtistsTable
 	ReleaseAppearancesTable.ForeignKeys[1].RefTable = ReleasesTable
+	SessionsTable.ForeignKeys[0].RefTable = UsersTable
 	TracksTable.ForeignKeys[0].RefTable = ReleasesTable
 	TrackAppearancesTable.ForeignKeys[0].RefTable = ArtistsTable
 	TrackAppearancesTable.ForeignKeys[1].RefTable = TracksTable
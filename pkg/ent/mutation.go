@@ -12,13 +12,16 @@ import (
 
 	"entgo.io/ent"
 	"entgo.io/ent/dialect/sql"
+	"github.com/Pineapple217/cvrs/pkg/ent/apitoken"
 	"github.com/Pineapple217/cvrs/pkg/ent/artist"
 	"github.com/Pineapple217/cvrs/pkg/ent/image"
 	"github.com/Pineapple217/cvrs/pkg/ent/imagedata"
+	"github.com/Pineapple217/cvrs/pkg/ent/lockout"
 	"github.com/Pineapple217/cvrs/pkg/ent/predicate"
 	"github.com/Pineapple217/cvrs/pkg/ent/processedimage"
 	"github.com/Pineapple217/cvrs/pkg/ent/release"
 	"github.com/Pineapple217/cvrs/pkg/ent/releaseappearance"
+	"github.com/Pineapple217/cvrs/pkg/ent/session"
 	"github.com/Pineapple217/cvrs/pkg/ent/task"
 	"github.com/Pineapple217/cvrs/pkg/ent/track"
 	"github.com/Pineapple217/cvrs/pkg/ent/trackappearance"
@@ -35,53 +38,53 @@ const (
 	OpUpdateOne = ent.OpUpdateOne
 
 	// Node types.
+	TypeApiToken          = "ApiToken"
 	TypeArtist            = "Artist"
 	TypeImage             = "Image"
 	TypeImageData         = "ImageData"
+	TypeLockout           = "Lockout"
 	TypeProcessedImage    = "ProcessedImage"
 	TypeRelease           = "Release"
 	TypeReleaseAppearance = "ReleaseAppearance"
+	TypeSession           = "Session"
 	TypeTask              = "Task"
 	TypeTrack             = "Track"
 	TypeTrackAppearance   = "TrackAppearance"
 	TypeUser              = "User"
 )
 
-// ArtistMutation represents an operation that mutates the Artist nodes in the graph.
-type ArtistMutation struct {
+// ApiTokenMutation represents an operation that mutates the ApiToken nodes in the graph.
+type ApiTokenMutation struct {
 	config
-	op                        Op
-	typ                       string
-	id                        *pid.ID
-	name                      *string
-	created_at                *time.Time
-	updated_at                *time.Time
-	deleted_at                *time.Time
-	clearedFields             map[string]struct{}
-	appearing_tracks          map[pid.ID]struct{}
-	removedappearing_tracks   map[pid.ID]struct{}
-	clearedappearing_tracks   bool
-	appearing_releases        map[pid.ID]struct{}
-	removedappearing_releases map[pid.ID]struct{}
-	clearedappearing_releases bool
-	image                     *pid.ID
-	clearedimage              bool
-	done                      bool
-	oldValue                  func(context.Context) (*Artist, error)
-	predicates                []predicate.Artist
+	op            Op
+	typ           string
+	id            *pid.ID
+	name          *string
+	token_hash    *[]byte
+	scopes        *[]string
+	appendscopes  []string
+	created_at    *time.Time
+	last_used_at  *time.Time
+	expires_at    *time.Time
+	clearedFields map[string]struct{}
+	user          *pid.ID
+	cleareduser   bool
+	done          bool
+	oldValue      func(context.Context) (*ApiToken, error)
+	predicates    []predicate.ApiToken
 }
 
-var _ ent.Mutation = (*ArtistMutation)(nil)
+var _ ent.Mutation = (*ApiTokenMutation)(nil)
 
-// artistOption allows management of the mutation configuration using functional options.
-type artistOption func(*ArtistMutation)
+// apitokenOption allows management of the mutation configuration using functional options.
+type apitokenOption func(*ApiTokenMutation)
 
-// newArtistMutation creates new mutation for the Artist entity.
-func newArtistMutation(c config, op Op, opts ...artistOption) *ArtistMutation {
-	m := &ArtistMutation{
+// newApiTokenMutation creates new mutation for the ApiToken entity.
+func newApiTokenMutation(c config, op Op, opts ...apitokenOption) *ApiTokenMutation {
+	m := &ApiTokenMutation{
 		config:        c,
 		op:            op,
-		typ:           TypeArtist,
+		typ:           TypeApiToken,
 		clearedFields: make(map[string]struct{}),
 	}
 	for _, opt := range opts {
@@ -90,20 +93,20 @@ func newArtistMutation(c config, op Op, opts ...artistOption) *ArtistMutation {
 	return m
 }
 
-// withArtistID sets the ID field of the mutation.
-func withArtistID(id pid.ID) artistOption {
-	return func(m *ArtistMutation) {
+// withApiTokenID sets the ID field of the mutation.
+func withApiTokenID(id pid.ID) apitokenOption {
+	return func(m *ApiTokenMutation) {
 		var (
 			err   error
 			once  sync.Once
-			value *Artist
+			value *ApiToken
 		)
-		m.oldValue = func(ctx context.Context) (*Artist, error) {
+		m.oldValue = func(ctx context.Context) (*ApiToken, error) {
 			once.Do(func() {
 				if m.done {
 					err = errors.New("querying old values post mutation is not allowed")
 				} else {
-					value, err = m.Client().Artist.Get(ctx, id)
+					value, err = m.Client().ApiToken.Get(ctx, id)
 				}
 			})
 			return value, err
@@ -112,10 +115,10 @@ func withArtistID(id pid.ID) artistOption {
 	}
 }
 
-// withArtist sets the old Artist of the mutation.
-func withArtist(node *Artist) artistOption {
-	return func(m *ArtistMutation) {
-		m.oldValue = func(context.Context) (*Artist, error) {
+// withApiToken sets the old ApiToken of the mutation.
+func withApiToken(node *ApiToken) apitokenOption {
+	return func(m *ApiTokenMutation) {
+		m.oldValue = func(context.Context) (*ApiToken, error) {
 			return node, nil
 		}
 		m.id = &node.ID
@@ -124,7 +127,7 @@ func withArtist(node *Artist) artistOption {
 
 // Client returns a new `ent.Client` from the mutation. If the mutation was
 // executed in a transaction (ent.Tx), a transactional client is returned.
-func (m ArtistMutation) Client() *Client {
+func (m ApiTokenMutation) Client() *Client {
 	client := &Client{config: m.config}
 	client.init()
 	return client
@@ -132,7 +135,7 @@ func (m ArtistMutation) Client() *Client {
 
 // Tx returns an `ent.Tx` for mutations that were executed in transactions;
 // it returns an error otherwise.
-func (m ArtistMutation) Tx() (*Tx, error) {
+func (m ApiTokenMutation) Tx() (*Tx, error) {
 	if _, ok := m.driver.(*txDriver); !ok {
 		return nil, errors.New("ent: mutation is not running in a transaction")
 	}
@@ -142,14 +145,14 @@ func (m ArtistMutation) Tx() (*Tx, error) {
 }
 
 // SetID sets the value of the id field. Note that this
-// operation is only accepted on creation of Artist entities.
-func (m *ArtistMutation) SetID(id pid.ID) {
+// operation is only accepted on creation of ApiToken entities.
+func (m *ApiTokenMutation) SetID(id pid.ID) {
 	m.id = &id
 }
 
 // ID returns the ID value in the mutation. Note that the ID is only available
 // if it was provided to the builder or after it was returned from the database.
-func (m *ArtistMutation) ID() (id pid.ID, exists bool) {
+func (m *ApiTokenMutation) ID() (id pid.ID, exists bool) {
 	if m.id == nil {
 		return
 	}
@@ -160,7 +163,7 @@ func (m *ArtistMutation) ID() (id pid.ID, exists bool) {
 // That means, if the mutation is applied within a transaction with an isolation level such
 // as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
 // or updated by the mutation.
-func (m *ArtistMutation) IDs(ctx context.Context) ([]pid.ID, error) {
+func (m *ApiTokenMutation) IDs(ctx context.Context) ([]pid.ID, error) {
 	switch {
 	case m.op.Is(OpUpdateOne | OpDeleteOne):
 		id, exists := m.ID()
@@ -169,19 +172,19 @@ func (m *ArtistMutation) IDs(ctx context.Context) ([]pid.ID, error) {
 		}
 		fallthrough
 	case m.op.Is(OpUpdate | OpDelete):
-		return m.Client().Artist.Query().Where(m.predicates...).IDs(ctx)
+		return m.Client().ApiToken.Query().Where(m.predicates...).IDs(ctx)
 	default:
 		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
 	}
 }
 
 // SetName sets the "name" field.
-func (m *ArtistMutation) SetName(s string) {
+func (m *ApiTokenMutation) SetName(s string) {
 	m.name = &s
 }
 
 // Name returns the value of the "name" field in the mutation.
-func (m *ArtistMutation) Name() (r string, exists bool) {
+func (m *ApiTokenMutation) Name() (r string, exists bool) {
 	v := m.name
 	if v == nil {
 		return
@@ -189,10 +192,10 @@ func (m *ArtistMutation) Name() (r string, exists bool) {
 	return *v, true
 }
 
-// OldName returns the old "name" field's value of the Artist entity.
-// If the Artist object wasn't provided to the builder, the object is fetched from the database.
+// OldName returns the old "name" field's value of the ApiToken entity.
+// If the ApiToken object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ArtistMutation) OldName(ctx context.Context) (v string, err error) {
+func (m *ApiTokenMutation) OldName(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
 		return v, errors.New("OldName is only allowed on UpdateOne operations")
 	}
@@ -207,287 +210,279 @@ func (m *ArtistMutation) OldName(ctx context.Context) (v string, err error) {
 }
 
 // ResetName resets all changes to the "name" field.
-func (m *ArtistMutation) ResetName() {
+func (m *ApiTokenMutation) ResetName() {
 	m.name = nil
 }
 
-// SetCreatedAt sets the "created_at" field.
-func (m *ArtistMutation) SetCreatedAt(t time.Time) {
-	m.created_at = &t
+// SetTokenHash sets the "token_hash" field.
+func (m *ApiTokenMutation) SetTokenHash(b []byte) {
+	m.token_hash = &b
 }
 
-// CreatedAt returns the value of the "created_at" field in the mutation.
-func (m *ArtistMutation) CreatedAt() (r time.Time, exists bool) {
-	v := m.created_at
+// TokenHash returns the value of the "token_hash" field in the mutation.
+func (m *ApiTokenMutation) TokenHash() (r []byte, exists bool) {
+	v := m.token_hash
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldCreatedAt returns the old "created_at" field's value of the Artist entity.
-// If the Artist object wasn't provided to the builder, the object is fetched from the database.
+// OldTokenHash returns the old "token_hash" field's value of the ApiToken entity.
+// If the ApiToken object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ArtistMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+func (m *ApiTokenMutation) OldTokenHash(ctx context.Context) (v []byte, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
+		return v, errors.New("OldTokenHash is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+		return v, errors.New("OldTokenHash requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+		return v, fmt.Errorf("querying old value for OldTokenHash: %w", err)
 	}
-	return oldValue.CreatedAt, nil
+	return oldValue.TokenHash, nil
 }
 
-// ResetCreatedAt resets all changes to the "created_at" field.
-func (m *ArtistMutation) ResetCreatedAt() {
-	m.created_at = nil
+// ResetTokenHash resets all changes to the "token_hash" field.
+func (m *ApiTokenMutation) ResetTokenHash() {
+	m.token_hash = nil
 }
 
-// SetUpdatedAt sets the "updated_at" field.
-func (m *ArtistMutation) SetUpdatedAt(t time.Time) {
-	m.updated_at = &t
+// SetScopes sets the "scopes" field.
+func (m *ApiTokenMutation) SetScopes(s []string) {
+	m.scopes = &s
+	m.appendscopes = nil
 }
 
-// UpdatedAt returns the value of the "updated_at" field in the mutation.
-func (m *ArtistMutation) UpdatedAt() (r time.Time, exists bool) {
-	v := m.updated_at
+// Scopes returns the value of the "scopes" field in the mutation.
+func (m *ApiTokenMutation) Scopes() (r []string, exists bool) {
+	v := m.scopes
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldUpdatedAt returns the old "updated_at" field's value of the Artist entity.
-// If the Artist object wasn't provided to the builder, the object is fetched from the database.
+// OldScopes returns the old "scopes" field's value of the ApiToken entity.
+// If the ApiToken object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ArtistMutation) OldUpdatedAt(ctx context.Context) (v time.Time, err error) {
+func (m *ApiTokenMutation) OldScopes(ctx context.Context) (v []string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldUpdatedAt is only allowed on UpdateOne operations")
+		return v, errors.New("OldScopes is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldUpdatedAt requires an ID field in the mutation")
+		return v, errors.New("OldScopes requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldUpdatedAt: %w", err)
+		return v, fmt.Errorf("querying old value for OldScopes: %w", err)
 	}
-	return oldValue.UpdatedAt, nil
+	return oldValue.Scopes, nil
 }
 
-// ResetUpdatedAt resets all changes to the "updated_at" field.
-func (m *ArtistMutation) ResetUpdatedAt() {
-	m.updated_at = nil
+// AppendScopes adds s to the "scopes" field.
+func (m *ApiTokenMutation) AppendScopes(s []string) {
+	m.appendscopes = append(m.appendscopes, s...)
 }
 
-// SetDeletedAt sets the "deleted_at" field.
-func (m *ArtistMutation) SetDeletedAt(t time.Time) {
-	m.deleted_at = &t
+// AppendedScopes returns the list of values that were appended to the "scopes" field in this mutation.
+func (m *ApiTokenMutation) AppendedScopes() ([]string, bool) {
+	if len(m.appendscopes) == 0 {
+		return nil, false
+	}
+	return m.appendscopes, true
 }
 
-// DeletedAt returns the value of the "deleted_at" field in the mutation.
-func (m *ArtistMutation) DeletedAt() (r time.Time, exists bool) {
-	v := m.deleted_at
+// ResetScopes resets all changes to the "scopes" field.
+func (m *ApiTokenMutation) ResetScopes() {
+	m.scopes = nil
+	m.appendscopes = nil
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (m *ApiTokenMutation) SetCreatedAt(t time.Time) {
+	m.created_at = &t
+}
+
+// CreatedAt returns the value of the "created_at" field in the mutation.
+func (m *ApiTokenMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m.created_at
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldDeletedAt returns the old "deleted_at" field's value of the Artist entity.
-// If the Artist object wasn't provided to the builder, the object is fetched from the database.
+// OldCreatedAt returns the old "created_at" field's value of the ApiToken entity.
+// If the ApiToken object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ArtistMutation) OldDeletedAt(ctx context.Context) (v *time.Time, err error) {
+func (m *ApiTokenMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldDeletedAt is only allowed on UpdateOne operations")
+		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldDeletedAt requires an ID field in the mutation")
+		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldDeletedAt: %w", err)
+		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
 	}
-	return oldValue.DeletedAt, nil
-}
-
-// ClearDeletedAt clears the value of the "deleted_at" field.
-func (m *ArtistMutation) ClearDeletedAt() {
-	m.deleted_at = nil
-	m.clearedFields[artist.FieldDeletedAt] = struct{}{}
+	return oldValue.CreatedAt, nil
 }
 
-// DeletedAtCleared returns if the "deleted_at" field was cleared in this mutation.
-func (m *ArtistMutation) DeletedAtCleared() bool {
-	_, ok := m.clearedFields[artist.FieldDeletedAt]
-	return ok
+// ResetCreatedAt resets all changes to the "created_at" field.
+func (m *ApiTokenMutation) ResetCreatedAt() {
+	m.created_at = nil
 }
 
-// ResetDeletedAt resets all changes to the "deleted_at" field.
-func (m *ArtistMutation) ResetDeletedAt() {
-	m.deleted_at = nil
-	delete(m.clearedFields, artist.FieldDeletedAt)
+// SetLastUsedAt sets the "last_used_at" field.
+func (m *ApiTokenMutation) SetLastUsedAt(t time.Time) {
+	m.last_used_at = &t
 }
 
-// AddAppearingTrackIDs adds the "appearing_tracks" edge to the Track entity by ids.
-func (m *ArtistMutation) AddAppearingTrackIDs(ids ...pid.ID) {
-	if m.appearing_tracks == nil {
-		m.appearing_tracks = make(map[pid.ID]struct{})
-	}
-	for i := range ids {
-		m.appearing_tracks[ids[i]] = struct{}{}
+// LastUsedAt returns the value of the "last_used_at" field in the mutation.
+func (m *ApiTokenMutation) LastUsedAt() (r time.Time, exists bool) {
+	v := m.last_used_at
+	if v == nil {
+		return
 	}
+	return *v, true
 }
 
-// ClearAppearingTracks clears the "appearing_tracks" edge to the Track entity.
-func (m *ArtistMutation) ClearAppearingTracks() {
-	m.clearedappearing_tracks = true
-}
-
-// AppearingTracksCleared reports if the "appearing_tracks" edge to the Track entity was cleared.
-func (m *ArtistMutation) AppearingTracksCleared() bool {
-	return m.clearedappearing_tracks
-}
-
-// RemoveAppearingTrackIDs removes the "appearing_tracks" edge to the Track entity by IDs.
-func (m *ArtistMutation) RemoveAppearingTrackIDs(ids ...pid.ID) {
-	if m.removedappearing_tracks == nil {
-		m.removedappearing_tracks = make(map[pid.ID]struct{})
+// OldLastUsedAt returns the old "last_used_at" field's value of the ApiToken entity.
+// If the ApiToken object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ApiTokenMutation) OldLastUsedAt(ctx context.Context) (v *time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldLastUsedAt is only allowed on UpdateOne operations")
 	}
-	for i := range ids {
-		delete(m.appearing_tracks, ids[i])
-		m.removedappearing_tracks[ids[i]] = struct{}{}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldLastUsedAt requires an ID field in the mutation")
 	}
-}
-
-// RemovedAppearingTracks returns the removed IDs of the "appearing_tracks" edge to the Track entity.
-func (m *ArtistMutation) RemovedAppearingTracksIDs() (ids []pid.ID) {
-	for id := range m.removedappearing_tracks {
-		ids = append(ids, id)
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldLastUsedAt: %w", err)
 	}
-	return
+	return oldValue.LastUsedAt, nil
 }
 
-// AppearingTracksIDs returns the "appearing_tracks" edge IDs in the mutation.
-func (m *ArtistMutation) AppearingTracksIDs() (ids []pid.ID) {
-	for id := range m.appearing_tracks {
-		ids = append(ids, id)
-	}
-	return
+// ClearLastUsedAt clears the value of the "last_used_at" field.
+func (m *ApiTokenMutation) ClearLastUsedAt() {
+	m.last_used_at = nil
+	m.clearedFields[apitoken.FieldLastUsedAt] = struct{}{}
 }
 
-// ResetAppearingTracks resets all changes to the "appearing_tracks" edge.
-func (m *ArtistMutation) ResetAppearingTracks() {
-	m.appearing_tracks = nil
-	m.clearedappearing_tracks = false
-	m.removedappearing_tracks = nil
+// LastUsedAtCleared returns if the "last_used_at" field was cleared in this mutation.
+func (m *ApiTokenMutation) LastUsedAtCleared() bool {
+	_, ok := m.clearedFields[apitoken.FieldLastUsedAt]
+	return ok
 }
 
-// AddAppearingReleaseIDs adds the "appearing_releases" edge to the Release entity by ids.
-func (m *ArtistMutation) AddAppearingReleaseIDs(ids ...pid.ID) {
-	if m.appearing_releases == nil {
-		m.appearing_releases = make(map[pid.ID]struct{})
-	}
-	for i := range ids {
-		m.appearing_releases[ids[i]] = struct{}{}
-	}
+// ResetLastUsedAt resets all changes to the "last_used_at" field.
+func (m *ApiTokenMutation) ResetLastUsedAt() {
+	m.last_used_at = nil
+	delete(m.clearedFields, apitoken.FieldLastUsedAt)
 }
 
-// ClearAppearingReleases clears the "appearing_releases" edge to the Release entity.
-func (m *ArtistMutation) ClearAppearingReleases() {
-	m.clearedappearing_releases = true
+// SetExpiresAt sets the "expires_at" field.
+func (m *ApiTokenMutation) SetExpiresAt(t time.Time) {
+	m.expires_at = &t
 }
 
-// AppearingReleasesCleared reports if the "appearing_releases" edge to the Release entity was cleared.
-func (m *ArtistMutation) AppearingReleasesCleared() bool {
-	return m.clearedappearing_releases
+// ExpiresAt returns the value of the "expires_at" field in the mutation.
+func (m *ApiTokenMutation) ExpiresAt() (r time.Time, exists bool) {
+	v := m.expires_at
+	if v == nil {
+		return
+	}
+	return *v, true
 }
 
-// RemoveAppearingReleaseIDs removes the "appearing_releases" edge to the Release entity by IDs.
-func (m *ArtistMutation) RemoveAppearingReleaseIDs(ids ...pid.ID) {
-	if m.removedappearing_releases == nil {
-		m.removedappearing_releases = make(map[pid.ID]struct{})
+// OldExpiresAt returns the old "expires_at" field's value of the ApiToken entity.
+// If the ApiToken object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ApiTokenMutation) OldExpiresAt(ctx context.Context) (v *time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldExpiresAt is only allowed on UpdateOne operations")
 	}
-	for i := range ids {
-		delete(m.appearing_releases, ids[i])
-		m.removedappearing_releases[ids[i]] = struct{}{}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldExpiresAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldExpiresAt: %w", err)
 	}
+	return oldValue.ExpiresAt, nil
 }
 
-// RemovedAppearingReleases returns the removed IDs of the "appearing_releases" edge to the Release entity.
-func (m *ArtistMutation) RemovedAppearingReleasesIDs() (ids []pid.ID) {
-	for id := range m.removedappearing_releases {
-		ids = append(ids, id)
-	}
-	return
+// ClearExpiresAt clears the value of the "expires_at" field.
+func (m *ApiTokenMutation) ClearExpiresAt() {
+	m.expires_at = nil
+	m.clearedFields[apitoken.FieldExpiresAt] = struct{}{}
 }
 
-// AppearingReleasesIDs returns the "appearing_releases" edge IDs in the mutation.
-func (m *ArtistMutation) AppearingReleasesIDs() (ids []pid.ID) {
-	for id := range m.appearing_releases {
-		ids = append(ids, id)
-	}
-	return
+// ExpiresAtCleared returns if the "expires_at" field was cleared in this mutation.
+func (m *ApiTokenMutation) ExpiresAtCleared() bool {
+	_, ok := m.clearedFields[apitoken.FieldExpiresAt]
+	return ok
 }
 
-// ResetAppearingReleases resets all changes to the "appearing_releases" edge.
-func (m *ArtistMutation) ResetAppearingReleases() {
-	m.appearing_releases = nil
-	m.clearedappearing_releases = false
-	m.removedappearing_releases = nil
+// ResetExpiresAt resets all changes to the "expires_at" field.
+func (m *ApiTokenMutation) ResetExpiresAt() {
+	m.expires_at = nil
+	delete(m.clearedFields, apitoken.FieldExpiresAt)
 }
 
-// SetImageID sets the "image" edge to the Image entity by id.
-func (m *ArtistMutation) SetImageID(id pid.ID) {
-	m.image = &id
+// SetUserID sets the "user" edge to the User entity by id.
+func (m *ApiTokenMutation) SetUserID(id pid.ID) {
+	m.user = &id
 }
 
-// ClearImage clears the "image" edge to the Image entity.
-func (m *ArtistMutation) ClearImage() {
-	m.clearedimage = true
+// ClearUser clears the "user" edge to the User entity.
+func (m *ApiTokenMutation) ClearUser() {
+	m.cleareduser = true
 }
 
-// ImageCleared reports if the "image" edge to the Image entity was cleared.
-func (m *ArtistMutation) ImageCleared() bool {
-	return m.clearedimage
+// UserCleared reports if the "user" edge to the User entity was cleared.
+func (m *ApiTokenMutation) UserCleared() bool {
+	return m.cleareduser
 }
 
-// ImageID returns the "image" edge ID in the mutation.
-func (m *ArtistMutation) ImageID() (id pid.ID, exists bool) {
-	if m.image != nil {
-		return *m.image, true
+// UserID returns the "user" edge ID in the mutation.
+func (m *ApiTokenMutation) UserID() (id pid.ID, exists bool) {
+	if m.user != nil {
+		return *m.user, true
 	}
 	return
 }
 
-// ImageIDs returns the "image" edge IDs in the mutation.
+// UserIDs returns the "user" edge IDs in the mutation.
 // Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
-// ImageID instead. It exists only for internal usage by the builders.
-func (m *ArtistMutation) ImageIDs() (ids []pid.ID) {
-	if id := m.image; id != nil {
+// UserID instead. It exists only for internal usage by the builders.
+func (m *ApiTokenMutation) UserIDs() (ids []pid.ID) {
+	if id := m.user; id != nil {
 		ids = append(ids, *id)
 	}
 	return
 }
 
-// ResetImage resets all changes to the "image" edge.
-func (m *ArtistMutation) ResetImage() {
-	m.image = nil
-	m.clearedimage = false
+// ResetUser resets all changes to the "user" edge.
+func (m *ApiTokenMutation) ResetUser() {
+	m.user = nil
+	m.cleareduser = false
 }
 
-// Where appends a list predicates to the ArtistMutation builder.
-func (m *ArtistMutation) Where(ps ...predicate.Artist) {
+// Where appends a list predicates to the ApiTokenMutation builder.
+func (m *ApiTokenMutation) Where(ps ...predicate.ApiToken) {
 	m.predicates = append(m.predicates, ps...)
 }
 
-// WhereP appends storage-level predicates to the ArtistMutation builder. Using this method,
+// WhereP appends storage-level predicates to the ApiTokenMutation builder. Using this method,
 // users can use type-assertion to append predicates that do not depend on any generated package.
-func (m *ArtistMutation) WhereP(ps ...func(*sql.Selector)) {
-	p := make([]predicate.Artist, len(ps))
+func (m *ApiTokenMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.ApiToken, len(ps))
 	for i := range ps {
 		p[i] = ps[i]
 	}
@@ -495,36 +490,42 @@ func (m *ArtistMutation) WhereP(ps ...func(*sql.Selector)) {
 }
 
 // Op returns the operation name.
-func (m *ArtistMutation) Op() Op {
+func (m *ApiTokenMutation) Op() Op {
 	return m.op
 }
 
 // SetOp allows setting the mutation operation.
-func (m *ArtistMutation) SetOp(op Op) {
+func (m *ApiTokenMutation) SetOp(op Op) {
 	m.op = op
 }
 
-// Type returns the node type of this mutation (Artist).
-func (m *ArtistMutation) Type() string {
+// Type returns the node type of this mutation (ApiToken).
+func (m *ApiTokenMutation) Type() string {
 	return m.typ
 }
 
 // Fields returns all fields that were changed during this mutation. Note that in
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
-func (m *ArtistMutation) Fields() []string {
-	fields := make([]string, 0, 4)
+func (m *ApiTokenMutation) Fields() []string {
+	fields := make([]string, 0, 6)
 	if m.name != nil {
-		fields = append(fields, artist.FieldName)
+		fields = append(fields, apitoken.FieldName)
+	}
+	if m.token_hash != nil {
+		fields = append(fields, apitoken.FieldTokenHash)
+	}
+	if m.scopes != nil {
+		fields = append(fields, apitoken.FieldScopes)
 	}
 	if m.created_at != nil {
-		fields = append(fields, artist.FieldCreatedAt)
+		fields = append(fields, apitoken.FieldCreatedAt)
 	}
-	if m.updated_at != nil {
-		fields = append(fields, artist.FieldUpdatedAt)
+	if m.last_used_at != nil {
+		fields = append(fields, apitoken.FieldLastUsedAt)
 	}
-	if m.deleted_at != nil {
-		fields = append(fields, artist.FieldDeletedAt)
+	if m.expires_at != nil {
+		fields = append(fields, apitoken.FieldExpiresAt)
 	}
 	return fields
 }
@@ -532,16 +533,20 @@ func (m *ArtistMutation) Fields() []string {
 // Field returns the value of a field with the given name. The second boolean
 // return value indicates that this field was not set, or was not defined in the
 // schema.
-func (m *ArtistMutation) Field(name string) (ent.Value, bool) {
+func (m *ApiTokenMutation) Field(name string) (ent.Value, bool) {
 	switch name {
-	case artist.FieldName:
+	case apitoken.FieldName:
 		return m.Name()
-	case artist.FieldCreatedAt:
+	case apitoken.FieldTokenHash:
+		return m.TokenHash()
+	case apitoken.FieldScopes:
+		return m.Scopes()
+	case apitoken.FieldCreatedAt:
 		return m.CreatedAt()
-	case artist.FieldUpdatedAt:
-		return m.UpdatedAt()
-	case artist.FieldDeletedAt:
-		return m.DeletedAt()
+	case apitoken.FieldLastUsedAt:
+		return m.LastUsedAt()
+	case apitoken.FieldExpiresAt:
+		return m.ExpiresAt()
 	}
 	return nil, false
 }
@@ -549,160 +554,172 @@ func (m *ArtistMutation) Field(name string) (ent.Value, bool) {
 // OldField returns the old value of the field from the database. An error is
 // returned if the mutation operation is not UpdateOne, or the query to the
 // database failed.
-func (m *ArtistMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+func (m *ApiTokenMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
 	switch name {
-	case artist.FieldName:
+	case apitoken.FieldName:
 		return m.OldName(ctx)
-	case artist.FieldCreatedAt:
+	case apitoken.FieldTokenHash:
+		return m.OldTokenHash(ctx)
+	case apitoken.FieldScopes:
+		return m.OldScopes(ctx)
+	case apitoken.FieldCreatedAt:
 		return m.OldCreatedAt(ctx)
-	case artist.FieldUpdatedAt:
-		return m.OldUpdatedAt(ctx)
-	case artist.FieldDeletedAt:
-		return m.OldDeletedAt(ctx)
+	case apitoken.FieldLastUsedAt:
+		return m.OldLastUsedAt(ctx)
+	case apitoken.FieldExpiresAt:
+		return m.OldExpiresAt(ctx)
 	}
-	return nil, fmt.Errorf("unknown Artist field %s", name)
+	return nil, fmt.Errorf("unknown ApiToken field %s", name)
 }
 
 // SetField sets the value of a field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *ArtistMutation) SetField(name string, value ent.Value) error {
+func (m *ApiTokenMutation) SetField(name string, value ent.Value) error {
 	switch name {
-	case artist.FieldName:
+	case apitoken.FieldName:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
 		m.SetName(v)
 		return nil
-	case artist.FieldCreatedAt:
+	case apitoken.FieldTokenHash:
+		v, ok := value.([]byte)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetTokenHash(v)
+		return nil
+	case apitoken.FieldScopes:
+		v, ok := value.([]string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetScopes(v)
+		return nil
+	case apitoken.FieldCreatedAt:
 		v, ok := value.(time.Time)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
 		m.SetCreatedAt(v)
 		return nil
-	case artist.FieldUpdatedAt:
+	case apitoken.FieldLastUsedAt:
 		v, ok := value.(time.Time)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetUpdatedAt(v)
+		m.SetLastUsedAt(v)
 		return nil
-	case artist.FieldDeletedAt:
+	case apitoken.FieldExpiresAt:
 		v, ok := value.(time.Time)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetDeletedAt(v)
+		m.SetExpiresAt(v)
 		return nil
 	}
-	return fmt.Errorf("unknown Artist field %s", name)
+	return fmt.Errorf("unknown ApiToken field %s", name)
 }
 
 // AddedFields returns all numeric fields that were incremented/decremented during
 // this mutation.
-func (m *ArtistMutation) AddedFields() []string {
+func (m *ApiTokenMutation) AddedFields() []string {
 	return nil
 }
 
 // AddedField returns the numeric value that was incremented/decremented on a field
 // with the given name. The second boolean return value indicates that this field
 // was not set, or was not defined in the schema.
-func (m *ArtistMutation) AddedField(name string) (ent.Value, bool) {
+func (m *ApiTokenMutation) AddedField(name string) (ent.Value, bool) {
 	return nil, false
 }
 
 // AddField adds the value to the field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *ArtistMutation) AddField(name string, value ent.Value) error {
+func (m *ApiTokenMutation) AddField(name string, value ent.Value) error {
 	switch name {
 	}
-	return fmt.Errorf("unknown Artist numeric field %s", name)
+	return fmt.Errorf("unknown ApiToken numeric field %s", name)
 }
 
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
-func (m *ArtistMutation) ClearedFields() []string {
+func (m *ApiTokenMutation) ClearedFields() []string {
 	var fields []string
-	if m.FieldCleared(artist.FieldDeletedAt) {
-		fields = append(fields, artist.FieldDeletedAt)
+	if m.FieldCleared(apitoken.FieldLastUsedAt) {
+		fields = append(fields, apitoken.FieldLastUsedAt)
+	}
+	if m.FieldCleared(apitoken.FieldExpiresAt) {
+		fields = append(fields, apitoken.FieldExpiresAt)
 	}
 	return fields
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
 // cleared in this mutation.
-func (m *ArtistMutation) FieldCleared(name string) bool {
+func (m *ApiTokenMutation) FieldCleared(name string) bool {
 	_, ok := m.clearedFields[name]
 	return ok
 }
 
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
-func (m *ArtistMutation) ClearField(name string) error {
+func (m *ApiTokenMutation) ClearField(name string) error {
 	switch name {
-	case artist.FieldDeletedAt:
-		m.ClearDeletedAt()
+	case apitoken.FieldLastUsedAt:
+		m.ClearLastUsedAt()
+		return nil
+	case apitoken.FieldExpiresAt:
+		m.ClearExpiresAt()
 		return nil
 	}
-	return fmt.Errorf("unknown Artist nullable field %s", name)
+	return fmt.Errorf("unknown ApiToken nullable field %s", name)
 }
 
 // ResetField resets all changes in the mutation for the field with the given name.
 // It returns an error if the field is not defined in the schema.
-func (m *ArtistMutation) ResetField(name string) error {
+func (m *ApiTokenMutation) ResetField(name string) error {
 	switch name {
-	case artist.FieldName:
+	case apitoken.FieldName:
 		m.ResetName()
 		return nil
-	case artist.FieldCreatedAt:
+	case apitoken.FieldTokenHash:
+		m.ResetTokenHash()
+		return nil
+	case apitoken.FieldScopes:
+		m.ResetScopes()
+		return nil
+	case apitoken.FieldCreatedAt:
 		m.ResetCreatedAt()
 		return nil
-	case artist.FieldUpdatedAt:
-		m.ResetUpdatedAt()
+	case apitoken.FieldLastUsedAt:
+		m.ResetLastUsedAt()
 		return nil
-	case artist.FieldDeletedAt:
-		m.ResetDeletedAt()
+	case apitoken.FieldExpiresAt:
+		m.ResetExpiresAt()
 		return nil
 	}
-	return fmt.Errorf("unknown Artist field %s", name)
+	return fmt.Errorf("unknown ApiToken field %s", name)
 }
 
 // AddedEdges returns all edge names that were set/added in this mutation.
-func (m *ArtistMutation) AddedEdges() []string {
-	edges := make([]string, 0, 3)
-	if m.appearing_tracks != nil {
-		edges = append(edges, artist.EdgeAppearingTracks)
-	}
-	if m.appearing_releases != nil {
-		edges = append(edges, artist.EdgeAppearingReleases)
-	}
-	if m.image != nil {
-		edges = append(edges, artist.EdgeImage)
+func (m *ApiTokenMutation) AddedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.user != nil {
+		edges = append(edges, apitoken.EdgeUser)
 	}
 	return edges
 }
 
 // AddedIDs returns all IDs (to other nodes) that were added for the given edge
 // name in this mutation.
-func (m *ArtistMutation) AddedIDs(name string) []ent.Value {
+func (m *ApiTokenMutation) AddedIDs(name string) []ent.Value {
 	switch name {
-	case artist.EdgeAppearingTracks:
-		ids := make([]ent.Value, 0, len(m.appearing_tracks))
-		for id := range m.appearing_tracks {
-			ids = append(ids, id)
-		}
-		return ids
-	case artist.EdgeAppearingReleases:
-		ids := make([]ent.Value, 0, len(m.appearing_releases))
-		for id := range m.appearing_releases {
-			ids = append(ids, id)
-		}
-		return ids
-	case artist.EdgeImage:
-		if id := m.image; id != nil {
+	case apitoken.EdgeUser:
+		if id := m.user; id != nil {
 			return []ent.Value{*id}
 		}
 	}
@@ -710,141 +727,93 @@ func (m *ArtistMutation) AddedIDs(name string) []ent.Value {
 }
 
 // RemovedEdges returns all edge names that were removed in this mutation.
-func (m *ArtistMutation) RemovedEdges() []string {
-	edges := make([]string, 0, 3)
-	if m.removedappearing_tracks != nil {
-		edges = append(edges, artist.EdgeAppearingTracks)
-	}
-	if m.removedappearing_releases != nil {
-		edges = append(edges, artist.EdgeAppearingReleases)
-	}
+func (m *ApiTokenMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 1)
 	return edges
 }
 
 // RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
 // the given name in this mutation.
-func (m *ArtistMutation) RemovedIDs(name string) []ent.Value {
-	switch name {
-	case artist.EdgeAppearingTracks:
-		ids := make([]ent.Value, 0, len(m.removedappearing_tracks))
-		for id := range m.removedappearing_tracks {
-			ids = append(ids, id)
-		}
-		return ids
-	case artist.EdgeAppearingReleases:
-		ids := make([]ent.Value, 0, len(m.removedappearing_releases))
-		for id := range m.removedappearing_releases {
-			ids = append(ids, id)
-		}
-		return ids
-	}
+func (m *ApiTokenMutation) RemovedIDs(name string) []ent.Value {
 	return nil
 }
 
 // ClearedEdges returns all edge names that were cleared in this mutation.
-func (m *ArtistMutation) ClearedEdges() []string {
-	edges := make([]string, 0, 3)
-	if m.clearedappearing_tracks {
-		edges = append(edges, artist.EdgeAppearingTracks)
-	}
-	if m.clearedappearing_releases {
-		edges = append(edges, artist.EdgeAppearingReleases)
-	}
-	if m.clearedimage {
-		edges = append(edges, artist.EdgeImage)
+func (m *ApiTokenMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.cleareduser {
+		edges = append(edges, apitoken.EdgeUser)
 	}
 	return edges
 }
 
 // EdgeCleared returns a boolean which indicates if the edge with the given name
 // was cleared in this mutation.
-func (m *ArtistMutation) EdgeCleared(name string) bool {
+func (m *ApiTokenMutation) EdgeCleared(name string) bool {
 	switch name {
-	case artist.EdgeAppearingTracks:
-		return m.clearedappearing_tracks
-	case artist.EdgeAppearingReleases:
-		return m.clearedappearing_releases
-	case artist.EdgeImage:
-		return m.clearedimage
+	case apitoken.EdgeUser:
+		return m.cleareduser
 	}
 	return false
 }
 
 // ClearEdge clears the value of the edge with the given name. It returns an error
 // if that edge is not defined in the schema.
-func (m *ArtistMutation) ClearEdge(name string) error {
+func (m *ApiTokenMutation) ClearEdge(name string) error {
 	switch name {
-	case artist.EdgeImage:
-		m.ClearImage()
+	case apitoken.EdgeUser:
+		m.ClearUser()
 		return nil
 	}
-	return fmt.Errorf("unknown Artist unique edge %s", name)
+	return fmt.Errorf("unknown ApiToken unique edge %s", name)
 }
 
 // ResetEdge resets all changes to the edge with the given name in this mutation.
 // It returns an error if the edge is not defined in the schema.
-func (m *ArtistMutation) ResetEdge(name string) error {
+func (m *ApiTokenMutation) ResetEdge(name string) error {
 	switch name {
-	case artist.EdgeAppearingTracks:
-		m.ResetAppearingTracks()
-		return nil
-	case artist.EdgeAppearingReleases:
-		m.ResetAppearingReleases()
-		return nil
-	case artist.EdgeImage:
-		m.ResetImage()
+	case apitoken.EdgeUser:
+		m.ResetUser()
 		return nil
 	}
-	return fmt.Errorf("unknown Artist edge %s", name)
+	return fmt.Errorf("unknown ApiToken edge %s", name)
 }
 
-// ImageMutation represents an operation that mutates the Image nodes in the graph.
-type ImageMutation struct {
+// ArtistMutation represents an operation that mutates the Artist nodes in the graph.
+type ArtistMutation struct {
 	config
-	op                     Op
-	typ                    string
-	id                     *pid.ID
-	file                   *string
-	original_name          *string
-	_type                  *image.Type
-	note                   *string
-	dimention_width        *int
-	adddimention_width     *int
-	dimention_height       *int
-	adddimention_height    *int
-	size_bits              *uint32
-	addsize_bits           *int32
-	created_at             *time.Time
-	updated_at             *time.Time
-	deleted_at             *time.Time
-	clearedFields          map[string]struct{}
-	release                *pid.ID
-	clearedrelease         bool
-	artist                 *pid.ID
-	clearedartist          bool
-	uploader               *pid.ID
-	cleareduploader        bool
-	proccesed_image        map[pid.ID]struct{}
-	removedproccesed_image map[pid.ID]struct{}
-	clearedproccesed_image bool
-	data                   *int
-	cleareddata            bool
-	done                   bool
-	oldValue               func(context.Context) (*Image, error)
-	predicates             []predicate.Image
+	op                        Op
+	typ                       string
+	id                        *pid.ID
+	deleted_at                *time.Time
+	name                      *string
+	created_at                *time.Time
+	updated_at                *time.Time
+	clearedFields             map[string]struct{}
+	appearing_tracks          map[pid.ID]struct{}
+	removedappearing_tracks   map[pid.ID]struct{}
+	clearedappearing_tracks   bool
+	appearing_releases        map[pid.ID]struct{}
+	removedappearing_releases map[pid.ID]struct{}
+	clearedappearing_releases bool
+	image                     *pid.ID
+	clearedimage              bool
+	done                      bool
+	oldValue                  func(context.Context) (*Artist, error)
+	predicates                []predicate.Artist
 }
 
-var _ ent.Mutation = (*ImageMutation)(nil)
+var _ ent.Mutation = (*ArtistMutation)(nil)
 
-// imageOption allows management of the mutation configuration using functional options.
-type imageOption func(*ImageMutation)
+// artistOption allows management of the mutation configuration using functional options.
+type artistOption func(*ArtistMutation)
 
-// newImageMutation creates new mutation for the Image entity.
-func newImageMutation(c config, op Op, opts ...imageOption) *ImageMutation {
-	m := &ImageMutation{
+// newArtistMutation creates new mutation for the Artist entity.
+func newArtistMutation(c config, op Op, opts ...artistOption) *ArtistMutation {
+	m := &ArtistMutation{
 		config:        c,
 		op:            op,
-		typ:           TypeImage,
+		typ:           TypeArtist,
 		clearedFields: make(map[string]struct{}),
 	}
 	for _, opt := range opts {
@@ -853,20 +822,20 @@ func newImageMutation(c config, op Op, opts ...imageOption) *ImageMutation {
 	return m
 }
 
-// withImageID sets the ID field of the mutation.
-func withImageID(id pid.ID) imageOption {
-	return func(m *ImageMutation) {
+// withArtistID sets the ID field of the mutation.
+func withArtistID(id pid.ID) artistOption {
+	return func(m *ArtistMutation) {
 		var (
 			err   error
 			once  sync.Once
-			value *Image
+			value *Artist
 		)
-		m.oldValue = func(ctx context.Context) (*Image, error) {
+		m.oldValue = func(ctx context.Context) (*Artist, error) {
 			once.Do(func() {
 				if m.done {
 					err = errors.New("querying old values post mutation is not allowed")
 				} else {
-					value, err = m.Client().Image.Get(ctx, id)
+					value, err = m.Client().Artist.Get(ctx, id)
 				}
 			})
 			return value, err
@@ -875,10 +844,10 @@ func withImageID(id pid.ID) imageOption {
 	}
 }
 
-// withImage sets the old Image of the mutation.
-func withImage(node *Image) imageOption {
-	return func(m *ImageMutation) {
-		m.oldValue = func(context.Context) (*Image, error) {
+// withArtist sets the old Artist of the mutation.
+func withArtist(node *Artist) artistOption {
+	return func(m *ArtistMutation) {
+		m.oldValue = func(context.Context) (*Artist, error) {
 			return node, nil
 		}
 		m.id = &node.ID
@@ -887,7 +856,7 @@ func withImage(node *Image) imageOption {
 
 // Client returns a new `ent.Client` from the mutation. If the mutation was
 // executed in a transaction (ent.Tx), a transactional client is returned.
-func (m ImageMutation) Client() *Client {
+func (m ArtistMutation) Client() *Client {
 	client := &Client{config: m.config}
 	client.init()
 	return client
@@ -895,7 +864,7 @@ func (m ImageMutation) Client() *Client {
 
 // Tx returns an `ent.Tx` for mutations that were executed in transactions;
 // it returns an error otherwise.
-func (m ImageMutation) Tx() (*Tx, error) {
+func (m ArtistMutation) Tx() (*Tx, error) {
 	if _, ok := m.driver.(*txDriver); !ok {
 		return nil, errors.New("ent: mutation is not running in a transaction")
 	}
@@ -905,14 +874,14 @@ func (m ImageMutation) Tx() (*Tx, error) {
 }
 
 // SetID sets the value of the id field. Note that this
-// operation is only accepted on creation of Image entities.
-func (m *ImageMutation) SetID(id pid.ID) {
+// operation is only accepted on creation of Artist entities.
+func (m *ArtistMutation) SetID(id pid.ID) {
 	m.id = &id
 }
 
 // ID returns the ID value in the mutation. Note that the ID is only available
 // if it was provided to the builder or after it was returned from the database.
-func (m *ImageMutation) ID() (id pid.ID, exists bool) {
+func (m *ArtistMutation) ID() (id pid.ID, exists bool) {
 	if m.id == nil {
 		return
 	}
@@ -923,7 +892,7 @@ func (m *ImageMutation) ID() (id pid.ID, exists bool) {
 // That means, if the mutation is applied within a transaction with an isolation level such
 // as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
 // or updated by the mutation.
-func (m *ImageMutation) IDs(ctx context.Context) ([]pid.ID, error) {
+func (m *ArtistMutation) IDs(ctx context.Context) ([]pid.ID, error) {
 	switch {
 	case m.op.Is(OpUpdateOne | OpDeleteOne):
 		id, exists := m.ID()
@@ -932,677 +901,3681 @@ func (m *ImageMutation) IDs(ctx context.Context) ([]pid.ID, error) {
 		}
 		fallthrough
 	case m.op.Is(OpUpdate | OpDelete):
-		return m.Client().Image.Query().Where(m.predicates...).IDs(ctx)
+		return m.Client().Artist.Query().Where(m.predicates...).IDs(ctx)
 	default:
 		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
 	}
 }
 
-// SetFile sets the "file" field.
-func (m *ImageMutation) SetFile(s string) {
-	m.file = &s
+// SetDeletedAt sets the "deleted_at" field.
+func (m *ArtistMutation) SetDeletedAt(t time.Time) {
+	m.deleted_at = &t
 }
 
-// File returns the value of the "file" field in the mutation.
-func (m *ImageMutation) File() (r string, exists bool) {
-	v := m.file
+// DeletedAt returns the value of the "deleted_at" field in the mutation.
+func (m *ArtistMutation) DeletedAt() (r time.Time, exists bool) {
+	v := m.deleted_at
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldFile returns the old "file" field's value of the Image entity.
-// If the Image object wasn't provided to the builder, the object is fetched from the database.
+// OldDeletedAt returns the old "deleted_at" field's value of the Artist entity.
+// If the Artist object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ImageMutation) OldFile(ctx context.Context) (v string, err error) {
+func (m *ArtistMutation) OldDeletedAt(ctx context.Context) (v *time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldFile is only allowed on UpdateOne operations")
+		return v, errors.New("OldDeletedAt is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldFile requires an ID field in the mutation")
+		return v, errors.New("OldDeletedAt requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldFile: %w", err)
+		return v, fmt.Errorf("querying old value for OldDeletedAt: %w", err)
 	}
-	return oldValue.File, nil
+	return oldValue.DeletedAt, nil
 }
 
-// ResetFile resets all changes to the "file" field.
-func (m *ImageMutation) ResetFile() {
-	m.file = nil
+// ClearDeletedAt clears the value of the "deleted_at" field.
+func (m *ArtistMutation) ClearDeletedAt() {
+	m.deleted_at = nil
+	m.clearedFields[artist.FieldDeletedAt] = struct{}{}
 }
 
-// SetOriginalName sets the "original_name" field.
-func (m *ImageMutation) SetOriginalName(s string) {
-	m.original_name = &s
+// DeletedAtCleared returns if the "deleted_at" field was cleared in this mutation.
+func (m *ArtistMutation) DeletedAtCleared() bool {
+	_, ok := m.clearedFields[artist.FieldDeletedAt]
+	return ok
 }
 
-// OriginalName returns the value of the "original_name" field in the mutation.
-func (m *ImageMutation) OriginalName() (r string, exists bool) {
-	v := m.original_name
+// ResetDeletedAt resets all changes to the "deleted_at" field.
+func (m *ArtistMutation) ResetDeletedAt() {
+	m.deleted_at = nil
+	delete(m.clearedFields, artist.FieldDeletedAt)
+}
+
+// SetName sets the "name" field.
+func (m *ArtistMutation) SetName(s string) {
+	m.name = &s
+}
+
+// Name returns the value of the "name" field in the mutation.
+func (m *ArtistMutation) Name() (r string, exists bool) {
+	v := m.name
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldOriginalName returns the old "original_name" field's value of the Image entity.
-// If the Image object wasn't provided to the builder, the object is fetched from the database.
+// OldName returns the old "name" field's value of the Artist entity.
+// If the Artist object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ImageMutation) OldOriginalName(ctx context.Context) (v string, err error) {
+func (m *ArtistMutation) OldName(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldOriginalName is only allowed on UpdateOne operations")
+		return v, errors.New("OldName is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldOriginalName requires an ID field in the mutation")
+		return v, errors.New("OldName requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldOriginalName: %w", err)
+		return v, fmt.Errorf("querying old value for OldName: %w", err)
 	}
-	return oldValue.OriginalName, nil
+	return oldValue.Name, nil
 }
 
-// ResetOriginalName resets all changes to the "original_name" field.
-func (m *ImageMutation) ResetOriginalName() {
-	m.original_name = nil
+// ResetName resets all changes to the "name" field.
+func (m *ArtistMutation) ResetName() {
+	m.name = nil
 }
 
-// SetType sets the "type" field.
-func (m *ImageMutation) SetType(i image.Type) {
-	m._type = &i
+// SetCreatedAt sets the "created_at" field.
+func (m *ArtistMutation) SetCreatedAt(t time.Time) {
+	m.created_at = &t
 }
 
-// GetType returns the value of the "type" field in the mutation.
-func (m *ImageMutation) GetType() (r image.Type, exists bool) {
-	v := m._type
+// CreatedAt returns the value of the "created_at" field in the mutation.
+func (m *ArtistMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m.created_at
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldType returns the old "type" field's value of the Image entity.
-// If the Image object wasn't provided to the builder, the object is fetched from the database.
+// OldCreatedAt returns the old "created_at" field's value of the Artist entity.
+// If the Artist object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ImageMutation) OldType(ctx context.Context) (v image.Type, err error) {
+func (m *ArtistMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldType is only allowed on UpdateOne operations")
+		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldType requires an ID field in the mutation")
+		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldType: %w", err)
+		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
 	}
-	return oldValue.Type, nil
+	return oldValue.CreatedAt, nil
 }
 
-// ResetType resets all changes to the "type" field.
-func (m *ImageMutation) ResetType() {
-	m._type = nil
+// ResetCreatedAt resets all changes to the "created_at" field.
+func (m *ArtistMutation) ResetCreatedAt() {
+	m.created_at = nil
 }
 
-// SetNote sets the "note" field.
-func (m *ImageMutation) SetNote(s string) {
-	m.note = &s
+// SetUpdatedAt sets the "updated_at" field.
+func (m *ArtistMutation) SetUpdatedAt(t time.Time) {
+	m.updated_at = &t
 }
 
-// Note returns the value of the "note" field in the mutation.
-func (m *ImageMutation) Note() (r string, exists bool) {
-	v := m.note
+// UpdatedAt returns the value of the "updated_at" field in the mutation.
+func (m *ArtistMutation) UpdatedAt() (r time.Time, exists bool) {
+	v := m.updated_at
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldNote returns the old "note" field's value of the Image entity.
-// If the Image object wasn't provided to the builder, the object is fetched from the database.
+// OldUpdatedAt returns the old "updated_at" field's value of the Artist entity.
+// If the Artist object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ImageMutation) OldNote(ctx context.Context) (v *string, err error) {
+func (m *ArtistMutation) OldUpdatedAt(ctx context.Context) (v time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldNote is only allowed on UpdateOne operations")
+		return v, errors.New("OldUpdatedAt is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldNote requires an ID field in the mutation")
+		return v, errors.New("OldUpdatedAt requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldNote: %w", err)
+		return v, fmt.Errorf("querying old value for OldUpdatedAt: %w", err)
 	}
-	return oldValue.Note, nil
+	return oldValue.UpdatedAt, nil
 }
 
-// ClearNote clears the value of the "note" field.
-func (m *ImageMutation) ClearNote() {
-	m.note = nil
-	m.clearedFields[image.FieldNote] = struct{}{}
+// ResetUpdatedAt resets all changes to the "updated_at" field.
+func (m *ArtistMutation) ResetUpdatedAt() {
+	m.updated_at = nil
 }
 
-// NoteCleared returns if the "note" field was cleared in this mutation.
-func (m *ImageMutation) NoteCleared() bool {
-	_, ok := m.clearedFields[image.FieldNote]
-	return ok
+// AddAppearingTrackIDs adds the "appearing_tracks" edge to the Track entity by ids.
+func (m *ArtistMutation) AddAppearingTrackIDs(ids ...pid.ID) {
+	if m.appearing_tracks == nil {
+		m.appearing_tracks = make(map[pid.ID]struct{})
+	}
+	for i := range ids {
+		m.appearing_tracks[ids[i]] = struct{}{}
+	}
 }
 
-// ResetNote resets all changes to the "note" field.
-func (m *ImageMutation) ResetNote() {
-	m.note = nil
-	delete(m.clearedFields, image.FieldNote)
+// ClearAppearingTracks clears the "appearing_tracks" edge to the Track entity.
+func (m *ArtistMutation) ClearAppearingTracks() {
+	m.clearedappearing_tracks = true
 }
 
-// SetDimentionWidth sets the "dimention_width" field.
-func (m *ImageMutation) SetDimentionWidth(i int) {
-	m.dimention_width = &i
-	m.adddimention_width = nil
+// AppearingTracksCleared reports if the "appearing_tracks" edge to the Track entity was cleared.
+func (m *ArtistMutation) AppearingTracksCleared() bool {
+	return m.clearedappearing_tracks
 }
 
-// DimentionWidth returns the value of the "dimention_width" field in the mutation.
-func (m *ImageMutation) DimentionWidth() (r int, exists bool) {
-	v := m.dimention_width
-	if v == nil {
-		return
+// RemoveAppearingTrackIDs removes the "appearing_tracks" edge to the Track entity by IDs.
+func (m *ArtistMutation) RemoveAppearingTrackIDs(ids ...pid.ID) {
+	if m.removedappearing_tracks == nil {
+		m.removedappearing_tracks = make(map[pid.ID]struct{})
+	}
+	for i := range ids {
+		delete(m.appearing_tracks, ids[i])
+		m.removedappearing_tracks[ids[i]] = struct{}{}
 	}
-	return *v, true
 }
 
-// OldDimentionWidth returns the old "dimention_width" field's value of the Image entity.
-// If the Image object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ImageMutation) OldDimentionWidth(ctx context.Context) (v int, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldDimentionWidth is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldDimentionWidth requires an ID field in the mutation")
-	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldDimentionWidth: %w", err)
+// RemovedAppearingTracks returns the removed IDs of the "appearing_tracks" edge to the Track entity.
+func (m *ArtistMutation) RemovedAppearingTracksIDs() (ids []pid.ID) {
+	for id := range m.removedappearing_tracks {
+		ids = append(ids, id)
 	}
-	return oldValue.DimentionWidth, nil
+	return
 }
 
-// AddDimentionWidth adds i to the "dimention_width" field.
-func (m *ImageMutation) AddDimentionWidth(i int) {
-	if m.adddimention_width != nil {
-		*m.adddimention_width += i
-	} else {
-		m.adddimention_width = &i
+// AppearingTracksIDs returns the "appearing_tracks" edge IDs in the mutation.
+func (m *ArtistMutation) AppearingTracksIDs() (ids []pid.ID) {
+	for id := range m.appearing_tracks {
+		ids = append(ids, id)
 	}
+	return
 }
 
-// AddedDimentionWidth returns the value that was added to the "dimention_width" field in this mutation.
-func (m *ImageMutation) AddedDimentionWidth() (r int, exists bool) {
-	v := m.adddimention_width
-	if v == nil {
-		return
-	}
-	return *v, true
+// ResetAppearingTracks resets all changes to the "appearing_tracks" edge.
+func (m *ArtistMutation) ResetAppearingTracks() {
+	m.appearing_tracks = nil
+	m.clearedappearing_tracks = false
+	m.removedappearing_tracks = nil
 }
 
-// ResetDimentionWidth resets all changes to the "dimention_width" field.
-func (m *ImageMutation) ResetDimentionWidth() {
-	m.dimention_width = nil
-	m.adddimention_width = nil
+// AddAppearingReleaseIDs adds the "appearing_releases" edge to the Release entity by ids.
+func (m *ArtistMutation) AddAppearingReleaseIDs(ids ...pid.ID) {
+	if m.appearing_releases == nil {
+		m.appearing_releases = make(map[pid.ID]struct{})
+	}
+	for i := range ids {
+		m.appearing_releases[ids[i]] = struct{}{}
+	}
 }
 
-// SetDimentionHeight sets the "dimention_height" field.
-func (m *ImageMutation) SetDimentionHeight(i int) {
-	m.dimention_height = &i
-	m.adddimention_height = nil
+// ClearAppearingReleases clears the "appearing_releases" edge to the Release entity.
+func (m *ArtistMutation) ClearAppearingReleases() {
+	m.clearedappearing_releases = true
 }
 
-// DimentionHeight returns the value of the "dimention_height" field in the mutation.
-func (m *ImageMutation) DimentionHeight() (r int, exists bool) {
-	v := m.dimention_height
-	if v == nil {
-		return
-	}
-	return *v, true
+// AppearingReleasesCleared reports if the "appearing_releases" edge to the Release entity was cleared.
+func (m *ArtistMutation) AppearingReleasesCleared() bool {
+	return m.clearedappearing_releases
 }
 
-// OldDimentionHeight returns the old "dimention_height" field's value of the Image entity.
-// If the Image object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ImageMutation) OldDimentionHeight(ctx context.Context) (v int, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldDimentionHeight is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldDimentionHeight requires an ID field in the mutation")
+// RemoveAppearingReleaseIDs removes the "appearing_releases" edge to the Release entity by IDs.
+func (m *ArtistMutation) RemoveAppearingReleaseIDs(ids ...pid.ID) {
+	if m.removedappearing_releases == nil {
+		m.removedappearing_releases = make(map[pid.ID]struct{})
 	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldDimentionHeight: %w", err)
+	for i := range ids {
+		delete(m.appearing_releases, ids[i])
+		m.removedappearing_releases[ids[i]] = struct{}{}
 	}
-	return oldValue.DimentionHeight, nil
 }
 
-// AddDimentionHeight adds i to the "dimention_height" field.
-func (m *ImageMutation) AddDimentionHeight(i int) {
-	if m.adddimention_height != nil {
-		*m.adddimention_height += i
-	} else {
-		m.adddimention_height = &i
+// RemovedAppearingReleases returns the removed IDs of the "appearing_releases" edge to the Release entity.
+func (m *ArtistMutation) RemovedAppearingReleasesIDs() (ids []pid.ID) {
+	for id := range m.removedappearing_releases {
+		ids = append(ids, id)
 	}
+	return
 }
 
-// AddedDimentionHeight returns the value that was added to the "dimention_height" field in this mutation.
-func (m *ImageMutation) AddedDimentionHeight() (r int, exists bool) {
-	v := m.adddimention_height
-	if v == nil {
-		return
+// AppearingReleasesIDs returns the "appearing_releases" edge IDs in the mutation.
+func (m *ArtistMutation) AppearingReleasesIDs() (ids []pid.ID) {
+	for id := range m.appearing_releases {
+		ids = append(ids, id)
 	}
-	return *v, true
+	return
 }
 
-// ResetDimentionHeight resets all changes to the "dimention_height" field.
-func (m *ImageMutation) ResetDimentionHeight() {
-	m.dimention_height = nil
-	m.adddimention_height = nil
+// ResetAppearingReleases resets all changes to the "appearing_releases" edge.
+func (m *ArtistMutation) ResetAppearingReleases() {
+	m.appearing_releases = nil
+	m.clearedappearing_releases = false
+	m.removedappearing_releases = nil
 }
 
-// SetSizeBits sets the "size_bits" field.
-func (m *ImageMutation) SetSizeBits(u uint32) {
-	m.size_bits = &u
-	m.addsize_bits = nil
+// SetImageID sets the "image" edge to the Image entity by id.
+func (m *ArtistMutation) SetImageID(id pid.ID) {
+	m.image = &id
 }
 
-// SizeBits returns the value of the "size_bits" field in the mutation.
-func (m *ImageMutation) SizeBits() (r uint32, exists bool) {
-	v := m.size_bits
-	if v == nil {
-		return
-	}
-	return *v, true
+// ClearImage clears the "image" edge to the Image entity.
+func (m *ArtistMutation) ClearImage() {
+	m.clearedimage = true
 }
 
-// OldSizeBits returns the old "size_bits" field's value of the Image entity.
-// If the Image object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ImageMutation) OldSizeBits(ctx context.Context) (v uint32, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldSizeBits is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldSizeBits requires an ID field in the mutation")
-	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldSizeBits: %w", err)
-	}
-	return oldValue.SizeBits, nil
+// ImageCleared reports if the "image" edge to the Image entity was cleared.
+func (m *ArtistMutation) ImageCleared() bool {
+	return m.clearedimage
 }
 
-// AddSizeBits adds u to the "size_bits" field.
-func (m *ImageMutation) AddSizeBits(u int32) {
-	if m.addsize_bits != nil {
-		*m.addsize_bits += u
-	} else {
-		m.addsize_bits = &u
+// ImageID returns the "image" edge ID in the mutation.
+func (m *ArtistMutation) ImageID() (id pid.ID, exists bool) {
+	if m.image != nil {
+		return *m.image, true
 	}
+	return
 }
 
-// AddedSizeBits returns the value that was added to the "size_bits" field in this mutation.
-func (m *ImageMutation) AddedSizeBits() (r int32, exists bool) {
-	v := m.addsize_bits
-	if v == nil {
-		return
+// ImageIDs returns the "image" edge IDs in the mutation.
+// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
+// ImageID instead. It exists only for internal usage by the builders.
+func (m *ArtistMutation) ImageIDs() (ids []pid.ID) {
+	if id := m.image; id != nil {
+		ids = append(ids, *id)
 	}
-	return *v, true
+	return
 }
 
-// ResetSizeBits resets all changes to the "size_bits" field.
-func (m *ImageMutation) ResetSizeBits() {
-	m.size_bits = nil
-	m.addsize_bits = nil
+// ResetImage resets all changes to the "image" edge.
+func (m *ArtistMutation) ResetImage() {
+	m.image = nil
+	m.clearedimage = false
 }
 
-// SetCreatedAt sets the "created_at" field.
-func (m *ImageMutation) SetCreatedAt(t time.Time) {
-	m.created_at = &t
+// Where appends a list predicates to the ArtistMutation builder.
+func (m *ArtistMutation) Where(ps ...predicate.Artist) {
+	m.predicates = append(m.predicates, ps...)
 }
 
-// CreatedAt returns the value of the "created_at" field in the mutation.
-func (m *ImageMutation) CreatedAt() (r time.Time, exists bool) {
-	v := m.created_at
-	if v == nil {
-		return
+// WhereP appends storage-level predicates to the ArtistMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *ArtistMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.Artist, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
 	}
-	return *v, true
+	m.Where(p...)
 }
 
-// OldCreatedAt returns the old "created_at" field's value of the Image entity.
-// If the Image object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ImageMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
-	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
-	}
-	return oldValue.CreatedAt, nil
+// Op returns the operation name.
+func (m *ArtistMutation) Op() Op {
+	return m.op
 }
 
-// ResetCreatedAt resets all changes to the "created_at" field.
-func (m *ImageMutation) ResetCreatedAt() {
-	m.created_at = nil
+// SetOp allows setting the mutation operation.
+func (m *ArtistMutation) SetOp(op Op) {
+	m.op = op
 }
 
-// SetUpdatedAt sets the "updated_at" field.
-func (m *ImageMutation) SetUpdatedAt(t time.Time) {
-	m.updated_at = &t
+// Type returns the node type of this mutation (Artist).
+func (m *ArtistMutation) Type() string {
+	return m.typ
 }
 
-// UpdatedAt returns the value of the "updated_at" field in the mutation.
-func (m *ImageMutation) UpdatedAt() (r time.Time, exists bool) {
-	v := m.updated_at
-	if v == nil {
-		return
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *ArtistMutation) Fields() []string {
+	fields := make([]string, 0, 4)
+	if m.deleted_at != nil {
+		fields = append(fields, artist.FieldDeletedAt)
 	}
-	return *v, true
-}
-
-// OldUpdatedAt returns the old "updated_at" field's value of the Image entity.
-// If the Image object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ImageMutation) OldUpdatedAt(ctx context.Context) (v time.Time, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldUpdatedAt is only allowed on UpdateOne operations")
+	if m.name != nil {
+		fields = append(fields, artist.FieldName)
 	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldUpdatedAt requires an ID field in the mutation")
+	if m.created_at != nil {
+		fields = append(fields, artist.FieldCreatedAt)
 	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldUpdatedAt: %w", err)
+	if m.updated_at != nil {
+		fields = append(fields, artist.FieldUpdatedAt)
 	}
-	return oldValue.UpdatedAt, nil
-}
-
-// ResetUpdatedAt resets all changes to the "updated_at" field.
-func (m *ImageMutation) ResetUpdatedAt() {
-	m.updated_at = nil
+	return fields
 }
 
-// SetDeletedAt sets the "deleted_at" field.
-func (m *ImageMutation) SetDeletedAt(t time.Time) {
-	m.deleted_at = &t
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *ArtistMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case artist.FieldDeletedAt:
+		return m.DeletedAt()
+	case artist.FieldName:
+		return m.Name()
+	case artist.FieldCreatedAt:
+		return m.CreatedAt()
+	case artist.FieldUpdatedAt:
+		return m.UpdatedAt()
+	}
+	return nil, false
 }
 
-// DeletedAt returns the value of the "deleted_at" field in the mutation.
-func (m *ImageMutation) DeletedAt() (r time.Time, exists bool) {
-	v := m.deleted_at
-	if v == nil {
-		return
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *ArtistMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case artist.FieldDeletedAt:
+		return m.OldDeletedAt(ctx)
+	case artist.FieldName:
+		return m.OldName(ctx)
+	case artist.FieldCreatedAt:
+		return m.OldCreatedAt(ctx)
+	case artist.FieldUpdatedAt:
+		return m.OldUpdatedAt(ctx)
 	}
-	return *v, true
+	return nil, fmt.Errorf("unknown Artist field %s", name)
 }
 
-// OldDeletedAt returns the old "deleted_at" field's value of the Image entity.
-// If the Image object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ImageMutation) OldDeletedAt(ctx context.Context) (v *time.Time, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldDeletedAt is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldDeletedAt requires an ID field in the mutation")
-	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldDeletedAt: %w", err)
-	}
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *ArtistMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case artist.FieldDeletedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetDeletedAt(v)
+		return nil
+	case artist.FieldName:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetName(v)
+		return nil
+	case artist.FieldCreatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCreatedAt(v)
+		return nil
+	case artist.FieldUpdatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUpdatedAt(v)
+		return nil
+	}
+	return fmt.Errorf("unknown Artist field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *ArtistMutation) AddedFields() []string {
+	return nil
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *ArtistMutation) AddedField(name string) (ent.Value, bool) {
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *ArtistMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	}
+	return fmt.Errorf("unknown Artist numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *ArtistMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(artist.FieldDeletedAt) {
+		fields = append(fields, artist.FieldDeletedAt)
+	}
+	return fields
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *ArtistMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *ArtistMutation) ClearField(name string) error {
+	switch name {
+	case artist.FieldDeletedAt:
+		m.ClearDeletedAt()
+		return nil
+	}
+	return fmt.Errorf("unknown Artist nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *ArtistMutation) ResetField(name string) error {
+	switch name {
+	case artist.FieldDeletedAt:
+		m.ResetDeletedAt()
+		return nil
+	case artist.FieldName:
+		m.ResetName()
+		return nil
+	case artist.FieldCreatedAt:
+		m.ResetCreatedAt()
+		return nil
+	case artist.FieldUpdatedAt:
+		m.ResetUpdatedAt()
+		return nil
+	}
+	return fmt.Errorf("unknown Artist field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *ArtistMutation) AddedEdges() []string {
+	edges := make([]string, 0, 3)
+	if m.appearing_tracks != nil {
+		edges = append(edges, artist.EdgeAppearingTracks)
+	}
+	if m.appearing_releases != nil {
+		edges = append(edges, artist.EdgeAppearingReleases)
+	}
+	if m.image != nil {
+		edges = append(edges, artist.EdgeImage)
+	}
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *ArtistMutation) AddedIDs(name string) []ent.Value {
+	switch name {
+	case artist.EdgeAppearingTracks:
+		ids := make([]ent.Value, 0, len(m.appearing_tracks))
+		for id := range m.appearing_tracks {
+			ids = append(ids, id)
+		}
+		return ids
+	case artist.EdgeAppearingReleases:
+		ids := make([]ent.Value, 0, len(m.appearing_releases))
+		for id := range m.appearing_releases {
+			ids = append(ids, id)
+		}
+		return ids
+	case artist.EdgeImage:
+		if id := m.image; id != nil {
+			return []ent.Value{*id}
+		}
+	}
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *ArtistMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 3)
+	if m.removedappearing_tracks != nil {
+		edges = append(edges, artist.EdgeAppearingTracks)
+	}
+	if m.removedappearing_releases != nil {
+		edges = append(edges, artist.EdgeAppearingReleases)
+	}
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *ArtistMutation) RemovedIDs(name string) []ent.Value {
+	switch name {
+	case artist.EdgeAppearingTracks:
+		ids := make([]ent.Value, 0, len(m.removedappearing_tracks))
+		for id := range m.removedappearing_tracks {
+			ids = append(ids, id)
+		}
+		return ids
+	case artist.EdgeAppearingReleases:
+		ids := make([]ent.Value, 0, len(m.removedappearing_releases))
+		for id := range m.removedappearing_releases {
+			ids = append(ids, id)
+		}
+		return ids
+	}
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *ArtistMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 3)
+	if m.clearedappearing_tracks {
+		edges = append(edges, artist.EdgeAppearingTracks)
+	}
+	if m.clearedappearing_releases {
+		edges = append(edges, artist.EdgeAppearingReleases)
+	}
+	if m.clearedimage {
+		edges = append(edges, artist.EdgeImage)
+	}
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *ArtistMutation) EdgeCleared(name string) bool {
+	switch name {
+	case artist.EdgeAppearingTracks:
+		return m.clearedappearing_tracks
+	case artist.EdgeAppearingReleases:
+		return m.clearedappearing_releases
+	case artist.EdgeImage:
+		return m.clearedimage
+	}
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *ArtistMutation) ClearEdge(name string) error {
+	switch name {
+	case artist.EdgeImage:
+		m.ClearImage()
+		return nil
+	}
+	return fmt.Errorf("unknown Artist unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *ArtistMutation) ResetEdge(name string) error {
+	switch name {
+	case artist.EdgeAppearingTracks:
+		m.ResetAppearingTracks()
+		return nil
+	case artist.EdgeAppearingReleases:
+		m.ResetAppearingReleases()
+		return nil
+	case artist.EdgeImage:
+		m.ResetImage()
+		return nil
+	}
+	return fmt.Errorf("unknown Artist edge %s", name)
+}
+
+// ImageMutation represents an operation that mutates the Image nodes in the graph.
+type ImageMutation struct {
+	config
+	op                     Op
+	typ                    string
+	id                     *pid.ID
+	deleted_at             *time.Time
+	file                   *string
+	original_name          *string
+	_type                  *image.Type
+	note                   *string
+	dimention_width        *int
+	adddimention_width     *int
+	dimention_height       *int
+	adddimention_height    *int
+	size_bits              *uint32
+	addsize_bits           *int32
+	hash                   *string
+	created_at             *time.Time
+	updated_at             *time.Time
+	clearedFields          map[string]struct{}
+	release                *pid.ID
+	clearedrelease         bool
+	artist                 *pid.ID
+	clearedartist          bool
+	uploader               *pid.ID
+	cleareduploader        bool
+	proccesed_image        map[pid.ID]struct{}
+	removedproccesed_image map[pid.ID]struct{}
+	clearedproccesed_image bool
+	data                   *int
+	cleareddata            bool
+	done                   bool
+	oldValue               func(context.Context) (*Image, error)
+	predicates             []predicate.Image
+}
+
+var _ ent.Mutation = (*ImageMutation)(nil)
+
+// imageOption allows management of the mutation configuration using functional options.
+type imageOption func(*ImageMutation)
+
+// newImageMutation creates new mutation for the Image entity.
+func newImageMutation(c config, op Op, opts ...imageOption) *ImageMutation {
+	m := &ImageMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeImage,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withImageID sets the ID field of the mutation.
+func withImageID(id pid.ID) imageOption {
+	return func(m *ImageMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *Image
+		)
+		m.oldValue = func(ctx context.Context) (*Image, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().Image.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withImage sets the old Image of the mutation.
+func withImage(node *Image) imageOption {
+	return func(m *ImageMutation) {
+		m.oldValue = func(context.Context) (*Image, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m ImageMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m ImageMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// SetID sets the value of the id field. Note that this
+// operation is only accepted on creation of Image entities.
+func (m *ImageMutation) SetID(id pid.ID) {
+	m.id = &id
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *ImageMutation) ID() (id pid.ID, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *ImageMutation) IDs(ctx context.Context) ([]pid.ID, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []pid.ID{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().Image.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetDeletedAt sets the "deleted_at" field.
+func (m *ImageMutation) SetDeletedAt(t time.Time) {
+	m.deleted_at = &t
+}
+
+// DeletedAt returns the value of the "deleted_at" field in the mutation.
+func (m *ImageMutation) DeletedAt() (r time.Time, exists bool) {
+	v := m.deleted_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldDeletedAt returns the old "deleted_at" field's value of the Image entity.
+// If the Image object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ImageMutation) OldDeletedAt(ctx context.Context) (v *time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldDeletedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldDeletedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldDeletedAt: %w", err)
+	}
 	return oldValue.DeletedAt, nil
 }
 
-// ClearDeletedAt clears the value of the "deleted_at" field.
-func (m *ImageMutation) ClearDeletedAt() {
-	m.deleted_at = nil
-	m.clearedFields[image.FieldDeletedAt] = struct{}{}
+// ClearDeletedAt clears the value of the "deleted_at" field.
+func (m *ImageMutation) ClearDeletedAt() {
+	m.deleted_at = nil
+	m.clearedFields[image.FieldDeletedAt] = struct{}{}
+}
+
+// DeletedAtCleared returns if the "deleted_at" field was cleared in this mutation.
+func (m *ImageMutation) DeletedAtCleared() bool {
+	_, ok := m.clearedFields[image.FieldDeletedAt]
+	return ok
+}
+
+// ResetDeletedAt resets all changes to the "deleted_at" field.
+func (m *ImageMutation) ResetDeletedAt() {
+	m.deleted_at = nil
+	delete(m.clearedFields, image.FieldDeletedAt)
+}
+
+// SetFile sets the "file" field.
+func (m *ImageMutation) SetFile(s string) {
+	m.file = &s
+}
+
+// File returns the value of the "file" field in the mutation.
+func (m *ImageMutation) File() (r string, exists bool) {
+	v := m.file
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldFile returns the old "file" field's value of the Image entity.
+// If the Image object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ImageMutation) OldFile(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldFile is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldFile requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldFile: %w", err)
+	}
+	return oldValue.File, nil
+}
+
+// ResetFile resets all changes to the "file" field.
+func (m *ImageMutation) ResetFile() {
+	m.file = nil
+}
+
+// SetOriginalName sets the "original_name" field.
+func (m *ImageMutation) SetOriginalName(s string) {
+	m.original_name = &s
+}
+
+// OriginalName returns the value of the "original_name" field in the mutation.
+func (m *ImageMutation) OriginalName() (r string, exists bool) {
+	v := m.original_name
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldOriginalName returns the old "original_name" field's value of the Image entity.
+// If the Image object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ImageMutation) OldOriginalName(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldOriginalName is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldOriginalName requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldOriginalName: %w", err)
+	}
+	return oldValue.OriginalName, nil
+}
+
+// ResetOriginalName resets all changes to the "original_name" field.
+func (m *ImageMutation) ResetOriginalName() {
+	m.original_name = nil
+}
+
+// SetType sets the "type" field.
+func (m *ImageMutation) SetType(i image.Type) {
+	m._type = &i
+}
+
+// GetType returns the value of the "type" field in the mutation.
+func (m *ImageMutation) GetType() (r image.Type, exists bool) {
+	v := m._type
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldType returns the old "type" field's value of the Image entity.
+// If the Image object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ImageMutation) OldType(ctx context.Context) (v image.Type, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldType is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldType requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldType: %w", err)
+	}
+	return oldValue.Type, nil
+}
+
+// ResetType resets all changes to the "type" field.
+func (m *ImageMutation) ResetType() {
+	m._type = nil
+}
+
+// SetNote sets the "note" field.
+func (m *ImageMutation) SetNote(s string) {
+	m.note = &s
+}
+
+// Note returns the value of the "note" field in the mutation.
+func (m *ImageMutation) Note() (r string, exists bool) {
+	v := m.note
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldNote returns the old "note" field's value of the Image entity.
+// If the Image object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ImageMutation) OldNote(ctx context.Context) (v *string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldNote is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldNote requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldNote: %w", err)
+	}
+	return oldValue.Note, nil
+}
+
+// ClearNote clears the value of the "note" field.
+func (m *ImageMutation) ClearNote() {
+	m.note = nil
+	m.clearedFields[image.FieldNote] = struct{}{}
+}
+
+// NoteCleared returns if the "note" field was cleared in this mutation.
+func (m *ImageMutation) NoteCleared() bool {
+	_, ok := m.clearedFields[image.FieldNote]
+	return ok
+}
+
+// ResetNote resets all changes to the "note" field.
+func (m *ImageMutation) ResetNote() {
+	m.note = nil
+	delete(m.clearedFields, image.FieldNote)
+}
+
+// SetDimentionWidth sets the "dimention_width" field.
+func (m *ImageMutation) SetDimentionWidth(i int) {
+	m.dimention_width = &i
+	m.adddimention_width = nil
+}
+
+// DimentionWidth returns the value of the "dimention_width" field in the mutation.
+func (m *ImageMutation) DimentionWidth() (r int, exists bool) {
+	v := m.dimention_width
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldDimentionWidth returns the old "dimention_width" field's value of the Image entity.
+// If the Image object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ImageMutation) OldDimentionWidth(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldDimentionWidth is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldDimentionWidth requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldDimentionWidth: %w", err)
+	}
+	return oldValue.DimentionWidth, nil
+}
+
+// AddDimentionWidth adds i to the "dimention_width" field.
+func (m *ImageMutation) AddDimentionWidth(i int) {
+	if m.adddimention_width != nil {
+		*m.adddimention_width += i
+	} else {
+		m.adddimention_width = &i
+	}
+}
+
+// AddedDimentionWidth returns the value that was added to the "dimention_width" field in this mutation.
+func (m *ImageMutation) AddedDimentionWidth() (r int, exists bool) {
+	v := m.adddimention_width
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetDimentionWidth resets all changes to the "dimention_width" field.
+func (m *ImageMutation) ResetDimentionWidth() {
+	m.dimention_width = nil
+	m.adddimention_width = nil
+}
+
+// SetDimentionHeight sets the "dimention_height" field.
+func (m *ImageMutation) SetDimentionHeight(i int) {
+	m.dimention_height = &i
+	m.adddimention_height = nil
+}
+
+// DimentionHeight returns the value of the "dimention_height" field in the mutation.
+func (m *ImageMutation) DimentionHeight() (r int, exists bool) {
+	v := m.dimention_height
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldDimentionHeight returns the old "dimention_height" field's value of the Image entity.
+// If the Image object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ImageMutation) OldDimentionHeight(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldDimentionHeight is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldDimentionHeight requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldDimentionHeight: %w", err)
+	}
+	return oldValue.DimentionHeight, nil
+}
+
+// AddDimentionHeight adds i to the "dimention_height" field.
+func (m *ImageMutation) AddDimentionHeight(i int) {
+	if m.adddimention_height != nil {
+		*m.adddimention_height += i
+	} else {
+		m.adddimention_height = &i
+	}
+}
+
+// AddedDimentionHeight returns the value that was added to the "dimention_height" field in this mutation.
+func (m *ImageMutation) AddedDimentionHeight() (r int, exists bool) {
+	v := m.adddimention_height
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetDimentionHeight resets all changes to the "dimention_height" field.
+func (m *ImageMutation) ResetDimentionHeight() {
+	m.dimention_height = nil
+	m.adddimention_height = nil
+}
+
+// SetSizeBits sets the "size_bits" field.
+func (m *ImageMutation) SetSizeBits(u uint32) {
+	m.size_bits = &u
+	m.addsize_bits = nil
+}
+
+// SizeBits returns the value of the "size_bits" field in the mutation.
+func (m *ImageMutation) SizeBits() (r uint32, exists bool) {
+	v := m.size_bits
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldSizeBits returns the old "size_bits" field's value of the Image entity.
+// If the Image object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ImageMutation) OldSizeBits(ctx context.Context) (v uint32, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldSizeBits is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldSizeBits requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldSizeBits: %w", err)
+	}
+	return oldValue.SizeBits, nil
+}
+
+// AddSizeBits adds u to the "size_bits" field.
+func (m *ImageMutation) AddSizeBits(u int32) {
+	if m.addsize_bits != nil {
+		*m.addsize_bits += u
+	} else {
+		m.addsize_bits = &u
+	}
+}
+
+// AddedSizeBits returns the value that was added to the "size_bits" field in this mutation.
+func (m *ImageMutation) AddedSizeBits() (r int32, exists bool) {
+	v := m.addsize_bits
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetSizeBits resets all changes to the "size_bits" field.
+func (m *ImageMutation) ResetSizeBits() {
+	m.size_bits = nil
+	m.addsize_bits = nil
+}
+
+// SetHash sets the "hash" field.
+func (m *ImageMutation) SetHash(s string) {
+	m.hash = &s
+}
+
+// Hash returns the value of the "hash" field in the mutation.
+func (m *ImageMutation) Hash() (r string, exists bool) {
+	v := m.hash
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldHash returns the old "hash" field's value of the Image entity.
+// If the Image object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ImageMutation) OldHash(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldHash is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldHash requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldHash: %w", err)
+	}
+	return oldValue.Hash, nil
+}
+
+// ClearHash clears the value of the "hash" field.
+func (m *ImageMutation) ClearHash() {
+	m.hash = nil
+	m.clearedFields[image.FieldHash] = struct{}{}
+}
+
+// HashCleared returns if the "hash" field was cleared in this mutation.
+func (m *ImageMutation) HashCleared() bool {
+	_, ok := m.clearedFields[image.FieldHash]
+	return ok
+}
+
+// ResetHash resets all changes to the "hash" field.
+func (m *ImageMutation) ResetHash() {
+	m.hash = nil
+	delete(m.clearedFields, image.FieldHash)
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (m *ImageMutation) SetCreatedAt(t time.Time) {
+	m.created_at = &t
+}
+
+// CreatedAt returns the value of the "created_at" field in the mutation.
+func (m *ImageMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m.created_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCreatedAt returns the old "created_at" field's value of the Image entity.
+// If the Image object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ImageMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+	}
+	return oldValue.CreatedAt, nil
+}
+
+// ResetCreatedAt resets all changes to the "created_at" field.
+func (m *ImageMutation) ResetCreatedAt() {
+	m.created_at = nil
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (m *ImageMutation) SetUpdatedAt(t time.Time) {
+	m.updated_at = &t
+}
+
+// UpdatedAt returns the value of the "updated_at" field in the mutation.
+func (m *ImageMutation) UpdatedAt() (r time.Time, exists bool) {
+	v := m.updated_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldUpdatedAt returns the old "updated_at" field's value of the Image entity.
+// If the Image object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ImageMutation) OldUpdatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldUpdatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldUpdatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldUpdatedAt: %w", err)
+	}
+	return oldValue.UpdatedAt, nil
+}
+
+// ResetUpdatedAt resets all changes to the "updated_at" field.
+func (m *ImageMutation) ResetUpdatedAt() {
+	m.updated_at = nil
+}
+
+// SetReleaseID sets the "release" edge to the Release entity by id.
+func (m *ImageMutation) SetReleaseID(id pid.ID) {
+	m.release = &id
+}
+
+// ClearRelease clears the "release" edge to the Release entity.
+func (m *ImageMutation) ClearRelease() {
+	m.clearedrelease = true
+}
+
+// ReleaseCleared reports if the "release" edge to the Release entity was cleared.
+func (m *ImageMutation) ReleaseCleared() bool {
+	return m.clearedrelease
+}
+
+// ReleaseID returns the "release" edge ID in the mutation.
+func (m *ImageMutation) ReleaseID() (id pid.ID, exists bool) {
+	if m.release != nil {
+		return *m.release, true
+	}
+	return
+}
+
+// ReleaseIDs returns the "release" edge IDs in the mutation.
+// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
+// ReleaseID instead. It exists only for internal usage by the builders.
+func (m *ImageMutation) ReleaseIDs() (ids []pid.ID) {
+	if id := m.release; id != nil {
+		ids = append(ids, *id)
+	}
+	return
+}
+
+// ResetRelease resets all changes to the "release" edge.
+func (m *ImageMutation) ResetRelease() {
+	m.release = nil
+	m.clearedrelease = false
+}
+
+// SetArtistID sets the "artist" edge to the Artist entity by id.
+func (m *ImageMutation) SetArtistID(id pid.ID) {
+	m.artist = &id
+}
+
+// ClearArtist clears the "artist" edge to the Artist entity.
+func (m *ImageMutation) ClearArtist() {
+	m.clearedartist = true
+}
+
+// ArtistCleared reports if the "artist" edge to the Artist entity was cleared.
+func (m *ImageMutation) ArtistCleared() bool {
+	return m.clearedartist
+}
+
+// ArtistID returns the "artist" edge ID in the mutation.
+func (m *ImageMutation) ArtistID() (id pid.ID, exists bool) {
+	if m.artist != nil {
+		return *m.artist, true
+	}
+	return
+}
+
+// ArtistIDs returns the "artist" edge IDs in the mutation.
+// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
+// ArtistID instead. It exists only for internal usage by the builders.
+func (m *ImageMutation) ArtistIDs() (ids []pid.ID) {
+	if id := m.artist; id != nil {
+		ids = append(ids, *id)
+	}
+	return
+}
+
+// ResetArtist resets all changes to the "artist" edge.
+func (m *ImageMutation) ResetArtist() {
+	m.artist = nil
+	m.clearedartist = false
+}
+
+// SetUploaderID sets the "uploader" edge to the User entity by id.
+func (m *ImageMutation) SetUploaderID(id pid.ID) {
+	m.uploader = &id
+}
+
+// ClearUploader clears the "uploader" edge to the User entity.
+func (m *ImageMutation) ClearUploader() {
+	m.cleareduploader = true
+}
+
+// UploaderCleared reports if the "uploader" edge to the User entity was cleared.
+func (m *ImageMutation) UploaderCleared() bool {
+	return m.cleareduploader
+}
+
+// UploaderID returns the "uploader" edge ID in the mutation.
+func (m *ImageMutation) UploaderID() (id pid.ID, exists bool) {
+	if m.uploader != nil {
+		return *m.uploader, true
+	}
+	return
+}
+
+// UploaderIDs returns the "uploader" edge IDs in the mutation.
+// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
+// UploaderID instead. It exists only for internal usage by the builders.
+func (m *ImageMutation) UploaderIDs() (ids []pid.ID) {
+	if id := m.uploader; id != nil {
+		ids = append(ids, *id)
+	}
+	return
+}
+
+// ResetUploader resets all changes to the "uploader" edge.
+func (m *ImageMutation) ResetUploader() {
+	m.uploader = nil
+	m.cleareduploader = false
+}
+
+// AddProccesedImageIDs adds the "proccesed_image" edge to the ProcessedImage entity by ids.
+func (m *ImageMutation) AddProccesedImageIDs(ids ...pid.ID) {
+	if m.proccesed_image == nil {
+		m.proccesed_image = make(map[pid.ID]struct{})
+	}
+	for i := range ids {
+		m.proccesed_image[ids[i]] = struct{}{}
+	}
+}
+
+// ClearProccesedImage clears the "proccesed_image" edge to the ProcessedImage entity.
+func (m *ImageMutation) ClearProccesedImage() {
+	m.clearedproccesed_image = true
+}
+
+// ProccesedImageCleared reports if the "proccesed_image" edge to the ProcessedImage entity was cleared.
+func (m *ImageMutation) ProccesedImageCleared() bool {
+	return m.clearedproccesed_image
+}
+
+// RemoveProccesedImageIDs removes the "proccesed_image" edge to the ProcessedImage entity by IDs.
+func (m *ImageMutation) RemoveProccesedImageIDs(ids ...pid.ID) {
+	if m.removedproccesed_image == nil {
+		m.removedproccesed_image = make(map[pid.ID]struct{})
+	}
+	for i := range ids {
+		delete(m.proccesed_image, ids[i])
+		m.removedproccesed_image[ids[i]] = struct{}{}
+	}
+}
+
+// RemovedProccesedImage returns the removed IDs of the "proccesed_image" edge to the ProcessedImage entity.
+func (m *ImageMutation) RemovedProccesedImageIDs() (ids []pid.ID) {
+	for id := range m.removedproccesed_image {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ProccesedImageIDs returns the "proccesed_image" edge IDs in the mutation.
+func (m *ImageMutation) ProccesedImageIDs() (ids []pid.ID) {
+	for id := range m.proccesed_image {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ResetProccesedImage resets all changes to the "proccesed_image" edge.
+func (m *ImageMutation) ResetProccesedImage() {
+	m.proccesed_image = nil
+	m.clearedproccesed_image = false
+	m.removedproccesed_image = nil
+}
+
+// SetDataID sets the "data" edge to the ImageData entity by id.
+func (m *ImageMutation) SetDataID(id int) {
+	m.data = &id
+}
+
+// ClearData clears the "data" edge to the ImageData entity.
+func (m *ImageMutation) ClearData() {
+	m.cleareddata = true
+}
+
+// DataCleared reports if the "data" edge to the ImageData entity was cleared.
+func (m *ImageMutation) DataCleared() bool {
+	return m.cleareddata
+}
+
+// DataID returns the "data" edge ID in the mutation.
+func (m *ImageMutation) DataID() (id int, exists bool) {
+	if m.data != nil {
+		return *m.data, true
+	}
+	return
+}
+
+// DataIDs returns the "data" edge IDs in the mutation.
+// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
+// DataID instead. It exists only for internal usage by the builders.
+func (m *ImageMutation) DataIDs() (ids []int) {
+	if id := m.data; id != nil {
+		ids = append(ids, *id)
+	}
+	return
+}
+
+// ResetData resets all changes to the "data" edge.
+func (m *ImageMutation) ResetData() {
+	m.data = nil
+	m.cleareddata = false
+}
+
+// Where appends a list predicates to the ImageMutation builder.
+func (m *ImageMutation) Where(ps ...predicate.Image) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the ImageMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *ImageMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.Image, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
+}
+
+// Op returns the operation name.
+func (m *ImageMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *ImageMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (Image).
+func (m *ImageMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *ImageMutation) Fields() []string {
+	fields := make([]string, 0, 11)
+	if m.deleted_at != nil {
+		fields = append(fields, image.FieldDeletedAt)
+	}
+	if m.file != nil {
+		fields = append(fields, image.FieldFile)
+	}
+	if m.original_name != nil {
+		fields = append(fields, image.FieldOriginalName)
+	}
+	if m._type != nil {
+		fields = append(fields, image.FieldType)
+	}
+	if m.note != nil {
+		fields = append(fields, image.FieldNote)
+	}
+	if m.dimention_width != nil {
+		fields = append(fields, image.FieldDimentionWidth)
+	}
+	if m.dimention_height != nil {
+		fields = append(fields, image.FieldDimentionHeight)
+	}
+	if m.size_bits != nil {
+		fields = append(fields, image.FieldSizeBits)
+	}
+	if m.hash != nil {
+		fields = append(fields, image.FieldHash)
+	}
+	if m.created_at != nil {
+		fields = append(fields, image.FieldCreatedAt)
+	}
+	if m.updated_at != nil {
+		fields = append(fields, image.FieldUpdatedAt)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *ImageMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case image.FieldDeletedAt:
+		return m.DeletedAt()
+	case image.FieldFile:
+		return m.File()
+	case image.FieldOriginalName:
+		return m.OriginalName()
+	case image.FieldType:
+		return m.GetType()
+	case image.FieldNote:
+		return m.Note()
+	case image.FieldDimentionWidth:
+		return m.DimentionWidth()
+	case image.FieldDimentionHeight:
+		return m.DimentionHeight()
+	case image.FieldSizeBits:
+		return m.SizeBits()
+	case image.FieldHash:
+		return m.Hash()
+	case image.FieldCreatedAt:
+		return m.CreatedAt()
+	case image.FieldUpdatedAt:
+		return m.UpdatedAt()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *ImageMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case image.FieldDeletedAt:
+		return m.OldDeletedAt(ctx)
+	case image.FieldFile:
+		return m.OldFile(ctx)
+	case image.FieldOriginalName:
+		return m.OldOriginalName(ctx)
+	case image.FieldType:
+		return m.OldType(ctx)
+	case image.FieldNote:
+		return m.OldNote(ctx)
+	case image.FieldDimentionWidth:
+		return m.OldDimentionWidth(ctx)
+	case image.FieldDimentionHeight:
+		return m.OldDimentionHeight(ctx)
+	case image.FieldSizeBits:
+		return m.OldSizeBits(ctx)
+	case image.FieldHash:
+		return m.OldHash(ctx)
+	case image.FieldCreatedAt:
+		return m.OldCreatedAt(ctx)
+	case image.FieldUpdatedAt:
+		return m.OldUpdatedAt(ctx)
+	}
+	return nil, fmt.Errorf("unknown Image field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *ImageMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case image.FieldDeletedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetDeletedAt(v)
+		return nil
+	case image.FieldFile:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetFile(v)
+		return nil
+	case image.FieldOriginalName:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetOriginalName(v)
+		return nil
+	case image.FieldType:
+		v, ok := value.(image.Type)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetType(v)
+		return nil
+	case image.FieldNote:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetNote(v)
+		return nil
+	case image.FieldDimentionWidth:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetDimentionWidth(v)
+		return nil
+	case image.FieldDimentionHeight:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetDimentionHeight(v)
+		return nil
+	case image.FieldSizeBits:
+		v, ok := value.(uint32)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetSizeBits(v)
+		return nil
+	case image.FieldHash:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetHash(v)
+		return nil
+	case image.FieldCreatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCreatedAt(v)
+		return nil
+	case image.FieldUpdatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUpdatedAt(v)
+		return nil
+	}
+	return fmt.Errorf("unknown Image field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *ImageMutation) AddedFields() []string {
+	var fields []string
+	if m.adddimention_width != nil {
+		fields = append(fields, image.FieldDimentionWidth)
+	}
+	if m.adddimention_height != nil {
+		fields = append(fields, image.FieldDimentionHeight)
+	}
+	if m.addsize_bits != nil {
+		fields = append(fields, image.FieldSizeBits)
+	}
+	return fields
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *ImageMutation) AddedField(name string) (ent.Value, bool) {
+	switch name {
+	case image.FieldDimentionWidth:
+		return m.AddedDimentionWidth()
+	case image.FieldDimentionHeight:
+		return m.AddedDimentionHeight()
+	case image.FieldSizeBits:
+		return m.AddedSizeBits()
+	}
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *ImageMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	case image.FieldDimentionWidth:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddDimentionWidth(v)
+		return nil
+	case image.FieldDimentionHeight:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddDimentionHeight(v)
+		return nil
+	case image.FieldSizeBits:
+		v, ok := value.(int32)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddSizeBits(v)
+		return nil
+	}
+	return fmt.Errorf("unknown Image numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *ImageMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(image.FieldDeletedAt) {
+		fields = append(fields, image.FieldDeletedAt)
+	}
+	if m.FieldCleared(image.FieldNote) {
+		fields = append(fields, image.FieldNote)
+	}
+	if m.FieldCleared(image.FieldHash) {
+		fields = append(fields, image.FieldHash)
+	}
+	return fields
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *ImageMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *ImageMutation) ClearField(name string) error {
+	switch name {
+	case image.FieldDeletedAt:
+		m.ClearDeletedAt()
+		return nil
+	case image.FieldNote:
+		m.ClearNote()
+		return nil
+	case image.FieldHash:
+		m.ClearHash()
+		return nil
+	}
+	return fmt.Errorf("unknown Image nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *ImageMutation) ResetField(name string) error {
+	switch name {
+	case image.FieldDeletedAt:
+		m.ResetDeletedAt()
+		return nil
+	case image.FieldFile:
+		m.ResetFile()
+		return nil
+	case image.FieldOriginalName:
+		m.ResetOriginalName()
+		return nil
+	case image.FieldType:
+		m.ResetType()
+		return nil
+	case image.FieldNote:
+		m.ResetNote()
+		return nil
+	case image.FieldDimentionWidth:
+		m.ResetDimentionWidth()
+		return nil
+	case image.FieldDimentionHeight:
+		m.ResetDimentionHeight()
+		return nil
+	case image.FieldSizeBits:
+		m.ResetSizeBits()
+		return nil
+	case image.FieldHash:
+		m.ResetHash()
+		return nil
+	case image.FieldCreatedAt:
+		m.ResetCreatedAt()
+		return nil
+	case image.FieldUpdatedAt:
+		m.ResetUpdatedAt()
+		return nil
+	}
+	return fmt.Errorf("unknown Image field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *ImageMutation) AddedEdges() []string {
+	edges := make([]string, 0, 5)
+	if m.release != nil {
+		edges = append(edges, image.EdgeRelease)
+	}
+	if m.artist != nil {
+		edges = append(edges, image.EdgeArtist)
+	}
+	if m.uploader != nil {
+		edges = append(edges, image.EdgeUploader)
+	}
+	if m.proccesed_image != nil {
+		edges = append(edges, image.EdgeProccesedImage)
+	}
+	if m.data != nil {
+		edges = append(edges, image.EdgeData)
+	}
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *ImageMutation) AddedIDs(name string) []ent.Value {
+	switch name {
+	case image.EdgeRelease:
+		if id := m.release; id != nil {
+			return []ent.Value{*id}
+		}
+	case image.EdgeArtist:
+		if id := m.artist; id != nil {
+			return []ent.Value{*id}
+		}
+	case image.EdgeUploader:
+		if id := m.uploader; id != nil {
+			return []ent.Value{*id}
+		}
+	case image.EdgeProccesedImage:
+		ids := make([]ent.Value, 0, len(m.proccesed_image))
+		for id := range m.proccesed_image {
+			ids = append(ids, id)
+		}
+		return ids
+	case image.EdgeData:
+		if id := m.data; id != nil {
+			return []ent.Value{*id}
+		}
+	}
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *ImageMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 5)
+	if m.removedproccesed_image != nil {
+		edges = append(edges, image.EdgeProccesedImage)
+	}
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *ImageMutation) RemovedIDs(name string) []ent.Value {
+	switch name {
+	case image.EdgeProccesedImage:
+		ids := make([]ent.Value, 0, len(m.removedproccesed_image))
+		for id := range m.removedproccesed_image {
+			ids = append(ids, id)
+		}
+		return ids
+	}
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *ImageMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 5)
+	if m.clearedrelease {
+		edges = append(edges, image.EdgeRelease)
+	}
+	if m.clearedartist {
+		edges = append(edges, image.EdgeArtist)
+	}
+	if m.cleareduploader {
+		edges = append(edges, image.EdgeUploader)
+	}
+	if m.clearedproccesed_image {
+		edges = append(edges, image.EdgeProccesedImage)
+	}
+	if m.cleareddata {
+		edges = append(edges, image.EdgeData)
+	}
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *ImageMutation) EdgeCleared(name string) bool {
+	switch name {
+	case image.EdgeRelease:
+		return m.clearedrelease
+	case image.EdgeArtist:
+		return m.clearedartist
+	case image.EdgeUploader:
+		return m.cleareduploader
+	case image.EdgeProccesedImage:
+		return m.clearedproccesed_image
+	case image.EdgeData:
+		return m.cleareddata
+	}
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *ImageMutation) ClearEdge(name string) error {
+	switch name {
+	case image.EdgeRelease:
+		m.ClearRelease()
+		return nil
+	case image.EdgeArtist:
+		m.ClearArtist()
+		return nil
+	case image.EdgeUploader:
+		m.ClearUploader()
+		return nil
+	case image.EdgeData:
+		m.ClearData()
+		return nil
+	}
+	return fmt.Errorf("unknown Image unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *ImageMutation) ResetEdge(name string) error {
+	switch name {
+	case image.EdgeRelease:
+		m.ResetRelease()
+		return nil
+	case image.EdgeArtist:
+		m.ResetArtist()
+		return nil
+	case image.EdgeUploader:
+		m.ResetUploader()
+		return nil
+	case image.EdgeProccesedImage:
+		m.ResetProccesedImage()
+		return nil
+	case image.EdgeData:
+		m.ResetData()
+		return nil
+	}
+	return fmt.Errorf("unknown Image edge %s", name)
+}
+
+// ImageDataMutation represents an operation that mutates the ImageData nodes in the graph.
+type ImageDataMutation struct {
+	config
+	op                Op
+	typ               string
+	id                *int
+	avr_r             *int
+	addavr_r          *int
+	avr_g             *int
+	addavr_g          *int
+	avr_b             *int
+	addavr_b          *int
+	avg_brightness    *int
+	addavg_brightness *int
+	avg_saturation    *int
+	addavg_saturation *int
+	phash             *int64
+	addphash          *int64
+	palette           *[]string
+	appendpalette     []string
+	thumbhash         *[]byte
+	placeholder       *string
+	placeholder_color *string
+	created_at        *time.Time
+	clearedFields     map[string]struct{}
+	image             map[pid.ID]struct{}
+	removedimage      map[pid.ID]struct{}
+	clearedimage      bool
+	done              bool
+	oldValue          func(context.Context) (*ImageData, error)
+	predicates        []predicate.ImageData
+}
+
+var _ ent.Mutation = (*ImageDataMutation)(nil)
+
+// imagedataOption allows management of the mutation configuration using functional options.
+type imagedataOption func(*ImageDataMutation)
+
+// newImageDataMutation creates new mutation for the ImageData entity.
+func newImageDataMutation(c config, op Op, opts ...imagedataOption) *ImageDataMutation {
+	m := &ImageDataMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeImageData,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withImageDataID sets the ID field of the mutation.
+func withImageDataID(id int) imagedataOption {
+	return func(m *ImageDataMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *ImageData
+		)
+		m.oldValue = func(ctx context.Context) (*ImageData, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().ImageData.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withImageData sets the old ImageData of the mutation.
+func withImageData(node *ImageData) imagedataOption {
+	return func(m *ImageDataMutation) {
+		m.oldValue = func(context.Context) (*ImageData, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m ImageDataMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m ImageDataMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *ImageDataMutation) ID() (id int, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *ImageDataMutation) IDs(ctx context.Context) ([]int, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []int{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().ImageData.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetAvrR sets the "avr_r" field.
+func (m *ImageDataMutation) SetAvrR(i int) {
+	m.avr_r = &i
+	m.addavr_r = nil
+}
+
+// AvrR returns the value of the "avr_r" field in the mutation.
+func (m *ImageDataMutation) AvrR() (r int, exists bool) {
+	v := m.avr_r
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldAvrR returns the old "avr_r" field's value of the ImageData entity.
+// If the ImageData object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ImageDataMutation) OldAvrR(ctx context.Context) (v *int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldAvrR is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldAvrR requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldAvrR: %w", err)
+	}
+	return oldValue.AvrR, nil
+}
+
+// AddAvrR adds i to the "avr_r" field.
+func (m *ImageDataMutation) AddAvrR(i int) {
+	if m.addavr_r != nil {
+		*m.addavr_r += i
+	} else {
+		m.addavr_r = &i
+	}
+}
+
+// AddedAvrR returns the value that was added to the "avr_r" field in this mutation.
+func (m *ImageDataMutation) AddedAvrR() (r int, exists bool) {
+	v := m.addavr_r
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ClearAvrR clears the value of the "avr_r" field.
+func (m *ImageDataMutation) ClearAvrR() {
+	m.avr_r = nil
+	m.addavr_r = nil
+	m.clearedFields[imagedata.FieldAvrR] = struct{}{}
+}
+
+// AvrRCleared returns if the "avr_r" field was cleared in this mutation.
+func (m *ImageDataMutation) AvrRCleared() bool {
+	_, ok := m.clearedFields[imagedata.FieldAvrR]
+	return ok
+}
+
+// ResetAvrR resets all changes to the "avr_r" field.
+func (m *ImageDataMutation) ResetAvrR() {
+	m.avr_r = nil
+	m.addavr_r = nil
+	delete(m.clearedFields, imagedata.FieldAvrR)
+}
+
+// SetAvrG sets the "avr_g" field.
+func (m *ImageDataMutation) SetAvrG(i int) {
+	m.avr_g = &i
+	m.addavr_g = nil
+}
+
+// AvrG returns the value of the "avr_g" field in the mutation.
+func (m *ImageDataMutation) AvrG() (r int, exists bool) {
+	v := m.avr_g
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldAvrG returns the old "avr_g" field's value of the ImageData entity.
+// If the ImageData object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ImageDataMutation) OldAvrG(ctx context.Context) (v *int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldAvrG is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldAvrG requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldAvrG: %w", err)
+	}
+	return oldValue.AvrG, nil
+}
+
+// AddAvrG adds i to the "avr_g" field.
+func (m *ImageDataMutation) AddAvrG(i int) {
+	if m.addavr_g != nil {
+		*m.addavr_g += i
+	} else {
+		m.addavr_g = &i
+	}
+}
+
+// AddedAvrG returns the value that was added to the "avr_g" field in this mutation.
+func (m *ImageDataMutation) AddedAvrG() (r int, exists bool) {
+	v := m.addavr_g
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ClearAvrG clears the value of the "avr_g" field.
+func (m *ImageDataMutation) ClearAvrG() {
+	m.avr_g = nil
+	m.addavr_g = nil
+	m.clearedFields[imagedata.FieldAvrG] = struct{}{}
+}
+
+// AvrGCleared returns if the "avr_g" field was cleared in this mutation.
+func (m *ImageDataMutation) AvrGCleared() bool {
+	_, ok := m.clearedFields[imagedata.FieldAvrG]
+	return ok
+}
+
+// ResetAvrG resets all changes to the "avr_g" field.
+func (m *ImageDataMutation) ResetAvrG() {
+	m.avr_g = nil
+	m.addavr_g = nil
+	delete(m.clearedFields, imagedata.FieldAvrG)
+}
+
+// SetAvrB sets the "avr_b" field.
+func (m *ImageDataMutation) SetAvrB(i int) {
+	m.avr_b = &i
+	m.addavr_b = nil
+}
+
+// AvrB returns the value of the "avr_b" field in the mutation.
+func (m *ImageDataMutation) AvrB() (r int, exists bool) {
+	v := m.avr_b
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldAvrB returns the old "avr_b" field's value of the ImageData entity.
+// If the ImageData object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ImageDataMutation) OldAvrB(ctx context.Context) (v *int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldAvrB is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldAvrB requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldAvrB: %w", err)
+	}
+	return oldValue.AvrB, nil
+}
+
+// AddAvrB adds i to the "avr_b" field.
+func (m *ImageDataMutation) AddAvrB(i int) {
+	if m.addavr_b != nil {
+		*m.addavr_b += i
+	} else {
+		m.addavr_b = &i
+	}
+}
+
+// AddedAvrB returns the value that was added to the "avr_b" field in this mutation.
+func (m *ImageDataMutation) AddedAvrB() (r int, exists bool) {
+	v := m.addavr_b
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ClearAvrB clears the value of the "avr_b" field.
+func (m *ImageDataMutation) ClearAvrB() {
+	m.avr_b = nil
+	m.addavr_b = nil
+	m.clearedFields[imagedata.FieldAvrB] = struct{}{}
+}
+
+// AvrBCleared returns if the "avr_b" field was cleared in this mutation.
+func (m *ImageDataMutation) AvrBCleared() bool {
+	_, ok := m.clearedFields[imagedata.FieldAvrB]
+	return ok
+}
+
+// ResetAvrB resets all changes to the "avr_b" field.
+func (m *ImageDataMutation) ResetAvrB() {
+	m.avr_b = nil
+	m.addavr_b = nil
+	delete(m.clearedFields, imagedata.FieldAvrB)
+}
+
+// SetAvgBrightness sets the "avg_brightness" field.
+func (m *ImageDataMutation) SetAvgBrightness(i int) {
+	m.avg_brightness = &i
+	m.addavg_brightness = nil
+}
+
+// AvgBrightness returns the value of the "avg_brightness" field in the mutation.
+func (m *ImageDataMutation) AvgBrightness() (r int, exists bool) {
+	v := m.avg_brightness
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldAvgBrightness returns the old "avg_brightness" field's value of the ImageData entity.
+// If the ImageData object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ImageDataMutation) OldAvgBrightness(ctx context.Context) (v *int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldAvgBrightness is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldAvgBrightness requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldAvgBrightness: %w", err)
+	}
+	return oldValue.AvgBrightness, nil
+}
+
+// AddAvgBrightness adds i to the "avg_brightness" field.
+func (m *ImageDataMutation) AddAvgBrightness(i int) {
+	if m.addavg_brightness != nil {
+		*m.addavg_brightness += i
+	} else {
+		m.addavg_brightness = &i
+	}
+}
+
+// AddedAvgBrightness returns the value that was added to the "avg_brightness" field in this mutation.
+func (m *ImageDataMutation) AddedAvgBrightness() (r int, exists bool) {
+	v := m.addavg_brightness
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ClearAvgBrightness clears the value of the "avg_brightness" field.
+func (m *ImageDataMutation) ClearAvgBrightness() {
+	m.avg_brightness = nil
+	m.addavg_brightness = nil
+	m.clearedFields[imagedata.FieldAvgBrightness] = struct{}{}
+}
+
+// AvgBrightnessCleared returns if the "avg_brightness" field was cleared in this mutation.
+func (m *ImageDataMutation) AvgBrightnessCleared() bool {
+	_, ok := m.clearedFields[imagedata.FieldAvgBrightness]
+	return ok
+}
+
+// ResetAvgBrightness resets all changes to the "avg_brightness" field.
+func (m *ImageDataMutation) ResetAvgBrightness() {
+	m.avg_brightness = nil
+	m.addavg_brightness = nil
+	delete(m.clearedFields, imagedata.FieldAvgBrightness)
+}
+
+// SetAvgSaturation sets the "avg_saturation" field.
+func (m *ImageDataMutation) SetAvgSaturation(i int) {
+	m.avg_saturation = &i
+	m.addavg_saturation = nil
+}
+
+// AvgSaturation returns the value of the "avg_saturation" field in the mutation.
+func (m *ImageDataMutation) AvgSaturation() (r int, exists bool) {
+	v := m.avg_saturation
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldAvgSaturation returns the old "avg_saturation" field's value of the ImageData entity.
+// If the ImageData object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ImageDataMutation) OldAvgSaturation(ctx context.Context) (v *int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldAvgSaturation is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldAvgSaturation requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldAvgSaturation: %w", err)
+	}
+	return oldValue.AvgSaturation, nil
+}
+
+// AddAvgSaturation adds i to the "avg_saturation" field.
+func (m *ImageDataMutation) AddAvgSaturation(i int) {
+	if m.addavg_saturation != nil {
+		*m.addavg_saturation += i
+	} else {
+		m.addavg_saturation = &i
+	}
+}
+
+// AddedAvgSaturation returns the value that was added to the "avg_saturation" field in this mutation.
+func (m *ImageDataMutation) AddedAvgSaturation() (r int, exists bool) {
+	v := m.addavg_saturation
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ClearAvgSaturation clears the value of the "avg_saturation" field.
+func (m *ImageDataMutation) ClearAvgSaturation() {
+	m.avg_saturation = nil
+	m.addavg_saturation = nil
+	m.clearedFields[imagedata.FieldAvgSaturation] = struct{}{}
+}
+
+// AvgSaturationCleared returns if the "avg_saturation" field was cleared in this mutation.
+func (m *ImageDataMutation) AvgSaturationCleared() bool {
+	_, ok := m.clearedFields[imagedata.FieldAvgSaturation]
+	return ok
+}
+
+// ResetAvgSaturation resets all changes to the "avg_saturation" field.
+func (m *ImageDataMutation) ResetAvgSaturation() {
+	m.avg_saturation = nil
+	m.addavg_saturation = nil
+	delete(m.clearedFields, imagedata.FieldAvgSaturation)
+}
+
+// SetPhash sets the "phash" field.
+func (m *ImageDataMutation) SetPhash(i int64) {
+	m.phash = &i
+	m.addphash = nil
+}
+
+// Phash returns the value of the "phash" field in the mutation.
+func (m *ImageDataMutation) Phash() (r int64, exists bool) {
+	v := m.phash
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldPhash returns the old "phash" field's value of the ImageData entity.
+// If the ImageData object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ImageDataMutation) OldPhash(ctx context.Context) (v *int64, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldPhash is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldPhash requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldPhash: %w", err)
+	}
+	return oldValue.Phash, nil
+}
+
+// AddPhash adds i to the "phash" field.
+func (m *ImageDataMutation) AddPhash(i int64) {
+	if m.addphash != nil {
+		*m.addphash += i
+	} else {
+		m.addphash = &i
+	}
+}
+
+// AddedPhash returns the value that was added to the "phash" field in this mutation.
+func (m *ImageDataMutation) AddedPhash() (r int64, exists bool) {
+	v := m.addphash
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ClearPhash clears the value of the "phash" field.
+func (m *ImageDataMutation) ClearPhash() {
+	m.phash = nil
+	m.addphash = nil
+	m.clearedFields[imagedata.FieldPhash] = struct{}{}
+}
+
+// PhashCleared returns if the "phash" field was cleared in this mutation.
+func (m *ImageDataMutation) PhashCleared() bool {
+	_, ok := m.clearedFields[imagedata.FieldPhash]
+	return ok
+}
+
+// ResetPhash resets all changes to the "phash" field.
+func (m *ImageDataMutation) ResetPhash() {
+	m.phash = nil
+	m.addphash = nil
+	delete(m.clearedFields, imagedata.FieldPhash)
+}
+
+// SetPalette sets the "palette" field.
+func (m *ImageDataMutation) SetPalette(s []string) {
+	m.palette = &s
+	m.appendpalette = nil
+}
+
+// Palette returns the value of the "palette" field in the mutation.
+func (m *ImageDataMutation) Palette() (r []string, exists bool) {
+	v := m.palette
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldPalette returns the old "palette" field's value of the ImageData entity.
+// If the ImageData object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ImageDataMutation) OldPalette(ctx context.Context) (v []string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldPalette is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldPalette requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldPalette: %w", err)
+	}
+	return oldValue.Palette, nil
+}
+
+// AppendPalette adds s to the "palette" field.
+func (m *ImageDataMutation) AppendPalette(s []string) {
+	m.appendpalette = append(m.appendpalette, s...)
+}
+
+// AppendedPalette returns the list of values that were appended to the "palette" field in this mutation.
+func (m *ImageDataMutation) AppendedPalette() ([]string, bool) {
+	if len(m.appendpalette) == 0 {
+		return nil, false
+	}
+	return m.appendpalette, true
+}
+
+// ClearPalette clears the value of the "palette" field.
+func (m *ImageDataMutation) ClearPalette() {
+	m.palette = nil
+	m.appendpalette = nil
+	m.clearedFields[imagedata.FieldPalette] = struct{}{}
+}
+
+// PaletteCleared returns if the "palette" field was cleared in this mutation.
+func (m *ImageDataMutation) PaletteCleared() bool {
+	_, ok := m.clearedFields[imagedata.FieldPalette]
+	return ok
+}
+
+// ResetPalette resets all changes to the "palette" field.
+func (m *ImageDataMutation) ResetPalette() {
+	m.palette = nil
+	m.appendpalette = nil
+	delete(m.clearedFields, imagedata.FieldPalette)
+}
+
+// SetThumbhash sets the "thumbhash" field.
+func (m *ImageDataMutation) SetThumbhash(b []byte) {
+	m.thumbhash = &b
+}
+
+// Thumbhash returns the value of the "thumbhash" field in the mutation.
+func (m *ImageDataMutation) Thumbhash() (r []byte, exists bool) {
+	v := m.thumbhash
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldThumbhash returns the old "thumbhash" field's value of the ImageData entity.
+// If the ImageData object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ImageDataMutation) OldThumbhash(ctx context.Context) (v []byte, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldThumbhash is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldThumbhash requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldThumbhash: %w", err)
+	}
+	return oldValue.Thumbhash, nil
+}
+
+// ClearThumbhash clears the value of the "thumbhash" field.
+func (m *ImageDataMutation) ClearThumbhash() {
+	m.thumbhash = nil
+	m.clearedFields[imagedata.FieldThumbhash] = struct{}{}
+}
+
+// ThumbhashCleared returns if the "thumbhash" field was cleared in this mutation.
+func (m *ImageDataMutation) ThumbhashCleared() bool {
+	_, ok := m.clearedFields[imagedata.FieldThumbhash]
+	return ok
+}
+
+// ResetThumbhash resets all changes to the "thumbhash" field.
+func (m *ImageDataMutation) ResetThumbhash() {
+	m.thumbhash = nil
+	delete(m.clearedFields, imagedata.FieldThumbhash)
+}
+
+// SetPlaceholder sets the "placeholder" field.
+func (m *ImageDataMutation) SetPlaceholder(s string) {
+	m.placeholder = &s
+}
+
+// Placeholder returns the value of the "placeholder" field in the mutation.
+func (m *ImageDataMutation) Placeholder() (r string, exists bool) {
+	v := m.placeholder
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldPlaceholder returns the old "placeholder" field's value of the ImageData entity.
+// If the ImageData object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ImageDataMutation) OldPlaceholder(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldPlaceholder is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldPlaceholder requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldPlaceholder: %w", err)
+	}
+	return oldValue.Placeholder, nil
+}
+
+// ClearPlaceholder clears the value of the "placeholder" field.
+func (m *ImageDataMutation) ClearPlaceholder() {
+	m.placeholder = nil
+	m.clearedFields[imagedata.FieldPlaceholder] = struct{}{}
+}
+
+// PlaceholderCleared returns if the "placeholder" field was cleared in this mutation.
+func (m *ImageDataMutation) PlaceholderCleared() bool {
+	_, ok := m.clearedFields[imagedata.FieldPlaceholder]
+	return ok
+}
+
+// ResetPlaceholder resets all changes to the "placeholder" field.
+func (m *ImageDataMutation) ResetPlaceholder() {
+	m.placeholder = nil
+	delete(m.clearedFields, imagedata.FieldPlaceholder)
+}
+
+// SetPlaceholderColor sets the "placeholder_color" field.
+func (m *ImageDataMutation) SetPlaceholderColor(s string) {
+	m.placeholder_color = &s
+}
+
+// PlaceholderColor returns the value of the "placeholder_color" field in the mutation.
+func (m *ImageDataMutation) PlaceholderColor() (r string, exists bool) {
+	v := m.placeholder_color
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldPlaceholderColor returns the old "placeholder_color" field's value of the ImageData entity.
+// If the ImageData object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ImageDataMutation) OldPlaceholderColor(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldPlaceholderColor is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldPlaceholderColor requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldPlaceholderColor: %w", err)
+	}
+	return oldValue.PlaceholderColor, nil
+}
+
+// ClearPlaceholderColor clears the value of the "placeholder_color" field.
+func (m *ImageDataMutation) ClearPlaceholderColor() {
+	m.placeholder_color = nil
+	m.clearedFields[imagedata.FieldPlaceholderColor] = struct{}{}
+}
+
+// PlaceholderColorCleared returns if the "placeholder_color" field was cleared in this mutation.
+func (m *ImageDataMutation) PlaceholderColorCleared() bool {
+	_, ok := m.clearedFields[imagedata.FieldPlaceholderColor]
+	return ok
+}
+
+// ResetPlaceholderColor resets all changes to the "placeholder_color" field.
+func (m *ImageDataMutation) ResetPlaceholderColor() {
+	m.placeholder_color = nil
+	delete(m.clearedFields, imagedata.FieldPlaceholderColor)
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (m *ImageDataMutation) SetCreatedAt(t time.Time) {
+	m.created_at = &t
+}
+
+// CreatedAt returns the value of the "created_at" field in the mutation.
+func (m *ImageDataMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m.created_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCreatedAt returns the old "created_at" field's value of the ImageData entity.
+// If the ImageData object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ImageDataMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+	}
+	return oldValue.CreatedAt, nil
+}
+
+// ResetCreatedAt resets all changes to the "created_at" field.
+func (m *ImageDataMutation) ResetCreatedAt() {
+	m.created_at = nil
+}
+
+// AddImageIDs adds the "image" edge to the Image entity by ids.
+func (m *ImageDataMutation) AddImageIDs(ids ...pid.ID) {
+	if m.image == nil {
+		m.image = make(map[pid.ID]struct{})
+	}
+	for i := range ids {
+		m.image[ids[i]] = struct{}{}
+	}
+}
+
+// ClearImage clears the "image" edge to the Image entity.
+func (m *ImageDataMutation) ClearImage() {
+	m.clearedimage = true
+}
+
+// ImageCleared reports if the "image" edge to the Image entity was cleared.
+func (m *ImageDataMutation) ImageCleared() bool {
+	return m.clearedimage
+}
+
+// RemoveImageIDs removes the "image" edge to the Image entity by IDs.
+func (m *ImageDataMutation) RemoveImageIDs(ids ...pid.ID) {
+	if m.removedimage == nil {
+		m.removedimage = make(map[pid.ID]struct{})
+	}
+	for i := range ids {
+		delete(m.image, ids[i])
+		m.removedimage[ids[i]] = struct{}{}
+	}
+}
+
+// RemovedImage returns the removed IDs of the "image" edge to the Image entity.
+func (m *ImageDataMutation) RemovedImageIDs() (ids []pid.ID) {
+	for id := range m.removedimage {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ImageIDs returns the "image" edge IDs in the mutation.
+func (m *ImageDataMutation) ImageIDs() (ids []pid.ID) {
+	for id := range m.image {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ResetImage resets all changes to the "image" edge.
+func (m *ImageDataMutation) ResetImage() {
+	m.image = nil
+	m.clearedimage = false
+	m.removedimage = nil
+}
+
+// Where appends a list predicates to the ImageDataMutation builder.
+func (m *ImageDataMutation) Where(ps ...predicate.ImageData) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the ImageDataMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *ImageDataMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.ImageData, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
+}
+
+// Op returns the operation name.
+func (m *ImageDataMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *ImageDataMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (ImageData).
+func (m *ImageDataMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *ImageDataMutation) Fields() []string {
+	fields := make([]string, 0, 11)
+	if m.avr_r != nil {
+		fields = append(fields, imagedata.FieldAvrR)
+	}
+	if m.avr_g != nil {
+		fields = append(fields, imagedata.FieldAvrG)
+	}
+	if m.avr_b != nil {
+		fields = append(fields, imagedata.FieldAvrB)
+	}
+	if m.avg_brightness != nil {
+		fields = append(fields, imagedata.FieldAvgBrightness)
+	}
+	if m.avg_saturation != nil {
+		fields = append(fields, imagedata.FieldAvgSaturation)
+	}
+	if m.phash != nil {
+		fields = append(fields, imagedata.FieldPhash)
+	}
+	if m.palette != nil {
+		fields = append(fields, imagedata.FieldPalette)
+	}
+	if m.thumbhash != nil {
+		fields = append(fields, imagedata.FieldThumbhash)
+	}
+	if m.placeholder != nil {
+		fields = append(fields, imagedata.FieldPlaceholder)
+	}
+	if m.placeholder_color != nil {
+		fields = append(fields, imagedata.FieldPlaceholderColor)
+	}
+	if m.created_at != nil {
+		fields = append(fields, imagedata.FieldCreatedAt)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *ImageDataMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case imagedata.FieldAvrR:
+		return m.AvrR()
+	case imagedata.FieldAvrG:
+		return m.AvrG()
+	case imagedata.FieldAvrB:
+		return m.AvrB()
+	case imagedata.FieldAvgBrightness:
+		return m.AvgBrightness()
+	case imagedata.FieldAvgSaturation:
+		return m.AvgSaturation()
+	case imagedata.FieldPhash:
+		return m.Phash()
+	case imagedata.FieldPalette:
+		return m.Palette()
+	case imagedata.FieldThumbhash:
+		return m.Thumbhash()
+	case imagedata.FieldPlaceholder:
+		return m.Placeholder()
+	case imagedata.FieldPlaceholderColor:
+		return m.PlaceholderColor()
+	case imagedata.FieldCreatedAt:
+		return m.CreatedAt()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *ImageDataMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case imagedata.FieldAvrR:
+		return m.OldAvrR(ctx)
+	case imagedata.FieldAvrG:
+		return m.OldAvrG(ctx)
+	case imagedata.FieldAvrB:
+		return m.OldAvrB(ctx)
+	case imagedata.FieldAvgBrightness:
+		return m.OldAvgBrightness(ctx)
+	case imagedata.FieldAvgSaturation:
+		return m.OldAvgSaturation(ctx)
+	case imagedata.FieldPhash:
+		return m.OldPhash(ctx)
+	case imagedata.FieldPalette:
+		return m.OldPalette(ctx)
+	case imagedata.FieldThumbhash:
+		return m.OldThumbhash(ctx)
+	case imagedata.FieldPlaceholder:
+		return m.OldPlaceholder(ctx)
+	case imagedata.FieldPlaceholderColor:
+		return m.OldPlaceholderColor(ctx)
+	case imagedata.FieldCreatedAt:
+		return m.OldCreatedAt(ctx)
+	}
+	return nil, fmt.Errorf("unknown ImageData field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *ImageDataMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case imagedata.FieldAvrR:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetAvrR(v)
+		return nil
+	case imagedata.FieldAvrG:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetAvrG(v)
+		return nil
+	case imagedata.FieldAvrB:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetAvrB(v)
+		return nil
+	case imagedata.FieldAvgBrightness:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetAvgBrightness(v)
+		return nil
+	case imagedata.FieldAvgSaturation:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetAvgSaturation(v)
+		return nil
+	case imagedata.FieldPhash:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetPhash(v)
+		return nil
+	case imagedata.FieldPalette:
+		v, ok := value.([]string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetPalette(v)
+		return nil
+	case imagedata.FieldThumbhash:
+		v, ok := value.([]byte)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetThumbhash(v)
+		return nil
+	case imagedata.FieldPlaceholder:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetPlaceholder(v)
+		return nil
+	case imagedata.FieldPlaceholderColor:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetPlaceholderColor(v)
+		return nil
+	case imagedata.FieldCreatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCreatedAt(v)
+		return nil
+	}
+	return fmt.Errorf("unknown ImageData field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *ImageDataMutation) AddedFields() []string {
+	var fields []string
+	if m.addavr_r != nil {
+		fields = append(fields, imagedata.FieldAvrR)
+	}
+	if m.addavr_g != nil {
+		fields = append(fields, imagedata.FieldAvrG)
+	}
+	if m.addavr_b != nil {
+		fields = append(fields, imagedata.FieldAvrB)
+	}
+	if m.addavg_brightness != nil {
+		fields = append(fields, imagedata.FieldAvgBrightness)
+	}
+	if m.addavg_saturation != nil {
+		fields = append(fields, imagedata.FieldAvgSaturation)
+	}
+	if m.addphash != nil {
+		fields = append(fields, imagedata.FieldPhash)
+	}
+	return fields
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *ImageDataMutation) AddedField(name string) (ent.Value, bool) {
+	switch name {
+	case imagedata.FieldAvrR:
+		return m.AddedAvrR()
+	case imagedata.FieldAvrG:
+		return m.AddedAvrG()
+	case imagedata.FieldAvrB:
+		return m.AddedAvrB()
+	case imagedata.FieldAvgBrightness:
+		return m.AddedAvgBrightness()
+	case imagedata.FieldAvgSaturation:
+		return m.AddedAvgSaturation()
+	case imagedata.FieldPhash:
+		return m.AddedPhash()
+	}
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *ImageDataMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	case imagedata.FieldAvrR:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddAvrR(v)
+		return nil
+	case imagedata.FieldAvrG:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddAvrG(v)
+		return nil
+	case imagedata.FieldAvrB:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddAvrB(v)
+		return nil
+	case imagedata.FieldAvgBrightness:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddAvgBrightness(v)
+		return nil
+	case imagedata.FieldAvgSaturation:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddAvgSaturation(v)
+		return nil
+	case imagedata.FieldPhash:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddPhash(v)
+		return nil
+	}
+	return fmt.Errorf("unknown ImageData numeric field %s", name)
 }
 
-// DeletedAtCleared returns if the "deleted_at" field was cleared in this mutation.
-func (m *ImageMutation) DeletedAtCleared() bool {
-	_, ok := m.clearedFields[image.FieldDeletedAt]
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *ImageDataMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(imagedata.FieldAvrR) {
+		fields = append(fields, imagedata.FieldAvrR)
+	}
+	if m.FieldCleared(imagedata.FieldAvrG) {
+		fields = append(fields, imagedata.FieldAvrG)
+	}
+	if m.FieldCleared(imagedata.FieldAvrB) {
+		fields = append(fields, imagedata.FieldAvrB)
+	}
+	if m.FieldCleared(imagedata.FieldAvgBrightness) {
+		fields = append(fields, imagedata.FieldAvgBrightness)
+	}
+	if m.FieldCleared(imagedata.FieldAvgSaturation) {
+		fields = append(fields, imagedata.FieldAvgSaturation)
+	}
+	if m.FieldCleared(imagedata.FieldPhash) {
+		fields = append(fields, imagedata.FieldPhash)
+	}
+	if m.FieldCleared(imagedata.FieldPalette) {
+		fields = append(fields, imagedata.FieldPalette)
+	}
+	if m.FieldCleared(imagedata.FieldThumbhash) {
+		fields = append(fields, imagedata.FieldThumbhash)
+	}
+	if m.FieldCleared(imagedata.FieldPlaceholder) {
+		fields = append(fields, imagedata.FieldPlaceholder)
+	}
+	if m.FieldCleared(imagedata.FieldPlaceholderColor) {
+		fields = append(fields, imagedata.FieldPlaceholderColor)
+	}
+	return fields
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *ImageDataMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
 	return ok
 }
 
-// ResetDeletedAt resets all changes to the "deleted_at" field.
-func (m *ImageMutation) ResetDeletedAt() {
-	m.deleted_at = nil
-	delete(m.clearedFields, image.FieldDeletedAt)
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *ImageDataMutation) ClearField(name string) error {
+	switch name {
+	case imagedata.FieldAvrR:
+		m.ClearAvrR()
+		return nil
+	case imagedata.FieldAvrG:
+		m.ClearAvrG()
+		return nil
+	case imagedata.FieldAvrB:
+		m.ClearAvrB()
+		return nil
+	case imagedata.FieldAvgBrightness:
+		m.ClearAvgBrightness()
+		return nil
+	case imagedata.FieldAvgSaturation:
+		m.ClearAvgSaturation()
+		return nil
+	case imagedata.FieldPhash:
+		m.ClearPhash()
+		return nil
+	case imagedata.FieldPalette:
+		m.ClearPalette()
+		return nil
+	case imagedata.FieldThumbhash:
+		m.ClearThumbhash()
+		return nil
+	case imagedata.FieldPlaceholder:
+		m.ClearPlaceholder()
+		return nil
+	case imagedata.FieldPlaceholderColor:
+		m.ClearPlaceholderColor()
+		return nil
+	}
+	return fmt.Errorf("unknown ImageData nullable field %s", name)
 }
 
-// SetReleaseID sets the "release" edge to the Release entity by id.
-func (m *ImageMutation) SetReleaseID(id pid.ID) {
-	m.release = &id
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *ImageDataMutation) ResetField(name string) error {
+	switch name {
+	case imagedata.FieldAvrR:
+		m.ResetAvrR()
+		return nil
+	case imagedata.FieldAvrG:
+		m.ResetAvrG()
+		return nil
+	case imagedata.FieldAvrB:
+		m.ResetAvrB()
+		return nil
+	case imagedata.FieldAvgBrightness:
+		m.ResetAvgBrightness()
+		return nil
+	case imagedata.FieldAvgSaturation:
+		m.ResetAvgSaturation()
+		return nil
+	case imagedata.FieldPhash:
+		m.ResetPhash()
+		return nil
+	case imagedata.FieldPalette:
+		m.ResetPalette()
+		return nil
+	case imagedata.FieldThumbhash:
+		m.ResetThumbhash()
+		return nil
+	case imagedata.FieldPlaceholder:
+		m.ResetPlaceholder()
+		return nil
+	case imagedata.FieldPlaceholderColor:
+		m.ResetPlaceholderColor()
+		return nil
+	case imagedata.FieldCreatedAt:
+		m.ResetCreatedAt()
+		return nil
+	}
+	return fmt.Errorf("unknown ImageData field %s", name)
 }
 
-// ClearRelease clears the "release" edge to the Release entity.
-func (m *ImageMutation) ClearRelease() {
-	m.clearedrelease = true
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *ImageDataMutation) AddedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.image != nil {
+		edges = append(edges, imagedata.EdgeImage)
+	}
+	return edges
 }
 
-// ReleaseCleared reports if the "release" edge to the Release entity was cleared.
-func (m *ImageMutation) ReleaseCleared() bool {
-	return m.clearedrelease
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *ImageDataMutation) AddedIDs(name string) []ent.Value {
+	switch name {
+	case imagedata.EdgeImage:
+		ids := make([]ent.Value, 0, len(m.image))
+		for id := range m.image {
+			ids = append(ids, id)
+		}
+		return ids
+	}
+	return nil
 }
 
-// ReleaseID returns the "release" edge ID in the mutation.
-func (m *ImageMutation) ReleaseID() (id pid.ID, exists bool) {
-	if m.release != nil {
-		return *m.release, true
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *ImageDataMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.removedimage != nil {
+		edges = append(edges, imagedata.EdgeImage)
+	}
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *ImageDataMutation) RemovedIDs(name string) []ent.Value {
+	switch name {
+	case imagedata.EdgeImage:
+		ids := make([]ent.Value, 0, len(m.removedimage))
+		for id := range m.removedimage {
+			ids = append(ids, id)
+		}
+		return ids
+	}
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *ImageDataMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.clearedimage {
+		edges = append(edges, imagedata.EdgeImage)
+	}
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *ImageDataMutation) EdgeCleared(name string) bool {
+	switch name {
+	case imagedata.EdgeImage:
+		return m.clearedimage
+	}
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *ImageDataMutation) ClearEdge(name string) error {
+	switch name {
+	}
+	return fmt.Errorf("unknown ImageData unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *ImageDataMutation) ResetEdge(name string) error {
+	switch name {
+	case imagedata.EdgeImage:
+		m.ResetImage()
+		return nil
+	}
+	return fmt.Errorf("unknown ImageData edge %s", name)
+}
+
+// LockoutMutation represents an operation that mutates the Lockout nodes in the graph.
+type LockoutMutation struct {
+	config
+	op              Op
+	typ             string
+	id              *pid.ID
+	key             *string
+	failures        *int
+	addfailures     *int
+	last_failure_at *time.Time
+	locked_until    *time.Time
+	clearedFields   map[string]struct{}
+	done            bool
+	oldValue        func(context.Context) (*Lockout, error)
+	predicates      []predicate.Lockout
+}
+
+var _ ent.Mutation = (*LockoutMutation)(nil)
+
+// lockoutOption allows management of the mutation configuration using functional options.
+type lockoutOption func(*LockoutMutation)
+
+// newLockoutMutation creates new mutation for the Lockout entity.
+func newLockoutMutation(c config, op Op, opts ...lockoutOption) *LockoutMutation {
+	m := &LockoutMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeLockout,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withLockoutID sets the ID field of the mutation.
+func withLockoutID(id pid.ID) lockoutOption {
+	return func(m *LockoutMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *Lockout
+		)
+		m.oldValue = func(ctx context.Context) (*Lockout, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().Lockout.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
 	}
-	return
 }
 
-// ReleaseIDs returns the "release" edge IDs in the mutation.
-// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
-// ReleaseID instead. It exists only for internal usage by the builders.
-func (m *ImageMutation) ReleaseIDs() (ids []pid.ID) {
-	if id := m.release; id != nil {
-		ids = append(ids, *id)
+// withLockout sets the old Lockout of the mutation.
+func withLockout(node *Lockout) lockoutOption {
+	return func(m *LockoutMutation) {
+		m.oldValue = func(context.Context) (*Lockout, error) {
+			return node, nil
+		}
+		m.id = &node.ID
 	}
-	return
 }
 
-// ResetRelease resets all changes to the "release" edge.
-func (m *ImageMutation) ResetRelease() {
-	m.release = nil
-	m.clearedrelease = false
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m LockoutMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
 }
 
-// SetArtistID sets the "artist" edge to the Artist entity by id.
-func (m *ImageMutation) SetArtistID(id pid.ID) {
-	m.artist = &id
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m LockoutMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
 }
 
-// ClearArtist clears the "artist" edge to the Artist entity.
-func (m *ImageMutation) ClearArtist() {
-	m.clearedartist = true
+// SetID sets the value of the id field. Note that this
+// operation is only accepted on creation of Lockout entities.
+func (m *LockoutMutation) SetID(id pid.ID) {
+	m.id = &id
 }
 
-// ArtistCleared reports if the "artist" edge to the Artist entity was cleared.
-func (m *ImageMutation) ArtistCleared() bool {
-	return m.clearedartist
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *LockoutMutation) ID() (id pid.ID, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
 }
 
-// ArtistID returns the "artist" edge ID in the mutation.
-func (m *ImageMutation) ArtistID() (id pid.ID, exists bool) {
-	if m.artist != nil {
-		return *m.artist, true
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *LockoutMutation) IDs(ctx context.Context) ([]pid.ID, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []pid.ID{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().Lockout.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
 	}
-	return
 }
 
-// ArtistIDs returns the "artist" edge IDs in the mutation.
-// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
-// ArtistID instead. It exists only for internal usage by the builders.
-func (m *ImageMutation) ArtistIDs() (ids []pid.ID) {
-	if id := m.artist; id != nil {
-		ids = append(ids, *id)
-	}
-	return
+// SetKey sets the "key" field.
+func (m *LockoutMutation) SetKey(s string) {
+	m.key = &s
 }
 
-// ResetArtist resets all changes to the "artist" edge.
-func (m *ImageMutation) ResetArtist() {
-	m.artist = nil
-	m.clearedartist = false
+// Key returns the value of the "key" field in the mutation.
+func (m *LockoutMutation) Key() (r string, exists bool) {
+	v := m.key
+	if v == nil {
+		return
+	}
+	return *v, true
 }
 
-// SetUploaderID sets the "uploader" edge to the User entity by id.
-func (m *ImageMutation) SetUploaderID(id pid.ID) {
-	m.uploader = &id
+// OldKey returns the old "key" field's value of the Lockout entity.
+// If the Lockout object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *LockoutMutation) OldKey(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldKey is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldKey requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldKey: %w", err)
+	}
+	return oldValue.Key, nil
 }
 
-// ClearUploader clears the "uploader" edge to the User entity.
-func (m *ImageMutation) ClearUploader() {
-	m.cleareduploader = true
+// ResetKey resets all changes to the "key" field.
+func (m *LockoutMutation) ResetKey() {
+	m.key = nil
 }
 
-// UploaderCleared reports if the "uploader" edge to the User entity was cleared.
-func (m *ImageMutation) UploaderCleared() bool {
-	return m.cleareduploader
+// SetFailures sets the "failures" field.
+func (m *LockoutMutation) SetFailures(i int) {
+	m.failures = &i
+	m.addfailures = nil
 }
 
-// UploaderID returns the "uploader" edge ID in the mutation.
-func (m *ImageMutation) UploaderID() (id pid.ID, exists bool) {
-	if m.uploader != nil {
-		return *m.uploader, true
+// Failures returns the value of the "failures" field in the mutation.
+func (m *LockoutMutation) Failures() (r int, exists bool) {
+	v := m.failures
+	if v == nil {
+		return
 	}
-	return
+	return *v, true
 }
 
-// UploaderIDs returns the "uploader" edge IDs in the mutation.
-// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
-// UploaderID instead. It exists only for internal usage by the builders.
-func (m *ImageMutation) UploaderIDs() (ids []pid.ID) {
-	if id := m.uploader; id != nil {
-		ids = append(ids, *id)
+// OldFailures returns the old "failures" field's value of the Lockout entity.
+// If the Lockout object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *LockoutMutation) OldFailures(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldFailures is only allowed on UpdateOne operations")
 	}
-	return
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldFailures requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldFailures: %w", err)
+	}
+	return oldValue.Failures, nil
 }
 
-// ResetUploader resets all changes to the "uploader" edge.
-func (m *ImageMutation) ResetUploader() {
-	m.uploader = nil
-	m.cleareduploader = false
+// AddFailures adds i to the "failures" field.
+func (m *LockoutMutation) AddFailures(i int) {
+	if m.addfailures != nil {
+		*m.addfailures += i
+	} else {
+		m.addfailures = &i
+	}
 }
 
-// AddProccesedImageIDs adds the "proccesed_image" edge to the ProcessedImage entity by ids.
-func (m *ImageMutation) AddProccesedImageIDs(ids ...pid.ID) {
-	if m.proccesed_image == nil {
-		m.proccesed_image = make(map[pid.ID]struct{})
-	}
-	for i := range ids {
-		m.proccesed_image[ids[i]] = struct{}{}
+// AddedFailures returns the value that was added to the "failures" field in this mutation.
+func (m *LockoutMutation) AddedFailures() (r int, exists bool) {
+	v := m.addfailures
+	if v == nil {
+		return
 	}
+	return *v, true
 }
 
-// ClearProccesedImage clears the "proccesed_image" edge to the ProcessedImage entity.
-func (m *ImageMutation) ClearProccesedImage() {
-	m.clearedproccesed_image = true
+// ResetFailures resets all changes to the "failures" field.
+func (m *LockoutMutation) ResetFailures() {
+	m.failures = nil
+	m.addfailures = nil
 }
 
-// ProccesedImageCleared reports if the "proccesed_image" edge to the ProcessedImage entity was cleared.
-func (m *ImageMutation) ProccesedImageCleared() bool {
-	return m.clearedproccesed_image
+// SetLastFailureAt sets the "last_failure_at" field.
+func (m *LockoutMutation) SetLastFailureAt(t time.Time) {
+	m.last_failure_at = &t
 }
 
-// RemoveProccesedImageIDs removes the "proccesed_image" edge to the ProcessedImage entity by IDs.
-func (m *ImageMutation) RemoveProccesedImageIDs(ids ...pid.ID) {
-	if m.removedproccesed_image == nil {
-		m.removedproccesed_image = make(map[pid.ID]struct{})
-	}
-	for i := range ids {
-		delete(m.proccesed_image, ids[i])
-		m.removedproccesed_image[ids[i]] = struct{}{}
+// LastFailureAt returns the value of the "last_failure_at" field in the mutation.
+func (m *LockoutMutation) LastFailureAt() (r time.Time, exists bool) {
+	v := m.last_failure_at
+	if v == nil {
+		return
 	}
+	return *v, true
 }
 
-// RemovedProccesedImage returns the removed IDs of the "proccesed_image" edge to the ProcessedImage entity.
-func (m *ImageMutation) RemovedProccesedImageIDs() (ids []pid.ID) {
-	for id := range m.removedproccesed_image {
-		ids = append(ids, id)
+// OldLastFailureAt returns the old "last_failure_at" field's value of the Lockout entity.
+// If the Lockout object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *LockoutMutation) OldLastFailureAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldLastFailureAt is only allowed on UpdateOne operations")
 	}
-	return
-}
-
-// ProccesedImageIDs returns the "proccesed_image" edge IDs in the mutation.
-func (m *ImageMutation) ProccesedImageIDs() (ids []pid.ID) {
-	for id := range m.proccesed_image {
-		ids = append(ids, id)
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldLastFailureAt requires an ID field in the mutation")
 	}
-	return
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldLastFailureAt: %w", err)
+	}
+	return oldValue.LastFailureAt, nil
 }
 
-// ResetProccesedImage resets all changes to the "proccesed_image" edge.
-func (m *ImageMutation) ResetProccesedImage() {
-	m.proccesed_image = nil
-	m.clearedproccesed_image = false
-	m.removedproccesed_image = nil
+// ResetLastFailureAt resets all changes to the "last_failure_at" field.
+func (m *LockoutMutation) ResetLastFailureAt() {
+	m.last_failure_at = nil
 }
 
-// SetDataID sets the "data" edge to the ImageData entity by id.
-func (m *ImageMutation) SetDataID(id int) {
-	m.data = &id
+// SetLockedUntil sets the "locked_until" field.
+func (m *LockoutMutation) SetLockedUntil(t time.Time) {
+	m.locked_until = &t
 }
 
-// ClearData clears the "data" edge to the ImageData entity.
-func (m *ImageMutation) ClearData() {
-	m.cleareddata = true
+// LockedUntil returns the value of the "locked_until" field in the mutation.
+func (m *LockoutMutation) LockedUntil() (r time.Time, exists bool) {
+	v := m.locked_until
+	if v == nil {
+		return
+	}
+	return *v, true
 }
 
-// DataCleared reports if the "data" edge to the ImageData entity was cleared.
-func (m *ImageMutation) DataCleared() bool {
-	return m.cleareddata
+// OldLockedUntil returns the old "locked_until" field's value of the Lockout entity.
+// If the Lockout object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *LockoutMutation) OldLockedUntil(ctx context.Context) (v *time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldLockedUntil is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldLockedUntil requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldLockedUntil: %w", err)
+	}
+	return oldValue.LockedUntil, nil
 }
 
-// DataID returns the "data" edge ID in the mutation.
-func (m *ImageMutation) DataID() (id int, exists bool) {
-	if m.data != nil {
-		return *m.data, true
-	}
-	return
+// ClearLockedUntil clears the value of the "locked_until" field.
+func (m *LockoutMutation) ClearLockedUntil() {
+	m.locked_until = nil
+	m.clearedFields[lockout.FieldLockedUntil] = struct{}{}
 }
 
-// DataIDs returns the "data" edge IDs in the mutation.
-// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
-// DataID instead. It exists only for internal usage by the builders.
-func (m *ImageMutation) DataIDs() (ids []int) {
-	if id := m.data; id != nil {
-		ids = append(ids, *id)
-	}
-	return
+// LockedUntilCleared returns if the "locked_until" field was cleared in this mutation.
+func (m *LockoutMutation) LockedUntilCleared() bool {
+	_, ok := m.clearedFields[lockout.FieldLockedUntil]
+	return ok
 }
 
-// ResetData resets all changes to the "data" edge.
-func (m *ImageMutation) ResetData() {
-	m.data = nil
-	m.cleareddata = false
+// ResetLockedUntil resets all changes to the "locked_until" field.
+func (m *LockoutMutation) ResetLockedUntil() {
+	m.locked_until = nil
+	delete(m.clearedFields, lockout.FieldLockedUntil)
 }
 
-// Where appends a list predicates to the ImageMutation builder.
-func (m *ImageMutation) Where(ps ...predicate.Image) {
+// Where appends a list predicates to the LockoutMutation builder.
+func (m *LockoutMutation) Where(ps ...predicate.Lockout) {
 	m.predicates = append(m.predicates, ps...)
 }
 
-// WhereP appends storage-level predicates to the ImageMutation builder. Using this method,
+// WhereP appends storage-level predicates to the LockoutMutation builder. Using this method,
 // users can use type-assertion to append predicates that do not depend on any generated package.
-func (m *ImageMutation) WhereP(ps ...func(*sql.Selector)) {
-	p := make([]predicate.Image, len(ps))
+func (m *LockoutMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.Lockout, len(ps))
 	for i := range ps {
 		p[i] = ps[i]
 	}
@@ -1610,54 +4583,36 @@ func (m *ImageMutation) WhereP(ps ...func(*sql.Selector)) {
 }
 
 // Op returns the operation name.
-func (m *ImageMutation) Op() Op {
+func (m *LockoutMutation) Op() Op {
 	return m.op
 }
 
 // SetOp allows setting the mutation operation.
-func (m *ImageMutation) SetOp(op Op) {
+func (m *LockoutMutation) SetOp(op Op) {
 	m.op = op
 }
 
-// Type returns the node type of this mutation (Image).
-func (m *ImageMutation) Type() string {
+// Type returns the node type of this mutation (Lockout).
+func (m *LockoutMutation) Type() string {
 	return m.typ
 }
 
 // Fields returns all fields that were changed during this mutation. Note that in
 // order to get all numeric fields that were incremented/decremented, call
-// AddedFields().
-func (m *ImageMutation) Fields() []string {
-	fields := make([]string, 0, 10)
-	if m.file != nil {
-		fields = append(fields, image.FieldFile)
-	}
-	if m.original_name != nil {
-		fields = append(fields, image.FieldOriginalName)
-	}
-	if m._type != nil {
-		fields = append(fields, image.FieldType)
-	}
-	if m.note != nil {
-		fields = append(fields, image.FieldNote)
-	}
-	if m.dimention_width != nil {
-		fields = append(fields, image.FieldDimentionWidth)
-	}
-	if m.dimention_height != nil {
-		fields = append(fields, image.FieldDimentionHeight)
-	}
-	if m.size_bits != nil {
-		fields = append(fields, image.FieldSizeBits)
+// AddedFields().
+func (m *LockoutMutation) Fields() []string {
+	fields := make([]string, 0, 4)
+	if m.key != nil {
+		fields = append(fields, lockout.FieldKey)
 	}
-	if m.created_at != nil {
-		fields = append(fields, image.FieldCreatedAt)
+	if m.failures != nil {
+		fields = append(fields, lockout.FieldFailures)
 	}
-	if m.updated_at != nil {
-		fields = append(fields, image.FieldUpdatedAt)
+	if m.last_failure_at != nil {
+		fields = append(fields, lockout.FieldLastFailureAt)
 	}
-	if m.deleted_at != nil {
-		fields = append(fields, image.FieldDeletedAt)
+	if m.locked_until != nil {
+		fields = append(fields, lockout.FieldLockedUntil)
 	}
 	return fields
 }
@@ -1665,28 +4620,16 @@ func (m *ImageMutation) Fields() []string {
 // Field returns the value of a field with the given name. The second boolean
 // return value indicates that this field was not set, or was not defined in the
 // schema.
-func (m *ImageMutation) Field(name string) (ent.Value, bool) {
+func (m *LockoutMutation) Field(name string) (ent.Value, bool) {
 	switch name {
-	case image.FieldFile:
-		return m.File()
-	case image.FieldOriginalName:
-		return m.OriginalName()
-	case image.FieldType:
-		return m.GetType()
-	case image.FieldNote:
-		return m.Note()
-	case image.FieldDimentionWidth:
-		return m.DimentionWidth()
-	case image.FieldDimentionHeight:
-		return m.DimentionHeight()
-	case image.FieldSizeBits:
-		return m.SizeBits()
-	case image.FieldCreatedAt:
-		return m.CreatedAt()
-	case image.FieldUpdatedAt:
-		return m.UpdatedAt()
-	case image.FieldDeletedAt:
-		return m.DeletedAt()
+	case lockout.FieldKey:
+		return m.Key()
+	case lockout.FieldFailures:
+		return m.Failures()
+	case lockout.FieldLastFailureAt:
+		return m.LastFailureAt()
+	case lockout.FieldLockedUntil:
+		return m.LockedUntil()
 	}
 	return nil, false
 }
@@ -1694,123 +4637,63 @@ func (m *ImageMutation) Field(name string) (ent.Value, bool) {
 // OldField returns the old value of the field from the database. An error is
 // returned if the mutation operation is not UpdateOne, or the query to the
 // database failed.
-func (m *ImageMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+func (m *LockoutMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
 	switch name {
-	case image.FieldFile:
-		return m.OldFile(ctx)
-	case image.FieldOriginalName:
-		return m.OldOriginalName(ctx)
-	case image.FieldType:
-		return m.OldType(ctx)
-	case image.FieldNote:
-		return m.OldNote(ctx)
-	case image.FieldDimentionWidth:
-		return m.OldDimentionWidth(ctx)
-	case image.FieldDimentionHeight:
-		return m.OldDimentionHeight(ctx)
-	case image.FieldSizeBits:
-		return m.OldSizeBits(ctx)
-	case image.FieldCreatedAt:
-		return m.OldCreatedAt(ctx)
-	case image.FieldUpdatedAt:
-		return m.OldUpdatedAt(ctx)
-	case image.FieldDeletedAt:
-		return m.OldDeletedAt(ctx)
+	case lockout.FieldKey:
+		return m.OldKey(ctx)
+	case lockout.FieldFailures:
+		return m.OldFailures(ctx)
+	case lockout.FieldLastFailureAt:
+		return m.OldLastFailureAt(ctx)
+	case lockout.FieldLockedUntil:
+		return m.OldLockedUntil(ctx)
 	}
-	return nil, fmt.Errorf("unknown Image field %s", name)
+	return nil, fmt.Errorf("unknown Lockout field %s", name)
 }
 
 // SetField sets the value of a field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *ImageMutation) SetField(name string, value ent.Value) error {
+func (m *LockoutMutation) SetField(name string, value ent.Value) error {
 	switch name {
-	case image.FieldFile:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetFile(v)
-		return nil
-	case image.FieldOriginalName:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetOriginalName(v)
-		return nil
-	case image.FieldType:
-		v, ok := value.(image.Type)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetType(v)
-		return nil
-	case image.FieldNote:
+	case lockout.FieldKey:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetNote(v)
-		return nil
-	case image.FieldDimentionWidth:
-		v, ok := value.(int)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetDimentionWidth(v)
+		m.SetKey(v)
 		return nil
-	case image.FieldDimentionHeight:
+	case lockout.FieldFailures:
 		v, ok := value.(int)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetDimentionHeight(v)
-		return nil
-	case image.FieldSizeBits:
-		v, ok := value.(uint32)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetSizeBits(v)
-		return nil
-	case image.FieldCreatedAt:
-		v, ok := value.(time.Time)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetCreatedAt(v)
+		m.SetFailures(v)
 		return nil
-	case image.FieldUpdatedAt:
+	case lockout.FieldLastFailureAt:
 		v, ok := value.(time.Time)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetUpdatedAt(v)
+		m.SetLastFailureAt(v)
 		return nil
-	case image.FieldDeletedAt:
+	case lockout.FieldLockedUntil:
 		v, ok := value.(time.Time)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetDeletedAt(v)
+		m.SetLockedUntil(v)
 		return nil
 	}
-	return fmt.Errorf("unknown Image field %s", name)
+	return fmt.Errorf("unknown Lockout field %s", name)
 }
 
 // AddedFields returns all numeric fields that were incremented/decremented during
 // this mutation.
-func (m *ImageMutation) AddedFields() []string {
+func (m *LockoutMutation) AddedFields() []string {
 	var fields []string
-	if m.adddimention_width != nil {
-		fields = append(fields, image.FieldDimentionWidth)
-	}
-	if m.adddimention_height != nil {
-		fields = append(fields, image.FieldDimentionHeight)
-	}
-	if m.addsize_bits != nil {
-		fields = append(fields, image.FieldSizeBits)
+	if m.addfailures != nil {
+		fields = append(fields, lockout.FieldFailures)
 	}
 	return fields
 }
@@ -1818,14 +4701,10 @@ func (m *ImageMutation) AddedFields() []string {
 // AddedField returns the numeric value that was incremented/decremented on a field
 // with the given name. The second boolean return value indicates that this field
 // was not set, or was not defined in the schema.
-func (m *ImageMutation) AddedField(name string) (ent.Value, bool) {
+func (m *LockoutMutation) AddedField(name string) (ent.Value, bool) {
 	switch name {
-	case image.FieldDimentionWidth:
-		return m.AddedDimentionWidth()
-	case image.FieldDimentionHeight:
-		return m.AddedDimentionHeight()
-	case image.FieldSizeBits:
-		return m.AddedSizeBits()
+	case lockout.FieldFailures:
+		return m.AddedFailures()
 	}
 	return nil, false
 }
@@ -1833,298 +4712,152 @@ func (m *ImageMutation) AddedField(name string) (ent.Value, bool) {
 // AddField adds the value to the field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *ImageMutation) AddField(name string, value ent.Value) error {
+func (m *LockoutMutation) AddField(name string, value ent.Value) error {
 	switch name {
-	case image.FieldDimentionWidth:
-		v, ok := value.(int)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.AddDimentionWidth(v)
-		return nil
-	case image.FieldDimentionHeight:
+	case lockout.FieldFailures:
 		v, ok := value.(int)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.AddDimentionHeight(v)
-		return nil
-	case image.FieldSizeBits:
-		v, ok := value.(int32)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.AddSizeBits(v)
+		m.AddFailures(v)
 		return nil
 	}
-	return fmt.Errorf("unknown Image numeric field %s", name)
+	return fmt.Errorf("unknown Lockout numeric field %s", name)
 }
 
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
-func (m *ImageMutation) ClearedFields() []string {
+func (m *LockoutMutation) ClearedFields() []string {
 	var fields []string
-	if m.FieldCleared(image.FieldNote) {
-		fields = append(fields, image.FieldNote)
-	}
-	if m.FieldCleared(image.FieldDeletedAt) {
-		fields = append(fields, image.FieldDeletedAt)
+	if m.FieldCleared(lockout.FieldLockedUntil) {
+		fields = append(fields, lockout.FieldLockedUntil)
 	}
 	return fields
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
 // cleared in this mutation.
-func (m *ImageMutation) FieldCleared(name string) bool {
+func (m *LockoutMutation) FieldCleared(name string) bool {
 	_, ok := m.clearedFields[name]
 	return ok
 }
 
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
-func (m *ImageMutation) ClearField(name string) error {
+func (m *LockoutMutation) ClearField(name string) error {
 	switch name {
-	case image.FieldNote:
-		m.ClearNote()
-		return nil
-	case image.FieldDeletedAt:
-		m.ClearDeletedAt()
+	case lockout.FieldLockedUntil:
+		m.ClearLockedUntil()
 		return nil
 	}
-	return fmt.Errorf("unknown Image nullable field %s", name)
+	return fmt.Errorf("unknown Lockout nullable field %s", name)
 }
 
 // ResetField resets all changes in the mutation for the field with the given name.
 // It returns an error if the field is not defined in the schema.
-func (m *ImageMutation) ResetField(name string) error {
+func (m *LockoutMutation) ResetField(name string) error {
 	switch name {
-	case image.FieldFile:
-		m.ResetFile()
-		return nil
-	case image.FieldOriginalName:
-		m.ResetOriginalName()
-		return nil
-	case image.FieldType:
-		m.ResetType()
+	case lockout.FieldKey:
+		m.ResetKey()
 		return nil
-	case image.FieldNote:
-		m.ResetNote()
-		return nil
-	case image.FieldDimentionWidth:
-		m.ResetDimentionWidth()
-		return nil
-	case image.FieldDimentionHeight:
-		m.ResetDimentionHeight()
+	case lockout.FieldFailures:
+		m.ResetFailures()
 		return nil
-	case image.FieldSizeBits:
-		m.ResetSizeBits()
-		return nil
-	case image.FieldCreatedAt:
-		m.ResetCreatedAt()
-		return nil
-	case image.FieldUpdatedAt:
-		m.ResetUpdatedAt()
+	case lockout.FieldLastFailureAt:
+		m.ResetLastFailureAt()
 		return nil
-	case image.FieldDeletedAt:
-		m.ResetDeletedAt()
+	case lockout.FieldLockedUntil:
+		m.ResetLockedUntil()
 		return nil
 	}
-	return fmt.Errorf("unknown Image field %s", name)
+	return fmt.Errorf("unknown Lockout field %s", name)
 }
 
 // AddedEdges returns all edge names that were set/added in this mutation.
-func (m *ImageMutation) AddedEdges() []string {
-	edges := make([]string, 0, 5)
-	if m.release != nil {
-		edges = append(edges, image.EdgeRelease)
-	}
-	if m.artist != nil {
-		edges = append(edges, image.EdgeArtist)
-	}
-	if m.uploader != nil {
-		edges = append(edges, image.EdgeUploader)
-	}
-	if m.proccesed_image != nil {
-		edges = append(edges, image.EdgeProccesedImage)
-	}
-	if m.data != nil {
-		edges = append(edges, image.EdgeData)
-	}
+func (m *LockoutMutation) AddedEdges() []string {
+	edges := make([]string, 0, 0)
 	return edges
 }
 
 // AddedIDs returns all IDs (to other nodes) that were added for the given edge
 // name in this mutation.
-func (m *ImageMutation) AddedIDs(name string) []ent.Value {
-	switch name {
-	case image.EdgeRelease:
-		if id := m.release; id != nil {
-			return []ent.Value{*id}
-		}
-	case image.EdgeArtist:
-		if id := m.artist; id != nil {
-			return []ent.Value{*id}
-		}
-	case image.EdgeUploader:
-		if id := m.uploader; id != nil {
-			return []ent.Value{*id}
-		}
-	case image.EdgeProccesedImage:
-		ids := make([]ent.Value, 0, len(m.proccesed_image))
-		for id := range m.proccesed_image {
-			ids = append(ids, id)
-		}
-		return ids
-	case image.EdgeData:
-		if id := m.data; id != nil {
-			return []ent.Value{*id}
-		}
-	}
+func (m *LockoutMutation) AddedIDs(name string) []ent.Value {
 	return nil
 }
 
 // RemovedEdges returns all edge names that were removed in this mutation.
-func (m *ImageMutation) RemovedEdges() []string {
-	edges := make([]string, 0, 5)
-	if m.removedproccesed_image != nil {
-		edges = append(edges, image.EdgeProccesedImage)
-	}
+func (m *LockoutMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 0)
 	return edges
 }
 
 // RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
 // the given name in this mutation.
-func (m *ImageMutation) RemovedIDs(name string) []ent.Value {
-	switch name {
-	case image.EdgeProccesedImage:
-		ids := make([]ent.Value, 0, len(m.removedproccesed_image))
-		for id := range m.removedproccesed_image {
-			ids = append(ids, id)
-		}
-		return ids
-	}
+func (m *LockoutMutation) RemovedIDs(name string) []ent.Value {
 	return nil
 }
-
-// ClearedEdges returns all edge names that were cleared in this mutation.
-func (m *ImageMutation) ClearedEdges() []string {
-	edges := make([]string, 0, 5)
-	if m.clearedrelease {
-		edges = append(edges, image.EdgeRelease)
-	}
-	if m.clearedartist {
-		edges = append(edges, image.EdgeArtist)
-	}
-	if m.cleareduploader {
-		edges = append(edges, image.EdgeUploader)
-	}
-	if m.clearedproccesed_image {
-		edges = append(edges, image.EdgeProccesedImage)
-	}
-	if m.cleareddata {
-		edges = append(edges, image.EdgeData)
-	}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *LockoutMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 0)
 	return edges
 }
 
 // EdgeCleared returns a boolean which indicates if the edge with the given name
 // was cleared in this mutation.
-func (m *ImageMutation) EdgeCleared(name string) bool {
-	switch name {
-	case image.EdgeRelease:
-		return m.clearedrelease
-	case image.EdgeArtist:
-		return m.clearedartist
-	case image.EdgeUploader:
-		return m.cleareduploader
-	case image.EdgeProccesedImage:
-		return m.clearedproccesed_image
-	case image.EdgeData:
-		return m.cleareddata
-	}
+func (m *LockoutMutation) EdgeCleared(name string) bool {
 	return false
 }
 
 // ClearEdge clears the value of the edge with the given name. It returns an error
 // if that edge is not defined in the schema.
-func (m *ImageMutation) ClearEdge(name string) error {
-	switch name {
-	case image.EdgeRelease:
-		m.ClearRelease()
-		return nil
-	case image.EdgeArtist:
-		m.ClearArtist()
-		return nil
-	case image.EdgeUploader:
-		m.ClearUploader()
-		return nil
-	case image.EdgeData:
-		m.ClearData()
-		return nil
-	}
-	return fmt.Errorf("unknown Image unique edge %s", name)
+func (m *LockoutMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown Lockout unique edge %s", name)
 }
 
 // ResetEdge resets all changes to the edge with the given name in this mutation.
 // It returns an error if the edge is not defined in the schema.
-func (m *ImageMutation) ResetEdge(name string) error {
-	switch name {
-	case image.EdgeRelease:
-		m.ResetRelease()
-		return nil
-	case image.EdgeArtist:
-		m.ResetArtist()
-		return nil
-	case image.EdgeUploader:
-		m.ResetUploader()
-		return nil
-	case image.EdgeProccesedImage:
-		m.ResetProccesedImage()
-		return nil
-	case image.EdgeData:
-		m.ResetData()
-		return nil
-	}
-	return fmt.Errorf("unknown Image edge %s", name)
+func (m *LockoutMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown Lockout edge %s", name)
 }
 
-// ImageDataMutation represents an operation that mutates the ImageData nodes in the graph.
-type ImageDataMutation struct {
+// ProcessedImageMutation represents an operation that mutates the ProcessedImage nodes in the graph.
+type ProcessedImageMutation struct {
 	config
-	op                Op
-	typ               string
-	id                *int
-	avr_r             *int
-	addavr_r          *int
-	avr_g             *int
-	addavr_g          *int
-	avr_b             *int
-	addavr_b          *int
-	avg_brightness    *int
-	addavg_brightness *int
-	avg_saturation    *int
-	addavg_saturation *int
-	created_at        *time.Time
-	clearedFields     map[string]struct{}
-	image             map[pid.ID]struct{}
-	removedimage      map[pid.ID]struct{}
-	clearedimage      bool
-	done              bool
-	oldValue          func(context.Context) (*ImageData, error)
-	predicates        []predicate.ImageData
+	op            Op
+	typ           string
+	id            *pid.ID
+	deleted_at    *time.Time
+	file          *string
+	variant       *string
+	_type         *processedimage.Type
+	width         *int
+	addwidth      *int
+	height        *int
+	addheight     *int
+	size_bits     *uint32
+	addsize_bits  *int32
+	created_at    *time.Time
+	updated_at    *time.Time
+	clearedFields map[string]struct{}
+	source        *pid.ID
+	clearedsource bool
+	done          bool
+	oldValue      func(context.Context) (*ProcessedImage, error)
+	predicates    []predicate.ProcessedImage
 }
 
-var _ ent.Mutation = (*ImageDataMutation)(nil)
+var _ ent.Mutation = (*ProcessedImageMutation)(nil)
 
-// imagedataOption allows management of the mutation configuration using functional options.
-type imagedataOption func(*ImageDataMutation)
+// processedimageOption allows management of the mutation configuration using functional options.
+type processedimageOption func(*ProcessedImageMutation)
 
-// newImageDataMutation creates new mutation for the ImageData entity.
-func newImageDataMutation(c config, op Op, opts ...imagedataOption) *ImageDataMutation {
-	m := &ImageDataMutation{
+// newProcessedImageMutation creates new mutation for the ProcessedImage entity.
+func newProcessedImageMutation(c config, op Op, opts ...processedimageOption) *ProcessedImageMutation {
+	m := &ProcessedImageMutation{
 		config:        c,
 		op:            op,
-		typ:           TypeImageData,
+		typ:           TypeProcessedImage,
 		clearedFields: make(map[string]struct{}),
 	}
 	for _, opt := range opts {
@@ -2133,20 +4866,20 @@ func newImageDataMutation(c config, op Op, opts ...imagedataOption) *ImageDataMu
 	return m
 }
 
-// withImageDataID sets the ID field of the mutation.
-func withImageDataID(id int) imagedataOption {
-	return func(m *ImageDataMutation) {
+// withProcessedImageID sets the ID field of the mutation.
+func withProcessedImageID(id pid.ID) processedimageOption {
+	return func(m *ProcessedImageMutation) {
 		var (
 			err   error
 			once  sync.Once
-			value *ImageData
+			value *ProcessedImage
 		)
-		m.oldValue = func(ctx context.Context) (*ImageData, error) {
+		m.oldValue = func(ctx context.Context) (*ProcessedImage, error) {
 			once.Do(func() {
 				if m.done {
 					err = errors.New("querying old values post mutation is not allowed")
 				} else {
-					value, err = m.Client().ImageData.Get(ctx, id)
+					value, err = m.Client().ProcessedImage.Get(ctx, id)
 				}
 			})
 			return value, err
@@ -2155,10 +4888,10 @@ func withImageDataID(id int) imagedataOption {
 	}
 }
 
-// withImageData sets the old ImageData of the mutation.
-func withImageData(node *ImageData) imagedataOption {
-	return func(m *ImageDataMutation) {
-		m.oldValue = func(context.Context) (*ImageData, error) {
+// withProcessedImage sets the old ProcessedImage of the mutation.
+func withProcessedImage(node *ProcessedImage) processedimageOption {
+	return func(m *ProcessedImageMutation) {
+		m.oldValue = func(context.Context) (*ProcessedImage, error) {
 			return node, nil
 		}
 		m.id = &node.ID
@@ -2167,7 +4900,7 @@ func withImageData(node *ImageData) imagedataOption {
 
 // Client returns a new `ent.Client` from the mutation. If the mutation was
 // executed in a transaction (ent.Tx), a transactional client is returned.
-func (m ImageDataMutation) Client() *Client {
+func (m ProcessedImageMutation) Client() *Client {
 	client := &Client{config: m.config}
 	client.init()
 	return client
@@ -2175,7 +4908,7 @@ func (m ImageDataMutation) Client() *Client {
 
 // Tx returns an `ent.Tx` for mutations that were executed in transactions;
 // it returns an error otherwise.
-func (m ImageDataMutation) Tx() (*Tx, error) {
+func (m ProcessedImageMutation) Tx() (*Tx, error) {
 	if _, ok := m.driver.(*txDriver); !ok {
 		return nil, errors.New("ent: mutation is not running in a transaction")
 	}
@@ -2184,9 +4917,15 @@ func (m ImageDataMutation) Tx() (*Tx, error) {
 	return tx, nil
 }
 
+// SetID sets the value of the id field. Note that this
+// operation is only accepted on creation of ProcessedImage entities.
+func (m *ProcessedImageMutation) SetID(id pid.ID) {
+	m.id = &id
+}
+
 // ID returns the ID value in the mutation. Note that the ID is only available
 // if it was provided to the builder or after it was returned from the database.
-func (m *ImageDataMutation) ID() (id int, exists bool) {
+func (m *ProcessedImageMutation) ID() (id pid.ID, exists bool) {
 	if m.id == nil {
 		return
 	}
@@ -2197,308 +4936,379 @@ func (m *ImageDataMutation) ID() (id int, exists bool) {
 // That means, if the mutation is applied within a transaction with an isolation level such
 // as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
 // or updated by the mutation.
-func (m *ImageDataMutation) IDs(ctx context.Context) ([]int, error) {
+func (m *ProcessedImageMutation) IDs(ctx context.Context) ([]pid.ID, error) {
 	switch {
 	case m.op.Is(OpUpdateOne | OpDeleteOne):
 		id, exists := m.ID()
 		if exists {
-			return []int{id}, nil
+			return []pid.ID{id}, nil
 		}
 		fallthrough
 	case m.op.Is(OpUpdate | OpDelete):
-		return m.Client().ImageData.Query().Where(m.predicates...).IDs(ctx)
+		return m.Client().ProcessedImage.Query().Where(m.predicates...).IDs(ctx)
 	default:
 		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
 	}
 }
 
-// SetAvrR sets the "avr_r" field.
-func (m *ImageDataMutation) SetAvrR(i int) {
-	m.avr_r = &i
-	m.addavr_r = nil
+// SetDeletedAt sets the "deleted_at" field.
+func (m *ProcessedImageMutation) SetDeletedAt(t time.Time) {
+	m.deleted_at = &t
 }
 
-// AvrR returns the value of the "avr_r" field in the mutation.
-func (m *ImageDataMutation) AvrR() (r int, exists bool) {
-	v := m.avr_r
+// DeletedAt returns the value of the "deleted_at" field in the mutation.
+func (m *ProcessedImageMutation) DeletedAt() (r time.Time, exists bool) {
+	v := m.deleted_at
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldAvrR returns the old "avr_r" field's value of the ImageData entity.
-// If the ImageData object wasn't provided to the builder, the object is fetched from the database.
+// OldDeletedAt returns the old "deleted_at" field's value of the ProcessedImage entity.
+// If the ProcessedImage object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ImageDataMutation) OldAvrR(ctx context.Context) (v int, err error) {
+func (m *ProcessedImageMutation) OldDeletedAt(ctx context.Context) (v *time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldAvrR is only allowed on UpdateOne operations")
+		return v, errors.New("OldDeletedAt is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldAvrR requires an ID field in the mutation")
+		return v, errors.New("OldDeletedAt requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldAvrR: %w", err)
+		return v, fmt.Errorf("querying old value for OldDeletedAt: %w", err)
 	}
-	return oldValue.AvrR, nil
+	return oldValue.DeletedAt, nil
 }
 
-// AddAvrR adds i to the "avr_r" field.
-func (m *ImageDataMutation) AddAvrR(i int) {
-	if m.addavr_r != nil {
-		*m.addavr_r += i
-	} else {
-		m.addavr_r = &i
+// ClearDeletedAt clears the value of the "deleted_at" field.
+func (m *ProcessedImageMutation) ClearDeletedAt() {
+	m.deleted_at = nil
+	m.clearedFields[processedimage.FieldDeletedAt] = struct{}{}
+}
+
+// DeletedAtCleared returns if the "deleted_at" field was cleared in this mutation.
+func (m *ProcessedImageMutation) DeletedAtCleared() bool {
+	_, ok := m.clearedFields[processedimage.FieldDeletedAt]
+	return ok
+}
+
+// ResetDeletedAt resets all changes to the "deleted_at" field.
+func (m *ProcessedImageMutation) ResetDeletedAt() {
+	m.deleted_at = nil
+	delete(m.clearedFields, processedimage.FieldDeletedAt)
+}
+
+// SetFile sets the "file" field.
+func (m *ProcessedImageMutation) SetFile(s string) {
+	m.file = &s
+}
+
+// File returns the value of the "file" field in the mutation.
+func (m *ProcessedImageMutation) File() (r string, exists bool) {
+	v := m.file
+	if v == nil {
+		return
 	}
+	return *v, true
 }
 
-// AddedAvrR returns the value that was added to the "avr_r" field in this mutation.
-func (m *ImageDataMutation) AddedAvrR() (r int, exists bool) {
-	v := m.addavr_r
+// OldFile returns the old "file" field's value of the ProcessedImage entity.
+// If the ProcessedImage object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ProcessedImageMutation) OldFile(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldFile is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldFile requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldFile: %w", err)
+	}
+	return oldValue.File, nil
+}
+
+// ClearFile clears the value of the "file" field.
+func (m *ProcessedImageMutation) ClearFile() {
+	m.file = nil
+	m.clearedFields[processedimage.FieldFile] = struct{}{}
+}
+
+// FileCleared returns if the "file" field was cleared in this mutation.
+func (m *ProcessedImageMutation) FileCleared() bool {
+	_, ok := m.clearedFields[processedimage.FieldFile]
+	return ok
+}
+
+// ResetFile resets all changes to the "file" field.
+func (m *ProcessedImageMutation) ResetFile() {
+	m.file = nil
+	delete(m.clearedFields, processedimage.FieldFile)
+}
+
+// SetVariant sets the "variant" field.
+func (m *ProcessedImageMutation) SetVariant(s string) {
+	m.variant = &s
+}
+
+// Variant returns the value of the "variant" field in the mutation.
+func (m *ProcessedImageMutation) Variant() (r string, exists bool) {
+	v := m.variant
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// ResetAvrR resets all changes to the "avr_r" field.
-func (m *ImageDataMutation) ResetAvrR() {
-	m.avr_r = nil
-	m.addavr_r = nil
+// OldVariant returns the old "variant" field's value of the ProcessedImage entity.
+// If the ProcessedImage object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ProcessedImageMutation) OldVariant(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldVariant is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldVariant requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldVariant: %w", err)
+	}
+	return oldValue.Variant, nil
 }
 
-// SetAvrG sets the "avr_g" field.
-func (m *ImageDataMutation) SetAvrG(i int) {
-	m.avr_g = &i
-	m.addavr_g = nil
+// ClearVariant clears the value of the "variant" field.
+func (m *ProcessedImageMutation) ClearVariant() {
+	m.variant = nil
+	m.clearedFields[processedimage.FieldVariant] = struct{}{}
+}
+
+// VariantCleared returns if the "variant" field was cleared in this mutation.
+func (m *ProcessedImageMutation) VariantCleared() bool {
+	_, ok := m.clearedFields[processedimage.FieldVariant]
+	return ok
+}
+
+// ResetVariant resets all changes to the "variant" field.
+func (m *ProcessedImageMutation) ResetVariant() {
+	m.variant = nil
+	delete(m.clearedFields, processedimage.FieldVariant)
+}
+
+// SetType sets the "type" field.
+func (m *ProcessedImageMutation) SetType(pr processedimage.Type) {
+	m._type = &pr
 }
 
-// AvrG returns the value of the "avr_g" field in the mutation.
-func (m *ImageDataMutation) AvrG() (r int, exists bool) {
-	v := m.avr_g
+// GetType returns the value of the "type" field in the mutation.
+func (m *ProcessedImageMutation) GetType() (r processedimage.Type, exists bool) {
+	v := m._type
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldAvrG returns the old "avr_g" field's value of the ImageData entity.
-// If the ImageData object wasn't provided to the builder, the object is fetched from the database.
+// OldType returns the old "type" field's value of the ProcessedImage entity.
+// If the ProcessedImage object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ImageDataMutation) OldAvrG(ctx context.Context) (v int, err error) {
+func (m *ProcessedImageMutation) OldType(ctx context.Context) (v processedimage.Type, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldAvrG is only allowed on UpdateOne operations")
+		return v, errors.New("OldType is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldAvrG requires an ID field in the mutation")
+		return v, errors.New("OldType requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldAvrG: %w", err)
-	}
-	return oldValue.AvrG, nil
-}
-
-// AddAvrG adds i to the "avr_g" field.
-func (m *ImageDataMutation) AddAvrG(i int) {
-	if m.addavr_g != nil {
-		*m.addavr_g += i
-	} else {
-		m.addavr_g = &i
-	}
-}
-
-// AddedAvrG returns the value that was added to the "avr_g" field in this mutation.
-func (m *ImageDataMutation) AddedAvrG() (r int, exists bool) {
-	v := m.addavr_g
-	if v == nil {
-		return
+		return v, fmt.Errorf("querying old value for OldType: %w", err)
 	}
-	return *v, true
+	return oldValue.Type, nil
 }
 
-// ResetAvrG resets all changes to the "avr_g" field.
-func (m *ImageDataMutation) ResetAvrG() {
-	m.avr_g = nil
-	m.addavr_g = nil
+// ResetType resets all changes to the "type" field.
+func (m *ProcessedImageMutation) ResetType() {
+	m._type = nil
 }
 
-// SetAvrB sets the "avr_b" field.
-func (m *ImageDataMutation) SetAvrB(i int) {
-	m.avr_b = &i
-	m.addavr_b = nil
+// SetWidth sets the "width" field.
+func (m *ProcessedImageMutation) SetWidth(i int) {
+	m.width = &i
+	m.addwidth = nil
 }
 
-// AvrB returns the value of the "avr_b" field in the mutation.
-func (m *ImageDataMutation) AvrB() (r int, exists bool) {
-	v := m.avr_b
+// Width returns the value of the "width" field in the mutation.
+func (m *ProcessedImageMutation) Width() (r int, exists bool) {
+	v := m.width
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldAvrB returns the old "avr_b" field's value of the ImageData entity.
-// If the ImageData object wasn't provided to the builder, the object is fetched from the database.
+// OldWidth returns the old "width" field's value of the ProcessedImage entity.
+// If the ProcessedImage object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ImageDataMutation) OldAvrB(ctx context.Context) (v int, err error) {
+func (m *ProcessedImageMutation) OldWidth(ctx context.Context) (v int, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldAvrB is only allowed on UpdateOne operations")
+		return v, errors.New("OldWidth is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldAvrB requires an ID field in the mutation")
+		return v, errors.New("OldWidth requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldAvrB: %w", err)
+		return v, fmt.Errorf("querying old value for OldWidth: %w", err)
 	}
-	return oldValue.AvrB, nil
+	return oldValue.Width, nil
 }
 
-// AddAvrB adds i to the "avr_b" field.
-func (m *ImageDataMutation) AddAvrB(i int) {
-	if m.addavr_b != nil {
-		*m.addavr_b += i
+// AddWidth adds i to the "width" field.
+func (m *ProcessedImageMutation) AddWidth(i int) {
+	if m.addwidth != nil {
+		*m.addwidth += i
 	} else {
-		m.addavr_b = &i
+		m.addwidth = &i
 	}
 }
 
-// AddedAvrB returns the value that was added to the "avr_b" field in this mutation.
-func (m *ImageDataMutation) AddedAvrB() (r int, exists bool) {
-	v := m.addavr_b
+// AddedWidth returns the value that was added to the "width" field in this mutation.
+func (m *ProcessedImageMutation) AddedWidth() (r int, exists bool) {
+	v := m.addwidth
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// ResetAvrB resets all changes to the "avr_b" field.
-func (m *ImageDataMutation) ResetAvrB() {
-	m.avr_b = nil
-	m.addavr_b = nil
+// ResetWidth resets all changes to the "width" field.
+func (m *ProcessedImageMutation) ResetWidth() {
+	m.width = nil
+	m.addwidth = nil
 }
 
-// SetAvgBrightness sets the "avg_brightness" field.
-func (m *ImageDataMutation) SetAvgBrightness(i int) {
-	m.avg_brightness = &i
-	m.addavg_brightness = nil
+// SetHeight sets the "height" field.
+func (m *ProcessedImageMutation) SetHeight(i int) {
+	m.height = &i
+	m.addheight = nil
 }
 
-// AvgBrightness returns the value of the "avg_brightness" field in the mutation.
-func (m *ImageDataMutation) AvgBrightness() (r int, exists bool) {
-	v := m.avg_brightness
+// Height returns the value of the "height" field in the mutation.
+func (m *ProcessedImageMutation) Height() (r int, exists bool) {
+	v := m.height
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldAvgBrightness returns the old "avg_brightness" field's value of the ImageData entity.
-// If the ImageData object wasn't provided to the builder, the object is fetched from the database.
+// OldHeight returns the old "height" field's value of the ProcessedImage entity.
+// If the ProcessedImage object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ImageDataMutation) OldAvgBrightness(ctx context.Context) (v int, err error) {
+func (m *ProcessedImageMutation) OldHeight(ctx context.Context) (v int, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldAvgBrightness is only allowed on UpdateOne operations")
+		return v, errors.New("OldHeight is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldAvgBrightness requires an ID field in the mutation")
+		return v, errors.New("OldHeight requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldAvgBrightness: %w", err)
+		return v, fmt.Errorf("querying old value for OldHeight: %w", err)
 	}
-	return oldValue.AvgBrightness, nil
+	return oldValue.Height, nil
 }
 
-// AddAvgBrightness adds i to the "avg_brightness" field.
-func (m *ImageDataMutation) AddAvgBrightness(i int) {
-	if m.addavg_brightness != nil {
-		*m.addavg_brightness += i
+// AddHeight adds i to the "height" field.
+func (m *ProcessedImageMutation) AddHeight(i int) {
+	if m.addheight != nil {
+		*m.addheight += i
 	} else {
-		m.addavg_brightness = &i
+		m.addheight = &i
 	}
 }
 
-// AddedAvgBrightness returns the value that was added to the "avg_brightness" field in this mutation.
-func (m *ImageDataMutation) AddedAvgBrightness() (r int, exists bool) {
-	v := m.addavg_brightness
+// AddedHeight returns the value that was added to the "height" field in this mutation.
+func (m *ProcessedImageMutation) AddedHeight() (r int, exists bool) {
+	v := m.addheight
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// ResetAvgBrightness resets all changes to the "avg_brightness" field.
-func (m *ImageDataMutation) ResetAvgBrightness() {
-	m.avg_brightness = nil
-	m.addavg_brightness = nil
+// ResetHeight resets all changes to the "height" field.
+func (m *ProcessedImageMutation) ResetHeight() {
+	m.height = nil
+	m.addheight = nil
 }
 
-// SetAvgSaturation sets the "avg_saturation" field.
-func (m *ImageDataMutation) SetAvgSaturation(i int) {
-	m.avg_saturation = &i
-	m.addavg_saturation = nil
+// SetSizeBits sets the "size_bits" field.
+func (m *ProcessedImageMutation) SetSizeBits(u uint32) {
+	m.size_bits = &u
+	m.addsize_bits = nil
 }
 
-// AvgSaturation returns the value of the "avg_saturation" field in the mutation.
-func (m *ImageDataMutation) AvgSaturation() (r int, exists bool) {
-	v := m.avg_saturation
+// SizeBits returns the value of the "size_bits" field in the mutation.
+func (m *ProcessedImageMutation) SizeBits() (r uint32, exists bool) {
+	v := m.size_bits
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldAvgSaturation returns the old "avg_saturation" field's value of the ImageData entity.
-// If the ImageData object wasn't provided to the builder, the object is fetched from the database.
+// OldSizeBits returns the old "size_bits" field's value of the ProcessedImage entity.
+// If the ProcessedImage object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ImageDataMutation) OldAvgSaturation(ctx context.Context) (v int, err error) {
+func (m *ProcessedImageMutation) OldSizeBits(ctx context.Context) (v uint32, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldAvgSaturation is only allowed on UpdateOne operations")
+		return v, errors.New("OldSizeBits is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldAvgSaturation requires an ID field in the mutation")
+		return v, errors.New("OldSizeBits requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldAvgSaturation: %w", err)
+		return v, fmt.Errorf("querying old value for OldSizeBits: %w", err)
 	}
-	return oldValue.AvgSaturation, nil
+	return oldValue.SizeBits, nil
 }
 
-// AddAvgSaturation adds i to the "avg_saturation" field.
-func (m *ImageDataMutation) AddAvgSaturation(i int) {
-	if m.addavg_saturation != nil {
-		*m.addavg_saturation += i
+// AddSizeBits adds u to the "size_bits" field.
+func (m *ProcessedImageMutation) AddSizeBits(u int32) {
+	if m.addsize_bits != nil {
+		*m.addsize_bits += u
 	} else {
-		m.addavg_saturation = &i
+		m.addsize_bits = &u
 	}
 }
 
-// AddedAvgSaturation returns the value that was added to the "avg_saturation" field in this mutation.
-func (m *ImageDataMutation) AddedAvgSaturation() (r int, exists bool) {
-	v := m.addavg_saturation
+// AddedSizeBits returns the value that was added to the "size_bits" field in this mutation.
+func (m *ProcessedImageMutation) AddedSizeBits() (r int32, exists bool) {
+	v := m.addsize_bits
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// ResetAvgSaturation resets all changes to the "avg_saturation" field.
-func (m *ImageDataMutation) ResetAvgSaturation() {
-	m.avg_saturation = nil
-	m.addavg_saturation = nil
+// ResetSizeBits resets all changes to the "size_bits" field.
+func (m *ProcessedImageMutation) ResetSizeBits() {
+	m.size_bits = nil
+	m.addsize_bits = nil
 }
 
 // SetCreatedAt sets the "created_at" field.
-func (m *ImageDataMutation) SetCreatedAt(t time.Time) {
+func (m *ProcessedImageMutation) SetCreatedAt(t time.Time) {
 	m.created_at = &t
 }
 
 // CreatedAt returns the value of the "created_at" field in the mutation.
-func (m *ImageDataMutation) CreatedAt() (r time.Time, exists bool) {
+func (m *ProcessedImageMutation) CreatedAt() (r time.Time, exists bool) {
 	v := m.created_at
 	if v == nil {
 		return
@@ -2506,10 +5316,10 @@ func (m *ImageDataMutation) CreatedAt() (r time.Time, exists bool) {
 	return *v, true
 }
 
-// OldCreatedAt returns the old "created_at" field's value of the ImageData entity.
-// If the ImageData object wasn't provided to the builder, the object is fetched from the database.
+// OldCreatedAt returns the old "created_at" field's value of the ProcessedImage entity.
+// If the ProcessedImage object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ImageDataMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+func (m *ProcessedImageMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
 		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
 	}
@@ -2524,73 +5334,94 @@ func (m *ImageDataMutation) OldCreatedAt(ctx context.Context) (v time.Time, err
 }
 
 // ResetCreatedAt resets all changes to the "created_at" field.
-func (m *ImageDataMutation) ResetCreatedAt() {
+func (m *ProcessedImageMutation) ResetCreatedAt() {
 	m.created_at = nil
 }
 
-// AddImageIDs adds the "image" edge to the Image entity by ids.
-func (m *ImageDataMutation) AddImageIDs(ids ...pid.ID) {
-	if m.image == nil {
-		m.image = make(map[pid.ID]struct{})
+// SetUpdatedAt sets the "updated_at" field.
+func (m *ProcessedImageMutation) SetUpdatedAt(t time.Time) {
+	m.updated_at = &t
+}
+
+// UpdatedAt returns the value of the "updated_at" field in the mutation.
+func (m *ProcessedImageMutation) UpdatedAt() (r time.Time, exists bool) {
+	v := m.updated_at
+	if v == nil {
+		return
 	}
-	for i := range ids {
-		m.image[ids[i]] = struct{}{}
+	return *v, true
+}
+
+// OldUpdatedAt returns the old "updated_at" field's value of the ProcessedImage entity.
+// If the ProcessedImage object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ProcessedImageMutation) OldUpdatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldUpdatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldUpdatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldUpdatedAt: %w", err)
 	}
+	return oldValue.UpdatedAt, nil
 }
 
-// ClearImage clears the "image" edge to the Image entity.
-func (m *ImageDataMutation) ClearImage() {
-	m.clearedimage = true
+// ResetUpdatedAt resets all changes to the "updated_at" field.
+func (m *ProcessedImageMutation) ResetUpdatedAt() {
+	m.updated_at = nil
 }
 
-// ImageCleared reports if the "image" edge to the Image entity was cleared.
-func (m *ImageDataMutation) ImageCleared() bool {
-	return m.clearedimage
+// SetSourceID sets the "source" edge to the Image entity by id.
+func (m *ProcessedImageMutation) SetSourceID(id pid.ID) {
+	m.source = &id
 }
 
-// RemoveImageIDs removes the "image" edge to the Image entity by IDs.
-func (m *ImageDataMutation) RemoveImageIDs(ids ...pid.ID) {
-	if m.removedimage == nil {
-		m.removedimage = make(map[pid.ID]struct{})
-	}
-	for i := range ids {
-		delete(m.image, ids[i])
-		m.removedimage[ids[i]] = struct{}{}
-	}
+// ClearSource clears the "source" edge to the Image entity.
+func (m *ProcessedImageMutation) ClearSource() {
+	m.clearedsource = true
 }
 
-// RemovedImage returns the removed IDs of the "image" edge to the Image entity.
-func (m *ImageDataMutation) RemovedImageIDs() (ids []pid.ID) {
-	for id := range m.removedimage {
-		ids = append(ids, id)
+// SourceCleared reports if the "source" edge to the Image entity was cleared.
+func (m *ProcessedImageMutation) SourceCleared() bool {
+	return m.clearedsource
+}
+
+// SourceID returns the "source" edge ID in the mutation.
+func (m *ProcessedImageMutation) SourceID() (id pid.ID, exists bool) {
+	if m.source != nil {
+		return *m.source, true
 	}
 	return
 }
 
-// ImageIDs returns the "image" edge IDs in the mutation.
-func (m *ImageDataMutation) ImageIDs() (ids []pid.ID) {
-	for id := range m.image {
-		ids = append(ids, id)
+// SourceIDs returns the "source" edge IDs in the mutation.
+// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
+// SourceID instead. It exists only for internal usage by the builders.
+func (m *ProcessedImageMutation) SourceIDs() (ids []pid.ID) {
+	if id := m.source; id != nil {
+		ids = append(ids, *id)
 	}
 	return
 }
 
-// ResetImage resets all changes to the "image" edge.
-func (m *ImageDataMutation) ResetImage() {
-	m.image = nil
-	m.clearedimage = false
-	m.removedimage = nil
+// ResetSource resets all changes to the "source" edge.
+func (m *ProcessedImageMutation) ResetSource() {
+	m.source = nil
+	m.clearedsource = false
 }
 
-// Where appends a list predicates to the ImageDataMutation builder.
-func (m *ImageDataMutation) Where(ps ...predicate.ImageData) {
+// Where appends a list predicates to the ProcessedImageMutation builder.
+func (m *ProcessedImageMutation) Where(ps ...predicate.ProcessedImage) {
 	m.predicates = append(m.predicates, ps...)
 }
 
-// WhereP appends storage-level predicates to the ImageDataMutation builder. Using this method,
+// WhereP appends storage-level predicates to the ProcessedImageMutation builder. Using this method,
 // users can use type-assertion to append predicates that do not depend on any generated package.
-func (m *ImageDataMutation) WhereP(ps ...func(*sql.Selector)) {
-	p := make([]predicate.ImageData, len(ps))
+func (m *ProcessedImageMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.ProcessedImage, len(ps))
 	for i := range ps {
 		p[i] = ps[i]
 	}
@@ -2598,42 +5429,51 @@ func (m *ImageDataMutation) WhereP(ps ...func(*sql.Selector)) {
 }
 
 // Op returns the operation name.
-func (m *ImageDataMutation) Op() Op {
+func (m *ProcessedImageMutation) Op() Op {
 	return m.op
 }
 
 // SetOp allows setting the mutation operation.
-func (m *ImageDataMutation) SetOp(op Op) {
+func (m *ProcessedImageMutation) SetOp(op Op) {
 	m.op = op
 }
 
-// Type returns the node type of this mutation (ImageData).
-func (m *ImageDataMutation) Type() string {
+// Type returns the node type of this mutation (ProcessedImage).
+func (m *ProcessedImageMutation) Type() string {
 	return m.typ
 }
 
 // Fields returns all fields that were changed during this mutation. Note that in
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
-func (m *ImageDataMutation) Fields() []string {
-	fields := make([]string, 0, 6)
-	if m.avr_r != nil {
-		fields = append(fields, imagedata.FieldAvrR)
+func (m *ProcessedImageMutation) Fields() []string {
+	fields := make([]string, 0, 9)
+	if m.deleted_at != nil {
+		fields = append(fields, processedimage.FieldDeletedAt)
 	}
-	if m.avr_g != nil {
-		fields = append(fields, imagedata.FieldAvrG)
+	if m.file != nil {
+		fields = append(fields, processedimage.FieldFile)
 	}
-	if m.avr_b != nil {
-		fields = append(fields, imagedata.FieldAvrB)
+	if m.variant != nil {
+		fields = append(fields, processedimage.FieldVariant)
 	}
-	if m.avg_brightness != nil {
-		fields = append(fields, imagedata.FieldAvgBrightness)
+	if m._type != nil {
+		fields = append(fields, processedimage.FieldType)
 	}
-	if m.avg_saturation != nil {
-		fields = append(fields, imagedata.FieldAvgSaturation)
+	if m.width != nil {
+		fields = append(fields, processedimage.FieldWidth)
+	}
+	if m.height != nil {
+		fields = append(fields, processedimage.FieldHeight)
+	}
+	if m.size_bits != nil {
+		fields = append(fields, processedimage.FieldSizeBits)
 	}
 	if m.created_at != nil {
-		fields = append(fields, imagedata.FieldCreatedAt)
+		fields = append(fields, processedimage.FieldCreatedAt)
+	}
+	if m.updated_at != nil {
+		fields = append(fields, processedimage.FieldUpdatedAt)
 	}
 	return fields
 }
@@ -2641,20 +5481,26 @@ func (m *ImageDataMutation) Fields() []string {
 // Field returns the value of a field with the given name. The second boolean
 // return value indicates that this field was not set, or was not defined in the
 // schema.
-func (m *ImageDataMutation) Field(name string) (ent.Value, bool) {
+func (m *ProcessedImageMutation) Field(name string) (ent.Value, bool) {
 	switch name {
-	case imagedata.FieldAvrR:
-		return m.AvrR()
-	case imagedata.FieldAvrG:
-		return m.AvrG()
-	case imagedata.FieldAvrB:
-		return m.AvrB()
-	case imagedata.FieldAvgBrightness:
-		return m.AvgBrightness()
-	case imagedata.FieldAvgSaturation:
-		return m.AvgSaturation()
-	case imagedata.FieldCreatedAt:
+	case processedimage.FieldDeletedAt:
+		return m.DeletedAt()
+	case processedimage.FieldFile:
+		return m.File()
+	case processedimage.FieldVariant:
+		return m.Variant()
+	case processedimage.FieldType:
+		return m.GetType()
+	case processedimage.FieldWidth:
+		return m.Width()
+	case processedimage.FieldHeight:
+		return m.Height()
+	case processedimage.FieldSizeBits:
+		return m.SizeBits()
+	case processedimage.FieldCreatedAt:
 		return m.CreatedAt()
+	case processedimage.FieldUpdatedAt:
+		return m.UpdatedAt()
 	}
 	return nil, false
 }
@@ -2662,93 +5508,114 @@ func (m *ImageDataMutation) Field(name string) (ent.Value, bool) {
 // OldField returns the old value of the field from the database. An error is
 // returned if the mutation operation is not UpdateOne, or the query to the
 // database failed.
-func (m *ImageDataMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+func (m *ProcessedImageMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
 	switch name {
-	case imagedata.FieldAvrR:
-		return m.OldAvrR(ctx)
-	case imagedata.FieldAvrG:
-		return m.OldAvrG(ctx)
-	case imagedata.FieldAvrB:
-		return m.OldAvrB(ctx)
-	case imagedata.FieldAvgBrightness:
-		return m.OldAvgBrightness(ctx)
-	case imagedata.FieldAvgSaturation:
-		return m.OldAvgSaturation(ctx)
-	case imagedata.FieldCreatedAt:
+	case processedimage.FieldDeletedAt:
+		return m.OldDeletedAt(ctx)
+	case processedimage.FieldFile:
+		return m.OldFile(ctx)
+	case processedimage.FieldVariant:
+		return m.OldVariant(ctx)
+	case processedimage.FieldType:
+		return m.OldType(ctx)
+	case processedimage.FieldWidth:
+		return m.OldWidth(ctx)
+	case processedimage.FieldHeight:
+		return m.OldHeight(ctx)
+	case processedimage.FieldSizeBits:
+		return m.OldSizeBits(ctx)
+	case processedimage.FieldCreatedAt:
 		return m.OldCreatedAt(ctx)
+	case processedimage.FieldUpdatedAt:
+		return m.OldUpdatedAt(ctx)
 	}
-	return nil, fmt.Errorf("unknown ImageData field %s", name)
+	return nil, fmt.Errorf("unknown ProcessedImage field %s", name)
 }
 
 // SetField sets the value of a field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *ImageDataMutation) SetField(name string, value ent.Value) error {
+func (m *ProcessedImageMutation) SetField(name string, value ent.Value) error {
 	switch name {
-	case imagedata.FieldAvrR:
-		v, ok := value.(int)
+	case processedimage.FieldDeletedAt:
+		v, ok := value.(time.Time)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetAvrR(v)
+		m.SetDeletedAt(v)
 		return nil
-	case imagedata.FieldAvrG:
-		v, ok := value.(int)
+	case processedimage.FieldFile:
+		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetAvrG(v)
+		m.SetFile(v)
 		return nil
-	case imagedata.FieldAvrB:
-		v, ok := value.(int)
+	case processedimage.FieldVariant:
+		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetAvrB(v)
+		m.SetVariant(v)
 		return nil
-	case imagedata.FieldAvgBrightness:
+	case processedimage.FieldType:
+		v, ok := value.(processedimage.Type)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetType(v)
+		return nil
+	case processedimage.FieldWidth:
 		v, ok := value.(int)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetAvgBrightness(v)
+		m.SetWidth(v)
 		return nil
-	case imagedata.FieldAvgSaturation:
+	case processedimage.FieldHeight:
 		v, ok := value.(int)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetAvgSaturation(v)
+		m.SetHeight(v)
 		return nil
-	case imagedata.FieldCreatedAt:
+	case processedimage.FieldSizeBits:
+		v, ok := value.(uint32)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetSizeBits(v)
+		return nil
+	case processedimage.FieldCreatedAt:
 		v, ok := value.(time.Time)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
 		m.SetCreatedAt(v)
 		return nil
+	case processedimage.FieldUpdatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUpdatedAt(v)
+		return nil
 	}
-	return fmt.Errorf("unknown ImageData field %s", name)
+	return fmt.Errorf("unknown ProcessedImage field %s", name)
 }
 
 // AddedFields returns all numeric fields that were incremented/decremented during
 // this mutation.
-func (m *ImageDataMutation) AddedFields() []string {
+func (m *ProcessedImageMutation) AddedFields() []string {
 	var fields []string
-	if m.addavr_r != nil {
-		fields = append(fields, imagedata.FieldAvrR)
-	}
-	if m.addavr_g != nil {
-		fields = append(fields, imagedata.FieldAvrG)
-	}
-	if m.addavr_b != nil {
-		fields = append(fields, imagedata.FieldAvrB)
+	if m.addwidth != nil {
+		fields = append(fields, processedimage.FieldWidth)
 	}
-	if m.addavg_brightness != nil {
-		fields = append(fields, imagedata.FieldAvgBrightness)
+	if m.addheight != nil {
+		fields = append(fields, processedimage.FieldHeight)
 	}
-	if m.addavg_saturation != nil {
-		fields = append(fields, imagedata.FieldAvgSaturation)
+	if m.addsize_bits != nil {
+		fields = append(fields, processedimage.FieldSizeBits)
 	}
 	return fields
 }
@@ -2756,18 +5623,14 @@ func (m *ImageDataMutation) AddedFields() []string {
 // AddedField returns the numeric value that was incremented/decremented on a field
 // with the given name. The second boolean return value indicates that this field
 // was not set, or was not defined in the schema.
-func (m *ImageDataMutation) AddedField(name string) (ent.Value, bool) {
+func (m *ProcessedImageMutation) AddedField(name string) (ent.Value, bool) {
 	switch name {
-	case imagedata.FieldAvrR:
-		return m.AddedAvrR()
-	case imagedata.FieldAvrG:
-		return m.AddedAvrG()
-	case imagedata.FieldAvrB:
-		return m.AddedAvrB()
-	case imagedata.FieldAvgBrightness:
-		return m.AddedAvgBrightness()
-	case imagedata.FieldAvgSaturation:
-		return m.AddedAvgSaturation()
+	case processedimage.FieldWidth:
+		return m.AddedWidth()
+	case processedimage.FieldHeight:
+		return m.AddedHeight()
+	case processedimage.FieldSizeBits:
+		return m.AddedSizeBits()
 	}
 	return nil, false
 }
@@ -2775,210 +5638,216 @@ func (m *ImageDataMutation) AddedField(name string) (ent.Value, bool) {
 // AddField adds the value to the field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *ImageDataMutation) AddField(name string, value ent.Value) error {
+func (m *ProcessedImageMutation) AddField(name string, value ent.Value) error {
 	switch name {
-	case imagedata.FieldAvrR:
-		v, ok := value.(int)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.AddAvrR(v)
-		return nil
-	case imagedata.FieldAvrG:
-		v, ok := value.(int)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.AddAvrG(v)
-		return nil
-	case imagedata.FieldAvrB:
+	case processedimage.FieldWidth:
 		v, ok := value.(int)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.AddAvrB(v)
+		m.AddWidth(v)
 		return nil
-	case imagedata.FieldAvgBrightness:
+	case processedimage.FieldHeight:
 		v, ok := value.(int)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.AddAvgBrightness(v)
+		m.AddHeight(v)
 		return nil
-	case imagedata.FieldAvgSaturation:
-		v, ok := value.(int)
+	case processedimage.FieldSizeBits:
+		v, ok := value.(int32)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.AddAvgSaturation(v)
+		m.AddSizeBits(v)
 		return nil
 	}
-	return fmt.Errorf("unknown ImageData numeric field %s", name)
+	return fmt.Errorf("unknown ProcessedImage numeric field %s", name)
 }
 
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
-func (m *ImageDataMutation) ClearedFields() []string {
-	return nil
+func (m *ProcessedImageMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(processedimage.FieldDeletedAt) {
+		fields = append(fields, processedimage.FieldDeletedAt)
+	}
+	if m.FieldCleared(processedimage.FieldFile) {
+		fields = append(fields, processedimage.FieldFile)
+	}
+	if m.FieldCleared(processedimage.FieldVariant) {
+		fields = append(fields, processedimage.FieldVariant)
+	}
+	return fields
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
 // cleared in this mutation.
-func (m *ImageDataMutation) FieldCleared(name string) bool {
+func (m *ProcessedImageMutation) FieldCleared(name string) bool {
 	_, ok := m.clearedFields[name]
 	return ok
 }
 
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
-func (m *ImageDataMutation) ClearField(name string) error {
-	return fmt.Errorf("unknown ImageData nullable field %s", name)
+func (m *ProcessedImageMutation) ClearField(name string) error {
+	switch name {
+	case processedimage.FieldDeletedAt:
+		m.ClearDeletedAt()
+		return nil
+	case processedimage.FieldFile:
+		m.ClearFile()
+		return nil
+	case processedimage.FieldVariant:
+		m.ClearVariant()
+		return nil
+	}
+	return fmt.Errorf("unknown ProcessedImage nullable field %s", name)
 }
 
 // ResetField resets all changes in the mutation for the field with the given name.
 // It returns an error if the field is not defined in the schema.
-func (m *ImageDataMutation) ResetField(name string) error {
+func (m *ProcessedImageMutation) ResetField(name string) error {
 	switch name {
-	case imagedata.FieldAvrR:
-		m.ResetAvrR()
+	case processedimage.FieldDeletedAt:
+		m.ResetDeletedAt()
 		return nil
-	case imagedata.FieldAvrG:
-		m.ResetAvrG()
+	case processedimage.FieldFile:
+		m.ResetFile()
 		return nil
-	case imagedata.FieldAvrB:
-		m.ResetAvrB()
+	case processedimage.FieldVariant:
+		m.ResetVariant()
 		return nil
-	case imagedata.FieldAvgBrightness:
-		m.ResetAvgBrightness()
+	case processedimage.FieldType:
+		m.ResetType()
 		return nil
-	case imagedata.FieldAvgSaturation:
-		m.ResetAvgSaturation()
+	case processedimage.FieldWidth:
+		m.ResetWidth()
 		return nil
-	case imagedata.FieldCreatedAt:
+	case processedimage.FieldHeight:
+		m.ResetHeight()
+		return nil
+	case processedimage.FieldSizeBits:
+		m.ResetSizeBits()
+		return nil
+	case processedimage.FieldCreatedAt:
 		m.ResetCreatedAt()
 		return nil
+	case processedimage.FieldUpdatedAt:
+		m.ResetUpdatedAt()
+		return nil
 	}
-	return fmt.Errorf("unknown ImageData field %s", name)
+	return fmt.Errorf("unknown ProcessedImage field %s", name)
 }
 
 // AddedEdges returns all edge names that were set/added in this mutation.
-func (m *ImageDataMutation) AddedEdges() []string {
+func (m *ProcessedImageMutation) AddedEdges() []string {
 	edges := make([]string, 0, 1)
-	if m.image != nil {
-		edges = append(edges, imagedata.EdgeImage)
+	if m.source != nil {
+		edges = append(edges, processedimage.EdgeSource)
 	}
 	return edges
 }
 
 // AddedIDs returns all IDs (to other nodes) that were added for the given edge
-// name in this mutation.
-func (m *ImageDataMutation) AddedIDs(name string) []ent.Value {
-	switch name {
-	case imagedata.EdgeImage:
-		ids := make([]ent.Value, 0, len(m.image))
-		for id := range m.image {
-			ids = append(ids, id)
+// name in this mutation.
+func (m *ProcessedImageMutation) AddedIDs(name string) []ent.Value {
+	switch name {
+	case processedimage.EdgeSource:
+		if id := m.source; id != nil {
+			return []ent.Value{*id}
 		}
-		return ids
 	}
 	return nil
 }
 
 // RemovedEdges returns all edge names that were removed in this mutation.
-func (m *ImageDataMutation) RemovedEdges() []string {
+func (m *ProcessedImageMutation) RemovedEdges() []string {
 	edges := make([]string, 0, 1)
-	if m.removedimage != nil {
-		edges = append(edges, imagedata.EdgeImage)
-	}
 	return edges
 }
 
 // RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
 // the given name in this mutation.
-func (m *ImageDataMutation) RemovedIDs(name string) []ent.Value {
-	switch name {
-	case imagedata.EdgeImage:
-		ids := make([]ent.Value, 0, len(m.removedimage))
-		for id := range m.removedimage {
-			ids = append(ids, id)
-		}
-		return ids
-	}
+func (m *ProcessedImageMutation) RemovedIDs(name string) []ent.Value {
 	return nil
 }
 
 // ClearedEdges returns all edge names that were cleared in this mutation.
-func (m *ImageDataMutation) ClearedEdges() []string {
+func (m *ProcessedImageMutation) ClearedEdges() []string {
 	edges := make([]string, 0, 1)
-	if m.clearedimage {
-		edges = append(edges, imagedata.EdgeImage)
+	if m.clearedsource {
+		edges = append(edges, processedimage.EdgeSource)
 	}
 	return edges
 }
 
 // EdgeCleared returns a boolean which indicates if the edge with the given name
 // was cleared in this mutation.
-func (m *ImageDataMutation) EdgeCleared(name string) bool {
+func (m *ProcessedImageMutation) EdgeCleared(name string) bool {
 	switch name {
-	case imagedata.EdgeImage:
-		return m.clearedimage
+	case processedimage.EdgeSource:
+		return m.clearedsource
 	}
 	return false
 }
 
 // ClearEdge clears the value of the edge with the given name. It returns an error
 // if that edge is not defined in the schema.
-func (m *ImageDataMutation) ClearEdge(name string) error {
+func (m *ProcessedImageMutation) ClearEdge(name string) error {
 	switch name {
+	case processedimage.EdgeSource:
+		m.ClearSource()
+		return nil
 	}
-	return fmt.Errorf("unknown ImageData unique edge %s", name)
+	return fmt.Errorf("unknown ProcessedImage unique edge %s", name)
 }
 
 // ResetEdge resets all changes to the edge with the given name in this mutation.
 // It returns an error if the edge is not defined in the schema.
-func (m *ImageDataMutation) ResetEdge(name string) error {
+func (m *ProcessedImageMutation) ResetEdge(name string) error {
 	switch name {
-	case imagedata.EdgeImage:
-		m.ResetImage()
+	case processedimage.EdgeSource:
+		m.ResetSource()
 		return nil
 	}
-	return fmt.Errorf("unknown ImageData edge %s", name)
+	return fmt.Errorf("unknown ProcessedImage edge %s", name)
 }
 
-// ProcessedImageMutation represents an operation that mutates the ProcessedImage nodes in the graph.
-type ProcessedImageMutation struct {
+// ReleaseMutation represents an operation that mutates the Release nodes in the graph.
+type ReleaseMutation struct {
 	config
-	op            Op
-	typ           string
-	id            *pid.ID
-	_type         *processedimage.Type
-	dimentions    *int
-	adddimentions *int
-	size_bits     *uint32
-	addsize_bits  *int32
-	thumb         *[]byte
-	created_at    *time.Time
-	updated_at    *time.Time
-	deleted_at    *time.Time
-	clearedFields map[string]struct{}
-	source        *pid.ID
-	clearedsource bool
-	done          bool
-	oldValue      func(context.Context) (*ProcessedImage, error)
-	predicates    []predicate.ProcessedImage
+	op                       Op
+	typ                      string
+	id                       *pid.ID
+	name                     *string
+	_type                    *release.Type
+	release_date             *time.Time
+	clearedFields            map[string]struct{}
+	image                    *pid.ID
+	clearedimage             bool
+	tracks                   map[pid.ID]struct{}
+	removedtracks            map[pid.ID]struct{}
+	clearedtracks            bool
+	appearing_artists        map[pid.ID]struct{}
+	removedappearing_artists map[pid.ID]struct{}
+	clearedappearing_artists bool
+	done                     bool
+	oldValue                 func(context.Context) (*Release, error)
+	predicates               []predicate.Release
 }
 
-var _ ent.Mutation = (*ProcessedImageMutation)(nil)
+var _ ent.Mutation = (*ReleaseMutation)(nil)
 
-// processedimageOption allows management of the mutation configuration using functional options.
-type processedimageOption func(*ProcessedImageMutation)
+// releaseOption allows management of the mutation configuration using functional options.
+type releaseOption func(*ReleaseMutation)
 
-// newProcessedImageMutation creates new mutation for the ProcessedImage entity.
-func newProcessedImageMutation(c config, op Op, opts ...processedimageOption) *ProcessedImageMutation {
-	m := &ProcessedImageMutation{
+// newReleaseMutation creates new mutation for the Release entity.
+func newReleaseMutation(c config, op Op, opts ...releaseOption) *ReleaseMutation {
+	m := &ReleaseMutation{
 		config:        c,
 		op:            op,
-		typ:           TypeProcessedImage,
+		typ:           TypeRelease,
 		clearedFields: make(map[string]struct{}),
 	}
 	for _, opt := range opts {
@@ -2987,20 +5856,20 @@ func newProcessedImageMutation(c config, op Op, opts ...processedimageOption) *P
 	return m
 }
 
-// withProcessedImageID sets the ID field of the mutation.
-func withProcessedImageID(id pid.ID) processedimageOption {
-	return func(m *ProcessedImageMutation) {
+// withReleaseID sets the ID field of the mutation.
+func withReleaseID(id pid.ID) releaseOption {
+	return func(m *ReleaseMutation) {
 		var (
 			err   error
 			once  sync.Once
-			value *ProcessedImage
+			value *Release
 		)
-		m.oldValue = func(ctx context.Context) (*ProcessedImage, error) {
+		m.oldValue = func(ctx context.Context) (*Release, error) {
 			once.Do(func() {
 				if m.done {
 					err = errors.New("querying old values post mutation is not allowed")
 				} else {
-					value, err = m.Client().ProcessedImage.Get(ctx, id)
+					value, err = m.Client().Release.Get(ctx, id)
 				}
 			})
 			return value, err
@@ -3009,10 +5878,10 @@ func withProcessedImageID(id pid.ID) processedimageOption {
 	}
 }
 
-// withProcessedImage sets the old ProcessedImage of the mutation.
-func withProcessedImage(node *ProcessedImage) processedimageOption {
-	return func(m *ProcessedImageMutation) {
-		m.oldValue = func(context.Context) (*ProcessedImage, error) {
+// withRelease sets the old Release of the mutation.
+func withRelease(node *Release) releaseOption {
+	return func(m *ReleaseMutation) {
+		m.oldValue = func(context.Context) (*Release, error) {
 			return node, nil
 		}
 		m.id = &node.ID
@@ -3021,7 +5890,7 @@ func withProcessedImage(node *ProcessedImage) processedimageOption {
 
 // Client returns a new `ent.Client` from the mutation. If the mutation was
 // executed in a transaction (ent.Tx), a transactional client is returned.
-func (m ProcessedImageMutation) Client() *Client {
+func (m ReleaseMutation) Client() *Client {
 	client := &Client{config: m.config}
 	client.init()
 	return client
@@ -3029,7 +5898,7 @@ func (m ProcessedImageMutation) Client() *Client {
 
 // Tx returns an `ent.Tx` for mutations that were executed in transactions;
 // it returns an error otherwise.
-func (m ProcessedImageMutation) Tx() (*Tx, error) {
+func (m ReleaseMutation) Tx() (*Tx, error) {
 	if _, ok := m.driver.(*txDriver); !ok {
 		return nil, errors.New("ent: mutation is not running in a transaction")
 	}
@@ -3039,14 +5908,14 @@ func (m ProcessedImageMutation) Tx() (*Tx, error) {
 }
 
 // SetID sets the value of the id field. Note that this
-// operation is only accepted on creation of ProcessedImage entities.
-func (m *ProcessedImageMutation) SetID(id pid.ID) {
+// operation is only accepted on creation of Release entities.
+func (m *ReleaseMutation) SetID(id pid.ID) {
 	m.id = &id
 }
 
 // ID returns the ID value in the mutation. Note that the ID is only available
 // if it was provided to the builder or after it was returned from the database.
-func (m *ProcessedImageMutation) ID() (id pid.ID, exists bool) {
+func (m *ReleaseMutation) ID() (id pid.ID, exists bool) {
 	if m.id == nil {
 		return
 	}
@@ -3057,374 +5926,285 @@ func (m *ProcessedImageMutation) ID() (id pid.ID, exists bool) {
 // That means, if the mutation is applied within a transaction with an isolation level such
 // as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
 // or updated by the mutation.
-func (m *ProcessedImageMutation) IDs(ctx context.Context) ([]pid.ID, error) {
+func (m *ReleaseMutation) IDs(ctx context.Context) ([]pid.ID, error) {
 	switch {
 	case m.op.Is(OpUpdateOne | OpDeleteOne):
 		id, exists := m.ID()
-		if exists {
-			return []pid.ID{id}, nil
-		}
-		fallthrough
-	case m.op.Is(OpUpdate | OpDelete):
-		return m.Client().ProcessedImage.Query().Where(m.predicates...).IDs(ctx)
-	default:
-		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
-	}
-}
-
-// SetType sets the "type" field.
-func (m *ProcessedImageMutation) SetType(pr processedimage.Type) {
-	m._type = &pr
-}
-
-// GetType returns the value of the "type" field in the mutation.
-func (m *ProcessedImageMutation) GetType() (r processedimage.Type, exists bool) {
-	v := m._type
-	if v == nil {
-		return
-	}
-	return *v, true
-}
-
-// OldType returns the old "type" field's value of the ProcessedImage entity.
-// If the ProcessedImage object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ProcessedImageMutation) OldType(ctx context.Context) (v processedimage.Type, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldType is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldType requires an ID field in the mutation")
-	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldType: %w", err)
-	}
-	return oldValue.Type, nil
-}
-
-// ResetType resets all changes to the "type" field.
-func (m *ProcessedImageMutation) ResetType() {
-	m._type = nil
-}
-
-// SetDimentions sets the "dimentions" field.
-func (m *ProcessedImageMutation) SetDimentions(i int) {
-	m.dimentions = &i
-	m.adddimentions = nil
-}
-
-// Dimentions returns the value of the "dimentions" field in the mutation.
-func (m *ProcessedImageMutation) Dimentions() (r int, exists bool) {
-	v := m.dimentions
-	if v == nil {
-		return
-	}
-	return *v, true
-}
-
-// OldDimentions returns the old "dimentions" field's value of the ProcessedImage entity.
-// If the ProcessedImage object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ProcessedImageMutation) OldDimentions(ctx context.Context) (v int, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldDimentions is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldDimentions requires an ID field in the mutation")
-	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldDimentions: %w", err)
-	}
-	return oldValue.Dimentions, nil
-}
-
-// AddDimentions adds i to the "dimentions" field.
-func (m *ProcessedImageMutation) AddDimentions(i int) {
-	if m.adddimentions != nil {
-		*m.adddimentions += i
-	} else {
-		m.adddimentions = &i
-	}
-}
-
-// AddedDimentions returns the value that was added to the "dimentions" field in this mutation.
-func (m *ProcessedImageMutation) AddedDimentions() (r int, exists bool) {
-	v := m.adddimentions
-	if v == nil {
-		return
-	}
-	return *v, true
-}
-
-// ResetDimentions resets all changes to the "dimentions" field.
-func (m *ProcessedImageMutation) ResetDimentions() {
-	m.dimentions = nil
-	m.adddimentions = nil
-}
-
-// SetSizeBits sets the "size_bits" field.
-func (m *ProcessedImageMutation) SetSizeBits(u uint32) {
-	m.size_bits = &u
-	m.addsize_bits = nil
-}
-
-// SizeBits returns the value of the "size_bits" field in the mutation.
-func (m *ProcessedImageMutation) SizeBits() (r uint32, exists bool) {
-	v := m.size_bits
-	if v == nil {
-		return
-	}
-	return *v, true
-}
-
-// OldSizeBits returns the old "size_bits" field's value of the ProcessedImage entity.
-// If the ProcessedImage object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ProcessedImageMutation) OldSizeBits(ctx context.Context) (v uint32, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldSizeBits is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldSizeBits requires an ID field in the mutation")
-	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldSizeBits: %w", err)
-	}
-	return oldValue.SizeBits, nil
-}
-
-// AddSizeBits adds u to the "size_bits" field.
-func (m *ProcessedImageMutation) AddSizeBits(u int32) {
-	if m.addsize_bits != nil {
-		*m.addsize_bits += u
-	} else {
-		m.addsize_bits = &u
-	}
-}
-
-// AddedSizeBits returns the value that was added to the "size_bits" field in this mutation.
-func (m *ProcessedImageMutation) AddedSizeBits() (r int32, exists bool) {
-	v := m.addsize_bits
-	if v == nil {
-		return
+		if exists {
+			return []pid.ID{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().Release.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
 	}
-	return *v, true
-}
-
-// ResetSizeBits resets all changes to the "size_bits" field.
-func (m *ProcessedImageMutation) ResetSizeBits() {
-	m.size_bits = nil
-	m.addsize_bits = nil
 }
 
-// SetThumb sets the "thumb" field.
-func (m *ProcessedImageMutation) SetThumb(b []byte) {
-	m.thumb = &b
+// SetName sets the "name" field.
+func (m *ReleaseMutation) SetName(s string) {
+	m.name = &s
 }
 
-// Thumb returns the value of the "thumb" field in the mutation.
-func (m *ProcessedImageMutation) Thumb() (r []byte, exists bool) {
-	v := m.thumb
+// Name returns the value of the "name" field in the mutation.
+func (m *ReleaseMutation) Name() (r string, exists bool) {
+	v := m.name
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldThumb returns the old "thumb" field's value of the ProcessedImage entity.
-// If the ProcessedImage object wasn't provided to the builder, the object is fetched from the database.
+// OldName returns the old "name" field's value of the Release entity.
+// If the Release object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ProcessedImageMutation) OldThumb(ctx context.Context) (v []byte, err error) {
+func (m *ReleaseMutation) OldName(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldThumb is only allowed on UpdateOne operations")
+		return v, errors.New("OldName is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldThumb requires an ID field in the mutation")
+		return v, errors.New("OldName requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldThumb: %w", err)
+		return v, fmt.Errorf("querying old value for OldName: %w", err)
 	}
-	return oldValue.Thumb, nil
+	return oldValue.Name, nil
 }
 
-// ResetThumb resets all changes to the "thumb" field.
-func (m *ProcessedImageMutation) ResetThumb() {
-	m.thumb = nil
+// ResetName resets all changes to the "name" field.
+func (m *ReleaseMutation) ResetName() {
+	m.name = nil
 }
 
-// SetCreatedAt sets the "created_at" field.
-func (m *ProcessedImageMutation) SetCreatedAt(t time.Time) {
-	m.created_at = &t
+// SetType sets the "type" field.
+func (m *ReleaseMutation) SetType(r release.Type) {
+	m._type = &r
 }
 
-// CreatedAt returns the value of the "created_at" field in the mutation.
-func (m *ProcessedImageMutation) CreatedAt() (r time.Time, exists bool) {
-	v := m.created_at
+// GetType returns the value of the "type" field in the mutation.
+func (m *ReleaseMutation) GetType() (r release.Type, exists bool) {
+	v := m._type
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldCreatedAt returns the old "created_at" field's value of the ProcessedImage entity.
-// If the ProcessedImage object wasn't provided to the builder, the object is fetched from the database.
+// OldType returns the old "type" field's value of the Release entity.
+// If the Release object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ProcessedImageMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+func (m *ReleaseMutation) OldType(ctx context.Context) (v release.Type, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
+		return v, errors.New("OldType is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+		return v, errors.New("OldType requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+		return v, fmt.Errorf("querying old value for OldType: %w", err)
 	}
-	return oldValue.CreatedAt, nil
+	return oldValue.Type, nil
 }
 
-// ResetCreatedAt resets all changes to the "created_at" field.
-func (m *ProcessedImageMutation) ResetCreatedAt() {
-	m.created_at = nil
+// ResetType resets all changes to the "type" field.
+func (m *ReleaseMutation) ResetType() {
+	m._type = nil
 }
 
-// SetUpdatedAt sets the "updated_at" field.
-func (m *ProcessedImageMutation) SetUpdatedAt(t time.Time) {
-	m.updated_at = &t
+// SetReleaseDate sets the "release_date" field.
+func (m *ReleaseMutation) SetReleaseDate(t time.Time) {
+	m.release_date = &t
 }
 
-// UpdatedAt returns the value of the "updated_at" field in the mutation.
-func (m *ProcessedImageMutation) UpdatedAt() (r time.Time, exists bool) {
-	v := m.updated_at
+// ReleaseDate returns the value of the "release_date" field in the mutation.
+func (m *ReleaseMutation) ReleaseDate() (r time.Time, exists bool) {
+	v := m.release_date
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldUpdatedAt returns the old "updated_at" field's value of the ProcessedImage entity.
-// If the ProcessedImage object wasn't provided to the builder, the object is fetched from the database.
+// OldReleaseDate returns the old "release_date" field's value of the Release entity.
+// If the Release object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ProcessedImageMutation) OldUpdatedAt(ctx context.Context) (v time.Time, err error) {
+func (m *ReleaseMutation) OldReleaseDate(ctx context.Context) (v time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldUpdatedAt is only allowed on UpdateOne operations")
+		return v, errors.New("OldReleaseDate is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldUpdatedAt requires an ID field in the mutation")
+		return v, errors.New("OldReleaseDate requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldUpdatedAt: %w", err)
+		return v, fmt.Errorf("querying old value for OldReleaseDate: %w", err)
 	}
-	return oldValue.UpdatedAt, nil
+	return oldValue.ReleaseDate, nil
 }
 
-// ResetUpdatedAt resets all changes to the "updated_at" field.
-func (m *ProcessedImageMutation) ResetUpdatedAt() {
-	m.updated_at = nil
+// ResetReleaseDate resets all changes to the "release_date" field.
+func (m *ReleaseMutation) ResetReleaseDate() {
+	m.release_date = nil
 }
 
-// SetDeletedAt sets the "deleted_at" field.
-func (m *ProcessedImageMutation) SetDeletedAt(t time.Time) {
-	m.deleted_at = &t
+// SetImageID sets the "image" edge to the Image entity by id.
+func (m *ReleaseMutation) SetImageID(id pid.ID) {
+	m.image = &id
 }
 
-// DeletedAt returns the value of the "deleted_at" field in the mutation.
-func (m *ProcessedImageMutation) DeletedAt() (r time.Time, exists bool) {
-	v := m.deleted_at
-	if v == nil {
-		return
+// ClearImage clears the "image" edge to the Image entity.
+func (m *ReleaseMutation) ClearImage() {
+	m.clearedimage = true
+}
+
+// ImageCleared reports if the "image" edge to the Image entity was cleared.
+func (m *ReleaseMutation) ImageCleared() bool {
+	return m.clearedimage
+}
+
+// ImageID returns the "image" edge ID in the mutation.
+func (m *ReleaseMutation) ImageID() (id pid.ID, exists bool) {
+	if m.image != nil {
+		return *m.image, true
 	}
-	return *v, true
+	return
 }
 
-// OldDeletedAt returns the old "deleted_at" field's value of the ProcessedImage entity.
-// If the ProcessedImage object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ProcessedImageMutation) OldDeletedAt(ctx context.Context) (v *time.Time, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldDeletedAt is only allowed on UpdateOne operations")
+// ImageIDs returns the "image" edge IDs in the mutation.
+// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
+// ImageID instead. It exists only for internal usage by the builders.
+func (m *ReleaseMutation) ImageIDs() (ids []pid.ID) {
+	if id := m.image; id != nil {
+		ids = append(ids, *id)
 	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldDeletedAt requires an ID field in the mutation")
+	return
+}
+
+// ResetImage resets all changes to the "image" edge.
+func (m *ReleaseMutation) ResetImage() {
+	m.image = nil
+	m.clearedimage = false
+}
+
+// AddTrackIDs adds the "tracks" edge to the Track entity by ids.
+func (m *ReleaseMutation) AddTrackIDs(ids ...pid.ID) {
+	if m.tracks == nil {
+		m.tracks = make(map[pid.ID]struct{})
 	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldDeletedAt: %w", err)
+	for i := range ids {
+		m.tracks[ids[i]] = struct{}{}
 	}
-	return oldValue.DeletedAt, nil
 }
 
-// ClearDeletedAt clears the value of the "deleted_at" field.
-func (m *ProcessedImageMutation) ClearDeletedAt() {
-	m.deleted_at = nil
-	m.clearedFields[processedimage.FieldDeletedAt] = struct{}{}
+// ClearTracks clears the "tracks" edge to the Track entity.
+func (m *ReleaseMutation) ClearTracks() {
+	m.clearedtracks = true
 }
 
-// DeletedAtCleared returns if the "deleted_at" field was cleared in this mutation.
-func (m *ProcessedImageMutation) DeletedAtCleared() bool {
-	_, ok := m.clearedFields[processedimage.FieldDeletedAt]
-	return ok
+// TracksCleared reports if the "tracks" edge to the Track entity was cleared.
+func (m *ReleaseMutation) TracksCleared() bool {
+	return m.clearedtracks
 }
 
-// ResetDeletedAt resets all changes to the "deleted_at" field.
-func (m *ProcessedImageMutation) ResetDeletedAt() {
-	m.deleted_at = nil
-	delete(m.clearedFields, processedimage.FieldDeletedAt)
+// RemoveTrackIDs removes the "tracks" edge to the Track entity by IDs.
+func (m *ReleaseMutation) RemoveTrackIDs(ids ...pid.ID) {
+	if m.removedtracks == nil {
+		m.removedtracks = make(map[pid.ID]struct{})
+	}
+	for i := range ids {
+		delete(m.tracks, ids[i])
+		m.removedtracks[ids[i]] = struct{}{}
+	}
 }
 
-// SetSourceID sets the "source" edge to the Image entity by id.
-func (m *ProcessedImageMutation) SetSourceID(id pid.ID) {
-	m.source = &id
+// RemovedTracks returns the removed IDs of the "tracks" edge to the Track entity.
+func (m *ReleaseMutation) RemovedTracksIDs() (ids []pid.ID) {
+	for id := range m.removedtracks {
+		ids = append(ids, id)
+	}
+	return
 }
 
-// ClearSource clears the "source" edge to the Image entity.
-func (m *ProcessedImageMutation) ClearSource() {
-	m.clearedsource = true
+// TracksIDs returns the "tracks" edge IDs in the mutation.
+func (m *ReleaseMutation) TracksIDs() (ids []pid.ID) {
+	for id := range m.tracks {
+		ids = append(ids, id)
+	}
+	return
 }
 
-// SourceCleared reports if the "source" edge to the Image entity was cleared.
-func (m *ProcessedImageMutation) SourceCleared() bool {
-	return m.clearedsource
+// ResetTracks resets all changes to the "tracks" edge.
+func (m *ReleaseMutation) ResetTracks() {
+	m.tracks = nil
+	m.clearedtracks = false
+	m.removedtracks = nil
 }
 
-// SourceID returns the "source" edge ID in the mutation.
-func (m *ProcessedImageMutation) SourceID() (id pid.ID, exists bool) {
-	if m.source != nil {
-		return *m.source, true
+// AddAppearingArtistIDs adds the "appearing_artists" edge to the Artist entity by ids.
+func (m *ReleaseMutation) AddAppearingArtistIDs(ids ...pid.ID) {
+	if m.appearing_artists == nil {
+		m.appearing_artists = make(map[pid.ID]struct{})
+	}
+	for i := range ids {
+		m.appearing_artists[ids[i]] = struct{}{}
+	}
+}
+
+// ClearAppearingArtists clears the "appearing_artists" edge to the Artist entity.
+func (m *ReleaseMutation) ClearAppearingArtists() {
+	m.clearedappearing_artists = true
+}
+
+// AppearingArtistsCleared reports if the "appearing_artists" edge to the Artist entity was cleared.
+func (m *ReleaseMutation) AppearingArtistsCleared() bool {
+	return m.clearedappearing_artists
+}
+
+// RemoveAppearingArtistIDs removes the "appearing_artists" edge to the Artist entity by IDs.
+func (m *ReleaseMutation) RemoveAppearingArtistIDs(ids ...pid.ID) {
+	if m.removedappearing_artists == nil {
+		m.removedappearing_artists = make(map[pid.ID]struct{})
+	}
+	for i := range ids {
+		delete(m.appearing_artists, ids[i])
+		m.removedappearing_artists[ids[i]] = struct{}{}
+	}
+}
+
+// RemovedAppearingArtists returns the removed IDs of the "appearing_artists" edge to the Artist entity.
+func (m *ReleaseMutation) RemovedAppearingArtistsIDs() (ids []pid.ID) {
+	for id := range m.removedappearing_artists {
+		ids = append(ids, id)
 	}
 	return
 }
 
-// SourceIDs returns the "source" edge IDs in the mutation.
-// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
-// SourceID instead. It exists only for internal usage by the builders.
-func (m *ProcessedImageMutation) SourceIDs() (ids []pid.ID) {
-	if id := m.source; id != nil {
-		ids = append(ids, *id)
+// AppearingArtistsIDs returns the "appearing_artists" edge IDs in the mutation.
+func (m *ReleaseMutation) AppearingArtistsIDs() (ids []pid.ID) {
+	for id := range m.appearing_artists {
+		ids = append(ids, id)
 	}
 	return
 }
 
-// ResetSource resets all changes to the "source" edge.
-func (m *ProcessedImageMutation) ResetSource() {
-	m.source = nil
-	m.clearedsource = false
+// ResetAppearingArtists resets all changes to the "appearing_artists" edge.
+func (m *ReleaseMutation) ResetAppearingArtists() {
+	m.appearing_artists = nil
+	m.clearedappearing_artists = false
+	m.removedappearing_artists = nil
 }
 
-// Where appends a list predicates to the ProcessedImageMutation builder.
-func (m *ProcessedImageMutation) Where(ps ...predicate.ProcessedImage) {
+// Where appends a list predicates to the ReleaseMutation builder.
+func (m *ReleaseMutation) Where(ps ...predicate.Release) {
 	m.predicates = append(m.predicates, ps...)
 }
 
-// WhereP appends storage-level predicates to the ProcessedImageMutation builder. Using this method,
+// WhereP appends storage-level predicates to the ReleaseMutation builder. Using this method,
 // users can use type-assertion to append predicates that do not depend on any generated package.
-func (m *ProcessedImageMutation) WhereP(ps ...func(*sql.Selector)) {
-	p := make([]predicate.ProcessedImage, len(ps))
+func (m *ReleaseMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.Release, len(ps))
 	for i := range ps {
 		p[i] = ps[i]
 	}
@@ -3432,45 +6212,33 @@ func (m *ProcessedImageMutation) WhereP(ps ...func(*sql.Selector)) {
 }
 
 // Op returns the operation name.
-func (m *ProcessedImageMutation) Op() Op {
+func (m *ReleaseMutation) Op() Op {
 	return m.op
 }
 
 // SetOp allows setting the mutation operation.
-func (m *ProcessedImageMutation) SetOp(op Op) {
+func (m *ReleaseMutation) SetOp(op Op) {
 	m.op = op
 }
 
-// Type returns the node type of this mutation (ProcessedImage).
-func (m *ProcessedImageMutation) Type() string {
+// Type returns the node type of this mutation (Release).
+func (m *ReleaseMutation) Type() string {
 	return m.typ
 }
 
 // Fields returns all fields that were changed during this mutation. Note that in
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
-func (m *ProcessedImageMutation) Fields() []string {
-	fields := make([]string, 0, 7)
-	if m._type != nil {
-		fields = append(fields, processedimage.FieldType)
-	}
-	if m.dimentions != nil {
-		fields = append(fields, processedimage.FieldDimentions)
-	}
-	if m.size_bits != nil {
-		fields = append(fields, processedimage.FieldSizeBits)
-	}
-	if m.thumb != nil {
-		fields = append(fields, processedimage.FieldThumb)
-	}
-	if m.created_at != nil {
-		fields = append(fields, processedimage.FieldCreatedAt)
+func (m *ReleaseMutation) Fields() []string {
+	fields := make([]string, 0, 3)
+	if m.name != nil {
+		fields = append(fields, release.FieldName)
 	}
-	if m.updated_at != nil {
-		fields = append(fields, processedimage.FieldUpdatedAt)
+	if m._type != nil {
+		fields = append(fields, release.FieldType)
 	}
-	if m.deleted_at != nil {
-		fields = append(fields, processedimage.FieldDeletedAt)
+	if m.release_date != nil {
+		fields = append(fields, release.FieldReleaseDate)
 	}
 	return fields
 }
@@ -3478,22 +6246,14 @@ func (m *ProcessedImageMutation) Fields() []string {
 // Field returns the value of a field with the given name. The second boolean
 // return value indicates that this field was not set, or was not defined in the
 // schema.
-func (m *ProcessedImageMutation) Field(name string) (ent.Value, bool) {
+func (m *ReleaseMutation) Field(name string) (ent.Value, bool) {
 	switch name {
-	case processedimage.FieldType:
+	case release.FieldName:
+		return m.Name()
+	case release.FieldType:
 		return m.GetType()
-	case processedimage.FieldDimentions:
-		return m.Dimentions()
-	case processedimage.FieldSizeBits:
-		return m.SizeBits()
-	case processedimage.FieldThumb:
-		return m.Thumb()
-	case processedimage.FieldCreatedAt:
-		return m.CreatedAt()
-	case processedimage.FieldUpdatedAt:
-		return m.UpdatedAt()
-	case processedimage.FieldDeletedAt:
-		return m.DeletedAt()
+	case release.FieldReleaseDate:
+		return m.ReleaseDate()
 	}
 	return nil, false
 }
@@ -3501,655 +6261,429 @@ func (m *ProcessedImageMutation) Field(name string) (ent.Value, bool) {
 // OldField returns the old value of the field from the database. An error is
 // returned if the mutation operation is not UpdateOne, or the query to the
 // database failed.
-func (m *ProcessedImageMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+func (m *ReleaseMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
 	switch name {
-	case processedimage.FieldType:
+	case release.FieldName:
+		return m.OldName(ctx)
+	case release.FieldType:
 		return m.OldType(ctx)
-	case processedimage.FieldDimentions:
-		return m.OldDimentions(ctx)
-	case processedimage.FieldSizeBits:
-		return m.OldSizeBits(ctx)
-	case processedimage.FieldThumb:
-		return m.OldThumb(ctx)
-	case processedimage.FieldCreatedAt:
-		return m.OldCreatedAt(ctx)
-	case processedimage.FieldUpdatedAt:
-		return m.OldUpdatedAt(ctx)
-	case processedimage.FieldDeletedAt:
-		return m.OldDeletedAt(ctx)
+	case release.FieldReleaseDate:
+		return m.OldReleaseDate(ctx)
 	}
-	return nil, fmt.Errorf("unknown ProcessedImage field %s", name)
+	return nil, fmt.Errorf("unknown Release field %s", name)
 }
 
 // SetField sets the value of a field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *ProcessedImageMutation) SetField(name string, value ent.Value) error {
+func (m *ReleaseMutation) SetField(name string, value ent.Value) error {
 	switch name {
-	case processedimage.FieldType:
-		v, ok := value.(processedimage.Type)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetType(v)
-		return nil
-	case processedimage.FieldDimentions:
-		v, ok := value.(int)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetDimentions(v)
-		return nil
-	case processedimage.FieldSizeBits:
-		v, ok := value.(uint32)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetSizeBits(v)
-		return nil
-	case processedimage.FieldThumb:
-		v, ok := value.([]byte)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetThumb(v)
-		return nil
-	case processedimage.FieldCreatedAt:
-		v, ok := value.(time.Time)
+	case release.FieldName:
+		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetCreatedAt(v)
+		m.SetName(v)
 		return nil
-	case processedimage.FieldUpdatedAt:
-		v, ok := value.(time.Time)
+	case release.FieldType:
+		v, ok := value.(release.Type)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetUpdatedAt(v)
+		m.SetType(v)
 		return nil
-	case processedimage.FieldDeletedAt:
+	case release.FieldReleaseDate:
 		v, ok := value.(time.Time)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetDeletedAt(v)
+		m.SetReleaseDate(v)
 		return nil
 	}
-	return fmt.Errorf("unknown ProcessedImage field %s", name)
+	return fmt.Errorf("unknown Release field %s", name)
 }
 
 // AddedFields returns all numeric fields that were incremented/decremented during
 // this mutation.
-func (m *ProcessedImageMutation) AddedFields() []string {
-	var fields []string
-	if m.adddimentions != nil {
-		fields = append(fields, processedimage.FieldDimentions)
-	}
-	if m.addsize_bits != nil {
-		fields = append(fields, processedimage.FieldSizeBits)
-	}
-	return fields
+func (m *ReleaseMutation) AddedFields() []string {
+	return nil
 }
 
 // AddedField returns the numeric value that was incremented/decremented on a field
 // with the given name. The second boolean return value indicates that this field
 // was not set, or was not defined in the schema.
-func (m *ProcessedImageMutation) AddedField(name string) (ent.Value, bool) {
-	switch name {
-	case processedimage.FieldDimentions:
-		return m.AddedDimentions()
-	case processedimage.FieldSizeBits:
-		return m.AddedSizeBits()
-	}
+func (m *ReleaseMutation) AddedField(name string) (ent.Value, bool) {
 	return nil, false
 }
 
 // AddField adds the value to the field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *ProcessedImageMutation) AddField(name string, value ent.Value) error {
+func (m *ReleaseMutation) AddField(name string, value ent.Value) error {
 	switch name {
-	case processedimage.FieldDimentions:
-		v, ok := value.(int)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.AddDimentions(v)
-		return nil
-	case processedimage.FieldSizeBits:
-		v, ok := value.(int32)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.AddSizeBits(v)
-		return nil
 	}
-	return fmt.Errorf("unknown ProcessedImage numeric field %s", name)
+	return fmt.Errorf("unknown Release numeric field %s", name)
 }
 
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
-func (m *ProcessedImageMutation) ClearedFields() []string {
-	var fields []string
-	if m.FieldCleared(processedimage.FieldDeletedAt) {
-		fields = append(fields, processedimage.FieldDeletedAt)
-	}
-	return fields
+func (m *ReleaseMutation) ClearedFields() []string {
+	return nil
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
 // cleared in this mutation.
-func (m *ProcessedImageMutation) FieldCleared(name string) bool {
+func (m *ReleaseMutation) FieldCleared(name string) bool {
 	_, ok := m.clearedFields[name]
 	return ok
 }
 
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
-func (m *ProcessedImageMutation) ClearField(name string) error {
-	switch name {
-	case processedimage.FieldDeletedAt:
-		m.ClearDeletedAt()
-		return nil
-	}
-	return fmt.Errorf("unknown ProcessedImage nullable field %s", name)
+func (m *ReleaseMutation) ClearField(name string) error {
+	return fmt.Errorf("unknown Release nullable field %s", name)
 }
 
 // ResetField resets all changes in the mutation for the field with the given name.
 // It returns an error if the field is not defined in the schema.
-func (m *ProcessedImageMutation) ResetField(name string) error {
+func (m *ReleaseMutation) ResetField(name string) error {
 	switch name {
-	case processedimage.FieldType:
-		m.ResetType()
-		return nil
-	case processedimage.FieldDimentions:
-		m.ResetDimentions()
-		return nil
-	case processedimage.FieldSizeBits:
-		m.ResetSizeBits()
-		return nil
-	case processedimage.FieldThumb:
-		m.ResetThumb()
-		return nil
-	case processedimage.FieldCreatedAt:
-		m.ResetCreatedAt()
+	case release.FieldName:
+		m.ResetName()
 		return nil
-	case processedimage.FieldUpdatedAt:
-		m.ResetUpdatedAt()
+	case release.FieldType:
+		m.ResetType()
 		return nil
-	case processedimage.FieldDeletedAt:
-		m.ResetDeletedAt()
+	case release.FieldReleaseDate:
+		m.ResetReleaseDate()
 		return nil
 	}
-	return fmt.Errorf("unknown ProcessedImage field %s", name)
+	return fmt.Errorf("unknown Release field %s", name)
 }
 
 // AddedEdges returns all edge names that were set/added in this mutation.
-func (m *ProcessedImageMutation) AddedEdges() []string {
-	edges := make([]string, 0, 1)
-	if m.source != nil {
-		edges = append(edges, processedimage.EdgeSource)
+func (m *ReleaseMutation) AddedEdges() []string {
+	edges := make([]string, 0, 3)
+	if m.image != nil {
+		edges = append(edges, release.EdgeImage)
+	}
+	if m.tracks != nil {
+		edges = append(edges, release.EdgeTracks)
+	}
+	if m.appearing_artists != nil {
+		edges = append(edges, release.EdgeAppearingArtists)
 	}
 	return edges
 }
 
 // AddedIDs returns all IDs (to other nodes) that were added for the given edge
 // name in this mutation.
-func (m *ProcessedImageMutation) AddedIDs(name string) []ent.Value {
+func (m *ReleaseMutation) AddedIDs(name string) []ent.Value {
 	switch name {
-	case processedimage.EdgeSource:
-		if id := m.source; id != nil {
+	case release.EdgeImage:
+		if id := m.image; id != nil {
 			return []ent.Value{*id}
 		}
+	case release.EdgeTracks:
+		ids := make([]ent.Value, 0, len(m.tracks))
+		for id := range m.tracks {
+			ids = append(ids, id)
+		}
+		return ids
+	case release.EdgeAppearingArtists:
+		ids := make([]ent.Value, 0, len(m.appearing_artists))
+		for id := range m.appearing_artists {
+			ids = append(ids, id)
+		}
+		return ids
 	}
 	return nil
 }
 
 // RemovedEdges returns all edge names that were removed in this mutation.
-func (m *ProcessedImageMutation) RemovedEdges() []string {
-	edges := make([]string, 0, 1)
+func (m *ReleaseMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 3)
+	if m.removedtracks != nil {
+		edges = append(edges, release.EdgeTracks)
+	}
+	if m.removedappearing_artists != nil {
+		edges = append(edges, release.EdgeAppearingArtists)
+	}
 	return edges
 }
 
 // RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
 // the given name in this mutation.
-func (m *ProcessedImageMutation) RemovedIDs(name string) []ent.Value {
+func (m *ReleaseMutation) RemovedIDs(name string) []ent.Value {
+	switch name {
+	case release.EdgeTracks:
+		ids := make([]ent.Value, 0, len(m.removedtracks))
+		for id := range m.removedtracks {
+			ids = append(ids, id)
+		}
+		return ids
+	case release.EdgeAppearingArtists:
+		ids := make([]ent.Value, 0, len(m.removedappearing_artists))
+		for id := range m.removedappearing_artists {
+			ids = append(ids, id)
+		}
+		return ids
+	}
 	return nil
 }
 
 // ClearedEdges returns all edge names that were cleared in this mutation.
-func (m *ProcessedImageMutation) ClearedEdges() []string {
-	edges := make([]string, 0, 1)
-	if m.clearedsource {
-		edges = append(edges, processedimage.EdgeSource)
+func (m *ReleaseMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 3)
+	if m.clearedimage {
+		edges = append(edges, release.EdgeImage)
+	}
+	if m.clearedtracks {
+		edges = append(edges, release.EdgeTracks)
+	}
+	if m.clearedappearing_artists {
+		edges = append(edges, release.EdgeAppearingArtists)
 	}
 	return edges
 }
 
 // EdgeCleared returns a boolean which indicates if the edge with the given name
 // was cleared in this mutation.
-func (m *ProcessedImageMutation) EdgeCleared(name string) bool {
+func (m *ReleaseMutation) EdgeCleared(name string) bool {
 	switch name {
-	case processedimage.EdgeSource:
-		return m.clearedsource
+	case release.EdgeImage:
+		return m.clearedimage
+	case release.EdgeTracks:
+		return m.clearedtracks
+	case release.EdgeAppearingArtists:
+		return m.clearedappearing_artists
 	}
 	return false
 }
 
 // ClearEdge clears the value of the edge with the given name. It returns an error
 // if that edge is not defined in the schema.
-func (m *ProcessedImageMutation) ClearEdge(name string) error {
+func (m *ReleaseMutation) ClearEdge(name string) error {
 	switch name {
-	case processedimage.EdgeSource:
-		m.ClearSource()
+	case release.EdgeImage:
+		m.ClearImage()
 		return nil
 	}
-	return fmt.Errorf("unknown ProcessedImage unique edge %s", name)
+	return fmt.Errorf("unknown Release unique edge %s", name)
 }
 
 // ResetEdge resets all changes to the edge with the given name in this mutation.
 // It returns an error if the edge is not defined in the schema.
-func (m *ProcessedImageMutation) ResetEdge(name string) error {
+func (m *ReleaseMutation) ResetEdge(name string) error {
 	switch name {
-	case processedimage.EdgeSource:
-		m.ResetSource()
+	case release.EdgeImage:
+		m.ResetImage()
+		return nil
+	case release.EdgeTracks:
+		m.ResetTracks()
+		return nil
+	case release.EdgeAppearingArtists:
+		m.ResetAppearingArtists()
 		return nil
 	}
-	return fmt.Errorf("unknown ProcessedImage edge %s", name)
+	return fmt.Errorf("unknown Release edge %s", name)
 }
 
-// ReleaseMutation represents an operation that mutates the Release nodes in the graph.
-type ReleaseMutation struct {
+// ReleaseAppearanceMutation represents an operation that mutates the ReleaseAppearance nodes in the graph.
+type ReleaseAppearanceMutation struct {
 	config
-	op                       Op
-	typ                      string
-	id                       *pid.ID
-	name                     *string
-	_type                    *release.Type
-	release_date             *time.Time
-	clearedFields            map[string]struct{}
-	image                    *pid.ID
-	clearedimage             bool
-	tracks                   map[pid.ID]struct{}
-	removedtracks            map[pid.ID]struct{}
-	clearedtracks            bool
-	appearing_artists        map[pid.ID]struct{}
-	removedappearing_artists map[pid.ID]struct{}
-	clearedappearing_artists bool
-	done                     bool
-	oldValue                 func(context.Context) (*Release, error)
-	predicates               []predicate.Release
+	op             Op
+	typ            string
+	_order         *int
+	add_order      *int
+	clearedFields  map[string]struct{}
+	artist         *pid.ID
+	clearedartist  bool
+	release        *pid.ID
+	clearedrelease bool
+	done           bool
+	oldValue       func(context.Context) (*ReleaseAppearance, error)
+	predicates     []predicate.ReleaseAppearance
 }
 
-var _ ent.Mutation = (*ReleaseMutation)(nil)
+var _ ent.Mutation = (*ReleaseAppearanceMutation)(nil)
 
-// releaseOption allows management of the mutation configuration using functional options.
-type releaseOption func(*ReleaseMutation)
+// releaseappearanceOption allows management of the mutation configuration using functional options.
+type releaseappearanceOption func(*ReleaseAppearanceMutation)
 
-// newReleaseMutation creates new mutation for the Release entity.
-func newReleaseMutation(c config, op Op, opts ...releaseOption) *ReleaseMutation {
-	m := &ReleaseMutation{
+// newReleaseAppearanceMutation creates new mutation for the ReleaseAppearance entity.
+func newReleaseAppearanceMutation(c config, op Op, opts ...releaseappearanceOption) *ReleaseAppearanceMutation {
+	m := &ReleaseAppearanceMutation{
 		config:        c,
 		op:            op,
-		typ:           TypeRelease,
-		clearedFields: make(map[string]struct{}),
-	}
-	for _, opt := range opts {
-		opt(m)
-	}
-	return m
-}
-
-// withReleaseID sets the ID field of the mutation.
-func withReleaseID(id pid.ID) releaseOption {
-	return func(m *ReleaseMutation) {
-		var (
-			err   error
-			once  sync.Once
-			value *Release
-		)
-		m.oldValue = func(ctx context.Context) (*Release, error) {
-			once.Do(func() {
-				if m.done {
-					err = errors.New("querying old values post mutation is not allowed")
-				} else {
-					value, err = m.Client().Release.Get(ctx, id)
-				}
-			})
-			return value, err
-		}
-		m.id = &id
-	}
-}
-
-// withRelease sets the old Release of the mutation.
-func withRelease(node *Release) releaseOption {
-	return func(m *ReleaseMutation) {
-		m.oldValue = func(context.Context) (*Release, error) {
-			return node, nil
-		}
-		m.id = &node.ID
-	}
-}
-
-// Client returns a new `ent.Client` from the mutation. If the mutation was
-// executed in a transaction (ent.Tx), a transactional client is returned.
-func (m ReleaseMutation) Client() *Client {
-	client := &Client{config: m.config}
-	client.init()
-	return client
-}
-
-// Tx returns an `ent.Tx` for mutations that were executed in transactions;
-// it returns an error otherwise.
-func (m ReleaseMutation) Tx() (*Tx, error) {
-	if _, ok := m.driver.(*txDriver); !ok {
-		return nil, errors.New("ent: mutation is not running in a transaction")
-	}
-	tx := &Tx{config: m.config}
-	tx.init()
-	return tx, nil
-}
-
-// SetID sets the value of the id field. Note that this
-// operation is only accepted on creation of Release entities.
-func (m *ReleaseMutation) SetID(id pid.ID) {
-	m.id = &id
-}
-
-// ID returns the ID value in the mutation. Note that the ID is only available
-// if it was provided to the builder or after it was returned from the database.
-func (m *ReleaseMutation) ID() (id pid.ID, exists bool) {
-	if m.id == nil {
-		return
-	}
-	return *m.id, true
-}
-
-// IDs queries the database and returns the entity ids that match the mutation's predicate.
-// That means, if the mutation is applied within a transaction with an isolation level such
-// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
-// or updated by the mutation.
-func (m *ReleaseMutation) IDs(ctx context.Context) ([]pid.ID, error) {
-	switch {
-	case m.op.Is(OpUpdateOne | OpDeleteOne):
-		id, exists := m.ID()
-		if exists {
-			return []pid.ID{id}, nil
-		}
-		fallthrough
-	case m.op.Is(OpUpdate | OpDelete):
-		return m.Client().Release.Query().Where(m.predicates...).IDs(ctx)
-	default:
-		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
-	}
-}
-
-// SetName sets the "name" field.
-func (m *ReleaseMutation) SetName(s string) {
-	m.name = &s
-}
-
-// Name returns the value of the "name" field in the mutation.
-func (m *ReleaseMutation) Name() (r string, exists bool) {
-	v := m.name
-	if v == nil {
-		return
-	}
-	return *v, true
-}
-
-// OldName returns the old "name" field's value of the Release entity.
-// If the Release object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ReleaseMutation) OldName(ctx context.Context) (v string, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldName is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldName requires an ID field in the mutation")
-	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldName: %w", err)
-	}
-	return oldValue.Name, nil
-}
-
-// ResetName resets all changes to the "name" field.
-func (m *ReleaseMutation) ResetName() {
-	m.name = nil
-}
-
-// SetType sets the "type" field.
-func (m *ReleaseMutation) SetType(r release.Type) {
-	m._type = &r
-}
-
-// GetType returns the value of the "type" field in the mutation.
-func (m *ReleaseMutation) GetType() (r release.Type, exists bool) {
-	v := m._type
-	if v == nil {
-		return
-	}
-	return *v, true
-}
-
-// OldType returns the old "type" field's value of the Release entity.
-// If the Release object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ReleaseMutation) OldType(ctx context.Context) (v release.Type, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldType is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldType requires an ID field in the mutation")
-	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldType: %w", err)
+		typ:           TypeReleaseAppearance,
+		clearedFields: make(map[string]struct{}),
 	}
-	return oldValue.Type, nil
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
 }
 
-// ResetType resets all changes to the "type" field.
-func (m *ReleaseMutation) ResetType() {
-	m._type = nil
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m ReleaseAppearanceMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
 }
 
-// SetReleaseDate sets the "release_date" field.
-func (m *ReleaseMutation) SetReleaseDate(t time.Time) {
-	m.release_date = &t
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m ReleaseAppearanceMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
 }
 
-// ReleaseDate returns the value of the "release_date" field in the mutation.
-func (m *ReleaseMutation) ReleaseDate() (r time.Time, exists bool) {
-	v := m.release_date
+// SetReleaseID sets the "release_id" field.
+func (m *ReleaseAppearanceMutation) SetReleaseID(pi pid.ID) {
+	m.release = &pi
+}
+
+// ReleaseID returns the value of the "release_id" field in the mutation.
+func (m *ReleaseAppearanceMutation) ReleaseID() (r pid.ID, exists bool) {
+	v := m.release
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldReleaseDate returns the old "release_date" field's value of the Release entity.
-// If the Release object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ReleaseMutation) OldReleaseDate(ctx context.Context) (v time.Time, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldReleaseDate is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldReleaseDate requires an ID field in the mutation")
-	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldReleaseDate: %w", err)
-	}
-	return oldValue.ReleaseDate, nil
+// ResetReleaseID resets all changes to the "release_id" field.
+func (m *ReleaseAppearanceMutation) ResetReleaseID() {
+	m.release = nil
 }
 
-// ResetReleaseDate resets all changes to the "release_date" field.
-func (m *ReleaseMutation) ResetReleaseDate() {
-	m.release_date = nil
+// SetArtistID sets the "artist_id" field.
+func (m *ReleaseAppearanceMutation) SetArtistID(pi pid.ID) {
+	m.artist = &pi
 }
 
-// SetImageID sets the "image" edge to the Image entity by id.
-func (m *ReleaseMutation) SetImageID(id pid.ID) {
-	m.image = &id
+// ArtistID returns the value of the "artist_id" field in the mutation.
+func (m *ReleaseAppearanceMutation) ArtistID() (r pid.ID, exists bool) {
+	v := m.artist
+	if v == nil {
+		return
+	}
+	return *v, true
 }
 
-// ClearImage clears the "image" edge to the Image entity.
-func (m *ReleaseMutation) ClearImage() {
-	m.clearedimage = true
+// ResetArtistID resets all changes to the "artist_id" field.
+func (m *ReleaseAppearanceMutation) ResetArtistID() {
+	m.artist = nil
 }
 
-// ImageCleared reports if the "image" edge to the Image entity was cleared.
-func (m *ReleaseMutation) ImageCleared() bool {
-	return m.clearedimage
+// SetOrder sets the "order" field.
+func (m *ReleaseAppearanceMutation) SetOrder(i int) {
+	m._order = &i
+	m.add_order = nil
 }
 
-// ImageID returns the "image" edge ID in the mutation.
-func (m *ReleaseMutation) ImageID() (id pid.ID, exists bool) {
-	if m.image != nil {
-		return *m.image, true
+// Order returns the value of the "order" field in the mutation.
+func (m *ReleaseAppearanceMutation) Order() (r int, exists bool) {
+	v := m._order
+	if v == nil {
+		return
 	}
-	return
+	return *v, true
 }
 
-// ImageIDs returns the "image" edge IDs in the mutation.
-// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
-// ImageID instead. It exists only for internal usage by the builders.
-func (m *ReleaseMutation) ImageIDs() (ids []pid.ID) {
-	if id := m.image; id != nil {
-		ids = append(ids, *id)
+// AddOrder adds i to the "order" field.
+func (m *ReleaseAppearanceMutation) AddOrder(i int) {
+	if m.add_order != nil {
+		*m.add_order += i
+	} else {
+		m.add_order = &i
 	}
-	return
-}
-
-// ResetImage resets all changes to the "image" edge.
-func (m *ReleaseMutation) ResetImage() {
-	m.image = nil
-	m.clearedimage = false
 }
 
-// AddTrackIDs adds the "tracks" edge to the Track entity by ids.
-func (m *ReleaseMutation) AddTrackIDs(ids ...pid.ID) {
-	if m.tracks == nil {
-		m.tracks = make(map[pid.ID]struct{})
-	}
-	for i := range ids {
-		m.tracks[ids[i]] = struct{}{}
+// AddedOrder returns the value that was added to the "order" field in this mutation.
+func (m *ReleaseAppearanceMutation) AddedOrder() (r int, exists bool) {
+	v := m.add_order
+	if v == nil {
+		return
 	}
+	return *v, true
 }
 
-// ClearTracks clears the "tracks" edge to the Track entity.
-func (m *ReleaseMutation) ClearTracks() {
-	m.clearedtracks = true
-}
-
-// TracksCleared reports if the "tracks" edge to the Track entity was cleared.
-func (m *ReleaseMutation) TracksCleared() bool {
-	return m.clearedtracks
+// ResetOrder resets all changes to the "order" field.
+func (m *ReleaseAppearanceMutation) ResetOrder() {
+	m._order = nil
+	m.add_order = nil
 }
 
-// RemoveTrackIDs removes the "tracks" edge to the Track entity by IDs.
-func (m *ReleaseMutation) RemoveTrackIDs(ids ...pid.ID) {
-	if m.removedtracks == nil {
-		m.removedtracks = make(map[pid.ID]struct{})
-	}
-	for i := range ids {
-		delete(m.tracks, ids[i])
-		m.removedtracks[ids[i]] = struct{}{}
-	}
+// ClearArtist clears the "artist" edge to the Artist entity.
+func (m *ReleaseAppearanceMutation) ClearArtist() {
+	m.clearedartist = true
+	m.clearedFields[releaseappearance.FieldArtistID] = struct{}{}
 }
 
-// RemovedTracks returns the removed IDs of the "tracks" edge to the Track entity.
-func (m *ReleaseMutation) RemovedTracksIDs() (ids []pid.ID) {
-	for id := range m.removedtracks {
-		ids = append(ids, id)
-	}
-	return
+// ArtistCleared reports if the "artist" edge to the Artist entity was cleared.
+func (m *ReleaseAppearanceMutation) ArtistCleared() bool {
+	return m.clearedartist
 }
 
-// TracksIDs returns the "tracks" edge IDs in the mutation.
-func (m *ReleaseMutation) TracksIDs() (ids []pid.ID) {
-	for id := range m.tracks {
-		ids = append(ids, id)
+// ArtistIDs returns the "artist" edge IDs in the mutation.
+// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
+// ArtistID instead. It exists only for internal usage by the builders.
+func (m *ReleaseAppearanceMutation) ArtistIDs() (ids []pid.ID) {
+	if id := m.artist; id != nil {
+		ids = append(ids, *id)
 	}
 	return
 }
 
-// ResetTracks resets all changes to the "tracks" edge.
-func (m *ReleaseMutation) ResetTracks() {
-	m.tracks = nil
-	m.clearedtracks = false
-	m.removedtracks = nil
-}
-
-// AddAppearingArtistIDs adds the "appearing_artists" edge to the Artist entity by ids.
-func (m *ReleaseMutation) AddAppearingArtistIDs(ids ...pid.ID) {
-	if m.appearing_artists == nil {
-		m.appearing_artists = make(map[pid.ID]struct{})
-	}
-	for i := range ids {
-		m.appearing_artists[ids[i]] = struct{}{}
-	}
-}
-
-// ClearAppearingArtists clears the "appearing_artists" edge to the Artist entity.
-func (m *ReleaseMutation) ClearAppearingArtists() {
-	m.clearedappearing_artists = true
-}
-
-// AppearingArtistsCleared reports if the "appearing_artists" edge to the Artist entity was cleared.
-func (m *ReleaseMutation) AppearingArtistsCleared() bool {
-	return m.clearedappearing_artists
+// ResetArtist resets all changes to the "artist" edge.
+func (m *ReleaseAppearanceMutation) ResetArtist() {
+	m.artist = nil
+	m.clearedartist = false
 }
 
-// RemoveAppearingArtistIDs removes the "appearing_artists" edge to the Artist entity by IDs.
-func (m *ReleaseMutation) RemoveAppearingArtistIDs(ids ...pid.ID) {
-	if m.removedappearing_artists == nil {
-		m.removedappearing_artists = make(map[pid.ID]struct{})
-	}
-	for i := range ids {
-		delete(m.appearing_artists, ids[i])
-		m.removedappearing_artists[ids[i]] = struct{}{}
-	}
+// ClearRelease clears the "release" edge to the Release entity.
+func (m *ReleaseAppearanceMutation) ClearRelease() {
+	m.clearedrelease = true
+	m.clearedFields[releaseappearance.FieldReleaseID] = struct{}{}
 }
 
-// RemovedAppearingArtists returns the removed IDs of the "appearing_artists" edge to the Artist entity.
-func (m *ReleaseMutation) RemovedAppearingArtistsIDs() (ids []pid.ID) {
-	for id := range m.removedappearing_artists {
-		ids = append(ids, id)
-	}
-	return
+// ReleaseCleared reports if the "release" edge to the Release entity was cleared.
+func (m *ReleaseAppearanceMutation) ReleaseCleared() bool {
+	return m.clearedrelease
 }
 
-// AppearingArtistsIDs returns the "appearing_artists" edge IDs in the mutation.
-func (m *ReleaseMutation) AppearingArtistsIDs() (ids []pid.ID) {
-	for id := range m.appearing_artists {
-		ids = append(ids, id)
+// ReleaseIDs returns the "release" edge IDs in the mutation.
+// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
+// ReleaseID instead. It exists only for internal usage by the builders.
+func (m *ReleaseAppearanceMutation) ReleaseIDs() (ids []pid.ID) {
+	if id := m.release; id != nil {
+		ids = append(ids, *id)
 	}
 	return
 }
 
-// ResetAppearingArtists resets all changes to the "appearing_artists" edge.
-func (m *ReleaseMutation) ResetAppearingArtists() {
-	m.appearing_artists = nil
-	m.clearedappearing_artists = false
-	m.removedappearing_artists = nil
+// ResetRelease resets all changes to the "release" edge.
+func (m *ReleaseAppearanceMutation) ResetRelease() {
+	m.release = nil
+	m.clearedrelease = false
 }
 
-// Where appends a list predicates to the ReleaseMutation builder.
-func (m *ReleaseMutation) Where(ps ...predicate.Release) {
+// Where appends a list predicates to the ReleaseAppearanceMutation builder.
+func (m *ReleaseAppearanceMutation) Where(ps ...predicate.ReleaseAppearance) {
 	m.predicates = append(m.predicates, ps...)
 }
 
-// WhereP appends storage-level predicates to the ReleaseMutation builder. Using this method,
+// WhereP appends storage-level predicates to the ReleaseAppearanceMutation builder. Using this method,
 // users can use type-assertion to append predicates that do not depend on any generated package.
-func (m *ReleaseMutation) WhereP(ps ...func(*sql.Selector)) {
-	p := make([]predicate.Release, len(ps))
+func (m *ReleaseAppearanceMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.ReleaseAppearance, len(ps))
 	for i := range ps {
 		p[i] = ps[i]
 	}
@@ -4157,33 +6691,33 @@ func (m *ReleaseMutation) WhereP(ps ...func(*sql.Selector)) {
 }
 
 // Op returns the operation name.
-func (m *ReleaseMutation) Op() Op {
+func (m *ReleaseAppearanceMutation) Op() Op {
 	return m.op
 }
 
 // SetOp allows setting the mutation operation.
-func (m *ReleaseMutation) SetOp(op Op) {
+func (m *ReleaseAppearanceMutation) SetOp(op Op) {
 	m.op = op
 }
 
-// Type returns the node type of this mutation (Release).
-func (m *ReleaseMutation) Type() string {
+// Type returns the node type of this mutation (ReleaseAppearance).
+func (m *ReleaseAppearanceMutation) Type() string {
 	return m.typ
 }
 
 // Fields returns all fields that were changed during this mutation. Note that in
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
-func (m *ReleaseMutation) Fields() []string {
+func (m *ReleaseAppearanceMutation) Fields() []string {
 	fields := make([]string, 0, 3)
-	if m.name != nil {
-		fields = append(fields, release.FieldName)
+	if m.release != nil {
+		fields = append(fields, releaseappearance.FieldReleaseID)
 	}
-	if m._type != nil {
-		fields = append(fields, release.FieldType)
+	if m.artist != nil {
+		fields = append(fields, releaseappearance.FieldArtistID)
 	}
-	if m.release_date != nil {
-		fields = append(fields, release.FieldReleaseDate)
+	if m._order != nil {
+		fields = append(fields, releaseappearance.FieldOrder)
 	}
 	return fields
 }
@@ -4191,14 +6725,14 @@ func (m *ReleaseMutation) Fields() []string {
 // Field returns the value of a field with the given name. The second boolean
 // return value indicates that this field was not set, or was not defined in the
 // schema.
-func (m *ReleaseMutation) Field(name string) (ent.Value, bool) {
+func (m *ReleaseAppearanceMutation) Field(name string) (ent.Value, bool) {
 	switch name {
-	case release.FieldName:
-		return m.Name()
-	case release.FieldType:
-		return m.GetType()
-	case release.FieldReleaseDate:
-		return m.ReleaseDate()
+	case releaseappearance.FieldReleaseID:
+		return m.ReleaseID()
+	case releaseappearance.FieldArtistID:
+		return m.ArtistID()
+	case releaseappearance.FieldOrder:
+		return m.Order()
 	}
 	return nil, false
 }
@@ -4206,262 +6740,236 @@ func (m *ReleaseMutation) Field(name string) (ent.Value, bool) {
 // OldField returns the old value of the field from the database. An error is
 // returned if the mutation operation is not UpdateOne, or the query to the
 // database failed.
-func (m *ReleaseMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
-	switch name {
-	case release.FieldName:
-		return m.OldName(ctx)
-	case release.FieldType:
-		return m.OldType(ctx)
-	case release.FieldReleaseDate:
-		return m.OldReleaseDate(ctx)
-	}
-	return nil, fmt.Errorf("unknown Release field %s", name)
+func (m *ReleaseAppearanceMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	return nil, errors.New("edge schema ReleaseAppearance does not support getting old values")
 }
 
 // SetField sets the value of a field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *ReleaseMutation) SetField(name string, value ent.Value) error {
+func (m *ReleaseAppearanceMutation) SetField(name string, value ent.Value) error {
 	switch name {
-	case release.FieldName:
-		v, ok := value.(string)
+	case releaseappearance.FieldReleaseID:
+		v, ok := value.(pid.ID)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetName(v)
+		m.SetReleaseID(v)
 		return nil
-	case release.FieldType:
-		v, ok := value.(release.Type)
+	case releaseappearance.FieldArtistID:
+		v, ok := value.(pid.ID)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetType(v)
+		m.SetArtistID(v)
 		return nil
-	case release.FieldReleaseDate:
-		v, ok := value.(time.Time)
+	case releaseappearance.FieldOrder:
+		v, ok := value.(int)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetReleaseDate(v)
+		m.SetOrder(v)
 		return nil
 	}
-	return fmt.Errorf("unknown Release field %s", name)
+	return fmt.Errorf("unknown ReleaseAppearance field %s", name)
 }
 
 // AddedFields returns all numeric fields that were incremented/decremented during
 // this mutation.
-func (m *ReleaseMutation) AddedFields() []string {
-	return nil
+func (m *ReleaseAppearanceMutation) AddedFields() []string {
+	var fields []string
+	if m.add_order != nil {
+		fields = append(fields, releaseappearance.FieldOrder)
+	}
+	return fields
 }
 
 // AddedField returns the numeric value that was incremented/decremented on a field
 // with the given name. The second boolean return value indicates that this field
 // was not set, or was not defined in the schema.
-func (m *ReleaseMutation) AddedField(name string) (ent.Value, bool) {
+func (m *ReleaseAppearanceMutation) AddedField(name string) (ent.Value, bool) {
+	switch name {
+	case releaseappearance.FieldOrder:
+		return m.AddedOrder()
+	}
 	return nil, false
 }
 
 // AddField adds the value to the field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *ReleaseMutation) AddField(name string, value ent.Value) error {
+func (m *ReleaseAppearanceMutation) AddField(name string, value ent.Value) error {
 	switch name {
+	case releaseappearance.FieldOrder:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddOrder(v)
+		return nil
 	}
-	return fmt.Errorf("unknown Release numeric field %s", name)
+	return fmt.Errorf("unknown ReleaseAppearance numeric field %s", name)
 }
 
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
-func (m *ReleaseMutation) ClearedFields() []string {
+func (m *ReleaseAppearanceMutation) ClearedFields() []string {
 	return nil
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
 // cleared in this mutation.
-func (m *ReleaseMutation) FieldCleared(name string) bool {
+func (m *ReleaseAppearanceMutation) FieldCleared(name string) bool {
 	_, ok := m.clearedFields[name]
 	return ok
 }
 
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
-func (m *ReleaseMutation) ClearField(name string) error {
-	return fmt.Errorf("unknown Release nullable field %s", name)
+func (m *ReleaseAppearanceMutation) ClearField(name string) error {
+	return fmt.Errorf("unknown ReleaseAppearance nullable field %s", name)
 }
 
 // ResetField resets all changes in the mutation for the field with the given name.
 // It returns an error if the field is not defined in the schema.
-func (m *ReleaseMutation) ResetField(name string) error {
+func (m *ReleaseAppearanceMutation) ResetField(name string) error {
 	switch name {
-	case release.FieldName:
-		m.ResetName()
+	case releaseappearance.FieldReleaseID:
+		m.ResetReleaseID()
 		return nil
-	case release.FieldType:
-		m.ResetType()
+	case releaseappearance.FieldArtistID:
+		m.ResetArtistID()
 		return nil
-	case release.FieldReleaseDate:
-		m.ResetReleaseDate()
+	case releaseappearance.FieldOrder:
+		m.ResetOrder()
 		return nil
 	}
-	return fmt.Errorf("unknown Release field %s", name)
+	return fmt.Errorf("unknown ReleaseAppearance field %s", name)
 }
 
 // AddedEdges returns all edge names that were set/added in this mutation.
-func (m *ReleaseMutation) AddedEdges() []string {
-	edges := make([]string, 0, 3)
-	if m.image != nil {
-		edges = append(edges, release.EdgeImage)
-	}
-	if m.tracks != nil {
-		edges = append(edges, release.EdgeTracks)
+func (m *ReleaseAppearanceMutation) AddedEdges() []string {
+	edges := make([]string, 0, 2)
+	if m.artist != nil {
+		edges = append(edges, releaseappearance.EdgeArtist)
 	}
-	if m.appearing_artists != nil {
-		edges = append(edges, release.EdgeAppearingArtists)
+	if m.release != nil {
+		edges = append(edges, releaseappearance.EdgeRelease)
 	}
 	return edges
 }
 
 // AddedIDs returns all IDs (to other nodes) that were added for the given edge
 // name in this mutation.
-func (m *ReleaseMutation) AddedIDs(name string) []ent.Value {
+func (m *ReleaseAppearanceMutation) AddedIDs(name string) []ent.Value {
 	switch name {
-	case release.EdgeImage:
-		if id := m.image; id != nil {
+	case releaseappearance.EdgeArtist:
+		if id := m.artist; id != nil {
 			return []ent.Value{*id}
 		}
-	case release.EdgeTracks:
-		ids := make([]ent.Value, 0, len(m.tracks))
-		for id := range m.tracks {
-			ids = append(ids, id)
-		}
-		return ids
-	case release.EdgeAppearingArtists:
-		ids := make([]ent.Value, 0, len(m.appearing_artists))
-		for id := range m.appearing_artists {
-			ids = append(ids, id)
+	case releaseappearance.EdgeRelease:
+		if id := m.release; id != nil {
+			return []ent.Value{*id}
 		}
-		return ids
 	}
 	return nil
 }
 
 // RemovedEdges returns all edge names that were removed in this mutation.
-func (m *ReleaseMutation) RemovedEdges() []string {
-	edges := make([]string, 0, 3)
-	if m.removedtracks != nil {
-		edges = append(edges, release.EdgeTracks)
-	}
-	if m.removedappearing_artists != nil {
-		edges = append(edges, release.EdgeAppearingArtists)
-	}
+func (m *ReleaseAppearanceMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 2)
 	return edges
 }
 
 // RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
 // the given name in this mutation.
-func (m *ReleaseMutation) RemovedIDs(name string) []ent.Value {
-	switch name {
-	case release.EdgeTracks:
-		ids := make([]ent.Value, 0, len(m.removedtracks))
-		for id := range m.removedtracks {
-			ids = append(ids, id)
-		}
-		return ids
-	case release.EdgeAppearingArtists:
-		ids := make([]ent.Value, 0, len(m.removedappearing_artists))
-		for id := range m.removedappearing_artists {
-			ids = append(ids, id)
-		}
-		return ids
-	}
+func (m *ReleaseAppearanceMutation) RemovedIDs(name string) []ent.Value {
 	return nil
 }
 
 // ClearedEdges returns all edge names that were cleared in this mutation.
-func (m *ReleaseMutation) ClearedEdges() []string {
-	edges := make([]string, 0, 3)
-	if m.clearedimage {
-		edges = append(edges, release.EdgeImage)
-	}
-	if m.clearedtracks {
-		edges = append(edges, release.EdgeTracks)
+func (m *ReleaseAppearanceMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 2)
+	if m.clearedartist {
+		edges = append(edges, releaseappearance.EdgeArtist)
 	}
-	if m.clearedappearing_artists {
-		edges = append(edges, release.EdgeAppearingArtists)
+	if m.clearedrelease {
+		edges = append(edges, releaseappearance.EdgeRelease)
 	}
 	return edges
 }
 
 // EdgeCleared returns a boolean which indicates if the edge with the given name
 // was cleared in this mutation.
-func (m *ReleaseMutation) EdgeCleared(name string) bool {
+func (m *ReleaseAppearanceMutation) EdgeCleared(name string) bool {
 	switch name {
-	case release.EdgeImage:
-		return m.clearedimage
-	case release.EdgeTracks:
-		return m.clearedtracks
-	case release.EdgeAppearingArtists:
-		return m.clearedappearing_artists
+	case releaseappearance.EdgeArtist:
+		return m.clearedartist
+	case releaseappearance.EdgeRelease:
+		return m.clearedrelease
 	}
 	return false
 }
 
 // ClearEdge clears the value of the edge with the given name. It returns an error
 // if that edge is not defined in the schema.
-func (m *ReleaseMutation) ClearEdge(name string) error {
+func (m *ReleaseAppearanceMutation) ClearEdge(name string) error {
 	switch name {
-	case release.EdgeImage:
-		m.ClearImage()
+	case releaseappearance.EdgeArtist:
+		m.ClearArtist()
+		return nil
+	case releaseappearance.EdgeRelease:
+		m.ClearRelease()
 		return nil
 	}
-	return fmt.Errorf("unknown Release unique edge %s", name)
+	return fmt.Errorf("unknown ReleaseAppearance unique edge %s", name)
 }
 
 // ResetEdge resets all changes to the edge with the given name in this mutation.
 // It returns an error if the edge is not defined in the schema.
-func (m *ReleaseMutation) ResetEdge(name string) error {
+func (m *ReleaseAppearanceMutation) ResetEdge(name string) error {
 	switch name {
-	case release.EdgeImage:
-		m.ResetImage()
-		return nil
-	case release.EdgeTracks:
-		m.ResetTracks()
+	case releaseappearance.EdgeArtist:
+		m.ResetArtist()
 		return nil
-	case release.EdgeAppearingArtists:
-		m.ResetAppearingArtists()
+	case releaseappearance.EdgeRelease:
+		m.ResetRelease()
 		return nil
 	}
-	return fmt.Errorf("unknown Release edge %s", name)
+	return fmt.Errorf("unknown ReleaseAppearance edge %s", name)
 }
 
-// ReleaseAppearanceMutation represents an operation that mutates the ReleaseAppearance nodes in the graph.
-type ReleaseAppearanceMutation struct {
+// SessionMutation represents an operation that mutates the Session nodes in the graph.
+type SessionMutation struct {
 	config
-	op             Op
-	typ            string
-	_order         *int
-	add_order      *int
-	clearedFields  map[string]struct{}
-	artist         *pid.ID
-	clearedartist  bool
-	release        *pid.ID
-	clearedrelease bool
-	done           bool
-	oldValue       func(context.Context) (*ReleaseAppearance, error)
-	predicates     []predicate.ReleaseAppearance
+	op            Op
+	typ           string
+	id            *pid.ID
+	refresh_hash  *[]byte
+	device        *string
+	created_at    *time.Time
+	last_used_at  *time.Time
+	expires_at    *time.Time
+	revoked_at    *time.Time
+	clearedFields map[string]struct{}
+	user          *pid.ID
+	cleareduser   bool
+	done          bool
+	oldValue      func(context.Context) (*Session, error)
+	predicates    []predicate.Session
 }
 
-var _ ent.Mutation = (*ReleaseAppearanceMutation)(nil)
+var _ ent.Mutation = (*SessionMutation)(nil)
 
-// releaseappearanceOption allows management of the mutation configuration using functional options.
-type releaseappearanceOption func(*ReleaseAppearanceMutation)
+// sessionOption allows management of the mutation configuration using functional options.
+type sessionOption func(*SessionMutation)
 
-// newReleaseAppearanceMutation creates new mutation for the ReleaseAppearance entity.
-func newReleaseAppearanceMutation(c config, op Op, opts ...releaseappearanceOption) *ReleaseAppearanceMutation {
-	m := &ReleaseAppearanceMutation{
+// newSessionMutation creates new mutation for the Session entity.
+func newSessionMutation(c config, op Op, opts ...sessionOption) *SessionMutation {
+	m := &SessionMutation{
 		config:        c,
 		op:            op,
-		typ:           TypeReleaseAppearance,
+		typ:           TypeSession,
 		clearedFields: make(map[string]struct{}),
 	}
 	for _, opt := range opts {
@@ -4470,9 +6978,41 @@ func newReleaseAppearanceMutation(c config, op Op, opts ...releaseappearanceOpti
 	return m
 }
 
+// withSessionID sets the ID field of the mutation.
+func withSessionID(id pid.ID) sessionOption {
+	return func(m *SessionMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *Session
+		)
+		m.oldValue = func(ctx context.Context) (*Session, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().Session.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withSession sets the old Session of the mutation.
+func withSession(node *Session) sessionOption {
+	return func(m *SessionMutation) {
+		m.oldValue = func(context.Context) (*Session, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
 // Client returns a new `ent.Client` from the mutation. If the mutation was
 // executed in a transaction (ent.Tx), a transactional client is returned.
-func (m ReleaseAppearanceMutation) Client() *Client {
+func (m SessionMutation) Client() *Client {
 	client := &Client{config: m.config}
 	client.init()
 	return client
@@ -4480,7 +7020,7 @@ func (m ReleaseAppearanceMutation) Client() *Client {
 
 // Tx returns an `ent.Tx` for mutations that were executed in transactions;
 // it returns an error otherwise.
-func (m ReleaseAppearanceMutation) Tx() (*Tx, error) {
+func (m SessionMutation) Tx() (*Tx, error) {
 	if _, ok := m.driver.(*txDriver); !ok {
 		return nil, errors.New("ent: mutation is not running in a transaction")
 	}
@@ -4489,146 +7029,317 @@ func (m ReleaseAppearanceMutation) Tx() (*Tx, error) {
 	return tx, nil
 }
 
-// SetReleaseID sets the "release_id" field.
-func (m *ReleaseAppearanceMutation) SetReleaseID(pi pid.ID) {
-	m.release = &pi
+// SetID sets the value of the id field. Note that this
+// operation is only accepted on creation of Session entities.
+func (m *SessionMutation) SetID(id pid.ID) {
+	m.id = &id
 }
 
-// ReleaseID returns the value of the "release_id" field in the mutation.
-func (m *ReleaseAppearanceMutation) ReleaseID() (r pid.ID, exists bool) {
-	v := m.release
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *SessionMutation) ID() (id pid.ID, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *SessionMutation) IDs(ctx context.Context) ([]pid.ID, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []pid.ID{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().Session.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetRefreshHash sets the "refresh_hash" field.
+func (m *SessionMutation) SetRefreshHash(b []byte) {
+	m.refresh_hash = &b
+}
+
+// RefreshHash returns the value of the "refresh_hash" field in the mutation.
+func (m *SessionMutation) RefreshHash() (r []byte, exists bool) {
+	v := m.refresh_hash
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// ResetReleaseID resets all changes to the "release_id" field.
-func (m *ReleaseAppearanceMutation) ResetReleaseID() {
-	m.release = nil
+// OldRefreshHash returns the old "refresh_hash" field's value of the Session entity.
+// If the Session object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *SessionMutation) OldRefreshHash(ctx context.Context) (v []byte, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldRefreshHash is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldRefreshHash requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldRefreshHash: %w", err)
+	}
+	return oldValue.RefreshHash, nil
 }
 
-// SetArtistID sets the "artist_id" field.
-func (m *ReleaseAppearanceMutation) SetArtistID(pi pid.ID) {
-	m.artist = &pi
+// ResetRefreshHash resets all changes to the "refresh_hash" field.
+func (m *SessionMutation) ResetRefreshHash() {
+	m.refresh_hash = nil
 }
 
-// ArtistID returns the value of the "artist_id" field in the mutation.
-func (m *ReleaseAppearanceMutation) ArtistID() (r pid.ID, exists bool) {
-	v := m.artist
+// SetDevice sets the "device" field.
+func (m *SessionMutation) SetDevice(s string) {
+	m.device = &s
+}
+
+// Device returns the value of the "device" field in the mutation.
+func (m *SessionMutation) Device() (r string, exists bool) {
+	v := m.device
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// ResetArtistID resets all changes to the "artist_id" field.
-func (m *ReleaseAppearanceMutation) ResetArtistID() {
-	m.artist = nil
+// OldDevice returns the old "device" field's value of the Session entity.
+// If the Session object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *SessionMutation) OldDevice(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldDevice is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldDevice requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldDevice: %w", err)
+	}
+	return oldValue.Device, nil
 }
 
-// SetOrder sets the "order" field.
-func (m *ReleaseAppearanceMutation) SetOrder(i int) {
-	m._order = &i
-	m.add_order = nil
+// ResetDevice resets all changes to the "device" field.
+func (m *SessionMutation) ResetDevice() {
+	m.device = nil
 }
 
-// Order returns the value of the "order" field in the mutation.
-func (m *ReleaseAppearanceMutation) Order() (r int, exists bool) {
-	v := m._order
+// SetCreatedAt sets the "created_at" field.
+func (m *SessionMutation) SetCreatedAt(t time.Time) {
+	m.created_at = &t
+}
+
+// CreatedAt returns the value of the "created_at" field in the mutation.
+func (m *SessionMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m.created_at
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// AddOrder adds i to the "order" field.
-func (m *ReleaseAppearanceMutation) AddOrder(i int) {
-	if m.add_order != nil {
-		*m.add_order += i
-	} else {
-		m.add_order = &i
+// OldCreatedAt returns the old "created_at" field's value of the Session entity.
+// If the Session object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *SessionMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
 	}
+	return oldValue.CreatedAt, nil
 }
 
-// AddedOrder returns the value that was added to the "order" field in this mutation.
-func (m *ReleaseAppearanceMutation) AddedOrder() (r int, exists bool) {
-	v := m.add_order
+// ResetCreatedAt resets all changes to the "created_at" field.
+func (m *SessionMutation) ResetCreatedAt() {
+	m.created_at = nil
+}
+
+// SetLastUsedAt sets the "last_used_at" field.
+func (m *SessionMutation) SetLastUsedAt(t time.Time) {
+	m.last_used_at = &t
+}
+
+// LastUsedAt returns the value of the "last_used_at" field in the mutation.
+func (m *SessionMutation) LastUsedAt() (r time.Time, exists bool) {
+	v := m.last_used_at
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// ResetOrder resets all changes to the "order" field.
-func (m *ReleaseAppearanceMutation) ResetOrder() {
-	m._order = nil
-	m.add_order = nil
+// OldLastUsedAt returns the old "last_used_at" field's value of the Session entity.
+// If the Session object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *SessionMutation) OldLastUsedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldLastUsedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldLastUsedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldLastUsedAt: %w", err)
+	}
+	return oldValue.LastUsedAt, nil
 }
 
-// ClearArtist clears the "artist" edge to the Artist entity.
-func (m *ReleaseAppearanceMutation) ClearArtist() {
-	m.clearedartist = true
-	m.clearedFields[releaseappearance.FieldArtistID] = struct{}{}
+// ResetLastUsedAt resets all changes to the "last_used_at" field.
+func (m *SessionMutation) ResetLastUsedAt() {
+	m.last_used_at = nil
 }
 
-// ArtistCleared reports if the "artist" edge to the Artist entity was cleared.
-func (m *ReleaseAppearanceMutation) ArtistCleared() bool {
-	return m.clearedartist
+// SetExpiresAt sets the "expires_at" field.
+func (m *SessionMutation) SetExpiresAt(t time.Time) {
+	m.expires_at = &t
 }
 
-// ArtistIDs returns the "artist" edge IDs in the mutation.
-// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
-// ArtistID instead. It exists only for internal usage by the builders.
-func (m *ReleaseAppearanceMutation) ArtistIDs() (ids []pid.ID) {
-	if id := m.artist; id != nil {
-		ids = append(ids, *id)
+// ExpiresAt returns the value of the "expires_at" field in the mutation.
+func (m *SessionMutation) ExpiresAt() (r time.Time, exists bool) {
+	v := m.expires_at
+	if v == nil {
+		return
 	}
-	return
+	return *v, true
 }
 
-// ResetArtist resets all changes to the "artist" edge.
-func (m *ReleaseAppearanceMutation) ResetArtist() {
-	m.artist = nil
-	m.clearedartist = false
+// OldExpiresAt returns the old "expires_at" field's value of the Session entity.
+// If the Session object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *SessionMutation) OldExpiresAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldExpiresAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldExpiresAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldExpiresAt: %w", err)
+	}
+	return oldValue.ExpiresAt, nil
 }
 
-// ClearRelease clears the "release" edge to the Release entity.
-func (m *ReleaseAppearanceMutation) ClearRelease() {
-	m.clearedrelease = true
-	m.clearedFields[releaseappearance.FieldReleaseID] = struct{}{}
+// ResetExpiresAt resets all changes to the "expires_at" field.
+func (m *SessionMutation) ResetExpiresAt() {
+	m.expires_at = nil
 }
 
-// ReleaseCleared reports if the "release" edge to the Release entity was cleared.
-func (m *ReleaseAppearanceMutation) ReleaseCleared() bool {
-	return m.clearedrelease
+// SetRevokedAt sets the "revoked_at" field.
+func (m *SessionMutation) SetRevokedAt(t time.Time) {
+	m.revoked_at = &t
 }
 
-// ReleaseIDs returns the "release" edge IDs in the mutation.
+// RevokedAt returns the value of the "revoked_at" field in the mutation.
+func (m *SessionMutation) RevokedAt() (r time.Time, exists bool) {
+	v := m.revoked_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldRevokedAt returns the old "revoked_at" field's value of the Session entity.
+// If the Session object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *SessionMutation) OldRevokedAt(ctx context.Context) (v *time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldRevokedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldRevokedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldRevokedAt: %w", err)
+	}
+	return oldValue.RevokedAt, nil
+}
+
+// ClearRevokedAt clears the value of the "revoked_at" field.
+func (m *SessionMutation) ClearRevokedAt() {
+	m.revoked_at = nil
+	m.clearedFields[session.FieldRevokedAt] = struct{}{}
+}
+
+// RevokedAtCleared returns if the "revoked_at" field was cleared in this mutation.
+func (m *SessionMutation) RevokedAtCleared() bool {
+	_, ok := m.clearedFields[session.FieldRevokedAt]
+	return ok
+}
+
+// ResetRevokedAt resets all changes to the "revoked_at" field.
+func (m *SessionMutation) ResetRevokedAt() {
+	m.revoked_at = nil
+	delete(m.clearedFields, session.FieldRevokedAt)
+}
+
+// SetUserID sets the "user" edge to the User entity by id.
+func (m *SessionMutation) SetUserID(id pid.ID) {
+	m.user = &id
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (m *SessionMutation) ClearUser() {
+	m.cleareduser = true
+}
+
+// UserCleared reports if the "user" edge to the User entity was cleared.
+func (m *SessionMutation) UserCleared() bool {
+	return m.cleareduser
+}
+
+// UserID returns the "user" edge ID in the mutation.
+func (m *SessionMutation) UserID() (id pid.ID, exists bool) {
+	if m.user != nil {
+		return *m.user, true
+	}
+	return
+}
+
+// UserIDs returns the "user" edge IDs in the mutation.
 // Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
-// ReleaseID instead. It exists only for internal usage by the builders.
-func (m *ReleaseAppearanceMutation) ReleaseIDs() (ids []pid.ID) {
-	if id := m.release; id != nil {
+// UserID instead. It exists only for internal usage by the builders.
+func (m *SessionMutation) UserIDs() (ids []pid.ID) {
+	if id := m.user; id != nil {
 		ids = append(ids, *id)
 	}
 	return
 }
 
-// ResetRelease resets all changes to the "release" edge.
-func (m *ReleaseAppearanceMutation) ResetRelease() {
-	m.release = nil
-	m.clearedrelease = false
+// ResetUser resets all changes to the "user" edge.
+func (m *SessionMutation) ResetUser() {
+	m.user = nil
+	m.cleareduser = false
 }
 
-// Where appends a list predicates to the ReleaseAppearanceMutation builder.
-func (m *ReleaseAppearanceMutation) Where(ps ...predicate.ReleaseAppearance) {
+// Where appends a list predicates to the SessionMutation builder.
+func (m *SessionMutation) Where(ps ...predicate.Session) {
 	m.predicates = append(m.predicates, ps...)
 }
 
-// WhereP appends storage-level predicates to the ReleaseAppearanceMutation builder. Using this method,
+// WhereP appends storage-level predicates to the SessionMutation builder. Using this method,
 // users can use type-assertion to append predicates that do not depend on any generated package.
-func (m *ReleaseAppearanceMutation) WhereP(ps ...func(*sql.Selector)) {
-	p := make([]predicate.ReleaseAppearance, len(ps))
+func (m *SessionMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.Session, len(ps))
 	for i := range ps {
 		p[i] = ps[i]
 	}
@@ -4636,33 +7347,42 @@ func (m *ReleaseAppearanceMutation) WhereP(ps ...func(*sql.Selector)) {
 }
 
 // Op returns the operation name.
-func (m *ReleaseAppearanceMutation) Op() Op {
+func (m *SessionMutation) Op() Op {
 	return m.op
 }
 
 // SetOp allows setting the mutation operation.
-func (m *ReleaseAppearanceMutation) SetOp(op Op) {
+func (m *SessionMutation) SetOp(op Op) {
 	m.op = op
 }
 
-// Type returns the node type of this mutation (ReleaseAppearance).
-func (m *ReleaseAppearanceMutation) Type() string {
+// Type returns the node type of this mutation (Session).
+func (m *SessionMutation) Type() string {
 	return m.typ
 }
 
 // Fields returns all fields that were changed during this mutation. Note that in
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
-func (m *ReleaseAppearanceMutation) Fields() []string {
-	fields := make([]string, 0, 3)
-	if m.release != nil {
-		fields = append(fields, releaseappearance.FieldReleaseID)
+func (m *SessionMutation) Fields() []string {
+	fields := make([]string, 0, 6)
+	if m.refresh_hash != nil {
+		fields = append(fields, session.FieldRefreshHash)
 	}
-	if m.artist != nil {
-		fields = append(fields, releaseappearance.FieldArtistID)
+	if m.device != nil {
+		fields = append(fields, session.FieldDevice)
 	}
-	if m._order != nil {
-		fields = append(fields, releaseappearance.FieldOrder)
+	if m.created_at != nil {
+		fields = append(fields, session.FieldCreatedAt)
+	}
+	if m.last_used_at != nil {
+		fields = append(fields, session.FieldLastUsedAt)
+	}
+	if m.expires_at != nil {
+		fields = append(fields, session.FieldExpiresAt)
+	}
+	if m.revoked_at != nil {
+		fields = append(fields, session.FieldRevokedAt)
 	}
 	return fields
 }
@@ -4670,14 +7390,20 @@ func (m *ReleaseAppearanceMutation) Fields() []string {
 // Field returns the value of a field with the given name. The second boolean
 // return value indicates that this field was not set, or was not defined in the
 // schema.
-func (m *ReleaseAppearanceMutation) Field(name string) (ent.Value, bool) {
+func (m *SessionMutation) Field(name string) (ent.Value, bool) {
 	switch name {
-	case releaseappearance.FieldReleaseID:
-		return m.ReleaseID()
-	case releaseappearance.FieldArtistID:
-		return m.ArtistID()
-	case releaseappearance.FieldOrder:
-		return m.Order()
+	case session.FieldRefreshHash:
+		return m.RefreshHash()
+	case session.FieldDevice:
+		return m.Device()
+	case session.FieldCreatedAt:
+		return m.CreatedAt()
+	case session.FieldLastUsedAt:
+		return m.LastUsedAt()
+	case session.FieldExpiresAt:
+		return m.ExpiresAt()
+	case session.FieldRevokedAt:
+		return m.RevokedAt()
 	}
 	return nil, false
 }
@@ -4685,135 +7411,166 @@ func (m *ReleaseAppearanceMutation) Field(name string) (ent.Value, bool) {
 // OldField returns the old value of the field from the database. An error is
 // returned if the mutation operation is not UpdateOne, or the query to the
 // database failed.
-func (m *ReleaseAppearanceMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
-	return nil, errors.New("edge schema ReleaseAppearance does not support getting old values")
+func (m *SessionMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case session.FieldRefreshHash:
+		return m.OldRefreshHash(ctx)
+	case session.FieldDevice:
+		return m.OldDevice(ctx)
+	case session.FieldCreatedAt:
+		return m.OldCreatedAt(ctx)
+	case session.FieldLastUsedAt:
+		return m.OldLastUsedAt(ctx)
+	case session.FieldExpiresAt:
+		return m.OldExpiresAt(ctx)
+	case session.FieldRevokedAt:
+		return m.OldRevokedAt(ctx)
+	}
+	return nil, fmt.Errorf("unknown Session field %s", name)
 }
 
 // SetField sets the value of a field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *ReleaseAppearanceMutation) SetField(name string, value ent.Value) error {
+func (m *SessionMutation) SetField(name string, value ent.Value) error {
 	switch name {
-	case releaseappearance.FieldReleaseID:
-		v, ok := value.(pid.ID)
+	case session.FieldRefreshHash:
+		v, ok := value.([]byte)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetReleaseID(v)
+		m.SetRefreshHash(v)
 		return nil
-	case releaseappearance.FieldArtistID:
-		v, ok := value.(pid.ID)
+	case session.FieldDevice:
+		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetArtistID(v)
+		m.SetDevice(v)
 		return nil
-	case releaseappearance.FieldOrder:
-		v, ok := value.(int)
+	case session.FieldCreatedAt:
+		v, ok := value.(time.Time)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetOrder(v)
+		m.SetCreatedAt(v)
+		return nil
+	case session.FieldLastUsedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetLastUsedAt(v)
+		return nil
+	case session.FieldExpiresAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetExpiresAt(v)
+		return nil
+	case session.FieldRevokedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetRevokedAt(v)
 		return nil
 	}
-	return fmt.Errorf("unknown ReleaseAppearance field %s", name)
+	return fmt.Errorf("unknown Session field %s", name)
 }
 
 // AddedFields returns all numeric fields that were incremented/decremented during
 // this mutation.
-func (m *ReleaseAppearanceMutation) AddedFields() []string {
-	var fields []string
-	if m.add_order != nil {
-		fields = append(fields, releaseappearance.FieldOrder)
-	}
-	return fields
+func (m *SessionMutation) AddedFields() []string {
+	return nil
 }
 
 // AddedField returns the numeric value that was incremented/decremented on a field
 // with the given name. The second boolean return value indicates that this field
 // was not set, or was not defined in the schema.
-func (m *ReleaseAppearanceMutation) AddedField(name string) (ent.Value, bool) {
-	switch name {
-	case releaseappearance.FieldOrder:
-		return m.AddedOrder()
-	}
+func (m *SessionMutation) AddedField(name string) (ent.Value, bool) {
 	return nil, false
 }
 
 // AddField adds the value to the field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *ReleaseAppearanceMutation) AddField(name string, value ent.Value) error {
+func (m *SessionMutation) AddField(name string, value ent.Value) error {
 	switch name {
-	case releaseappearance.FieldOrder:
-		v, ok := value.(int)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.AddOrder(v)
-		return nil
 	}
-	return fmt.Errorf("unknown ReleaseAppearance numeric field %s", name)
+	return fmt.Errorf("unknown Session numeric field %s", name)
 }
 
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
-func (m *ReleaseAppearanceMutation) ClearedFields() []string {
-	return nil
+func (m *SessionMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(session.FieldRevokedAt) {
+		fields = append(fields, session.FieldRevokedAt)
+	}
+	return fields
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
 // cleared in this mutation.
-func (m *ReleaseAppearanceMutation) FieldCleared(name string) bool {
+func (m *SessionMutation) FieldCleared(name string) bool {
 	_, ok := m.clearedFields[name]
 	return ok
 }
 
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
-func (m *ReleaseAppearanceMutation) ClearField(name string) error {
-	return fmt.Errorf("unknown ReleaseAppearance nullable field %s", name)
+func (m *SessionMutation) ClearField(name string) error {
+	switch name {
+	case session.FieldRevokedAt:
+		m.ClearRevokedAt()
+		return nil
+	}
+	return fmt.Errorf("unknown Session nullable field %s", name)
 }
 
 // ResetField resets all changes in the mutation for the field with the given name.
 // It returns an error if the field is not defined in the schema.
-func (m *ReleaseAppearanceMutation) ResetField(name string) error {
+func (m *SessionMutation) ResetField(name string) error {
 	switch name {
-	case releaseappearance.FieldReleaseID:
-		m.ResetReleaseID()
+	case session.FieldRefreshHash:
+		m.ResetRefreshHash()
 		return nil
-	case releaseappearance.FieldArtistID:
-		m.ResetArtistID()
+	case session.FieldDevice:
+		m.ResetDevice()
 		return nil
-	case releaseappearance.FieldOrder:
-		m.ResetOrder()
+	case session.FieldCreatedAt:
+		m.ResetCreatedAt()
+		return nil
+	case session.FieldLastUsedAt:
+		m.ResetLastUsedAt()
+		return nil
+	case session.FieldExpiresAt:
+		m.ResetExpiresAt()
+		return nil
+	case session.FieldRevokedAt:
+		m.ResetRevokedAt()
 		return nil
 	}
-	return fmt.Errorf("unknown ReleaseAppearance field %s", name)
+	return fmt.Errorf("unknown Session field %s", name)
 }
 
 // AddedEdges returns all edge names that were set/added in this mutation.
-func (m *ReleaseAppearanceMutation) AddedEdges() []string {
-	edges := make([]string, 0, 2)
-	if m.artist != nil {
-		edges = append(edges, releaseappearance.EdgeArtist)
-	}
-	if m.release != nil {
-		edges = append(edges, releaseappearance.EdgeRelease)
+func (m *SessionMutation) AddedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.user != nil {
+		edges = append(edges, session.EdgeUser)
 	}
 	return edges
 }
 
 // AddedIDs returns all IDs (to other nodes) that were added for the given edge
 // name in this mutation.
-func (m *ReleaseAppearanceMutation) AddedIDs(name string) []ent.Value {
+func (m *SessionMutation) AddedIDs(name string) []ent.Value {
 	switch name {
-	case releaseappearance.EdgeArtist:
-		if id := m.artist; id != nil {
-			return []ent.Value{*id}
-		}
-	case releaseappearance.EdgeRelease:
-		if id := m.release; id != nil {
+	case session.EdgeUser:
+		if id := m.user; id != nil {
 			return []ent.Value{*id}
 		}
 	}
@@ -4821,67 +7578,56 @@ func (m *ReleaseAppearanceMutation) AddedIDs(name string) []ent.Value {
 }
 
 // RemovedEdges returns all edge names that were removed in this mutation.
-func (m *ReleaseAppearanceMutation) RemovedEdges() []string {
-	edges := make([]string, 0, 2)
+func (m *SessionMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 1)
 	return edges
 }
 
 // RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
 // the given name in this mutation.
-func (m *ReleaseAppearanceMutation) RemovedIDs(name string) []ent.Value {
+func (m *SessionMutation) RemovedIDs(name string) []ent.Value {
 	return nil
 }
 
 // ClearedEdges returns all edge names that were cleared in this mutation.
-func (m *ReleaseAppearanceMutation) ClearedEdges() []string {
-	edges := make([]string, 0, 2)
-	if m.clearedartist {
-		edges = append(edges, releaseappearance.EdgeArtist)
-	}
-	if m.clearedrelease {
-		edges = append(edges, releaseappearance.EdgeRelease)
+func (m *SessionMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.cleareduser {
+		edges = append(edges, session.EdgeUser)
 	}
 	return edges
 }
 
 // EdgeCleared returns a boolean which indicates if the edge with the given name
 // was cleared in this mutation.
-func (m *ReleaseAppearanceMutation) EdgeCleared(name string) bool {
+func (m *SessionMutation) EdgeCleared(name string) bool {
 	switch name {
-	case releaseappearance.EdgeArtist:
-		return m.clearedartist
-	case releaseappearance.EdgeRelease:
-		return m.clearedrelease
+	case session.EdgeUser:
+		return m.cleareduser
 	}
 	return false
 }
 
 // ClearEdge clears the value of the edge with the given name. It returns an error
 // if that edge is not defined in the schema.
-func (m *ReleaseAppearanceMutation) ClearEdge(name string) error {
+func (m *SessionMutation) ClearEdge(name string) error {
 	switch name {
-	case releaseappearance.EdgeArtist:
-		m.ClearArtist()
-		return nil
-	case releaseappearance.EdgeRelease:
-		m.ClearRelease()
+	case session.EdgeUser:
+		m.ClearUser()
 		return nil
 	}
-	return fmt.Errorf("unknown ReleaseAppearance unique edge %s", name)
+	return fmt.Errorf("unknown Session unique edge %s", name)
 }
 
 // ResetEdge resets all changes to the edge with the given name in this mutation.
 // It returns an error if the edge is not defined in the schema.
-func (m *ReleaseAppearanceMutation) ResetEdge(name string) error {
+func (m *SessionMutation) ResetEdge(name string) error {
 	switch name {
-	case releaseappearance.EdgeArtist:
-		m.ResetArtist()
-		return nil
-	case releaseappearance.EdgeRelease:
-		m.ResetRelease()
+	case session.EdgeUser:
+		m.ResetUser()
 		return nil
 	}
-	return fmt.Errorf("unknown ReleaseAppearance edge %s", name)
+	return fmt.Errorf("unknown Session edge %s", name)
 }
 
 // TaskMutation represents an operation that mutates the Task nodes in the graph.
@@ -6551,20 +9297,27 @@ func (m *TrackAppearanceMutation) ResetEdge(name string) error {
 // UserMutation represents an operation that mutates the User nodes in the graph.
 type UserMutation struct {
 	config
-	op            Op
-	typ           string
-	id            *pid.ID
-	username      *string
-	password      *[]byte
-	is_admin      *bool
-	created_at    *time.Time
-	clearedFields map[string]struct{}
-	images        map[pid.ID]struct{}
-	removedimages map[pid.ID]struct{}
-	clearedimages bool
-	done          bool
-	oldValue      func(context.Context) (*User, error)
-	predicates    []predicate.User
+	op                Op
+	typ               string
+	id                *pid.ID
+	username          *string
+	password          *[]byte
+	oidc_subject      *string
+	is_admin          *bool
+	created_at        *time.Time
+	clearedFields     map[string]struct{}
+	images            map[pid.ID]struct{}
+	removedimages     map[pid.ID]struct{}
+	clearedimages     bool
+	sessions          map[pid.ID]struct{}
+	removedsessions   map[pid.ID]struct{}
+	clearedsessions   bool
+	api_tokens        map[pid.ID]struct{}
+	removedapi_tokens map[pid.ID]struct{}
+	clearedapi_tokens bool
+	done              bool
+	oldValue          func(context.Context) (*User, error)
+	predicates        []predicate.User
 }
 
 var _ ent.Mutation = (*UserMutation)(nil)
@@ -6738,9 +9491,71 @@ func (m *UserMutation) OldPassword(ctx context.Context) (v []byte, err error) {
 	return oldValue.Password, nil
 }
 
+// ClearPassword clears the value of the "password" field.
+func (m *UserMutation) ClearPassword() {
+	m.password = nil
+	m.clearedFields[user.FieldPassword] = struct{}{}
+}
+
+// PasswordCleared returns if the "password" field was cleared in this mutation.
+func (m *UserMutation) PasswordCleared() bool {
+	_, ok := m.clearedFields[user.FieldPassword]
+	return ok
+}
+
 // ResetPassword resets all changes to the "password" field.
 func (m *UserMutation) ResetPassword() {
 	m.password = nil
+	delete(m.clearedFields, user.FieldPassword)
+}
+
+// SetOidcSubject sets the "oidc_subject" field.
+func (m *UserMutation) SetOidcSubject(s string) {
+	m.oidc_subject = &s
+}
+
+// OidcSubject returns the value of the "oidc_subject" field in the mutation.
+func (m *UserMutation) OidcSubject() (r string, exists bool) {
+	v := m.oidc_subject
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldOidcSubject returns the old "oidc_subject" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldOidcSubject(ctx context.Context) (v *string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldOidcSubject is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldOidcSubject requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldOidcSubject: %w", err)
+	}
+	return oldValue.OidcSubject, nil
+}
+
+// ClearOidcSubject clears the value of the "oidc_subject" field.
+func (m *UserMutation) ClearOidcSubject() {
+	m.oidc_subject = nil
+	m.clearedFields[user.FieldOidcSubject] = struct{}{}
+}
+
+// OidcSubjectCleared returns if the "oidc_subject" field was cleared in this mutation.
+func (m *UserMutation) OidcSubjectCleared() bool {
+	_, ok := m.clearedFields[user.FieldOidcSubject]
+	return ok
+}
+
+// ResetOidcSubject resets all changes to the "oidc_subject" field.
+func (m *UserMutation) ResetOidcSubject() {
+	m.oidc_subject = nil
+	delete(m.clearedFields, user.FieldOidcSubject)
 }
 
 // SetIsAdmin sets the "is_admin" field.
@@ -6869,6 +9684,114 @@ func (m *UserMutation) ResetImages() {
 	m.removedimages = nil
 }
 
+// AddSessionIDs adds the "sessions" edge to the Session entity by ids.
+func (m *UserMutation) AddSessionIDs(ids ...pid.ID) {
+	if m.sessions == nil {
+		m.sessions = make(map[pid.ID]struct{})
+	}
+	for i := range ids {
+		m.sessions[ids[i]] = struct{}{}
+	}
+}
+
+// ClearSessions clears the "sessions" edge to the Session entity.
+func (m *UserMutation) ClearSessions() {
+	m.clearedsessions = true
+}
+
+// SessionsCleared reports if the "sessions" edge to the Session entity was cleared.
+func (m *UserMutation) SessionsCleared() bool {
+	return m.clearedsessions
+}
+
+// RemoveSessionIDs removes the "sessions" edge to the Session entity by IDs.
+func (m *UserMutation) RemoveSessionIDs(ids ...pid.ID) {
+	if m.removedsessions == nil {
+		m.removedsessions = make(map[pid.ID]struct{})
+	}
+	for i := range ids {
+		delete(m.sessions, ids[i])
+		m.removedsessions[ids[i]] = struct{}{}
+	}
+}
+
+// RemovedSessions returns the removed IDs of the "sessions" edge to the Session entity.
+func (m *UserMutation) RemovedSessionsIDs() (ids []pid.ID) {
+	for id := range m.removedsessions {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// SessionsIDs returns the "sessions" edge IDs in the mutation.
+func (m *UserMutation) SessionsIDs() (ids []pid.ID) {
+	for id := range m.sessions {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ResetSessions resets all changes to the "sessions" edge.
+func (m *UserMutation) ResetSessions() {
+	m.sessions = nil
+	m.clearedsessions = false
+	m.removedsessions = nil
+}
+
+// AddAPITokenIDs adds the "api_tokens" edge to the ApiToken entity by ids.
+func (m *UserMutation) AddAPITokenIDs(ids ...pid.ID) {
+	if m.api_tokens == nil {
+		m.api_tokens = make(map[pid.ID]struct{})
+	}
+	for i := range ids {
+		m.api_tokens[ids[i]] = struct{}{}
+	}
+}
+
+// ClearAPITokens clears the "api_tokens" edge to the ApiToken entity.
+func (m *UserMutation) ClearAPITokens() {
+	m.clearedapi_tokens = true
+}
+
+// APITokensCleared reports if the "api_tokens" edge to the ApiToken entity was cleared.
+func (m *UserMutation) APITokensCleared() bool {
+	return m.clearedapi_tokens
+}
+
+// RemoveAPITokenIDs removes the "api_tokens" edge to the ApiToken entity by IDs.
+func (m *UserMutation) RemoveAPITokenIDs(ids ...pid.ID) {
+	if m.removedapi_tokens == nil {
+		m.removedapi_tokens = make(map[pid.ID]struct{})
+	}
+	for i := range ids {
+		delete(m.api_tokens, ids[i])
+		m.removedapi_tokens[ids[i]] = struct{}{}
+	}
+}
+
+// RemovedAPITokens returns the removed IDs of the "api_tokens" edge to the ApiToken entity.
+func (m *UserMutation) RemovedAPITokensIDs() (ids []pid.ID) {
+	for id := range m.removedapi_tokens {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// APITokensIDs returns the "api_tokens" edge IDs in the mutation.
+func (m *UserMutation) APITokensIDs() (ids []pid.ID) {
+	for id := range m.api_tokens {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ResetAPITokens resets all changes to the "api_tokens" edge.
+func (m *UserMutation) ResetAPITokens() {
+	m.api_tokens = nil
+	m.clearedapi_tokens = false
+	m.removedapi_tokens = nil
+}
+
 // Where appends a list predicates to the UserMutation builder.
 func (m *UserMutation) Where(ps ...predicate.User) {
 	m.predicates = append(m.predicates, ps...)
@@ -6903,13 +9826,16 @@ func (m *UserMutation) Type() string {
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
 func (m *UserMutation) Fields() []string {
-	fields := make([]string, 0, 4)
+	fields := make([]string, 0, 5)
 	if m.username != nil {
 		fields = append(fields, user.FieldUsername)
 	}
 	if m.password != nil {
 		fields = append(fields, user.FieldPassword)
 	}
+	if m.oidc_subject != nil {
+		fields = append(fields, user.FieldOidcSubject)
+	}
 	if m.is_admin != nil {
 		fields = append(fields, user.FieldIsAdmin)
 	}
@@ -6928,6 +9854,8 @@ func (m *UserMutation) Field(name string) (ent.Value, bool) {
 		return m.Username()
 	case user.FieldPassword:
 		return m.Password()
+	case user.FieldOidcSubject:
+		return m.OidcSubject()
 	case user.FieldIsAdmin:
 		return m.IsAdmin()
 	case user.FieldCreatedAt:
@@ -6945,6 +9873,8 @@ func (m *UserMutation) OldField(ctx context.Context, name string) (ent.Value, er
 		return m.OldUsername(ctx)
 	case user.FieldPassword:
 		return m.OldPassword(ctx)
+	case user.FieldOidcSubject:
+		return m.OldOidcSubject(ctx)
 	case user.FieldIsAdmin:
 		return m.OldIsAdmin(ctx)
 	case user.FieldCreatedAt:
@@ -6972,6 +9902,13 @@ func (m *UserMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetPassword(v)
 		return nil
+	case user.FieldOidcSubject:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetOidcSubject(v)
+		return nil
 	case user.FieldIsAdmin:
 		v, ok := value.(bool)
 		if !ok {
@@ -7015,7 +9952,14 @@ func (m *UserMutation) AddField(name string, value ent.Value) error {
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
 func (m *UserMutation) ClearedFields() []string {
-	return nil
+	var fields []string
+	if m.FieldCleared(user.FieldPassword) {
+		fields = append(fields, user.FieldPassword)
+	}
+	if m.FieldCleared(user.FieldOidcSubject) {
+		fields = append(fields, user.FieldOidcSubject)
+	}
+	return fields
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
@@ -7028,6 +9972,14 @@ func (m *UserMutation) FieldCleared(name string) bool {
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
 func (m *UserMutation) ClearField(name string) error {
+	switch name {
+	case user.FieldPassword:
+		m.ClearPassword()
+		return nil
+	case user.FieldOidcSubject:
+		m.ClearOidcSubject()
+		return nil
+	}
 	return fmt.Errorf("unknown User nullable field %s", name)
 }
 
@@ -7041,6 +9993,9 @@ func (m *UserMutation) ResetField(name string) error {
 	case user.FieldPassword:
 		m.ResetPassword()
 		return nil
+	case user.FieldOidcSubject:
+		m.ResetOidcSubject()
+		return nil
 	case user.FieldIsAdmin:
 		m.ResetIsAdmin()
 		return nil
@@ -7053,10 +10008,16 @@ func (m *UserMutation) ResetField(name string) error {
 
 // AddedEdges returns all edge names that were set/added in this mutation.
 func (m *UserMutation) AddedEdges() []string {
-	edges := make([]string, 0, 1)
+	edges := make([]string, 0, 3)
 	if m.images != nil {
 		edges = append(edges, user.EdgeImages)
 	}
+	if m.sessions != nil {
+		edges = append(edges, user.EdgeSessions)
+	}
+	if m.api_tokens != nil {
+		edges = append(edges, user.EdgeAPITokens)
+	}
 	return edges
 }
 
@@ -7070,16 +10031,34 @@ func (m *UserMutation) AddedIDs(name string) []ent.Value {
 			ids = append(ids, id)
 		}
 		return ids
+	case user.EdgeSessions:
+		ids := make([]ent.Value, 0, len(m.sessions))
+		for id := range m.sessions {
+			ids = append(ids, id)
+		}
+		return ids
+	case user.EdgeAPITokens:
+		ids := make([]ent.Value, 0, len(m.api_tokens))
+		for id := range m.api_tokens {
+			ids = append(ids, id)
+		}
+		return ids
 	}
 	return nil
 }
 
 // RemovedEdges returns all edge names that were removed in this mutation.
 func (m *UserMutation) RemovedEdges() []string {
-	edges := make([]string, 0, 1)
+	edges := make([]string, 0, 3)
 	if m.removedimages != nil {
 		edges = append(edges, user.EdgeImages)
 	}
+	if m.removedsessions != nil {
+		edges = append(edges, user.EdgeSessions)
+	}
+	if m.removedapi_tokens != nil {
+		edges = append(edges, user.EdgeAPITokens)
+	}
 	return edges
 }
 
@@ -7093,16 +10072,34 @@ func (m *UserMutation) RemovedIDs(name string) []ent.Value {
 			ids = append(ids, id)
 		}
 		return ids
+	case user.EdgeSessions:
+		ids := make([]ent.Value, 0, len(m.removedsessions))
+		for id := range m.removedsessions {
+			ids = append(ids, id)
+		}
+		return ids
+	case user.EdgeAPITokens:
+		ids := make([]ent.Value, 0, len(m.removedapi_tokens))
+		for id := range m.removedapi_tokens {
+			ids = append(ids, id)
+		}
+		return ids
 	}
 	return nil
 }
 
 // ClearedEdges returns all edge names that were cleared in this mutation.
 func (m *UserMutation) ClearedEdges() []string {
-	edges := make([]string, 0, 1)
+	edges := make([]string, 0, 3)
 	if m.clearedimages {
 		edges = append(edges, user.EdgeImages)
 	}
+	if m.clearedsessions {
+		edges = append(edges, user.EdgeSessions)
+	}
+	if m.clearedapi_tokens {
+		edges = append(edges, user.EdgeAPITokens)
+	}
 	return edges
 }
 
@@ -7112,6 +10109,10 @@ func (m *UserMutation) EdgeCleared(name string) bool {
 	switch name {
 	case user.EdgeImages:
 		return m.clearedimages
+	case user.EdgeSessions:
+		return m.clearedsessions
+	case user.EdgeAPITokens:
+		return m.clearedapi_tokens
 	}
 	return false
 }
@@ -7131,6 +10132,12 @@ func (m *UserMutation) ResetEdge(name string) error {
 	case user.EdgeImages:
 		m.ResetImages()
 		return nil
+	case user.EdgeSessions:
+		m.ResetSessions()
+		return nil
+	case user.EdgeAPITokens:
+		m.ResetAPITokens()
+		return nil
 	}
 	return fmt.Errorf("unknown User edge %s", name)
 }
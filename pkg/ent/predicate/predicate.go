@@ -6,6 +6,9 @@ import (
 	"entgo.io/ent/dialect/sql"
 )
 
+// ApiToken is the predicate function for apitoken builders.
+type ApiToken func(*sql.Selector)
+
 // Artist is the predicate function for artist builders.
 type Artist func(*sql.Selector)
 
@@ -15,6 +18,9 @@ type Image func(*sql.Selector)
 // ImageData is the predicate function for imagedata builders.
 type ImageData func(*sql.Selector)
 
+// Lockout is the predicate function for lockout builders.
+type Lockout func(*sql.Selector)
+
 // ProcessedImage is the predicate function for processedimage builders.
 type ProcessedImage func(*sql.Selector)
 
@@ -24,6 +30,9 @@ type Release func(*sql.Selector)
 // ReleaseAppearance is the predicate function for releaseappearance builders.
 type ReleaseAppearance func(*sql.Selector)
 
+// Session is the predicate function for session builders.
+type Session func(*sql.Selector)
+
 // Task is the predicate function for task builders.
 type Task func(*sql.Selector)
 
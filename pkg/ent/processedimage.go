@@ -19,20 +19,24 @@ type ProcessedImage struct {
 	config `json:"-"`
 	// ID of the ent.
 	ID pid.ID `json:"id,omitempty"`
+	// DeletedAt holds the value of the "deleted_at" field.
+	DeletedAt *time.Time `json:"deleted_at,omitzero"`
+	// File holds the value of the "file" field.
+	File string `json:"file,omitempty"`
+	// Variant holds the value of the "variant" field.
+	Variant string `json:"variant,omitempty"`
 	// Type holds the value of the "type" field.
 	Type processedimage.Type `json:"type,omitempty"`
-	// Dimentions holds the value of the "dimentions" field.
-	Dimentions int `json:"dimentions,omitempty"`
+	// Width holds the value of the "width" field.
+	Width int `json:"width,omitempty"`
+	// Height holds the value of the "height" field.
+	Height int `json:"height,omitempty"`
 	// SizeBits holds the value of the "size_bits" field.
 	SizeBits uint32 `json:"size_bits,omitempty"`
-	// Thumb holds the value of the "thumb" field.
-	Thumb []byte `json:"thumb,omitempty"`
 	// CreatedAt holds the value of the "created_at" field.
 	CreatedAt time.Time `json:"created_at,omitzero"`
 	// UpdatedAt holds the value of the "updated_at" field.
 	UpdatedAt time.Time `json:"updated_at,omitzero"`
-	// DeletedAt holds the value of the "deleted_at" field.
-	DeletedAt *time.Time `json:"deleted_at,omitzero"`
 	// Edges holds the relations/edges for other nodes in the graph.
 	// The values are being populated by the ProcessedImageQuery when eager-loading is set.
 	Edges                 ProcessedImageEdges `json:"edges"`
@@ -65,13 +69,11 @@ func (*ProcessedImage) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
 	for i := range columns {
 		switch columns[i] {
-		case processedimage.FieldThumb:
-			values[i] = new([]byte)
-		case processedimage.FieldID, processedimage.FieldDimentions, processedimage.FieldSizeBits:
+		case processedimage.FieldID, processedimage.FieldWidth, processedimage.FieldHeight, processedimage.FieldSizeBits:
 			values[i] = new(sql.NullInt64)
-		case processedimage.FieldType:
+		case processedimage.FieldFile, processedimage.FieldVariant, processedimage.FieldType:
 			values[i] = new(sql.NullString)
-		case processedimage.FieldCreatedAt, processedimage.FieldUpdatedAt, processedimage.FieldDeletedAt:
+		case processedimage.FieldDeletedAt, processedimage.FieldCreatedAt, processedimage.FieldUpdatedAt:
 			values[i] = new(sql.NullTime)
 		case processedimage.ForeignKeys[0]: // image_proccesed_image
 			values[i] = new(sql.NullInt64)
@@ -96,17 +98,42 @@ func (pi *ProcessedImage) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				pi.ID = pid.ID(value.Int64)
 			}
+		case processedimage.FieldDeletedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field deleted_at", values[i])
+			} else if value.Valid {
+				pi.DeletedAt = new(time.Time)
+				*pi.DeletedAt = value.Time
+			}
+		case processedimage.FieldFile:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field file", values[i])
+			} else if value.Valid {
+				pi.File = value.String
+			}
+		case processedimage.FieldVariant:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field variant", values[i])
+			} else if value.Valid {
+				pi.Variant = value.String
+			}
 		case processedimage.FieldType:
 			if value, ok := values[i].(*sql.NullString); !ok {
 				return fmt.Errorf("unexpected type %T for field type", values[i])
 			} else if value.Valid {
 				pi.Type = processedimage.Type(value.String)
 			}
-		case processedimage.FieldDimentions:
+		case processedimage.FieldWidth:
 			if value, ok := values[i].(*sql.NullInt64); !ok {
-				return fmt.Errorf("unexpected type %T for field dimentions", values[i])
+				return fmt.Errorf("unexpected type %T for field width", values[i])
 			} else if value.Valid {
-				pi.Dimentions = int(value.Int64)
+				pi.Width = int(value.Int64)
+			}
+		case processedimage.FieldHeight:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field height", values[i])
+			} else if value.Valid {
+				pi.Height = int(value.Int64)
 			}
 		case processedimage.FieldSizeBits:
 			if value, ok := values[i].(*sql.NullInt64); !ok {
@@ -114,12 +141,6 @@ func (pi *ProcessedImage) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				pi.SizeBits = uint32(value.Int64)
 			}
-		case processedimage.FieldThumb:
-			if value, ok := values[i].(*[]byte); !ok {
-				return fmt.Errorf("unexpected type %T for field thumb", values[i])
-			} else if value != nil {
-				pi.Thumb = *value
-			}
 		case processedimage.FieldCreatedAt:
 			if value, ok := values[i].(*sql.NullTime); !ok {
 				return fmt.Errorf("unexpected type %T for field created_at", values[i])
@@ -132,13 +153,6 @@ func (pi *ProcessedImage) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				pi.UpdatedAt = value.Time
 			}
-		case processedimage.FieldDeletedAt:
-			if value, ok := values[i].(*sql.NullTime); !ok {
-				return fmt.Errorf("unexpected type %T for field deleted_at", values[i])
-			} else if value.Valid {
-				pi.DeletedAt = new(time.Time)
-				*pi.DeletedAt = value.Time
-			}
 		case processedimage.ForeignKeys[0]:
 			if value, ok := values[i].(*sql.NullInt64); !ok {
 				return fmt.Errorf("unexpected type %T for field image_proccesed_image", values[i])
@@ -187,28 +201,34 @@ func (pi *ProcessedImage) String() string {
 	var builder strings.Builder
 	builder.WriteString("ProcessedImage(")
 	builder.WriteString(fmt.Sprintf("id=%v, ", pi.ID))
+	if v := pi.DeletedAt; v != nil {
+		builder.WriteString("deleted_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("file=")
+	builder.WriteString(pi.File)
+	builder.WriteString(", ")
+	builder.WriteString("variant=")
+	builder.WriteString(pi.Variant)
+	builder.WriteString(", ")
 	builder.WriteString("type=")
 	builder.WriteString(fmt.Sprintf("%v", pi.Type))
 	builder.WriteString(", ")
-	builder.WriteString("dimentions=")
-	builder.WriteString(fmt.Sprintf("%v", pi.Dimentions))
+	builder.WriteString("width=")
+	builder.WriteString(fmt.Sprintf("%v", pi.Width))
+	builder.WriteString(", ")
+	builder.WriteString("height=")
+	builder.WriteString(fmt.Sprintf("%v", pi.Height))
 	builder.WriteString(", ")
 	builder.WriteString("size_bits=")
 	builder.WriteString(fmt.Sprintf("%v", pi.SizeBits))
 	builder.WriteString(", ")
-	builder.WriteString("thumb=")
-	builder.WriteString(fmt.Sprintf("%v", pi.Thumb))
-	builder.WriteString(", ")
 	builder.WriteString("created_at=")
 	builder.WriteString(pi.CreatedAt.Format(time.ANSIC))
 	builder.WriteString(", ")
 	builder.WriteString("updated_at=")
 	builder.WriteString(pi.UpdatedAt.Format(time.ANSIC))
-	builder.WriteString(", ")
-	if v := pi.DeletedAt; v != nil {
-		builder.WriteString("deleted_at=")
-		builder.WriteString(v.Format(time.ANSIC))
-	}
 	builder.WriteByte(')')
 	return builder.String()
 }
@@ -56,9 +56,29 @@ func IDLTE(id pid.ID) predicate.ProcessedImage {
 	return predicate.ProcessedImage(sql.FieldLTE(FieldID, id))
 }
 
-// Dimentions applies equality check predicate on the "dimentions" field. It's identical to DimentionsEQ.
-func Dimentions(v int) predicate.ProcessedImage {
-	return predicate.ProcessedImage(sql.FieldEQ(FieldDimentions, v))
+// DeletedAt applies equality check predicate on the "deleted_at" field. It's identical to DeletedAtEQ.
+func DeletedAt(v time.Time) predicate.ProcessedImage {
+	return predicate.ProcessedImage(sql.FieldEQ(FieldDeletedAt, v))
+}
+
+// File applies equality check predicate on the "file" field. It's identical to FileEQ.
+func File(v string) predicate.ProcessedImage {
+	return predicate.ProcessedImage(sql.FieldEQ(FieldFile, v))
+}
+
+// Variant applies equality check predicate on the "variant" field. It's identical to VariantEQ.
+func Variant(v string) predicate.ProcessedImage {
+	return predicate.ProcessedImage(sql.FieldEQ(FieldVariant, v))
+}
+
+// Width applies equality check predicate on the "width" field. It's identical to WidthEQ.
+func Width(v int) predicate.ProcessedImage {
+	return predicate.ProcessedImage(sql.FieldEQ(FieldWidth, v))
+}
+
+// Height applies equality check predicate on the "height" field. It's identical to HeightEQ.
+func Height(v int) predicate.ProcessedImage {
+	return predicate.ProcessedImage(sql.FieldEQ(FieldHeight, v))
 }
 
 // SizeBits applies equality check predicate on the "size_bits" field. It's identical to SizeBitsEQ.
@@ -66,11 +86,6 @@ func SizeBits(v uint32) predicate.ProcessedImage {
 	return predicate.ProcessedImage(sql.FieldEQ(FieldSizeBits, v))
 }
 
-// Thumb applies equality check predicate on the "thumb" field. It's identical to ThumbEQ.
-func Thumb(v []byte) predicate.ProcessedImage {
-	return predicate.ProcessedImage(sql.FieldEQ(FieldThumb, v))
-}
-
 // CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
 func CreatedAt(v time.Time) predicate.ProcessedImage {
 	return predicate.ProcessedImage(sql.FieldEQ(FieldCreatedAt, v))
@@ -81,11 +96,206 @@ func UpdatedAt(v time.Time) predicate.ProcessedImage {
 	return predicate.ProcessedImage(sql.FieldEQ(FieldUpdatedAt, v))
 }
 
-// DeletedAt applies equality check predicate on the "deleted_at" field. It's identical to DeletedAtEQ.
-func DeletedAt(v time.Time) predicate.ProcessedImage {
+// DeletedAtEQ applies the EQ predicate on the "deleted_at" field.
+func DeletedAtEQ(v time.Time) predicate.ProcessedImage {
 	return predicate.ProcessedImage(sql.FieldEQ(FieldDeletedAt, v))
 }
 
+// DeletedAtNEQ applies the NEQ predicate on the "deleted_at" field.
+func DeletedAtNEQ(v time.Time) predicate.ProcessedImage {
+	return predicate.ProcessedImage(sql.FieldNEQ(FieldDeletedAt, v))
+}
+
+// DeletedAtIn applies the In predicate on the "deleted_at" field.
+func DeletedAtIn(vs ...time.Time) predicate.ProcessedImage {
+	return predicate.ProcessedImage(sql.FieldIn(FieldDeletedAt, vs...))
+}
+
+// DeletedAtNotIn applies the NotIn predicate on the "deleted_at" field.
+func DeletedAtNotIn(vs ...time.Time) predicate.ProcessedImage {
+	return predicate.ProcessedImage(sql.FieldNotIn(FieldDeletedAt, vs...))
+}
+
+// DeletedAtGT applies the GT predicate on the "deleted_at" field.
+func DeletedAtGT(v time.Time) predicate.ProcessedImage {
+	return predicate.ProcessedImage(sql.FieldGT(FieldDeletedAt, v))
+}
+
+// DeletedAtGTE applies the GTE predicate on the "deleted_at" field.
+func DeletedAtGTE(v time.Time) predicate.ProcessedImage {
+	return predicate.ProcessedImage(sql.FieldGTE(FieldDeletedAt, v))
+}
+
+// DeletedAtLT applies the LT predicate on the "deleted_at" field.
+func DeletedAtLT(v time.Time) predicate.ProcessedImage {
+	return predicate.ProcessedImage(sql.FieldLT(FieldDeletedAt, v))
+}
+
+// DeletedAtLTE applies the LTE predicate on the "deleted_at" field.
+func DeletedAtLTE(v time.Time) predicate.ProcessedImage {
+	return predicate.ProcessedImage(sql.FieldLTE(FieldDeletedAt, v))
+}
+
+// DeletedAtIsNil applies the IsNil predicate on the "deleted_at" field.
+func DeletedAtIsNil() predicate.ProcessedImage {
+	return predicate.ProcessedImage(sql.FieldIsNull(FieldDeletedAt))
+}
+
+// DeletedAtNotNil applies the NotNil predicate on the "deleted_at" field.
+func DeletedAtNotNil() predicate.ProcessedImage {
+	return predicate.ProcessedImage(sql.FieldNotNull(FieldDeletedAt))
+}
+
+// FileEQ applies the EQ predicate on the "file" field.
+func FileEQ(v string) predicate.ProcessedImage {
+	return predicate.ProcessedImage(sql.FieldEQ(FieldFile, v))
+}
+
+// FileNEQ applies the NEQ predicate on the "file" field.
+func FileNEQ(v string) predicate.ProcessedImage {
+	return predicate.ProcessedImage(sql.FieldNEQ(FieldFile, v))
+}
+
+// FileIn applies the In predicate on the "file" field.
+func FileIn(vs ...string) predicate.ProcessedImage {
+	return predicate.ProcessedImage(sql.FieldIn(FieldFile, vs...))
+}
+
+// FileNotIn applies the NotIn predicate on the "file" field.
+func FileNotIn(vs ...string) predicate.ProcessedImage {
+	return predicate.ProcessedImage(sql.FieldNotIn(FieldFile, vs...))
+}
+
+// FileGT applies the GT predicate on the "file" field.
+func FileGT(v string) predicate.ProcessedImage {
+	return predicate.ProcessedImage(sql.FieldGT(FieldFile, v))
+}
+
+// FileGTE applies the GTE predicate on the "file" field.
+func FileGTE(v string) predicate.ProcessedImage {
+	return predicate.ProcessedImage(sql.FieldGTE(FieldFile, v))
+}
+
+// FileLT applies the LT predicate on the "file" field.
+func FileLT(v string) predicate.ProcessedImage {
+	return predicate.ProcessedImage(sql.FieldLT(FieldFile, v))
+}
+
+// FileLTE applies the LTE predicate on the "file" field.
+func FileLTE(v string) predicate.ProcessedImage {
+	return predicate.ProcessedImage(sql.FieldLTE(FieldFile, v))
+}
+
+// FileContains applies the Contains predicate on the "file" field.
+func FileContains(v string) predicate.ProcessedImage {
+	return predicate.ProcessedImage(sql.FieldContains(FieldFile, v))
+}
+
+// FileHasPrefix applies the HasPrefix predicate on the "file" field.
+func FileHasPrefix(v string) predicate.ProcessedImage {
+	return predicate.ProcessedImage(sql.FieldHasPrefix(FieldFile, v))
+}
+
+// FileHasSuffix applies the HasSuffix predicate on the "file" field.
+func FileHasSuffix(v string) predicate.ProcessedImage {
+	return predicate.ProcessedImage(sql.FieldHasSuffix(FieldFile, v))
+}
+
+// FileIsNil applies the IsNil predicate on the "file" field.
+func FileIsNil() predicate.ProcessedImage {
+	return predicate.ProcessedImage(sql.FieldIsNull(FieldFile))
+}
+
+// FileNotNil applies the NotNil predicate on the "file" field.
+func FileNotNil() predicate.ProcessedImage {
+	return predicate.ProcessedImage(sql.FieldNotNull(FieldFile))
+}
+
+// FileEqualFold applies the EqualFold predicate on the "file" field.
+func FileEqualFold(v string) predicate.ProcessedImage {
+	return predicate.ProcessedImage(sql.FieldEqualFold(FieldFile, v))
+}
+
+// FileContainsFold applies the ContainsFold predicate on the "file" field.
+func FileContainsFold(v string) predicate.ProcessedImage {
+	return predicate.ProcessedImage(sql.FieldContainsFold(FieldFile, v))
+}
+
+// VariantEQ applies the EQ predicate on the "variant" field.
+func VariantEQ(v string) predicate.ProcessedImage {
+	return predicate.ProcessedImage(sql.FieldEQ(FieldVariant, v))
+}
+
+// VariantNEQ applies the NEQ predicate on the "variant" field.
+func VariantNEQ(v string) predicate.ProcessedImage {
+	return predicate.ProcessedImage(sql.FieldNEQ(FieldVariant, v))
+}
+
+// VariantIn applies the In predicate on the "variant" field.
+func VariantIn(vs ...string) predicate.ProcessedImage {
+	return predicate.ProcessedImage(sql.FieldIn(FieldVariant, vs...))
+}
+
+// VariantNotIn applies the NotIn predicate on the "variant" field.
+func VariantNotIn(vs ...string) predicate.ProcessedImage {
+	return predicate.ProcessedImage(sql.FieldNotIn(FieldVariant, vs...))
+}
+
+// VariantGT applies the GT predicate on the "variant" field.
+func VariantGT(v string) predicate.ProcessedImage {
+	return predicate.ProcessedImage(sql.FieldGT(FieldVariant, v))
+}
+
+// VariantGTE applies the GTE predicate on the "variant" field.
+func VariantGTE(v string) predicate.ProcessedImage {
+	return predicate.ProcessedImage(sql.FieldGTE(FieldVariant, v))
+}
+
+// VariantLT applies the LT predicate on the "variant" field.
+func VariantLT(v string) predicate.ProcessedImage {
+	return predicate.ProcessedImage(sql.FieldLT(FieldVariant, v))
+}
+
+// VariantLTE applies the LTE predicate on the "variant" field.
+func VariantLTE(v string) predicate.ProcessedImage {
+	return predicate.ProcessedImage(sql.FieldLTE(FieldVariant, v))
+}
+
+// VariantContains applies the Contains predicate on the "variant" field.
+func VariantContains(v string) predicate.ProcessedImage {
+	return predicate.ProcessedImage(sql.FieldContains(FieldVariant, v))
+}
+
+// VariantHasPrefix applies the HasPrefix predicate on the "variant" field.
+func VariantHasPrefix(v string) predicate.ProcessedImage {
+	return predicate.ProcessedImage(sql.FieldHasPrefix(FieldVariant, v))
+}
+
+// VariantHasSuffix applies the HasSuffix predicate on the "variant" field.
+func VariantHasSuffix(v string) predicate.ProcessedImage {
+	return predicate.ProcessedImage(sql.FieldHasSuffix(FieldVariant, v))
+}
+
+// VariantIsNil applies the IsNil predicate on the "variant" field.
+func VariantIsNil() predicate.ProcessedImage {
+	return predicate.ProcessedImage(sql.FieldIsNull(FieldVariant))
+}
+
+// VariantNotNil applies the NotNil predicate on the "variant" field.
+func VariantNotNil() predicate.ProcessedImage {
+	return predicate.ProcessedImage(sql.FieldNotNull(FieldVariant))
+}
+
+// VariantEqualFold applies the EqualFold predicate on the "variant" field.
+func VariantEqualFold(v string) predicate.ProcessedImage {
+	return predicate.ProcessedImage(sql.FieldEqualFold(FieldVariant, v))
+}
+
+// VariantContainsFold applies the ContainsFold predicate on the "variant" field.
+func VariantContainsFold(v string) predicate.ProcessedImage {
+	return predicate.ProcessedImage(sql.FieldContainsFold(FieldVariant, v))
+}
+
 // TypeEQ applies the EQ predicate on the "type" field.
 func TypeEQ(v Type) predicate.ProcessedImage {
 	return predicate.ProcessedImage(sql.FieldEQ(FieldType, v))
@@ -106,44 +316,84 @@ func TypeNotIn(vs ...Type) predicate.ProcessedImage {
 	return predicate.ProcessedImage(sql.FieldNotIn(FieldType, vs...))
 }
 
-// DimentionsEQ applies the EQ predicate on the "dimentions" field.
-func DimentionsEQ(v int) predicate.ProcessedImage {
-	return predicate.ProcessedImage(sql.FieldEQ(FieldDimentions, v))
+// WidthEQ applies the EQ predicate on the "width" field.
+func WidthEQ(v int) predicate.ProcessedImage {
+	return predicate.ProcessedImage(sql.FieldEQ(FieldWidth, v))
+}
+
+// WidthNEQ applies the NEQ predicate on the "width" field.
+func WidthNEQ(v int) predicate.ProcessedImage {
+	return predicate.ProcessedImage(sql.FieldNEQ(FieldWidth, v))
+}
+
+// WidthIn applies the In predicate on the "width" field.
+func WidthIn(vs ...int) predicate.ProcessedImage {
+	return predicate.ProcessedImage(sql.FieldIn(FieldWidth, vs...))
+}
+
+// WidthNotIn applies the NotIn predicate on the "width" field.
+func WidthNotIn(vs ...int) predicate.ProcessedImage {
+	return predicate.ProcessedImage(sql.FieldNotIn(FieldWidth, vs...))
 }
 
-// DimentionsNEQ applies the NEQ predicate on the "dimentions" field.
-func DimentionsNEQ(v int) predicate.ProcessedImage {
-	return predicate.ProcessedImage(sql.FieldNEQ(FieldDimentions, v))
+// WidthGT applies the GT predicate on the "width" field.
+func WidthGT(v int) predicate.ProcessedImage {
+	return predicate.ProcessedImage(sql.FieldGT(FieldWidth, v))
 }
 
-// DimentionsIn applies the In predicate on the "dimentions" field.
-func DimentionsIn(vs ...int) predicate.ProcessedImage {
-	return predicate.ProcessedImage(sql.FieldIn(FieldDimentions, vs...))
+// WidthGTE applies the GTE predicate on the "width" field.
+func WidthGTE(v int) predicate.ProcessedImage {
+	return predicate.ProcessedImage(sql.FieldGTE(FieldWidth, v))
 }
 
-// DimentionsNotIn applies the NotIn predicate on the "dimentions" field.
-func DimentionsNotIn(vs ...int) predicate.ProcessedImage {
-	return predicate.ProcessedImage(sql.FieldNotIn(FieldDimentions, vs...))
+// WidthLT applies the LT predicate on the "width" field.
+func WidthLT(v int) predicate.ProcessedImage {
+	return predicate.ProcessedImage(sql.FieldLT(FieldWidth, v))
 }
 
-// DimentionsGT applies the GT predicate on the "dimentions" field.
-func DimentionsGT(v int) predicate.ProcessedImage {
-	return predicate.ProcessedImage(sql.FieldGT(FieldDimentions, v))
+// WidthLTE applies the LTE predicate on the "width" field.
+func WidthLTE(v int) predicate.ProcessedImage {
+	return predicate.ProcessedImage(sql.FieldLTE(FieldWidth, v))
 }
 
-// DimentionsGTE applies the GTE predicate on the "dimentions" field.
-func DimentionsGTE(v int) predicate.ProcessedImage {
-	return predicate.ProcessedImage(sql.FieldGTE(FieldDimentions, v))
+// HeightEQ applies the EQ predicate on the "height" field.
+func HeightEQ(v int) predicate.ProcessedImage {
+	return predicate.ProcessedImage(sql.FieldEQ(FieldHeight, v))
 }
 
-// DimentionsLT applies the LT predicate on the "dimentions" field.
-func DimentionsLT(v int) predicate.ProcessedImage {
-	return predicate.ProcessedImage(sql.FieldLT(FieldDimentions, v))
+// HeightNEQ applies the NEQ predicate on the "height" field.
+func HeightNEQ(v int) predicate.ProcessedImage {
+	return predicate.ProcessedImage(sql.FieldNEQ(FieldHeight, v))
 }
 
-// DimentionsLTE applies the LTE predicate on the "dimentions" field.
-func DimentionsLTE(v int) predicate.ProcessedImage {
-	return predicate.ProcessedImage(sql.FieldLTE(FieldDimentions, v))
+// HeightIn applies the In predicate on the "height" field.
+func HeightIn(vs ...int) predicate.ProcessedImage {
+	return predicate.ProcessedImage(sql.FieldIn(FieldHeight, vs...))
+}
+
+// HeightNotIn applies the NotIn predicate on the "height" field.
+func HeightNotIn(vs ...int) predicate.ProcessedImage {
+	return predicate.ProcessedImage(sql.FieldNotIn(FieldHeight, vs...))
+}
+
+// HeightGT applies the GT predicate on the "height" field.
+func HeightGT(v int) predicate.ProcessedImage {
+	return predicate.ProcessedImage(sql.FieldGT(FieldHeight, v))
+}
+
+// HeightGTE applies the GTE predicate on the "height" field.
+func HeightGTE(v int) predicate.ProcessedImage {
+	return predicate.ProcessedImage(sql.FieldGTE(FieldHeight, v))
+}
+
+// HeightLT applies the LT predicate on the "height" field.
+func HeightLT(v int) predicate.ProcessedImage {
+	return predicate.ProcessedImage(sql.FieldLT(FieldHeight, v))
+}
+
+// HeightLTE applies the LTE predicate on the "height" field.
+func HeightLTE(v int) predicate.ProcessedImage {
+	return predicate.ProcessedImage(sql.FieldLTE(FieldHeight, v))
 }
 
 // SizeBitsEQ applies the EQ predicate on the "size_bits" field.
@@ -186,46 +436,6 @@ func SizeBitsLTE(v uint32) predicate.ProcessedImage {
 	return predicate.ProcessedImage(sql.FieldLTE(FieldSizeBits, v))
 }
 
-// ThumbEQ applies the EQ predicate on the "thumb" field.
-func ThumbEQ(v []byte) predicate.ProcessedImage {
-	return predicate.ProcessedImage(sql.FieldEQ(FieldThumb, v))
-}
-
-// ThumbNEQ applies the NEQ predicate on the "thumb" field.
-func ThumbNEQ(v []byte) predicate.ProcessedImage {
-	return predicate.ProcessedImage(sql.FieldNEQ(FieldThumb, v))
-}
-
-// ThumbIn applies the In predicate on the "thumb" field.
-func ThumbIn(vs ...[]byte) predicate.ProcessedImage {
-	return predicate.ProcessedImage(sql.FieldIn(FieldThumb, vs...))
-}
-
-// ThumbNotIn applies the NotIn predicate on the "thumb" field.
-func ThumbNotIn(vs ...[]byte) predicate.ProcessedImage {
-	return predicate.ProcessedImage(sql.FieldNotIn(FieldThumb, vs...))
-}
-
-// ThumbGT applies the GT predicate on the "thumb" field.
-func ThumbGT(v []byte) predicate.ProcessedImage {
-	return predicate.ProcessedImage(sql.FieldGT(FieldThumb, v))
-}
-
-// ThumbGTE applies the GTE predicate on the "thumb" field.
-func ThumbGTE(v []byte) predicate.ProcessedImage {
-	return predicate.ProcessedImage(sql.FieldGTE(FieldThumb, v))
-}
-
-// ThumbLT applies the LT predicate on the "thumb" field.
-func ThumbLT(v []byte) predicate.ProcessedImage {
-	return predicate.ProcessedImage(sql.FieldLT(FieldThumb, v))
-}
-
-// ThumbLTE applies the LTE predicate on the "thumb" field.
-func ThumbLTE(v []byte) predicate.ProcessedImage {
-	return predicate.ProcessedImage(sql.FieldLTE(FieldThumb, v))
-}
-
 // CreatedAtEQ applies the EQ predicate on the "created_at" field.
 func CreatedAtEQ(v time.Time) predicate.ProcessedImage {
 	return predicate.ProcessedImage(sql.FieldEQ(FieldCreatedAt, v))
@@ -306,56 +516,6 @@ func UpdatedAtLTE(v time.Time) predicate.ProcessedImage {
 	return predicate.ProcessedImage(sql.FieldLTE(FieldUpdatedAt, v))
 }
 
-// DeletedAtEQ applies the EQ predicate on the "deleted_at" field.
-func DeletedAtEQ(v time.Time) predicate.ProcessedImage {
-	return predicate.ProcessedImage(sql.FieldEQ(FieldDeletedAt, v))
-}
-
-// DeletedAtNEQ applies the NEQ predicate on the "deleted_at" field.
-func DeletedAtNEQ(v time.Time) predicate.ProcessedImage {
-	return predicate.ProcessedImage(sql.FieldNEQ(FieldDeletedAt, v))
-}
-
-// DeletedAtIn applies the In predicate on the "deleted_at" field.
-func DeletedAtIn(vs ...time.Time) predicate.ProcessedImage {
-	return predicate.ProcessedImage(sql.FieldIn(FieldDeletedAt, vs...))
-}
-
-// DeletedAtNotIn applies the NotIn predicate on the "deleted_at" field.
-func DeletedAtNotIn(vs ...time.Time) predicate.ProcessedImage {
-	return predicate.ProcessedImage(sql.FieldNotIn(FieldDeletedAt, vs...))
-}
-
-// DeletedAtGT applies the GT predicate on the "deleted_at" field.
-func DeletedAtGT(v time.Time) predicate.ProcessedImage {
-	return predicate.ProcessedImage(sql.FieldGT(FieldDeletedAt, v))
-}
-
-// DeletedAtGTE applies the GTE predicate on the "deleted_at" field.
-func DeletedAtGTE(v time.Time) predicate.ProcessedImage {
-	return predicate.ProcessedImage(sql.FieldGTE(FieldDeletedAt, v))
-}
-
-// DeletedAtLT applies the LT predicate on the "deleted_at" field.
-func DeletedAtLT(v time.Time) predicate.ProcessedImage {
-	return predicate.ProcessedImage(sql.FieldLT(FieldDeletedAt, v))
-}
-
-// DeletedAtLTE applies the LTE predicate on the "deleted_at" field.
-func DeletedAtLTE(v time.Time) predicate.ProcessedImage {
-	return predicate.ProcessedImage(sql.FieldLTE(FieldDeletedAt, v))
-}
-
-// DeletedAtIsNil applies the IsNil predicate on the "deleted_at" field.
-func DeletedAtIsNil() predicate.ProcessedImage {
-	return predicate.ProcessedImage(sql.FieldIsNull(FieldDeletedAt))
-}
-
-// DeletedAtNotNil applies the NotNil predicate on the "deleted_at" field.
-func DeletedAtNotNil() predicate.ProcessedImage {
-	return predicate.ProcessedImage(sql.FieldNotNull(FieldDeletedAt))
-}
-
 // HasSource applies the HasEdge predicate on the "source" edge.
 func HasSource() predicate.ProcessedImage {
 	return predicate.ProcessedImage(func(s *sql.Selector) {
@@ -22,27 +22,69 @@ type ProcessedImageCreate struct {
 	hooks    []Hook
 }
 
+// SetDeletedAt sets the "deleted_at" field.
+func (pic *ProcessedImageCreate) SetDeletedAt(t time.Time) *ProcessedImageCreate {
+	pic.mutation.SetDeletedAt(t)
+	return pic
+}
+
+// SetNillableDeletedAt sets the "deleted_at" field if the given value is not nil.
+func (pic *ProcessedImageCreate) SetNillableDeletedAt(t *time.Time) *ProcessedImageCreate {
+	if t != nil {
+		pic.SetDeletedAt(*t)
+	}
+	return pic
+}
+
+// SetFile sets the "file" field.
+func (pic *ProcessedImageCreate) SetFile(s string) *ProcessedImageCreate {
+	pic.mutation.SetFile(s)
+	return pic
+}
+
+// SetNillableFile sets the "file" field if the given value is not nil.
+func (pic *ProcessedImageCreate) SetNillableFile(s *string) *ProcessedImageCreate {
+	if s != nil {
+		pic.SetFile(*s)
+	}
+	return pic
+}
+
+// SetVariant sets the "variant" field.
+func (pic *ProcessedImageCreate) SetVariant(s string) *ProcessedImageCreate {
+	pic.mutation.SetVariant(s)
+	return pic
+}
+
+// SetNillableVariant sets the "variant" field if the given value is not nil.
+func (pic *ProcessedImageCreate) SetNillableVariant(s *string) *ProcessedImageCreate {
+	if s != nil {
+		pic.SetVariant(*s)
+	}
+	return pic
+}
+
 // SetType sets the "type" field.
 func (pic *ProcessedImageCreate) SetType(pr processedimage.Type) *ProcessedImageCreate {
 	pic.mutation.SetType(pr)
 	return pic
 }
 
-// SetDimentions sets the "dimentions" field.
-func (pic *ProcessedImageCreate) SetDimentions(i int) *ProcessedImageCreate {
-	pic.mutation.SetDimentions(i)
+// SetWidth sets the "width" field.
+func (pic *ProcessedImageCreate) SetWidth(i int) *ProcessedImageCreate {
+	pic.mutation.SetWidth(i)
 	return pic
 }
 
-// SetSizeBits sets the "size_bits" field.
-func (pic *ProcessedImageCreate) SetSizeBits(u uint32) *ProcessedImageCreate {
-	pic.mutation.SetSizeBits(u)
+// SetHeight sets the "height" field.
+func (pic *ProcessedImageCreate) SetHeight(i int) *ProcessedImageCreate {
+	pic.mutation.SetHeight(i)
 	return pic
 }
 
-// SetThumb sets the "thumb" field.
-func (pic *ProcessedImageCreate) SetThumb(b []byte) *ProcessedImageCreate {
-	pic.mutation.SetThumb(b)
+// SetSizeBits sets the "size_bits" field.
+func (pic *ProcessedImageCreate) SetSizeBits(u uint32) *ProcessedImageCreate {
+	pic.mutation.SetSizeBits(u)
 	return pic
 }
 
@@ -74,20 +116,6 @@ func (pic *ProcessedImageCreate) SetNillableUpdatedAt(t *time.Time) *ProcessedIm
 	return pic
 }
 
-// SetDeletedAt sets the "deleted_at" field.
-func (pic *ProcessedImageCreate) SetDeletedAt(t time.Time) *ProcessedImageCreate {
-	pic.mutation.SetDeletedAt(t)
-	return pic
-}
-
-// SetNillableDeletedAt sets the "deleted_at" field if the given value is not nil.
-func (pic *ProcessedImageCreate) SetNillableDeletedAt(t *time.Time) *ProcessedImageCreate {
-	if t != nil {
-		pic.SetDeletedAt(*t)
-	}
-	return pic
-}
-
 // SetID sets the "id" field.
 func (pic *ProcessedImageCreate) SetID(pi pid.ID) *ProcessedImageCreate {
 	pic.mutation.SetID(pi)
@@ -172,25 +200,25 @@ func (pic *ProcessedImageCreate) check() error {
 			return &ValidationError{Name: "type", err: fmt.Errorf(`ent: validator failed for field "ProcessedImage.type": %w`, err)}
 		}
 	}
-	if _, ok := pic.mutation.Dimentions(); !ok {
-		return &ValidationError{Name: "dimentions", err: errors.New(`ent: missing required field "ProcessedImage.dimentions"`)}
+	if _, ok := pic.mutation.Width(); !ok {
+		return &ValidationError{Name: "width", err: errors.New(`ent: missing required field "ProcessedImage.width"`)}
 	}
-	if v, ok := pic.mutation.Dimentions(); ok {
-		if err := processedimage.DimentionsValidator(v); err != nil {
-			return &ValidationError{Name: "dimentions", err: fmt.Errorf(`ent: validator failed for field "ProcessedImage.dimentions": %w`, err)}
+	if v, ok := pic.mutation.Width(); ok {
+		if err := processedimage.WidthValidator(v); err != nil {
+			return &ValidationError{Name: "width", err: fmt.Errorf(`ent: validator failed for field "ProcessedImage.width": %w`, err)}
 		}
 	}
-	if _, ok := pic.mutation.SizeBits(); !ok {
-		return &ValidationError{Name: "size_bits", err: errors.New(`ent: missing required field "ProcessedImage.size_bits"`)}
+	if _, ok := pic.mutation.Height(); !ok {
+		return &ValidationError{Name: "height", err: errors.New(`ent: missing required field "ProcessedImage.height"`)}
 	}
-	if _, ok := pic.mutation.Thumb(); !ok {
-		return &ValidationError{Name: "thumb", err: errors.New(`ent: missing required field "ProcessedImage.thumb"`)}
-	}
-	if v, ok := pic.mutation.Thumb(); ok {
-		if err := processedimage.ThumbValidator(v); err != nil {
-			return &ValidationError{Name: "thumb", err: fmt.Errorf(`ent: validator failed for field "ProcessedImage.thumb": %w`, err)}
+	if v, ok := pic.mutation.Height(); ok {
+		if err := processedimage.HeightValidator(v); err != nil {
+			return &ValidationError{Name: "height", err: fmt.Errorf(`ent: validator failed for field "ProcessedImage.height": %w`, err)}
 		}
 	}
+	if _, ok := pic.mutation.SizeBits(); !ok {
+		return &ValidationError{Name: "size_bits", err: errors.New(`ent: missing required field "ProcessedImage.size_bits"`)}
+	}
 	if _, ok := pic.mutation.CreatedAt(); !ok {
 		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "ProcessedImage.created_at"`)}
 	}
@@ -232,22 +260,34 @@ func (pic *ProcessedImageCreate) createSpec() (*ProcessedImage, *sqlgraph.Create
 		_node.ID = id
 		_spec.ID.Value = id
 	}
+	if value, ok := pic.mutation.DeletedAt(); ok {
+		_spec.SetField(processedimage.FieldDeletedAt, field.TypeTime, value)
+		_node.DeletedAt = &value
+	}
+	if value, ok := pic.mutation.File(); ok {
+		_spec.SetField(processedimage.FieldFile, field.TypeString, value)
+		_node.File = value
+	}
+	if value, ok := pic.mutation.Variant(); ok {
+		_spec.SetField(processedimage.FieldVariant, field.TypeString, value)
+		_node.Variant = value
+	}
 	if value, ok := pic.mutation.GetType(); ok {
 		_spec.SetField(processedimage.FieldType, field.TypeEnum, value)
 		_node.Type = value
 	}
-	if value, ok := pic.mutation.Dimentions(); ok {
-		_spec.SetField(processedimage.FieldDimentions, field.TypeInt, value)
-		_node.Dimentions = value
+	if value, ok := pic.mutation.Width(); ok {
+		_spec.SetField(processedimage.FieldWidth, field.TypeInt, value)
+		_node.Width = value
+	}
+	if value, ok := pic.mutation.Height(); ok {
+		_spec.SetField(processedimage.FieldHeight, field.TypeInt, value)
+		_node.Height = value
 	}
 	if value, ok := pic.mutation.SizeBits(); ok {
 		_spec.SetField(processedimage.FieldSizeBits, field.TypeUint32, value)
 		_node.SizeBits = value
 	}
-	if value, ok := pic.mutation.Thumb(); ok {
-		_spec.SetField(processedimage.FieldThumb, field.TypeBytes, value)
-		_node.Thumb = value
-	}
 	if value, ok := pic.mutation.CreatedAt(); ok {
 		_spec.SetField(processedimage.FieldCreatedAt, field.TypeTime, value)
 		_node.CreatedAt = value
@@ -256,10 +296,6 @@ func (pic *ProcessedImageCreate) createSpec() (*ProcessedImage, *sqlgraph.Create
 		_spec.SetField(processedimage.FieldUpdatedAt, field.TypeTime, value)
 		_node.UpdatedAt = value
 	}
-	if value, ok := pic.mutation.DeletedAt(); ok {
-		_spec.SetField(processedimage.FieldDeletedAt, field.TypeTime, value)
-		_node.DeletedAt = &value
-	}
 	if nodes := pic.mutation.SourceIDs(); len(nodes) > 0 {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -300,12 +300,12 @@ func (piq *ProcessedImageQuery) WithSource(opts ...func(*ImageQuery)) *Processed
 // Example:
 //
 //	var v []struct {
-//		Type processedimage.Type `json:"type,omitempty"`
+//		DeletedAt time.Time `json:"deleted_at,omitzero"`
 //		Count int `json:"count,omitempty"`
 //	}
 //
 //	client.ProcessedImage.Query().
-//		GroupBy(processedimage.FieldType).
+//		GroupBy(processedimage.FieldDeletedAt).
 //		Aggregate(ent.Count()).
 //		Scan(ctx, &v)
 func (piq *ProcessedImageQuery) GroupBy(field string, fields ...string) *ProcessedImageGroupBy {
@@ -323,11 +323,11 @@ func (piq *ProcessedImageQuery) GroupBy(field string, fields ...string) *Process
 // Example:
 //
 //	var v []struct {
-//		Type processedimage.Type `json:"type,omitempty"`
+//		DeletedAt time.Time `json:"deleted_at,omitzero"`
 //	}
 //
 //	client.ProcessedImage.Query().
-//		Select(processedimage.FieldType).
+//		Select(processedimage.FieldDeletedAt).
 //		Scan(ctx, &v)
 func (piq *ProcessedImageQuery) Select(fields ...string) *ProcessedImageSelect {
 	piq.ctx.Fields = append(piq.ctx.Fields, fields...)
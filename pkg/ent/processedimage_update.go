@@ -30,6 +30,66 @@ func (piu *ProcessedImageUpdate) Where(ps ...predicate.ProcessedImage) *Processe
 	return piu
 }
 
+// SetDeletedAt sets the "deleted_at" field.
+func (piu *ProcessedImageUpdate) SetDeletedAt(t time.Time) *ProcessedImageUpdate {
+	piu.mutation.SetDeletedAt(t)
+	return piu
+}
+
+// SetNillableDeletedAt sets the "deleted_at" field if the given value is not nil.
+func (piu *ProcessedImageUpdate) SetNillableDeletedAt(t *time.Time) *ProcessedImageUpdate {
+	if t != nil {
+		piu.SetDeletedAt(*t)
+	}
+	return piu
+}
+
+// ClearDeletedAt clears the value of the "deleted_at" field.
+func (piu *ProcessedImageUpdate) ClearDeletedAt() *ProcessedImageUpdate {
+	piu.mutation.ClearDeletedAt()
+	return piu
+}
+
+// SetFile sets the "file" field.
+func (piu *ProcessedImageUpdate) SetFile(s string) *ProcessedImageUpdate {
+	piu.mutation.SetFile(s)
+	return piu
+}
+
+// SetNillableFile sets the "file" field if the given value is not nil.
+func (piu *ProcessedImageUpdate) SetNillableFile(s *string) *ProcessedImageUpdate {
+	if s != nil {
+		piu.SetFile(*s)
+	}
+	return piu
+}
+
+// ClearFile clears the value of the "file" field.
+func (piu *ProcessedImageUpdate) ClearFile() *ProcessedImageUpdate {
+	piu.mutation.ClearFile()
+	return piu
+}
+
+// SetVariant sets the "variant" field.
+func (piu *ProcessedImageUpdate) SetVariant(s string) *ProcessedImageUpdate {
+	piu.mutation.SetVariant(s)
+	return piu
+}
+
+// SetNillableVariant sets the "variant" field if the given value is not nil.
+func (piu *ProcessedImageUpdate) SetNillableVariant(s *string) *ProcessedImageUpdate {
+	if s != nil {
+		piu.SetVariant(*s)
+	}
+	return piu
+}
+
+// ClearVariant clears the value of the "variant" field.
+func (piu *ProcessedImageUpdate) ClearVariant() *ProcessedImageUpdate {
+	piu.mutation.ClearVariant()
+	return piu
+}
+
 // SetType sets the "type" field.
 func (piu *ProcessedImageUpdate) SetType(pr processedimage.Type) *ProcessedImageUpdate {
 	piu.mutation.SetType(pr)
@@ -44,24 +104,45 @@ func (piu *ProcessedImageUpdate) SetNillableType(pr *processedimage.Type) *Proce
 	return piu
 }
 
-// SetDimentions sets the "dimentions" field.
-func (piu *ProcessedImageUpdate) SetDimentions(i int) *ProcessedImageUpdate {
-	piu.mutation.ResetDimentions()
-	piu.mutation.SetDimentions(i)
+// SetWidth sets the "width" field.
+func (piu *ProcessedImageUpdate) SetWidth(i int) *ProcessedImageUpdate {
+	piu.mutation.ResetWidth()
+	piu.mutation.SetWidth(i)
 	return piu
 }
 
-// SetNillableDimentions sets the "dimentions" field if the given value is not nil.
-func (piu *ProcessedImageUpdate) SetNillableDimentions(i *int) *ProcessedImageUpdate {
+// SetNillableWidth sets the "width" field if the given value is not nil.
+func (piu *ProcessedImageUpdate) SetNillableWidth(i *int) *ProcessedImageUpdate {
 	if i != nil {
-		piu.SetDimentions(*i)
+		piu.SetWidth(*i)
 	}
 	return piu
 }
 
-// AddDimentions adds i to the "dimentions" field.
-func (piu *ProcessedImageUpdate) AddDimentions(i int) *ProcessedImageUpdate {
-	piu.mutation.AddDimentions(i)
+// AddWidth adds i to the "width" field.
+func (piu *ProcessedImageUpdate) AddWidth(i int) *ProcessedImageUpdate {
+	piu.mutation.AddWidth(i)
+	return piu
+}
+
+// SetHeight sets the "height" field.
+func (piu *ProcessedImageUpdate) SetHeight(i int) *ProcessedImageUpdate {
+	piu.mutation.ResetHeight()
+	piu.mutation.SetHeight(i)
+	return piu
+}
+
+// SetNillableHeight sets the "height" field if the given value is not nil.
+func (piu *ProcessedImageUpdate) SetNillableHeight(i *int) *ProcessedImageUpdate {
+	if i != nil {
+		piu.SetHeight(*i)
+	}
+	return piu
+}
+
+// AddHeight adds i to the "height" field.
+func (piu *ProcessedImageUpdate) AddHeight(i int) *ProcessedImageUpdate {
+	piu.mutation.AddHeight(i)
 	return piu
 }
 
@@ -86,38 +167,12 @@ func (piu *ProcessedImageUpdate) AddSizeBits(u int32) *ProcessedImageUpdate {
 	return piu
 }
 
-// SetThumb sets the "thumb" field.
-func (piu *ProcessedImageUpdate) SetThumb(b []byte) *ProcessedImageUpdate {
-	piu.mutation.SetThumb(b)
-	return piu
-}
-
 // SetUpdatedAt sets the "updated_at" field.
 func (piu *ProcessedImageUpdate) SetUpdatedAt(t time.Time) *ProcessedImageUpdate {
 	piu.mutation.SetUpdatedAt(t)
 	return piu
 }
 
-// SetDeletedAt sets the "deleted_at" field.
-func (piu *ProcessedImageUpdate) SetDeletedAt(t time.Time) *ProcessedImageUpdate {
-	piu.mutation.SetDeletedAt(t)
-	return piu
-}
-
-// SetNillableDeletedAt sets the "deleted_at" field if the given value is not nil.
-func (piu *ProcessedImageUpdate) SetNillableDeletedAt(t *time.Time) *ProcessedImageUpdate {
-	if t != nil {
-		piu.SetDeletedAt(*t)
-	}
-	return piu
-}
-
-// ClearDeletedAt clears the value of the "deleted_at" field.
-func (piu *ProcessedImageUpdate) ClearDeletedAt() *ProcessedImageUpdate {
-	piu.mutation.ClearDeletedAt()
-	return piu
-}
-
 // SetSourceID sets the "source" edge to the Image entity by ID.
 func (piu *ProcessedImageUpdate) SetSourceID(id pid.ID) *ProcessedImageUpdate {
 	piu.mutation.SetSourceID(id)
@@ -183,14 +238,14 @@ func (piu *ProcessedImageUpdate) check() error {
 			return &ValidationError{Name: "type", err: fmt.Errorf(`ent: validator failed for field "ProcessedImage.type": %w`, err)}
 		}
 	}
-	if v, ok := piu.mutation.Dimentions(); ok {
-		if err := processedimage.DimentionsValidator(v); err != nil {
-			return &ValidationError{Name: "dimentions", err: fmt.Errorf(`ent: validator failed for field "ProcessedImage.dimentions": %w`, err)}
+	if v, ok := piu.mutation.Width(); ok {
+		if err := processedimage.WidthValidator(v); err != nil {
+			return &ValidationError{Name: "width", err: fmt.Errorf(`ent: validator failed for field "ProcessedImage.width": %w`, err)}
 		}
 	}
-	if v, ok := piu.mutation.Thumb(); ok {
-		if err := processedimage.ThumbValidator(v); err != nil {
-			return &ValidationError{Name: "thumb", err: fmt.Errorf(`ent: validator failed for field "ProcessedImage.thumb": %w`, err)}
+	if v, ok := piu.mutation.Height(); ok {
+		if err := processedimage.HeightValidator(v); err != nil {
+			return &ValidationError{Name: "height", err: fmt.Errorf(`ent: validator failed for field "ProcessedImage.height": %w`, err)}
 		}
 	}
 	if piu.mutation.SourceCleared() && len(piu.mutation.SourceIDs()) > 0 {
@@ -211,14 +266,38 @@ func (piu *ProcessedImageUpdate) sqlSave(ctx context.Context) (n int, err error)
 			}
 		}
 	}
+	if value, ok := piu.mutation.DeletedAt(); ok {
+		_spec.SetField(processedimage.FieldDeletedAt, field.TypeTime, value)
+	}
+	if piu.mutation.DeletedAtCleared() {
+		_spec.ClearField(processedimage.FieldDeletedAt, field.TypeTime)
+	}
+	if value, ok := piu.mutation.File(); ok {
+		_spec.SetField(processedimage.FieldFile, field.TypeString, value)
+	}
+	if piu.mutation.FileCleared() {
+		_spec.ClearField(processedimage.FieldFile, field.TypeString)
+	}
+	if value, ok := piu.mutation.Variant(); ok {
+		_spec.SetField(processedimage.FieldVariant, field.TypeString, value)
+	}
+	if piu.mutation.VariantCleared() {
+		_spec.ClearField(processedimage.FieldVariant, field.TypeString)
+	}
 	if value, ok := piu.mutation.GetType(); ok {
 		_spec.SetField(processedimage.FieldType, field.TypeEnum, value)
 	}
-	if value, ok := piu.mutation.Dimentions(); ok {
-		_spec.SetField(processedimage.FieldDimentions, field.TypeInt, value)
+	if value, ok := piu.mutation.Width(); ok {
+		_spec.SetField(processedimage.FieldWidth, field.TypeInt, value)
+	}
+	if value, ok := piu.mutation.AddedWidth(); ok {
+		_spec.AddField(processedimage.FieldWidth, field.TypeInt, value)
 	}
-	if value, ok := piu.mutation.AddedDimentions(); ok {
-		_spec.AddField(processedimage.FieldDimentions, field.TypeInt, value)
+	if value, ok := piu.mutation.Height(); ok {
+		_spec.SetField(processedimage.FieldHeight, field.TypeInt, value)
+	}
+	if value, ok := piu.mutation.AddedHeight(); ok {
+		_spec.AddField(processedimage.FieldHeight, field.TypeInt, value)
 	}
 	if value, ok := piu.mutation.SizeBits(); ok {
 		_spec.SetField(processedimage.FieldSizeBits, field.TypeUint32, value)
@@ -226,18 +305,9 @@ func (piu *ProcessedImageUpdate) sqlSave(ctx context.Context) (n int, err error)
 	if value, ok := piu.mutation.AddedSizeBits(); ok {
 		_spec.AddField(processedimage.FieldSizeBits, field.TypeUint32, value)
 	}
-	if value, ok := piu.mutation.Thumb(); ok {
-		_spec.SetField(processedimage.FieldThumb, field.TypeBytes, value)
-	}
 	if value, ok := piu.mutation.UpdatedAt(); ok {
 		_spec.SetField(processedimage.FieldUpdatedAt, field.TypeTime, value)
 	}
-	if value, ok := piu.mutation.DeletedAt(); ok {
-		_spec.SetField(processedimage.FieldDeletedAt, field.TypeTime, value)
-	}
-	if piu.mutation.DeletedAtCleared() {
-		_spec.ClearField(processedimage.FieldDeletedAt, field.TypeTime)
-	}
 	if piu.mutation.SourceCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -287,6 +357,66 @@ type ProcessedImageUpdateOne struct {
 	mutation *ProcessedImageMutation
 }
 
+// SetDeletedAt sets the "deleted_at" field.
+func (piuo *ProcessedImageUpdateOne) SetDeletedAt(t time.Time) *ProcessedImageUpdateOne {
+	piuo.mutation.SetDeletedAt(t)
+	return piuo
+}
+
+// SetNillableDeletedAt sets the "deleted_at" field if the given value is not nil.
+func (piuo *ProcessedImageUpdateOne) SetNillableDeletedAt(t *time.Time) *ProcessedImageUpdateOne {
+	if t != nil {
+		piuo.SetDeletedAt(*t)
+	}
+	return piuo
+}
+
+// ClearDeletedAt clears the value of the "deleted_at" field.
+func (piuo *ProcessedImageUpdateOne) ClearDeletedAt() *ProcessedImageUpdateOne {
+	piuo.mutation.ClearDeletedAt()
+	return piuo
+}
+
+// SetFile sets the "file" field.
+func (piuo *ProcessedImageUpdateOne) SetFile(s string) *ProcessedImageUpdateOne {
+	piuo.mutation.SetFile(s)
+	return piuo
+}
+
+// SetNillableFile sets the "file" field if the given value is not nil.
+func (piuo *ProcessedImageUpdateOne) SetNillableFile(s *string) *ProcessedImageUpdateOne {
+	if s != nil {
+		piuo.SetFile(*s)
+	}
+	return piuo
+}
+
+// ClearFile clears the value of the "file" field.
+func (piuo *ProcessedImageUpdateOne) ClearFile() *ProcessedImageUpdateOne {
+	piuo.mutation.ClearFile()
+	return piuo
+}
+
+// SetVariant sets the "variant" field.
+func (piuo *ProcessedImageUpdateOne) SetVariant(s string) *ProcessedImageUpdateOne {
+	piuo.mutation.SetVariant(s)
+	return piuo
+}
+
+// SetNillableVariant sets the "variant" field if the given value is not nil.
+func (piuo *ProcessedImageUpdateOne) SetNillableVariant(s *string) *ProcessedImageUpdateOne {
+	if s != nil {
+		piuo.SetVariant(*s)
+	}
+	return piuo
+}
+
+// ClearVariant clears the value of the "variant" field.
+func (piuo *ProcessedImageUpdateOne) ClearVariant() *ProcessedImageUpdateOne {
+	piuo.mutation.ClearVariant()
+	return piuo
+}
+
 // SetType sets the "type" field.
 func (piuo *ProcessedImageUpdateOne) SetType(pr processedimage.Type) *ProcessedImageUpdateOne {
 	piuo.mutation.SetType(pr)
@@ -301,24 +431,45 @@ func (piuo *ProcessedImageUpdateOne) SetNillableType(pr *processedimage.Type) *P
 	return piuo
 }
 
-// SetDimentions sets the "dimentions" field.
-func (piuo *ProcessedImageUpdateOne) SetDimentions(i int) *ProcessedImageUpdateOne {
-	piuo.mutation.ResetDimentions()
-	piuo.mutation.SetDimentions(i)
+// SetWidth sets the "width" field.
+func (piuo *ProcessedImageUpdateOne) SetWidth(i int) *ProcessedImageUpdateOne {
+	piuo.mutation.ResetWidth()
+	piuo.mutation.SetWidth(i)
 	return piuo
 }
 
-// SetNillableDimentions sets the "dimentions" field if the given value is not nil.
-func (piuo *ProcessedImageUpdateOne) SetNillableDimentions(i *int) *ProcessedImageUpdateOne {
+// SetNillableWidth sets the "width" field if the given value is not nil.
+func (piuo *ProcessedImageUpdateOne) SetNillableWidth(i *int) *ProcessedImageUpdateOne {
 	if i != nil {
-		piuo.SetDimentions(*i)
+		piuo.SetWidth(*i)
 	}
 	return piuo
 }
 
-// AddDimentions adds i to the "dimentions" field.
-func (piuo *ProcessedImageUpdateOne) AddDimentions(i int) *ProcessedImageUpdateOne {
-	piuo.mutation.AddDimentions(i)
+// AddWidth adds i to the "width" field.
+func (piuo *ProcessedImageUpdateOne) AddWidth(i int) *ProcessedImageUpdateOne {
+	piuo.mutation.AddWidth(i)
+	return piuo
+}
+
+// SetHeight sets the "height" field.
+func (piuo *ProcessedImageUpdateOne) SetHeight(i int) *ProcessedImageUpdateOne {
+	piuo.mutation.ResetHeight()
+	piuo.mutation.SetHeight(i)
+	return piuo
+}
+
+// SetNillableHeight sets the "height" field if the given value is not nil.
+func (piuo *ProcessedImageUpdateOne) SetNillableHeight(i *int) *ProcessedImageUpdateOne {
+	if i != nil {
+		piuo.SetHeight(*i)
+	}
+	return piuo
+}
+
+// AddHeight adds i to the "height" field.
+func (piuo *ProcessedImageUpdateOne) AddHeight(i int) *ProcessedImageUpdateOne {
+	piuo.mutation.AddHeight(i)
 	return piuo
 }
 
@@ -343,38 +494,12 @@ func (piuo *ProcessedImageUpdateOne) AddSizeBits(u int32) *ProcessedImageUpdateO
 	return piuo
 }
 
-// SetThumb sets the "thumb" field.
-func (piuo *ProcessedImageUpdateOne) SetThumb(b []byte) *ProcessedImageUpdateOne {
-	piuo.mutation.SetThumb(b)
-	return piuo
-}
-
 // SetUpdatedAt sets the "updated_at" field.
 func (piuo *ProcessedImageUpdateOne) SetUpdatedAt(t time.Time) *ProcessedImageUpdateOne {
 	piuo.mutation.SetUpdatedAt(t)
 	return piuo
 }
 
-// SetDeletedAt sets the "deleted_at" field.
-func (piuo *ProcessedImageUpdateOne) SetDeletedAt(t time.Time) *ProcessedImageUpdateOne {
-	piuo.mutation.SetDeletedAt(t)
-	return piuo
-}
-
-// SetNillableDeletedAt sets the "deleted_at" field if the given value is not nil.
-func (piuo *ProcessedImageUpdateOne) SetNillableDeletedAt(t *time.Time) *ProcessedImageUpdateOne {
-	if t != nil {
-		piuo.SetDeletedAt(*t)
-	}
-	return piuo
-}
-
-// ClearDeletedAt clears the value of the "deleted_at" field.
-func (piuo *ProcessedImageUpdateOne) ClearDeletedAt() *ProcessedImageUpdateOne {
-	piuo.mutation.ClearDeletedAt()
-	return piuo
-}
-
 // SetSourceID sets the "source" edge to the Image entity by ID.
 func (piuo *ProcessedImageUpdateOne) SetSourceID(id pid.ID) *ProcessedImageUpdateOne {
 	piuo.mutation.SetSourceID(id)
@@ -453,14 +578,14 @@ func (piuo *ProcessedImageUpdateOne) check() error {
 			return &ValidationError{Name: "type", err: fmt.Errorf(`ent: validator failed for field "ProcessedImage.type": %w`, err)}
 		}
 	}
-	if v, ok := piuo.mutation.Dimentions(); ok {
-		if err := processedimage.DimentionsValidator(v); err != nil {
-			return &ValidationError{Name: "dimentions", err: fmt.Errorf(`ent: validator failed for field "ProcessedImage.dimentions": %w`, err)}
+	if v, ok := piuo.mutation.Width(); ok {
+		if err := processedimage.WidthValidator(v); err != nil {
+			return &ValidationError{Name: "width", err: fmt.Errorf(`ent: validator failed for field "ProcessedImage.width": %w`, err)}
 		}
 	}
-	if v, ok := piuo.mutation.Thumb(); ok {
-		if err := processedimage.ThumbValidator(v); err != nil {
-			return &ValidationError{Name: "thumb", err: fmt.Errorf(`ent: validator failed for field "ProcessedImage.thumb": %w`, err)}
+	if v, ok := piuo.mutation.Height(); ok {
+		if err := processedimage.HeightValidator(v); err != nil {
+			return &ValidationError{Name: "height", err: fmt.Errorf(`ent: validator failed for field "ProcessedImage.height": %w`, err)}
 		}
 	}
 	if piuo.mutation.SourceCleared() && len(piuo.mutation.SourceIDs()) > 0 {
@@ -498,14 +623,38 @@ func (piuo *ProcessedImageUpdateOne) sqlSave(ctx context.Context) (_node *Proces
 			}
 		}
 	}
+	if value, ok := piuo.mutation.DeletedAt(); ok {
+		_spec.SetField(processedimage.FieldDeletedAt, field.TypeTime, value)
+	}
+	if piuo.mutation.DeletedAtCleared() {
+		_spec.ClearField(processedimage.FieldDeletedAt, field.TypeTime)
+	}
+	if value, ok := piuo.mutation.File(); ok {
+		_spec.SetField(processedimage.FieldFile, field.TypeString, value)
+	}
+	if piuo.mutation.FileCleared() {
+		_spec.ClearField(processedimage.FieldFile, field.TypeString)
+	}
+	if value, ok := piuo.mutation.Variant(); ok {
+		_spec.SetField(processedimage.FieldVariant, field.TypeString, value)
+	}
+	if piuo.mutation.VariantCleared() {
+		_spec.ClearField(processedimage.FieldVariant, field.TypeString)
+	}
 	if value, ok := piuo.mutation.GetType(); ok {
 		_spec.SetField(processedimage.FieldType, field.TypeEnum, value)
 	}
-	if value, ok := piuo.mutation.Dimentions(); ok {
-		_spec.SetField(processedimage.FieldDimentions, field.TypeInt, value)
+	if value, ok := piuo.mutation.Width(); ok {
+		_spec.SetField(processedimage.FieldWidth, field.TypeInt, value)
+	}
+	if value, ok := piuo.mutation.AddedWidth(); ok {
+		_spec.AddField(processedimage.FieldWidth, field.TypeInt, value)
 	}
-	if value, ok := piuo.mutation.AddedDimentions(); ok {
-		_spec.AddField(processedimage.FieldDimentions, field.TypeInt, value)
+	if value, ok := piuo.mutation.Height(); ok {
+		_spec.SetField(processedimage.FieldHeight, field.TypeInt, value)
+	}
+	if value, ok := piuo.mutation.AddedHeight(); ok {
+		_spec.AddField(processedimage.FieldHeight, field.TypeInt, value)
 	}
 	if value, ok := piuo.mutation.SizeBits(); ok {
 		_spec.SetField(processedimage.FieldSizeBits, field.TypeUint32, value)
@@ -513,18 +662,9 @@ func (piuo *ProcessedImageUpdateOne) sqlSave(ctx context.Context) (_node *Proces
 	if value, ok := piuo.mutation.AddedSizeBits(); ok {
 		_spec.AddField(processedimage.FieldSizeBits, field.TypeUint32, value)
 	}
-	if value, ok := piuo.mutation.Thumb(); ok {
-		_spec.SetField(processedimage.FieldThumb, field.TypeBytes, value)
-	}
 	if value, ok := piuo.mutation.UpdatedAt(); ok {
 		_spec.SetField(processedimage.FieldUpdatedAt, field.TypeTime, value)
 	}
-	if value, ok := piuo.mutation.DeletedAt(); ok {
-		_spec.SetField(processedimage.FieldDeletedAt, field.TypeTime, value)
-	}
-	if piuo.mutation.DeletedAtCleared() {
-		_spec.ClearField(processedimage.FieldDeletedAt, field.TypeTime)
-	}
 	if piuo.mutation.SourceCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
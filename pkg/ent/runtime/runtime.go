@@ -5,13 +5,16 @@ package runtime
 import (
 	"time"
 
+	"github.com/Pineapple217/cvrs/pkg/ent/apitoken"
 	"github.com/Pineapple217/cvrs/pkg/ent/artist"
 	"github.com/Pineapple217/cvrs/pkg/ent/image"
 	"github.com/Pineapple217/cvrs/pkg/ent/imagedata"
+	"github.com/Pineapple217/cvrs/pkg/ent/lockout"
 	"github.com/Pineapple217/cvrs/pkg/ent/processedimage"
 	"github.com/Pineapple217/cvrs/pkg/ent/release"
 	"github.com/Pineapple217/cvrs/pkg/ent/releaseappearance"
 	"github.com/Pineapple217/cvrs/pkg/ent/schema"
+	"github.com/Pineapple217/cvrs/pkg/ent/session"
 	"github.com/Pineapple217/cvrs/pkg/ent/task"
 	"github.com/Pineapple217/cvrs/pkg/ent/track"
 	"github.com/Pineapple217/cvrs/pkg/ent/trackappearance"
@@ -23,7 +26,44 @@ import (
 // (default values, validators, hooks and policies) and stitches it
 // to their package variables.
 func init() {
+	apitokenMixin := schema.ApiToken{}.Mixin()
+	apitokenMixinFields0 := apitokenMixin[0].Fields()
+	_ = apitokenMixinFields0
+	apitokenFields := schema.ApiToken{}.Fields()
+	_ = apitokenFields
+	// apitokenDescName is the schema descriptor for name field.
+	apitokenDescName := apitokenFields[0].Descriptor()
+	// apitoken.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	apitoken.NameValidator = func() func(string) error {
+		validators := apitokenDescName.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(name string) error {
+			for _, fn := range fns {
+				if err := fn(name); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
+	// apitokenDescTokenHash is the schema descriptor for token_hash field.
+	apitokenDescTokenHash := apitokenFields[1].Descriptor()
+	// apitoken.TokenHashValidator is a validator for the "token_hash" field. It is called by the builders before save.
+	apitoken.TokenHashValidator = apitokenDescTokenHash.Validators[0].(func([]byte) error)
+	// apitokenDescCreatedAt is the schema descriptor for created_at field.
+	apitokenDescCreatedAt := apitokenFields[3].Descriptor()
+	// apitoken.DefaultCreatedAt holds the default value on creation for the created_at field.
+	apitoken.DefaultCreatedAt = apitokenDescCreatedAt.Default.(func() time.Time)
+	// apitokenDescID is the schema descriptor for id field.
+	apitokenDescID := apitokenMixinFields0[0].Descriptor()
+	// apitoken.DefaultID holds the default value on creation for the id field.
+	apitoken.DefaultID = apitokenDescID.Default.(func() pid.ID)
 	artistMixin := schema.Artist{}.Mixin()
+	artistMixinInters1 := artistMixin[1].Interceptors()
+	artist.Interceptors[0] = artistMixinInters1[0]
 	artistMixinFields0 := artistMixin[0].Fields()
 	_ = artistMixinFields0
 	artistFields := schema.Artist{}.Fields()
@@ -49,6 +89,8 @@ func init() {
 	imageMixin := schema.Image{}.Mixin()
 	imageHooks := schema.Image{}.Hooks()
 	image.Hooks[0] = imageHooks[0]
+	imageMixinInters1 := imageMixin[1].Interceptors()
+	image.Interceptors[0] = imageMixinInters1[0]
 	imageMixinFields0 := imageMixin[0].Fields()
 	_ = imageMixinFields0
 	imageFields := schema.Image{}.Fields()
@@ -70,11 +112,11 @@ func init() {
 	// image.DimentionHeightValidator is a validator for the "dimention_height" field. It is called by the builders before save.
 	image.DimentionHeightValidator = imageDescDimentionHeight.Validators[0].(func(int) error)
 	// imageDescCreatedAt is the schema descriptor for created_at field.
-	imageDescCreatedAt := imageFields[7].Descriptor()
+	imageDescCreatedAt := imageFields[8].Descriptor()
 	// image.DefaultCreatedAt holds the default value on creation for the created_at field.
 	image.DefaultCreatedAt = imageDescCreatedAt.Default.(func() time.Time)
 	// imageDescUpdatedAt is the schema descriptor for updated_at field.
-	imageDescUpdatedAt := imageFields[8].Descriptor()
+	imageDescUpdatedAt := imageFields[9].Descriptor()
 	// image.DefaultUpdatedAt holds the default value on creation for the updated_at field.
 	image.DefaultUpdatedAt = imageDescUpdatedAt.Default.(func() time.Time)
 	// image.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
@@ -86,28 +128,53 @@ func init() {
 	imagedataFields := schema.ImageData{}.Fields()
 	_ = imagedataFields
 	// imagedataDescCreatedAt is the schema descriptor for created_at field.
-	imagedataDescCreatedAt := imagedataFields[5].Descriptor()
+	imagedataDescCreatedAt := imagedataFields[10].Descriptor()
 	// imagedata.DefaultCreatedAt holds the default value on creation for the created_at field.
 	imagedata.DefaultCreatedAt = imagedataDescCreatedAt.Default.(func() time.Time)
+	lockoutMixin := schema.Lockout{}.Mixin()
+	lockoutMixinFields0 := lockoutMixin[0].Fields()
+	_ = lockoutMixinFields0
+	lockoutFields := schema.Lockout{}.Fields()
+	_ = lockoutFields
+	// lockoutDescKey is the schema descriptor for key field.
+	lockoutDescKey := lockoutFields[0].Descriptor()
+	// lockout.KeyValidator is a validator for the "key" field. It is called by the builders before save.
+	lockout.KeyValidator = lockoutDescKey.Validators[0].(func(string) error)
+	// lockoutDescFailures is the schema descriptor for failures field.
+	lockoutDescFailures := lockoutFields[1].Descriptor()
+	// lockout.DefaultFailures holds the default value on creation for the failures field.
+	lockout.DefaultFailures = lockoutDescFailures.Default.(int)
+	// lockout.FailuresValidator is a validator for the "failures" field. It is called by the builders before save.
+	lockout.FailuresValidator = lockoutDescFailures.Validators[0].(func(int) error)
+	// lockoutDescLastFailureAt is the schema descriptor for last_failure_at field.
+	lockoutDescLastFailureAt := lockoutFields[2].Descriptor()
+	// lockout.DefaultLastFailureAt holds the default value on creation for the last_failure_at field.
+	lockout.DefaultLastFailureAt = lockoutDescLastFailureAt.Default.(func() time.Time)
+	// lockoutDescID is the schema descriptor for id field.
+	lockoutDescID := lockoutMixinFields0[0].Descriptor()
+	// lockout.DefaultID holds the default value on creation for the id field.
+	lockout.DefaultID = lockoutDescID.Default.(func() pid.ID)
 	processedimageMixin := schema.ProcessedImage{}.Mixin()
+	processedimageMixinInters1 := processedimageMixin[1].Interceptors()
+	processedimage.Interceptors[0] = processedimageMixinInters1[0]
 	processedimageMixinFields0 := processedimageMixin[0].Fields()
 	_ = processedimageMixinFields0
 	processedimageFields := schema.ProcessedImage{}.Fields()
 	_ = processedimageFields
-	// processedimageDescDimentions is the schema descriptor for dimentions field.
-	processedimageDescDimentions := processedimageFields[1].Descriptor()
-	// processedimage.DimentionsValidator is a validator for the "dimentions" field. It is called by the builders before save.
-	processedimage.DimentionsValidator = processedimageDescDimentions.Validators[0].(func(int) error)
-	// processedimageDescThumb is the schema descriptor for thumb field.
-	processedimageDescThumb := processedimageFields[3].Descriptor()
-	// processedimage.ThumbValidator is a validator for the "thumb" field. It is called by the builders before save.
-	processedimage.ThumbValidator = processedimageDescThumb.Validators[0].(func([]byte) error)
+	// processedimageDescWidth is the schema descriptor for width field.
+	processedimageDescWidth := processedimageFields[3].Descriptor()
+	// processedimage.WidthValidator is a validator for the "width" field. It is called by the builders before save.
+	processedimage.WidthValidator = processedimageDescWidth.Validators[0].(func(int) error)
+	// processedimageDescHeight is the schema descriptor for height field.
+	processedimageDescHeight := processedimageFields[4].Descriptor()
+	// processedimage.HeightValidator is a validator for the "height" field. It is called by the builders before save.
+	processedimage.HeightValidator = processedimageDescHeight.Validators[0].(func(int) error)
 	// processedimageDescCreatedAt is the schema descriptor for created_at field.
-	processedimageDescCreatedAt := processedimageFields[4].Descriptor()
+	processedimageDescCreatedAt := processedimageFields[6].Descriptor()
 	// processedimage.DefaultCreatedAt holds the default value on creation for the created_at field.
 	processedimage.DefaultCreatedAt = processedimageDescCreatedAt.Default.(func() time.Time)
 	// processedimageDescUpdatedAt is the schema descriptor for updated_at field.
-	processedimageDescUpdatedAt := processedimageFields[5].Descriptor()
+	processedimageDescUpdatedAt := processedimageFields[7].Descriptor()
 	// processedimage.DefaultUpdatedAt holds the default value on creation for the updated_at field.
 	processedimage.DefaultUpdatedAt = processedimageDescUpdatedAt.Default.(func() time.Time)
 	// processedimage.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
@@ -143,6 +210,33 @@ func init() {
 	releaseappearanceDescOrder := releaseappearanceFields[2].Descriptor()
 	// releaseappearance.OrderValidator is a validator for the "order" field. It is called by the builders before save.
 	releaseappearance.OrderValidator = releaseappearanceDescOrder.Validators[0].(func(int) error)
+	sessionMixin := schema.Session{}.Mixin()
+	sessionMixinFields0 := sessionMixin[0].Fields()
+	_ = sessionMixinFields0
+	sessionFields := schema.Session{}.Fields()
+	_ = sessionFields
+	// sessionDescRefreshHash is the schema descriptor for refresh_hash field.
+	sessionDescRefreshHash := sessionFields[0].Descriptor()
+	// session.RefreshHashValidator is a validator for the "refresh_hash" field. It is called by the builders before save.
+	session.RefreshHashValidator = sessionDescRefreshHash.Validators[0].(func([]byte) error)
+	// sessionDescDevice is the schema descriptor for device field.
+	sessionDescDevice := sessionFields[1].Descriptor()
+	// session.DefaultDevice holds the default value on creation for the device field.
+	session.DefaultDevice = sessionDescDevice.Default.(string)
+	// session.DeviceValidator is a validator for the "device" field. It is called by the builders before save.
+	session.DeviceValidator = sessionDescDevice.Validators[0].(func(string) error)
+	// sessionDescCreatedAt is the schema descriptor for created_at field.
+	sessionDescCreatedAt := sessionFields[2].Descriptor()
+	// session.DefaultCreatedAt holds the default value on creation for the created_at field.
+	session.DefaultCreatedAt = sessionDescCreatedAt.Default.(func() time.Time)
+	// sessionDescLastUsedAt is the schema descriptor for last_used_at field.
+	sessionDescLastUsedAt := sessionFields[3].Descriptor()
+	// session.DefaultLastUsedAt holds the default value on creation for the last_used_at field.
+	session.DefaultLastUsedAt = sessionDescLastUsedAt.Default.(func() time.Time)
+	// sessionDescID is the schema descriptor for id field.
+	sessionDescID := sessionMixinFields0[0].Descriptor()
+	// session.DefaultID holds the default value on creation for the id field.
+	session.DefaultID = sessionDescID.Default.(func() pid.ID)
 	taskMixin := schema.Task{}.Mixin()
 	taskMixinFields0 := taskMixin[0].Fields()
 	_ = taskMixinFields0
@@ -221,11 +315,11 @@ func init() {
 	// user.PasswordValidator is a validator for the "password" field. It is called by the builders before save.
 	user.PasswordValidator = userDescPassword.Validators[0].(func([]byte) error)
 	// userDescIsAdmin is the schema descriptor for is_admin field.
-	userDescIsAdmin := userFields[2].Descriptor()
+	userDescIsAdmin := userFields[3].Descriptor()
 	// user.DefaultIsAdmin holds the default value on creation for the is_admin field.
 	user.DefaultIsAdmin = userDescIsAdmin.Default.(bool)
 	// userDescCreatedAt is the schema descriptor for created_at field.
-	userDescCreatedAt := userFields[3].Descriptor()
+	userDescCreatedAt := userFields[4].Descriptor()
 	// user.DefaultCreatedAt holds the default value on creation for the created_at field.
 	user.DefaultCreatedAt = userDescCreatedAt.Default.(func() time.Time)
 	// userDescID is the schema descriptor for id field.
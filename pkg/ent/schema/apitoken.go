@@ -0,0 +1,60 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// ApiToken holds the schema definition for the ApiToken entity.
+type ApiToken struct {
+	ent.Schema
+}
+
+// Fields of the ApiToken.
+func (ApiToken) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("name").
+			NotEmpty().
+			MaxLen(64),
+		field.Bytes("token_hash").
+			NotEmpty().
+			Unique().
+			Sensitive(),
+		field.Strings("scopes"),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+		field.Time("last_used_at").
+			Optional().
+			Nillable(),
+		field.Time("expires_at").
+			Optional().
+			Nillable(),
+	}
+}
+
+// Edges of the ApiToken.
+func (ApiToken) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).
+			Ref("api_tokens").
+			Unique().
+			Required(),
+	}
+}
+
+func (ApiToken) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		IDMixin{},
+	}
+}
+
+func (ApiToken) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Edges("user"),
+	}
+}
@@ -24,9 +24,6 @@ func (Artist) Fields() []ent.Field {
 		field.Time("updated_at").
 			Default(time.Now).
 			UpdateDefault(time.Now),
-		field.Time("deleted_at").
-			Optional().
-			Nillable(),
 	}
 }
 
@@ -45,5 +42,6 @@ func (Artist) Edges() []ent.Edge {
 func (Artist) Mixin() []ent.Mixin {
 	return []ent.Mixin{
 		IDMixin{},
+		SoftDeleteMixin{},
 	}
 }
@@ -8,6 +8,7 @@ import (
 	"entgo.io/ent"
 	"entgo.io/ent/schema/edge"
 	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
 
 	gen "github.com/Pineapple217/cvrs/pkg/ent"
 	"github.com/Pineapple217/cvrs/pkg/ent/hook"
@@ -39,15 +40,15 @@ func (Image) Fields() []ent.Field {
 		field.Int("dimention_height").
 			Range(16, 10_000),
 		field.Uint32("size_bits"),
+		// SHA-256 of the file, images with the same hash share their files
+		field.String("hash").
+			Optional(),
 		field.Time("created_at").
 			Default(time.Now).
 			Immutable(),
 		field.Time("updated_at").
 			Default(time.Now).
 			UpdateDefault(time.Now),
-		field.Time("deleted_at").
-			Optional().
-			Nillable(),
 	}
 }
 
@@ -70,6 +71,13 @@ func (Image) Edges() []ent.Edge {
 	}
 }
 
+func (Image) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("hash"),
+		index.Fields("file"),
+	}
+}
+
 func (Image) Hooks() []ent.Hook {
 	return []ent.Hook{
 		hook.On(func(next ent.Mutator) ent.Mutator {
@@ -94,5 +102,6 @@ func (Image) Hooks() []ent.Hook {
 func (Image) Mixin() []ent.Mixin {
 	return []ent.Mixin{
 		IDMixin{},
+		SoftDeleteMixin{},
 	}
 }
@@ -16,11 +16,42 @@ type ImageData struct {
 // Fields of the ImageData.
 func (ImageData) Fields() []ent.Field {
 	return []ent.Field{
-		field.Int("avr_r"),
-		field.Int("avr_g"),
-		field.Int("avr_b"),
-		field.Int("avg_brightness"),
-		field.Int("avg_saturation"),
+		// Averages over the whole image, all from 0 to 255
+		field.Int("avr_r").
+			Optional().
+			Nillable(),
+		field.Int("avr_g").
+			Optional().
+			Nillable(),
+		field.Int("avr_b").
+			Optional().
+			Nillable(),
+		field.Int("avg_brightness").
+			Optional().
+			Nillable(),
+		field.Int("avg_saturation").
+			Optional().
+			Nillable(),
+		// dHash of the image, stored as int64 because sqlite has no uint64.
+		// Left out of the JSON, javascript numbers can not hold it.
+		field.Int64("phash").
+			Optional().
+			Nillable().
+			StructTag(`json:"-"`),
+		// Dominant colors as #rrggbb, the most common first
+		field.Strings("palette").
+			Optional(),
+		// ThumbHash of the whole image, the placeholder fields are derived
+		// from it
+		field.Bytes("thumbhash").
+			Optional().
+			StructTag(`json:"-"`),
+		// Blurred preview to show while the image loads, a PNG data URI
+		field.String("placeholder").
+			Optional(),
+		// Average color of the placeholder as #rrggbb
+		field.String("placeholder_color").
+			Optional(),
 		field.Time("created_at").
 			Default(time.Now).
 			Immutable(),
@@ -0,0 +1,37 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+)
+
+// Lockout holds the schema definition for the Lockout entity.
+// It counts failed logins for a username or an IP address.
+type Lockout struct {
+	ent.Schema
+}
+
+// Fields of the Lockout.
+func (Lockout) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("key").
+			NotEmpty().
+			Unique(),
+		field.Int("failures").
+			NonNegative().
+			Default(0),
+		field.Time("last_failure_at").
+			Default(time.Now),
+		field.Time("locked_until").
+			Optional().
+			Nillable(),
+	}
+}
+
+func (Lockout) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		IDMixin{},
+	}
+}
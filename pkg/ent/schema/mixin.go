@@ -1,9 +1,13 @@
 package schema
 
 import (
+	"context"
+
 	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/schema/field"
 	"entgo.io/ent/schema/mixin"
+	"github.com/Pineapple217/cvrs/pkg/ent/intercept"
 	"github.com/Pineapple217/cvrs/pkg/pid"
 )
 
@@ -22,3 +26,36 @@ func (IDMixin) Fields() []ent.Field {
 }
 
 func (IDMixin) Edges() []ent.Edge { return nil }
+
+type softDeleteKey struct{}
+
+// SkipSoftDelete returns a context that also returns soft-deleted rows
+func SkipSoftDelete(parent context.Context) context.Context {
+	return context.WithValue(parent, softDeleteKey{}, true)
+}
+
+// SoftDeleteMixin adds a deleted_at field and hides rows where it is set from
+// every query, including eager-loaded edges.
+type SoftDeleteMixin struct {
+	mixin.Schema
+}
+
+func (SoftDeleteMixin) Fields() []ent.Field {
+	return []ent.Field{
+		field.Time("deleted_at").
+			Optional().
+			Nillable(),
+	}
+}
+
+func (d SoftDeleteMixin) Interceptors() []ent.Interceptor {
+	return []ent.Interceptor{
+		intercept.TraverseFunc(func(ctx context.Context, q intercept.Query) error {
+			if skip, _ := ctx.Value(softDeleteKey{}).(bool); skip {
+				return nil
+			}
+			q.WhereP(sql.FieldIsNull(d.Fields()[0].Descriptor().Name))
+			return nil
+		}),
+	}
+}
@@ -17,26 +17,30 @@ type ProcessedImage struct {
 // Fields of the ProcessedImage.
 func (ProcessedImage) Fields() []ent.Field {
 	return []ent.Field{
+		// Blob key, shared by the variants of images with the same hash
+		field.String("file").
+			Optional(),
+		// Name of the configured variant, empty for variants made before they
+		// were configurable
+		field.String("variant").
+			Optional(),
 		field.Enum("type").
 			Values(
 				"WEBP",
 				"PNG",
 				"JPG",
 			),
-		field.Int("dimentions").
-			Range(16, 3_000),
+		field.Int("width").
+			Range(1, 3_000),
+		field.Int("height").
+			Range(1, 3_000),
 		field.Uint32("size_bits"),
-		field.Bytes("thumb").
-			NotEmpty(),
 		field.Time("created_at").
 			Default(time.Now).
 			Immutable(),
 		field.Time("updated_at").
 			Default(time.Now).
 			UpdateDefault(time.Now),
-		field.Time("deleted_at").
-			Optional().
-			Nillable(),
 	}
 }
 
@@ -53,11 +57,13 @@ func (ProcessedImage) Edges() []ent.Edge {
 func (ProcessedImage) Mixin() []ent.Mixin {
 	return []ent.Mixin{
 		IDMixin{},
+		SoftDeleteMixin{},
 	}
 }
 
 func (ProcessedImage) Indexes() []ent.Index {
 	return []ent.Index{
 		index.Edges("source"),
+		index.Fields("file"),
 	}
 }
@@ -0,0 +1,59 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// Session holds the schema definition for the Session entity.
+type Session struct {
+	ent.Schema
+}
+
+// Fields of the Session.
+func (Session) Fields() []ent.Field {
+	return []ent.Field{
+		field.Bytes("refresh_hash").
+			NotEmpty().
+			Unique().
+			Sensitive(),
+		field.String("device").
+			MaxLen(128).
+			Default(""),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+		field.Time("last_used_at").
+			Default(time.Now),
+		field.Time("expires_at"),
+		field.Time("revoked_at").
+			Optional().
+			Nillable(),
+	}
+}
+
+// Edges of the Session.
+func (Session) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).
+			Ref("sessions").
+			Unique().
+			Required(),
+	}
+}
+
+func (Session) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		IDMixin{},
+	}
+}
+
+func (Session) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Edges("user"),
+	}
+}
@@ -19,6 +19,8 @@ func (Task) Fields() []ent.Field {
 		field.Enum("type").
 			Values(
 				"scale_img",
+				"backup",
+				"analyze_img",
 			),
 		field.Enum("status").
 			Values(
@@ -20,8 +20,15 @@ func (User) Fields() []ent.Field {
 			MinLen(3).
 			MaxLen(32).
 			Unique(),
+		// Users that only log in through oidc have no password
 		field.Bytes("password").
+			Optional().
 			NotEmpty(),
+		// Issuer and subject of the oidc identity, linking the user to it
+		field.String("oidc_subject").
+			Optional().
+			Nillable().
+			Unique(),
 		field.Bool("is_admin").
 			Default(false),
 		field.Time("created_at").
@@ -34,6 +41,8 @@ func (User) Fields() []ent.Field {
 func (User) Edges() []ent.Edge {
 	return []ent.Edge{
 		edge.To("images", Image.Type),
+		edge.To("sessions", Session.Type),
+		edge.To("api_tokens", ApiToken.Type),
 	}
 }
 
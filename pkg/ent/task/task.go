@@ -68,7 +68,9 @@ type Type string
 
 // Type values.
 const (
-	TypeScaleImg Type = "scale_img"
+	TypeScaleImg   Type = "scale_img"
+	TypeBackup     Type = "backup"
+	TypeAnalyzeImg Type = "analyze_img"
 )
 
 func (_type Type) String() string {
@@ -78,7 +80,7 @@ func (_type Type) String() string {
 // TypeValidator is a validator for the "type" field enum values. It is called by the builders before save.
 func TypeValidator(_type Type) error {
 	switch _type {
-	case TypeScaleImg:
+	case TypeScaleImg, TypeBackup, TypeAnalyzeImg:
 		return nil
 	default:
 		return fmt.Errorf("task: invalid enum value for type field: %q", _type)
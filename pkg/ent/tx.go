@@ -14,18 +14,24 @@ import (
 // Tx is a transactional client that is created by calling Client.Tx().
 type Tx struct {
 	config
+	// ApiToken is the client for interacting with the ApiToken builders.
+	ApiToken *ApiTokenClient
 	// Artist is the client for interacting with the Artist builders.
 	Artist *ArtistClient
 	// Image is the client for interacting with the Image builders.
 	Image *ImageClient
 	// ImageData is the client for interacting with the ImageData builders.
 	ImageData *ImageDataClient
+	// Lockout is the client for interacting with the Lockout builders.
+	Lockout *LockoutClient
 	// ProcessedImage is the client for interacting with the ProcessedImage builders.
 	ProcessedImage *ProcessedImageClient
 	// Release is the client for interacting with the Release builders.
 	Release *ReleaseClient
 	// ReleaseAppearance is the client for interacting with the ReleaseAppearance builders.
 	ReleaseAppearance *ReleaseAppearanceClient
+	// Session is the client for interacting with the Session builders.
+	Session *SessionClient
 	// Task is the client for interacting with the Task builders.
 	Task *TaskClient
 	// Track is the client for interacting with the Track builders.
@@ -165,12 +171,15 @@ func (tx *Tx) Client() *Client {
 }
 
 func (tx *Tx) init() {
+	tx.ApiToken = NewApiTokenClient(tx.config)
 	tx.Artist = NewArtistClient(tx.config)
 	tx.Image = NewImageClient(tx.config)
 	tx.ImageData = NewImageDataClient(tx.config)
+	tx.Lockout = NewLockoutClient(tx.config)
 	tx.ProcessedImage = NewProcessedImageClient(tx.config)
 	tx.Release = NewReleaseClient(tx.config)
 	tx.ReleaseAppearance = NewReleaseAppearanceClient(tx.config)
+	tx.Session = NewSessionClient(tx.config)
 	tx.Task = NewTaskClient(tx.config)
 	tx.Track = NewTrackClient(tx.config)
 	tx.TrackAppearance = NewTrackAppearanceClient(tx.config)
@@ -184,7 +193,7 @@ func (tx *Tx) init() {
 // of them in order to commit or rollback the transaction.
 //
 // If a closed transaction is embedded in one of the generated entities, and the entity
-// applies a query, for example: Artist.QueryXXX(), the query will be executed
+// applies a query, for example: ApiToken.QueryXXX(), the query will be executed
 // through the driver which created this transaction.
 //
 // Note that txDriver is not goroutine safe.
@@ -2,15 +2,17 @@ package handler
 
 import (
 	"encoding/json"
+	"fmt"
 	"log/slog"
 	"net/http"
-	"strconv"
 	"strings"
+	"time"
 
 	"github.com/Pineapple217/cvrs/pkg/ent"
 	"github.com/Pineapple217/cvrs/pkg/ent/artist"
 	"github.com/Pineapple217/cvrs/pkg/ent/image"
 	"github.com/Pineapple217/cvrs/pkg/ent/processedimage"
+	"github.com/Pineapple217/cvrs/pkg/ent/schema"
 	"github.com/Pineapple217/cvrs/pkg/pid"
 	"github.com/Pineapple217/cvrs/pkg/users"
 	"github.com/labstack/echo/v4"
@@ -54,52 +56,31 @@ func (h *Handler) ArtistsAdd(c echo.Context) error {
 		h.DB.HardDeleteImg(c.Request().Context(), DBimg.ID)
 		return err
 	}
+	h.warnSimilar(c, DBimg)
 	return c.NoContent(http.StatusOK)
 }
 
 type ArtistsPage struct {
-	Limit  int           `json:"limit"`
-	Offset int           `json:"offset"`
-	Artist []*ent.Artist `josn:"artists"`
+	Cursor
+	Artists []*ent.Artist `json:"artists"`
 }
 
 func (h *Handler) ArtistsGet(c echo.Context) error {
-	var err error
-	var offset, limit int
-	offsetStr := c.QueryParam("offset")
-	limitStr := c.QueryParam("limit")
-
-	offset, err = strconv.Atoi(offsetStr)
-	if err != nil {
-		offset = 0
-	}
-	limit, err = strconv.Atoi(limitStr)
-	if err != nil {
-		limit = 100
-	}
-	if limit > 200 {
-		limit = 200
-	}
-
-	as, err := h.DB.Client.Artist.Query().
-		Order(ent.Desc(artist.FieldUpdatedAt)).
-		Offset(offset).
-		Limit(limit).
+	q := h.DB.Client.Artist.Query().
 		WithImage(func(iq *ent.ImageQuery) {
 			iq.Select("id").WithProccesedImage(func(piq *ent.ProcessedImageQuery) {
-				piq.Order(ent.Desc(processedimage.FieldDimentions))
-			})
+				piq.Order(ent.Desc(processedimage.FieldWidth))
+			}).WithData()
 		}).
-		Where(artist.HasImageWith(image.HasProccesedImage())).
-		All(c.Request().Context())
+		Where(artist.HasImageWith(image.HasProccesedImage()))
+	as, cursor, err := paginate(c, queryCtx(c), q, func(a *ent.Artist) pid.ID { return a.ID })
 	if err != nil {
 		return err
 	}
 
 	return c.JSON(http.StatusOK, ArtistsPage{
-		Limit:  limit,
-		Offset: offset,
-		Artist: as,
+		Cursor:  cursor,
+		Artists: as,
 	})
 }
 
@@ -113,9 +94,9 @@ func (h *Handler) ArtistGetId(c echo.Context) error {
 	a, err := h.DB.Client.Artist.Query().
 		Where(artist.IDEQ(id)).
 		WithImage(func(iq *ent.ImageQuery) {
-			iq.WithProccesedImage()
+			iq.WithProccesedImage().WithData()
 		}).
-		Only(c.Request().Context())
+		Only(queryCtx(c))
 	if ent.IsNotFound(err) {
 		return echo.NewHTTPError(http.StatusNotFound)
 	}
@@ -123,6 +104,152 @@ func (h *Handler) ArtistGetId(c echo.Context) error {
 		slog.Warn("not singular", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError, "not singular")
 	}
+	if err != nil {
+		return err
+	}
 
 	return c.JSON(http.StatusOK, a)
 }
+
+// Nil fields are left untouched
+type ArtistUpdateRequest struct {
+	Name *string `json:"name"`
+}
+
+// Accepts either a plain json body or a multipart form with a "json" field and
+// an optional "img" file to replace the artist image.
+func (h *Handler) ArtistUpdate(c echo.Context) error {
+	idStr := c.Param("id")
+	id, err := pid.DecodeBase32(idStr)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid ID format provided")
+	}
+
+	var data ArtistUpdateRequest
+	img, err := bindJsonForm(c, &data)
+	if err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	a, err := h.DB.Client.Artist.Query().
+		Where(artist.IDEQ(id)).
+		WithImage(func(iq *ent.ImageQuery) {
+			iq.Select("id")
+		}).
+		Only(ctx)
+	if ent.IsNotFound(err) {
+		return echo.NewHTTPError(http.StatusNotFound)
+	}
+	if err != nil {
+		return err
+	}
+
+	oldImg := a.Edges.Image
+	u := a.Update()
+	if data.Name != nil {
+		u.SetName(strings.TrimSpace(*data.Name))
+	}
+	var DBimg *ent.Image
+	if img != nil {
+		if !users.HasScope(c, users.ScopeImagesWrite) {
+			return echo.NewHTTPError(http.StatusForbidden, "missing scope "+string(users.ScopeImagesWrite))
+		}
+		_, claims := users.IsAuth(c)
+		DBimg, err = h.DB.SaveImg(ctx, img, claims.UserId)
+		if err != nil {
+			return err
+		}
+		u.ClearImage().SetImage(DBimg)
+	}
+	err = u.Exec(ctx)
+	if err != nil {
+		if DBimg != nil {
+			h.DB.HardDeleteImg(ctx, DBimg.ID)
+		}
+		return err
+	}
+
+	if DBimg != nil && oldImg != nil {
+		err = h.DB.HardDeleteImg(ctx, oldImg.ID)
+		if err != nil {
+			slog.Warn("failed to delete replaced artist img", "img", oldImg.ID, "error", err)
+		}
+	}
+	if DBimg != nil {
+		h.warnSimilar(c, DBimg)
+	}
+	return c.NoContent(http.StatusOK)
+}
+
+// Soft deletes the artist together with its image and the image variants
+func (h *Handler) ArtistDelete(c echo.Context) error {
+	return h.setArtistDeleted(c, true)
+}
+
+func (h *Handler) ArtistRestore(c echo.Context) error {
+	return h.setArtistDeleted(c, false)
+}
+
+func (h *Handler) setArtistDeleted(c echo.Context, deleted bool) error {
+	idStr := c.Param("id")
+	id, err := pid.DecodeBase32(idStr)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid ID format provided")
+	}
+
+	ctx := schema.SkipSoftDelete(c.Request().Context())
+	a, err := h.DB.Client.Artist.Query().
+		Where(artist.IDEQ(id)).
+		WithImage(func(iq *ent.ImageQuery) {
+			iq.Select("id")
+		}).
+		Only(ctx)
+	if ent.IsNotFound(err) {
+		return echo.NewHTTPError(http.StatusNotFound)
+	}
+	if err != nil {
+		return err
+	}
+	if (a.DeletedAt != nil) == deleted {
+		return c.NoContent(http.StatusNoContent)
+	}
+
+	tx, err := h.DB.Client.Tx(ctx)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	au := tx.Artist.UpdateOneID(id)
+	if deleted {
+		au.SetDeletedAt(now)
+	} else {
+		au.ClearDeletedAt()
+	}
+	err = au.Exec(ctx)
+	if err == nil && a.Edges.Image != nil {
+		iu := tx.Image.UpdateOneID(a.Edges.Image.ID)
+		piu := tx.ProcessedImage.Update().
+			Where(processedimage.HasSourceWith(image.IDEQ(a.Edges.Image.ID)))
+		if deleted {
+			iu.SetDeletedAt(now)
+			piu.SetDeletedAt(now)
+		} else {
+			iu.ClearDeletedAt()
+			piu.ClearDeletedAt()
+		}
+		err = iu.Exec(ctx)
+		if err == nil {
+			err = piu.Exec(ctx)
+		}
+	}
+	if err == nil {
+		err = tx.Commit()
+	} else if rerr := tx.Rollback(); rerr != nil {
+		err = fmt.Errorf("%w: %v", err, rerr)
+	}
+	if err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,213 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"testing"
+
+	entImage "github.com/Pineapple217/cvrs/pkg/ent/image"
+	"github.com/Pineapple217/cvrs/pkg/ent/processedimage"
+	"github.com/Pineapple217/cvrs/pkg/pid"
+	"github.com/Pineapple217/cvrs/pkg/users"
+	"github.com/labstack/echo/v4"
+)
+
+func TestArtistUpdateImageScope(t *testing.T) {
+	h := newTestHandler(t)
+	ctx := context.Background()
+	u, err := users.CreateUser(ctx, h.DB, "bob", "secret", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, err := h.DB.Client.Artist.Create().SetName("artist").Save(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	src := image.NewRGBA(image.Rect(0, 0, 32, 32))
+	draw.Draw(src, src.Bounds(), &image.Uniform{color.RGBA{40, 200, 40, 255}}, image.Point{}, draw.Src)
+	var img bytes.Buffer
+	err = png.Encode(&img, src)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Same route and middleware as the server
+	e := echo.New()
+	e.PATCH("/api/artist/:id", h.ArtistUpdate, users.RequireScope(users.ScopeArtistsWrite))
+	e.Use(users.Auth(h.Conf.Auth, h.DB))
+	patch := func(scopes ...users.Scope) int {
+		_, token, err := users.NewApiToken(ctx, h.DB, u, "test", scopes, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var body bytes.Buffer
+		w := multipart.NewWriter(&body)
+		err = w.WriteField("json", `{"name": "renamed"}`)
+		if err == nil {
+			header := textproto.MIMEHeader{}
+			header.Set("Content-Disposition", `form-data; name="img"; filename="cover.png"`)
+			header.Set("Content-Type", "image/png")
+			var part io.Writer
+			part, err = w.CreatePart(header)
+			if err == nil {
+				_, err = part.Write(img.Bytes())
+			}
+		}
+		if err == nil {
+			err = w.Close()
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		req := httptest.NewRequest(http.MethodPatch, "/api/artist/"+a.ID.String(), &body)
+		req.Header.Set(echo.HeaderContentType, w.FormDataContentType())
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		return rec.Code
+	}
+
+	if status := patch(users.ScopeArtistsWrite); status != http.StatusForbidden {
+		t.Errorf("without %s: status %d, want 403", users.ScopeImagesWrite, status)
+	}
+	got, err := h.DB.Client.Artist.Get(ctx, a.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = got.QueryImage().Only(ctx)
+	if got.Name != "artist" || err == nil {
+		t.Errorf("forbidden update was applied: %s, image %v", got.Name, err)
+	}
+	if n, _ := h.DB.Client.Image.Query().Count(ctx); n != 0 {
+		t.Errorf("forbidden update saved %d images", n)
+	}
+
+	if status := patch(users.ScopeArtistsWrite, users.ScopeImagesWrite); status != http.StatusOK {
+		t.Errorf("with both scopes: status %d", status)
+	}
+	_, err = h.DB.Client.Artist.QueryImage(a).Only(ctx)
+	if err != nil {
+		t.Errorf("image not replaced: %v", err)
+	}
+}
+
+// Calls the handler like the router would, as an admin or as a regular user,
+// and returns the status
+func callArtist(t *testing.T, handler echo.HandlerFunc, method, target, id string, admin bool) int {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(httptest.NewRequest(method, target, nil), rec)
+	c.SetParamNames("id")
+	c.SetParamValues(id)
+	c.Set("isAuth", true)
+	c.Set("claims", users.JwtClaims{Username: "bob", IsAdmin: admin})
+	err := handler(c)
+	var httpErr *echo.HTTPError
+	switch {
+	case errors.As(err, &httpErr):
+		return httpErr.Code
+	case err != nil:
+		t.Fatalf("%s %s: %v", method, target, err)
+	}
+	return rec.Code
+}
+
+func TestArtistSoftDelete(t *testing.T) {
+	h := newTestHandler(t)
+	ctx := context.Background()
+	u, err := h.DB.Client.User.Create().SetUsername("bob").Save(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	img, err := h.DB.Client.Image.Create().
+		SetFile("original").
+		SetOriginalName("a.png").
+		SetType(entImage.TypePNG).
+		SetDimentionWidth(32).
+		SetDimentionHeight(32).
+		SetSizeBits(1).
+		SetUploader(u).
+		Save(ctx)
+	if err == nil {
+		_, err = h.DB.Client.ProcessedImage.Create().
+			SetFile("small").
+			SetVariant("small").
+			SetType(processedimage.TypeWEBP).
+			SetWidth(16).
+			SetHeight(16).
+			SetSizeBits(1).
+			SetSource(img).
+			Save(ctx)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, err := h.DB.Client.Artist.Create().SetName("artist").SetImage(img).Save(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := a.ID.String()
+	get := func(query string, admin bool) int {
+		return callArtist(t, h.ArtistGetId, http.MethodGet, "/api/artist/"+id+query, id, admin)
+	}
+	visible := func() (images, variants int) {
+		images, _ = h.DB.Client.Image.Query().Count(ctx)
+		variants, _ = h.DB.Client.ProcessedImage.Query().Count(ctx)
+		return images, variants
+	}
+
+	// Deleting twice is the same as deleting once
+	for range 2 {
+		status := callArtist(t, h.ArtistDelete, http.MethodDelete, "/api/artist/"+id, id, true)
+		if status != http.StatusNoContent {
+			t.Fatalf("delete: status %d", status)
+		}
+	}
+	tests := []struct {
+		query  string
+		admin  bool
+		status int
+	}{
+		{"", false, http.StatusNotFound},
+		{"", true, http.StatusNotFound},
+		{"?includeDeleted=true", false, http.StatusNotFound},
+		{"?includeDeleted=1", true, http.StatusNotFound},
+		{"?includeDeleted=true", true, http.StatusOK},
+	}
+	for _, tt := range tests {
+		if status := get(tt.query, tt.admin); status != tt.status {
+			t.Errorf("deleted, GET %q as admin %v: status %d, want %d", tt.query, tt.admin, status, tt.status)
+		}
+	}
+	if images, variants := visible(); images != 0 || variants != 0 {
+		t.Errorf("%d images and %d variants still visible", images, variants)
+	}
+
+	status := callArtist(t, h.ArtistRestore, http.MethodPost, "/api/artist/"+id+"/restore", id, true)
+	if status != http.StatusNoContent {
+		t.Fatalf("restore: status %d", status)
+	}
+	if status := get("", false); status != http.StatusOK {
+		t.Errorf("restored, GET: status %d", status)
+	}
+	if images, variants := visible(); images != 1 || variants != 1 {
+		t.Errorf("restored %d images and %d variants, want 1 and 1", images, variants)
+	}
+
+	for id, want := range map[string]int{"!": http.StatusBadRequest, pid.New().String(): http.StatusNotFound} {
+		if status := callArtist(t, h.ArtistDelete, http.MethodDelete, "/api/artist/"+id, id, true); status != want {
+			t.Errorf("DELETE %s: status %d, want %d", id, status, want)
+		}
+	}
+}
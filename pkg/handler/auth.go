@@ -2,26 +2,32 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/Pineapple217/cvrs/pkg/ent"
+	"github.com/Pineapple217/cvrs/pkg/ent/session"
 	"github.com/Pineapple217/cvrs/pkg/ent/user"
 	"github.com/Pineapple217/cvrs/pkg/pid"
 	"github.com/Pineapple217/cvrs/pkg/users"
 	"github.com/labstack/echo/v4"
-	"golang.org/x/crypto/bcrypt"
 )
 
 type loginRequest struct {
 	Username string `json:"username"`
 	Password string `json:"password"`
+	// Label to recognize the session by, defaults to the User-Agent
+	Device string `json:"device"`
 }
 
 type loginResponse struct {
-	IsAdmin  bool   `json:"isAdmin"`
-	Token    string `json:"token"`
-	Id       pid.ID `json:"id"`
-	Username string `json:"username"`
+	IsAdmin      bool   `json:"isAdmin"`
+	Token        string `json:"token"`
+	RefreshToken string `json:"refreshToken"`
+	Id           pid.ID `json:"id"`
+	Username     string `json:"username"`
 }
 
 func (h *Handler) Login(c echo.Context) error {
@@ -30,38 +36,186 @@ func (h *Handler) Login(c echo.Context) error {
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest)
 	}
+	if !h.Conf.Auth.PasswordLogin {
+		return echo.NewHTTPError(http.StatusForbidden, "password login is disabled")
+	}
 	if body.Password == "" || body.Username == "" {
 		return echo.NewHTTPError(http.StatusBadRequest)
 	}
-	user, err := h.DB.Client.User.Query().
-		Where(user.UsernameEQ(body.Username)).
-		Only(c.Request().Context())
+	user, err := users.Authenticate(c.Request().Context(), h.DB, h.Conf.Auth, body.Username, body.Password, c.RealIP())
+	var locked *users.LockedError
+	if errors.As(err, &locked) {
+		c.Response().Header().Set("Retry-After", strconv.Itoa(int(locked.RetryAfter.Seconds())+1))
+		return echo.NewHTTPError(http.StatusTooManyRequests, err.Error())
+	}
+	if errors.Is(err, users.ErrBadCredentials) {
+		return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+	}
+	if err != nil {
+		return err
+	}
+
+	device := body.Device
+	if device == "" {
+		device = c.Request().UserAgent()
+	}
+	resp, err := h.startSession(c, user, device)
 	if err != nil {
-		if ent.IsNotFound(err) {
-			return echo.NewHTTPError(http.StatusUnauthorized, "failed to authenticate")
-		}
 		return err
 	}
+	return c.JSON(http.StatusOK, resp)
+}
 
-	err = bcrypt.CompareHashAndPassword(user.Password, []byte(body.Password))
+// Creates a new session for the user and returns its tokens
+func (h *Handler) startSession(c echo.Context, user *ent.User, device string) (loginResponse, error) {
+	s, refreshToken, err := users.NewSession(c.Request().Context(), h.DB, h.Conf.Auth, user, device)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusUnauthorized, "failed to authenticate")
+		return loginResponse{}, err
+	}
+	token, err := users.CreateJWT(h.Conf.Auth, user, s.ID)
+	if err != nil {
+		return loginResponse{}, err
+	}
+
+	return loginResponse{
+		IsAdmin:      user.IsAdmin,
+		Id:           user.ID,
+		Token:        token,
+		RefreshToken: refreshToken,
+		Username:     user.Username,
+	}, nil
+}
+
+type authMethodsResponse struct {
+	Password bool `json:"password"`
+	Oidc     bool `json:"oidc"`
+}
+
+// Tells the login page which ways to log in are available
+func (h *Handler) AuthMethods(c echo.Context) error {
+	return c.JSON(http.StatusOK, authMethodsResponse{
+		Password: h.Conf.Auth.PasswordLogin,
+		Oidc:     h.oidc != nil,
+	})
+}
+
+type refreshRequest struct {
+	RefreshToken string `json:"refreshToken"`
+}
+
+func (h *Handler) Refresh(c echo.Context) error {
+	body := refreshRequest{}
+	err := json.NewDecoder(c.Request().Body).Decode(&body)
+	if err != nil || body.RefreshToken == "" {
+		return echo.NewHTTPError(http.StatusBadRequest)
 	}
 
-	token, err := users.CreateJWT(user)
+	s, refreshToken, err := users.RefreshSession(c.Request().Context(), h.DB, h.Conf.Auth, body.RefreshToken)
+	if errors.Is(err, users.ErrInvalidRefreshToken) {
+		return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+	}
+	if err != nil {
+		return err
+	}
+	user := s.Edges.User
+	token, err := users.CreateJWT(h.Conf.Auth, user, s.ID)
 	if err != nil {
 		return err
 	}
 
 	resp := loginResponse{
-		IsAdmin:  user.IsAdmin,
-		Id:       user.ID,
-		Token:    token,
-		Username: user.Username,
+		IsAdmin:      user.IsAdmin,
+		Id:           user.ID,
+		Token:        token,
+		RefreshToken: refreshToken,
+		Username:     user.Username,
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// Revokes the session of the token used for the request
+func (h *Handler) Logout(c echo.Context) error {
+	_, claims := users.IsAuth(c)
+	sessionId, err := claims.SessionId()
+	if err != nil {
+		// API tokens have no session, they are revoked with the CLI
+		return echo.NewHTTPError(http.StatusBadRequest, "not a session token")
+	}
+	err = users.RevokeSession(c.Request().Context(), h.DB, sessionId)
+	if err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+type sessionResponse struct {
+	Id         pid.ID    `json:"id"`
+	Device     string    `json:"device"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Current    bool      `json:"current"`
+}
+
+// Lists the active sessions of the current user
+func (h *Handler) Sessions(c echo.Context) error {
+	_, claims := users.IsAuth(c)
+	current, _ := claims.SessionId()
+	ss, err := h.DB.Client.Session.Query().
+		Where(
+			session.HasUserWith(user.IDEQ(claims.UserId)),
+			session.RevokedAtIsNil(),
+			session.ExpiresAtGT(time.Now()),
+		).
+		Order(ent.Desc(session.FieldLastUsedAt)).
+		All(c.Request().Context())
+	if err != nil {
+		return err
+	}
+
+	resp := make([]sessionResponse, len(ss))
+	for i, s := range ss {
+		resp[i] = sessionResponse{
+			Id:         s.ID,
+			Device:     s.Device,
+			CreatedAt:  s.CreatedAt,
+			LastUsedAt: s.LastUsedAt,
+			ExpiresAt:  s.ExpiresAt,
+			Current:    s.ID == current,
+		}
 	}
 	return c.JSON(http.StatusOK, resp)
 }
 
+// Revokes a session of the current user, admins can revoke any session
+func (h *Handler) SessionDelete(c echo.Context) error {
+	idStr := c.Param("id")
+	id, err := pid.DecodeBase32(idStr)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid ID format provided")
+	}
+
+	_, claims := users.IsAuth(c)
+	q := h.DB.Client.Session.Query().
+		Where(session.IDEQ(id))
+	if !claims.IsAdmin {
+		q.Where(session.HasUserWith(user.IDEQ(claims.UserId)))
+	}
+	exists, err := q.Exist(c.Request().Context())
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return echo.NewHTTPError(http.StatusNotFound)
+	}
+
+	err = users.RevokeSession(c.Request().Context(), h.DB, id)
+	if err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
 func (h *Handler) Users(c echo.Context) error {
 	users, err := h.DB.Client.User.Query().
 		Select(
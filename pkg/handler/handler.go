@@ -1,13 +1,60 @@
 package handler
 
-import "github.com/Pineapple217/cvrs/pkg/database"
+import (
+	"context"
+	"path"
+
+	"github.com/Pineapple217/cvrs/pkg/config"
+	"github.com/Pineapple217/cvrs/pkg/database"
+	"github.com/Pineapple217/cvrs/pkg/ent/schema"
+	"github.com/Pineapple217/cvrs/pkg/storage"
+	"github.com/Pineapple217/cvrs/pkg/users"
+	"github.com/labstack/echo/v4"
+	"golang.org/x/sync/singleflight"
+)
 
 type Handler struct {
-	DB *database.Database
+	DB   *database.Database
+	Conf config.Config
+	// Nil when single sign-on is not configured
+	oidc *users.Oidc
+	// Nil when resized images are not cached
+	resizeCache *storage.DiskCache
+	// Requests for the same resize wait for a single render
+	resizes singleflight.Group
 }
 
-func NewHandler(DB *database.Database) *Handler {
-	return &Handler{
-		DB: DB,
+func NewHandler(DB *database.Database, conf config.Config) (*Handler, error) {
+	h := &Handler{
+		DB:   DB,
+		Conf: conf,
+	}
+	if conf.Oidc.Enabled() {
+		h.oidc = users.NewOidc(conf.Oidc)
+	}
+	if conf.Images.Resize.CacheSize > 0 {
+		cache, err := storage.NewDiskCache(
+			path.Join(DB.Conf.DataLocation, database.CACHE_DIR),
+			int64(conf.Images.Resize.CacheSize)*1024*1024,
+		)
+		if err != nil {
+			return nil, err
+		}
+		h.resizeCache = cache
+	}
+	return h, nil
+}
+
+// Returns the request context, which also exposes soft-deleted rows when an
+// admin asks for them with ?includeDeleted=true
+func queryCtx(c echo.Context) context.Context {
+	ctx := c.Request().Context()
+	if c.QueryParam("includeDeleted") != "true" {
+		return ctx
+	}
+	_, claims := users.IsAuth(c)
+	if !claims.IsAdmin {
+		return ctx
 	}
+	return schema.SkipSoftDelete(ctx)
 }
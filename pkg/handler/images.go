@@ -0,0 +1,348 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/Pineapple217/cvrs/pkg/database"
+	"github.com/Pineapple217/cvrs/pkg/ent"
+	"github.com/Pineapple217/cvrs/pkg/ent/image"
+	"github.com/Pineapple217/cvrs/pkg/ent/processedimage"
+	"github.com/Pineapple217/cvrs/pkg/pid"
+	"github.com/Pineapple217/cvrs/pkg/storage"
+	"github.com/labstack/echo/v4"
+)
+
+type ImagesPage struct {
+	Cursor
+	Images []*ent.Image `json:"images"`
+}
+
+func (h *Handler) ImagesGet(c echo.Context) error {
+	q := h.DB.Client.Image.Query().
+		WithProccesedImage(func(piq *ent.ProcessedImageQuery) {
+			piq.Order(ent.Desc(processedimage.FieldWidth))
+		})
+	is, cursor, err := paginate(c, queryCtx(c), q, func(i *ent.Image) pid.ID { return i.ID })
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, ImagesPage{
+		Cursor: cursor,
+		Images: is,
+	})
+}
+
+// Blobs are never changed, only replaced by a new key
+const immutableCache = "public, max-age=31536000, immutable"
+
+// A blob that answers a request to /api/i/:key
+type imageFile struct {
+	key         string
+	contentType string
+	// Name for the Content-Disposition header
+	filename string
+	// Picked from the variants of an image, so it depends on the request
+	negotiated bool
+}
+
+// Serves an image file from the blob store, or redirects to a presigned URL
+// when the store supports it and presign is enabled. The key is the file of a
+// variant or the ID of an image, for an image the variant that best fits the
+// Accept header and ?size= is served. Files of soft-deleted images are not
+// found. With resize parameters the original is resized, see ImageResize.
+func (h *Handler) ImageFile(c echo.Context) error {
+	if isResizeRequest(c) {
+		return h.ImageResize(c)
+	}
+	ctx := c.Request().Context()
+	file, err := h.findImageFile(c, c.Param("key"))
+	if err != nil {
+		return err
+	}
+
+	header := c.Response().Header()
+	header.Set("Cache-Control", immutableCache)
+	etag := `"` + file.key + `"`
+	header.Set("ETag", etag)
+	if disposition := mime.FormatMediaType("inline", map[string]string{"filename": file.filename}); disposition != "" {
+		header.Set("Content-Disposition", disposition)
+	}
+	if file.negotiated {
+		header.Add("Vary", "Accept")
+		// New variants can show up once the image is processed
+		header.Set("Cache-Control", "public, max-age=3600")
+	}
+	if c.QueryParam("includeDeleted") == "true" {
+		// Soft-deleted images are only for admins
+		header.Set("Cache-Control", "private, no-cache")
+	}
+	// Blobs never change, so the key is a strong validator
+	if ifNoneMatch(c.Request().Header.Get("If-None-Match"), etag) {
+		return c.NoContent(http.StatusNotModified)
+	}
+
+	if presigner, ok := h.DB.Blobs.(storage.Presigner); ok && h.Conf.Storage.Presign {
+		url, err := presigner.Presign(ctx, file.key, h.Conf.Storage.PresignExpiry)
+		if errors.Is(err, fs.ErrInvalid) {
+			return echo.ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		// The redirect may not outlive the URL it points to
+		header.Set("Cache-Control", fmt.Sprintf("private, max-age=%d", int(h.Conf.Storage.PresignExpiry.Seconds()/2)))
+		return c.Redirect(http.StatusFound, url)
+	}
+
+	r, info, err := h.DB.Blobs.Get(ctx, file.key)
+	if err != nil {
+		header.Del("Cache-Control")
+		header.Del("ETag")
+	}
+	if errors.Is(err, fs.ErrNotExist) || errors.Is(err, fs.ErrInvalid) {
+		return echo.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	header.Set(echo.HeaderContentType, file.contentType)
+	// Files can be seeked, so range requests keep working for them
+	if rs, ok := r.(io.ReadSeeker); ok {
+		http.ServeContent(c.Response(), c.Request(), "", info.ModTime, rs)
+		return nil
+	}
+	header.Set(echo.HeaderContentLength, strconv.FormatInt(info.Size, 10))
+	if !info.ModTime.IsZero() {
+		header.Set(echo.HeaderLastModified, info.ModTime.UTC().Format(http.TimeFormat))
+	}
+	return c.Stream(http.StatusOK, file.contentType, r)
+}
+
+// Looks the key up as the file of a variant, then as the ID or file of an
+// image
+func (h *Handler) findImageFile(c echo.Context, key string) (imageFile, error) {
+	ctx := queryCtx(c)
+	procImg, err := h.DB.Client.ProcessedImage.Query().
+		Where(processedimage.FileEQ(key)).
+		WithSource().
+		First(ctx)
+	if err == nil && procImg.Edges.Source != nil {
+		return variantFile(procImg, procImg.Edges.Source), nil
+	}
+	if err != nil && !ent.IsNotFound(err) {
+		return imageFile{}, err
+	}
+
+	imgQuery := h.DB.Client.Image.Query().
+		Where(image.FileEQ(key))
+	if id, err := pid.DecodeBase32(key); err == nil {
+		imgQuery = h.DB.Client.Image.Query().
+			Where(image.Or(image.IDEQ(id), image.FileEQ(key)))
+	}
+	img, err := imgQuery.
+		WithProccesedImage().
+		Order(ent.Asc(image.FieldID)).
+		First(ctx)
+	if ent.IsNotFound(err) {
+		return imageFile{}, echo.ErrNotFound
+	}
+	if err != nil {
+		return imageFile{}, err
+	}
+
+	size := 0
+	if s := c.QueryParam("size"); s != "" {
+		size, err = strconv.Atoi(s)
+		if err != nil || size < 1 {
+			return imageFile{}, echo.NewHTTPError(http.StatusBadRequest, "size must be a positive number")
+		}
+	}
+	file, ok := negotiateImage(img, c.Request().Header.Get("Accept"), size)
+	if !ok {
+		return imageFile{}, echo.NewHTTPError(http.StatusNotAcceptable, "no variant of the image matches the Accept header")
+	}
+	return file, nil
+}
+
+func variantFile(procImg *ent.ProcessedImage, source *ent.Image) imageFile {
+	name := strings.TrimSuffix(source.OriginalName, path.Ext(source.OriginalName))
+	return imageFile{
+		key:         procImg.File,
+		contentType: database.VariantMIME[string(procImg.Type)],
+		filename:    name + "." + strings.ToLower(string(procImg.Type)),
+	}
+}
+
+// Without a size the original is preferred, with a size the smallest
+// variant that is at least that large. Only formats the Accept header allows
+// are considered, the best match wins.
+func negotiateImage(img *ent.Image, accept string, size int) (imageFile, bool) {
+	type candidate struct {
+		file     imageFile
+		longest  int
+		q        float64
+		original bool
+	}
+	candidates := []candidate{}
+	original := imageFile{
+		key:         img.File,
+		contentType: database.VariantMIME[string(img.Type)],
+		filename:    img.OriginalName,
+		negotiated:  true,
+	}
+	if q := acceptQuality(accept, original.contentType); q > 0 {
+		candidates = append(candidates, candidate{original, max(img.DimentionWidth, img.DimentionHeight), q, true})
+	}
+	for _, p := range img.Edges.ProccesedImage {
+		file := variantFile(p, img)
+		file.negotiated = true
+		if q := acceptQuality(accept, file.contentType); q > 0 {
+			candidates = append(candidates, candidate{file, max(p.Width, p.Height), q, false})
+		}
+	}
+	if len(candidates) == 0 {
+		return imageFile{}, false
+	}
+
+	fits := func(c candidate) bool {
+		return size == 0 || c.longest >= size
+	}
+	best := candidates[0]
+	for _, c := range candidates[1:] {
+		switch {
+		case c.q != best.q:
+			if c.q > best.q {
+				best = c
+			}
+		case fits(c) != fits(best):
+			if fits(c) {
+				best = c
+			}
+		case size == 0:
+			if !best.original && c.longest > best.longest {
+				best = c
+			}
+		case fits(c):
+			if c.longest < best.longest {
+				best = c
+			}
+		case c.longest > best.longest:
+			best = c
+		}
+	}
+	return best.file, true
+}
+
+// Quality the Accept header gives the content type, an empty header accepts
+// everything
+func acceptQuality(accept, contentType string) float64 {
+	if strings.TrimSpace(accept) == "" {
+		return 1
+	}
+	mainType, _, _ := strings.Cut(contentType, "/")
+	best, specificity := 0.0, -1
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		s := -1
+		switch mediaType {
+		case contentType:
+			s = 2
+		case mainType + "/*":
+			s = 1
+		case "*/*":
+			s = 0
+		}
+		if s <= specificity {
+			continue
+		}
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			q, err = strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+		}
+		best, specificity = q, s
+	}
+	return best
+}
+
+func ifNoneMatch(header, etag string) bool {
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == etag || tag == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// Points out existing images that look like the uploaded one in the
+// X-Similar-Images header, the upload itself always goes through
+func (h *Handler) warnSimilar(c echo.Context, img *ent.Image) {
+	similar, err := h.DB.SimilarToImage(c.Request().Context(), img)
+	if err != nil {
+		slog.Warn("failed to look for similar images", "img", img.ID, "error", err)
+		return
+	}
+	if len(similar) == 0 {
+		return
+	}
+	ids := make([]string, len(similar))
+	for i, s := range similar {
+		ids[i] = s.Image.ID.String()
+	}
+	c.Response().Header().Set("X-Similar-Images", strings.Join(ids, ","))
+}
+
+// Lists the images that look like the image, the maximum Hamming distance
+// can be set with ?distance=
+func (h *Handler) ImageSimilar(c echo.Context) error {
+	id, err := pid.DecodeBase32(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid ID format provided")
+	}
+	distance := database.SimilarDistance
+	if d := c.QueryParam("distance"); d != "" {
+		distance, err = strconv.Atoi(d)
+		if err != nil || distance < 0 || distance > 64 {
+			return echo.NewHTTPError(http.StatusBadRequest, "distance must be between 0 and 64")
+		}
+	}
+
+	ctx := queryCtx(c)
+	img, err := h.DB.Client.Image.Query().
+		Where(image.IDEQ(id)).
+		WithData().
+		Only(ctx)
+	if ent.IsNotFound(err) {
+		return echo.NewHTTPError(http.StatusNotFound)
+	}
+	if err != nil {
+		return err
+	}
+	if img.Edges.Data == nil || img.Edges.Data.Phash == nil {
+		return echo.NewHTTPError(http.StatusConflict, "image has not been processed yet")
+	}
+
+	similar, err := h.DB.SimilarImages(ctx, uint64(*img.Edges.Data.Phash), distance, img.ID)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, similar)
+}
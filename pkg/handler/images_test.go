@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/Pineapple217/cvrs/pkg/ent"
+	"github.com/Pineapple217/cvrs/pkg/ent/image"
+	"github.com/Pineapple217/cvrs/pkg/ent/processedimage"
+)
+
+func TestAcceptQuality(t *testing.T) {
+	tests := []struct {
+		accept      string
+		contentType string
+		want        float64
+	}{
+		{"", "image/webp", 1},
+		{"image/webp", "image/webp", 1},
+		{"image/webp", "image/png", 0},
+		{"image/*", "image/png", 1},
+		{"*/*;q=0.1", "image/png", 0.1},
+		// The most specific range wins, even with a lower quality
+		{"image/*;q=0.8, image/webp;q=0.5", "image/webp", 0.5},
+		{"image/avif,image/webp,*/*;q=0.8", "image/png", 0.8},
+		{"image/png;q=0", "image/png", 0},
+		{"text/html, image/png;q=abc, image/*;q=0.3", "image/png", 0.3},
+	}
+	for _, tt := range tests {
+		if got := acceptQuality(tt.accept, tt.contentType); got != tt.want {
+			t.Errorf("acceptQuality(%q, %q) = %v, want %v", tt.accept, tt.contentType, got, tt.want)
+		}
+	}
+}
+
+func TestNegotiateImage(t *testing.T) {
+	variant := func(file string, typ processedimage.Type, size int) *ent.ProcessedImage {
+		return &ent.ProcessedImage{File: file, Type: typ, Width: size, Height: size}
+	}
+	img := &ent.Image{
+		File:            "original",
+		OriginalName:    "cover.png",
+		Type:            image.TypePNG,
+		DimentionWidth:  1200,
+		DimentionHeight: 1000,
+		Edges: ent.ImageEdges{ProccesedImage: []*ent.ProcessedImage{
+			variant("small", processedimage.TypeWEBP, 256),
+			variant("medium", processedimage.TypeWEBP, 512),
+			variant("large", processedimage.TypeJPG, 1024),
+		}},
+	}
+
+	tests := []struct {
+		accept string
+		size   int
+		want   string
+		ok     bool
+	}{
+		{"", 0, "original", true},
+		{"", 300, "medium", true},
+		{"", 100, "small", true},
+		{"", 1100, "original", true},
+		// Nothing is large enough, the largest one is the closest
+		{"", 5000, "original", true},
+		{"image/webp", 0, "medium", true},
+		{"image/webp", 5000, "medium", true},
+		{"image/webp,image/*;q=0.5", 600, "medium", true},
+		{"image/jpeg", 300, "large", true},
+		{"image/avif", 0, "", false},
+	}
+	for _, tt := range tests {
+		got, ok := negotiateImage(img, tt.accept, tt.size)
+		if ok != tt.ok || got.key != tt.want {
+			t.Errorf("negotiateImage(%q, %d) = %q %v, want %q %v", tt.accept, tt.size, got.key, ok, tt.want, tt.ok)
+		}
+	}
+
+	file, _ := negotiateImage(img, "image/webp", 0)
+	if file.contentType != "image/webp" || file.filename != "cover.webp" || !file.negotiated {
+		t.Errorf("negotiated file %+v", file)
+	}
+}
+
+func TestIfNoneMatch(t *testing.T) {
+	tests := []struct {
+		header string
+		want   bool
+	}{
+		{"", false},
+		{`"abc"`, true},
+		{`W/"abc"`, true},
+		{`"x", "abc"`, true},
+		{`"abcd"`, false},
+		{"*", true},
+	}
+	for _, tt := range tests {
+		if got := ifNoneMatch(tt.header, `"abc"`); got != tt.want {
+			t.Errorf("ifNoneMatch(%q) = %v, want %v", tt.header, got, tt.want)
+		}
+	}
+}
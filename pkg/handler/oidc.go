@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Pineapple217/cvrs/pkg/users"
+	"github.com/labstack/echo/v4"
+)
+
+const oidcCookie = "cvrs_oidc"
+
+// The frontend page that picks up the tokens after single sign-on
+const oidcLoginPage = "/auth/login"
+
+// Only paths on this site are allowed, anything else would be an open redirect
+func safeRedirect(r string) string {
+	if !strings.HasPrefix(r, "/") || strings.HasPrefix(r, "//") || strings.HasPrefix(r, "/\\") {
+		return "/"
+	}
+	return r
+}
+
+// Sends the browser to the login page of the provider. Pass ?r= to pick the
+// frontend page to return to afterwards.
+func (h *Handler) OidcLogin(c echo.Context) error {
+	if h.oidc == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "single sign-on is not configured")
+	}
+	state, signed, err := users.NewOidcState(h.Conf.Auth, safeRedirect(c.QueryParam("r")))
+	if err != nil {
+		return err
+	}
+	authUrl, err := h.oidc.AuthCodeURL(c.Request().Context(), state.State, state.Nonce, state.Verifier)
+	if err != nil {
+		slog.Error("Failed to start oidc login", "error", err)
+		return echo.NewHTTPError(http.StatusBadGateway, "identity provider is unavailable")
+	}
+
+	c.SetCookie(&http.Cookie{
+		Name:     oidcCookie,
+		Value:    signed,
+		Path:     "/api/auth/oidc",
+		Expires:  time.Now().Add(10 * time.Minute),
+		HttpOnly: true,
+		Secure:   strings.HasPrefix(h.Conf.Oidc.RedirectUrl, "https://"),
+		SameSite: http.SameSiteLaxMode,
+	})
+	return c.Redirect(http.StatusFound, authUrl)
+}
+
+// Finishes the login started by OidcLogin. The tokens are handed to the
+// frontend in the URL fragment, which never reaches a server or its logs.
+func (h *Handler) OidcCallback(c echo.Context) error {
+	if h.oidc == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "single sign-on is not configured")
+	}
+	cookie, err := c.Cookie(oidcCookie)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "login expired, try again")
+	}
+	c.SetCookie(&http.Cookie{
+		Name:   oidcCookie,
+		Path:   "/api/auth/oidc",
+		MaxAge: -1,
+	})
+	state, err := users.ParseOidcState(h.Conf.Auth, cookie.Value)
+	if err != nil || state.State != c.QueryParam("state") {
+		return echo.NewHTTPError(http.StatusBadRequest, "login expired, try again")
+	}
+
+	fail := func(msg string) error {
+		return c.Redirect(http.StatusFound, oidcLoginPage+"?r="+url.QueryEscape(state.Redirect)+
+			"#"+url.Values{"error": {msg}}.Encode())
+	}
+	if e := c.QueryParam("error"); e != "" {
+		slog.Info("Oidc login refused by provider", "error", e, "description", c.QueryParam("error_description"))
+		return fail("login was refused by the identity provider")
+	}
+
+	ctx := c.Request().Context()
+	id, err := h.oidc.Exchange(ctx, c.QueryParam("code"), state.Nonce, state.Verifier)
+	if err != nil {
+		slog.Warn("Oidc login failed", "error", err)
+		return fail("login with the identity provider failed")
+	}
+	u, err := users.ProvisionUser(ctx, h.DB, id)
+	if errors.Is(err, users.ErrOidcUsername) {
+		return fail(err.Error())
+	}
+	if err != nil {
+		return err
+	}
+
+	resp, err := h.startSession(c, u, c.Request().UserAgent())
+	if err != nil {
+		return err
+	}
+	return c.Redirect(http.StatusFound, oidcLoginPage+"?r="+url.QueryEscape(state.Redirect)+
+		"#"+url.Values{"token": {resp.Token}, "refreshToken": {resp.RefreshToken}}.Encode())
+}
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/Pineapple217/cvrs/pkg/ent"
+	"github.com/Pineapple217/cvrs/pkg/ent/intercept"
+	"github.com/Pineapple217/cvrs/pkg/pid"
+	"github.com/labstack/echo/v4"
+)
+
+const defaultPageSize = 100
+const maxPageSize = 200
+
+// Cursor is embedded in every page envelope. Pass Next as ?after= to get the
+// following page and Prev as ?before= to get the one in front of it.
+type Cursor struct {
+	Limit int     `json:"limit"`
+	Next  *pid.ID `json:"next"`
+	Prev  *pid.ID `json:"prev"`
+}
+
+type pageRequest struct {
+	limit  int
+	after  *pid.ID
+	before *pid.ID
+}
+
+type pageQuery[T any] interface {
+	ent.Query
+	All(context.Context) ([]T, error)
+}
+
+func parsePageRequest(c echo.Context) (pageRequest, error) {
+	p := pageRequest{limit: defaultPageSize}
+	limit, err := strconv.Atoi(c.QueryParam("limit"))
+	if err == nil && limit > 0 {
+		p.limit = min(limit, maxPageSize)
+	}
+
+	afterStr := c.QueryParam("after")
+	beforeStr := c.QueryParam("before")
+	if afterStr != "" && beforeStr != "" {
+		return p, echo.NewHTTPError(http.StatusBadRequest, "after and before are mutually exclusive")
+	}
+	if afterStr != "" {
+		after, err := pid.DecodeBase32(afterStr)
+		if err != nil {
+			return p, echo.NewHTTPError(http.StatusBadRequest, "invalid cursor")
+		}
+		p.after = &after
+	}
+	if beforeStr != "" {
+		before, err := pid.DecodeBase32(beforeStr)
+		if err != nil {
+			return p, echo.NewHTTPError(http.StatusBadRequest, "invalid cursor")
+		}
+		p.before = &before
+	}
+	return p, nil
+}
+
+// Fetches one page of q, newest first. Because pid.IDs are time-sortable, the
+// ID doubles as a stable cursor that does not shift when rows get added.
+func paginate[T any](c echo.Context, ctx context.Context, q pageQuery[T], id func(T) pid.ID) ([]T, Cursor, error) {
+	p, err := parsePageRequest(c)
+	if err != nil {
+		return nil, Cursor{}, err
+	}
+	query, err := intercept.NewQuery(q)
+	if err != nil {
+		return nil, Cursor{}, err
+	}
+
+	// One extra row is fetched to find out if there is anything beyond this page
+	switch {
+	case p.before != nil:
+		query.WhereP(sql.FieldGT("id", *p.before))
+		query.Order(sql.OrderByField("id").ToFunc())
+	case p.after != nil:
+		query.WhereP(sql.FieldLT("id", *p.after))
+		query.Order(sql.OrderByField("id", sql.OrderDesc()).ToFunc())
+	default:
+		query.Order(sql.OrderByField("id", sql.OrderDesc()).ToFunc())
+	}
+	query.Limit(p.limit + 1)
+
+	items, err := q.All(ctx)
+	if err != nil {
+		return nil, Cursor{}, err
+	}
+	more := len(items) > p.limit
+	if more {
+		items = items[:p.limit]
+	}
+	if p.before != nil {
+		slices.Reverse(items)
+	}
+
+	cursor := Cursor{Limit: p.limit}
+	if len(items) == 0 {
+		return items, cursor, nil
+	}
+	first, last := id(items[0]), id(items[len(items)-1])
+	if p.before != nil {
+		cursor.Next = &last
+		if more {
+			cursor.Prev = &first
+		}
+	} else {
+		if more {
+			cursor.Next = &last
+		}
+		if p.after != nil {
+			cursor.Prev = &first
+		}
+	}
+	return items, cursor, nil
+}
@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/Pineapple217/cvrs/pkg/ent"
+	"github.com/Pineapple217/cvrs/pkg/pid"
+	"github.com/labstack/echo/v4"
+)
+
+func TestPaginate(t *testing.T) {
+	h := newTestHandler(t)
+	ctx := context.Background()
+	ids := []pid.ID{}
+	for i := range 5 {
+		u, err := h.DB.Client.User.Create().SetUsername(fmt.Sprintf("user%d", i)).Save(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, u.ID)
+	}
+	// Newest first, IDs made in the same millisecond are not ordered
+	slices.Sort(ids)
+	slices.Reverse(ids)
+	id := func(i int) string { return ids[i].String() }
+
+	// Indexes into ids, -1 for no cursor
+	tests := []struct {
+		query      string
+		want       []int
+		next, prev int
+		limit      int
+	}{
+		{"limit=2", []int{0, 1}, 1, -1, 2},
+		{"limit=2&after=" + id(1), []int{2, 3}, 3, 2, 2},
+		{"limit=2&after=" + id(3), []int{4}, -1, 4, 2},
+		{"limit=2&after=" + id(4), []int{}, -1, -1, 2},
+		{"limit=2&before=" + id(4), []int{2, 3}, 3, 2, 2},
+		{"limit=2&before=" + id(2), []int{0, 1}, 1, -1, 2},
+		{"limit=2&before=" + id(0), []int{}, -1, -1, 2},
+		{"limit=5", []int{0, 1, 2, 3, 4}, -1, -1, 5},
+		{"", []int{0, 1, 2, 3, 4}, -1, -1, defaultPageSize},
+		{"limit=0", []int{0, 1, 2, 3, 4}, -1, -1, defaultPageSize},
+		{"limit=abc", []int{0, 1, 2, 3, 4}, -1, -1, defaultPageSize},
+		{"limit=1000", []int{0, 1, 2, 3, 4}, -1, -1, maxPageSize},
+	}
+	e := echo.New()
+	for _, tt := range tests {
+		c := e.NewContext(httptest.NewRequest(http.MethodGet, "/?"+tt.query, nil), httptest.NewRecorder())
+		got, cursor, err := paginate(c, ctx, h.DB.Client.User.Query(), func(u *ent.User) pid.ID { return u.ID })
+		if err != nil {
+			t.Errorf("paginate(%q): %v", tt.query, err)
+			continue
+		}
+		gotIds := []pid.ID{}
+		for _, u := range got {
+			gotIds = append(gotIds, u.ID)
+		}
+		wantIds := []pid.ID{}
+		for _, i := range tt.want {
+			wantIds = append(wantIds, ids[i])
+		}
+		if !slices.Equal(gotIds, wantIds) {
+			t.Errorf("paginate(%q) = %v, want %v", tt.query, gotIds, wantIds)
+		}
+		cursorIs := func(got *pid.ID, want int) bool {
+			if want == -1 {
+				return got == nil
+			}
+			return got != nil && *got == ids[want]
+		}
+		if !cursorIs(cursor.Next, tt.next) || !cursorIs(cursor.Prev, tt.prev) || cursor.Limit != tt.limit {
+			t.Errorf("paginate(%q) cursor = %+v, want next %d prev %d limit %d", tt.query, cursor, tt.next, tt.prev, tt.limit)
+		}
+	}
+
+	for _, query := range []string{"after=!", "before=!", "after=" + id(0) + "&before=" + id(1)} {
+		c := e.NewContext(httptest.NewRequest(http.MethodGet, "/?"+query, nil), httptest.NewRecorder())
+		_, _, err := paginate(c, ctx, h.DB.Client.User.Query(), func(u *ent.User) pid.ID { return u.ID })
+		var httpErr *echo.HTTPError
+		if !errors.As(err, &httpErr) || httpErr.Code != http.StatusBadRequest {
+			t.Errorf("paginate(%q) error = %v, want status 400", query, err)
+		}
+	}
+}
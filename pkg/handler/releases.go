@@ -1,22 +1,48 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
+	"log/slog"
+	"mime/multipart"
 	"net/http"
 	"strings"
 	"time"
 
 	"github.com/Pineapple217/cvrs/pkg/database"
+	"github.com/Pineapple217/cvrs/pkg/ent"
+	"github.com/Pineapple217/cvrs/pkg/ent/artist"
+	"github.com/Pineapple217/cvrs/pkg/ent/processedimage"
+	"github.com/Pineapple217/cvrs/pkg/ent/release"
+	"github.com/Pineapple217/cvrs/pkg/ent/releaseappearance"
+	"github.com/Pineapple217/cvrs/pkg/ent/track"
+	"github.com/Pineapple217/cvrs/pkg/ent/trackappearance"
 	"github.com/Pineapple217/cvrs/pkg/pid"
 	"github.com/Pineapple217/cvrs/pkg/users"
 	"github.com/labstack/echo/v4"
 )
 
+type ReleaseTrack struct {
+	Title   string   `json:"title"`
+	Artists []pid.ID `json:"artists"`
+}
+
 type ReleaseAddRequest struct {
-	Name        string    `json:"name"`
-	Type        string    `json:"type"`
-	ReleaseDate time.Time `json:"releaseDate"`
-	Artists     []pid.ID  `json:"artists"`
+	Name        string         `json:"name"`
+	Type        string         `json:"type"`
+	ReleaseDate time.Time      `json:"releaseDate"`
+	Artists     []pid.ID       `json:"artists"`
+	Tracks      []ReleaseTrack `json:"tracks"`
+}
+
+// Nil fields are left untouched, an empty list clears the artists or tracks
+type ReleaseUpdateRequest struct {
+	Name        *string        `json:"name"`
+	Type        *string        `json:"type"`
+	ReleaseDate *time.Time     `json:"releaseDate"`
+	Artists     []pid.ID       `json:"artists"`
+	Tracks      []ReleaseTrack `json:"tracks"`
 }
 
 func (h *Handler) ReleaseAdd(c echo.Context) error {
@@ -43,6 +69,10 @@ func (h *Handler) ReleaseAdd(c echo.Context) error {
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, "bad release type")
 	}
+	err = h.checkCredits(c.Request().Context(), data.Artists, data.Tracks)
+	if err != nil {
+		return err
+	}
 
 	_, claims := users.IsAuth(c)
 	DBimg, err := h.DB.SaveImg(c.Request().Context(), img, claims.UserId)
@@ -50,16 +80,401 @@ func (h *Handler) ReleaseAdd(c echo.Context) error {
 		return err
 	}
 
-	_, err = h.DB.Client.Release.Create().
+	ctx := c.Request().Context()
+	tx, err := h.DB.Client.Tx(ctx)
+	if err != nil {
+		h.DB.HardDeleteImg(ctx, DBimg.ID)
+		return err
+	}
+	r, err := tx.Release.Create().
 		SetName(strings.TrimSpace(data.Name)).
 		SetImage(DBimg).
 		SetType(t).
 		SetReleaseDate(data.ReleaseDate).
-		Save(c.Request().Context())
+		Save(ctx)
+	if err == nil {
+		err = setReleaseArtists(ctx, tx, r.ID, data.Artists)
+	}
+	if err == nil {
+		err = setReleaseTracks(ctx, tx, r.ID, data.Tracks)
+	}
+	if err == nil {
+		err = tx.Commit()
+	} else if rerr := tx.Rollback(); rerr != nil {
+		err = fmt.Errorf("%w: %v", err, rerr)
+	}
+	if err != nil {
+		h.DB.HardDeleteImg(ctx, DBimg.ID)
+		return err
+	}
+
+	h.warnSimilar(c, DBimg)
+	r, err = queryRelease(h.DB.Client.Release.Query()).
+		Where(release.IDEQ(r.ID)).
+		Only(ctx)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, r)
+}
+
+type ReleasesPage struct {
+	Cursor
+	Releases []*ent.Release `json:"releases"`
+}
+
+func (h *Handler) ReleasesGet(c echo.Context) error {
+	q := queryRelease(h.DB.Client.Release.Query())
+	rs, cursor, err := paginate(c, queryCtx(c), q, func(r *ent.Release) pid.ID { return r.ID })
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, ReleasesPage{
+		Cursor:   cursor,
+		Releases: rs,
+	})
+}
+
+func (h *Handler) ReleaseGetId(c echo.Context) error {
+	idStr := c.Param("id")
+	id, err := pid.DecodeBase32(idStr)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid ID format provided")
+	}
+
+	r, err := queryRelease(h.DB.Client.Release.Query()).
+		Where(release.IDEQ(id)).
+		Only(queryCtx(c))
+	if ent.IsNotFound(err) {
+		return echo.NewHTTPError(http.StatusNotFound)
+	}
+	if ent.IsNotSingular(err) {
+		slog.Warn("not singular", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "not singular")
+	}
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, r)
+}
+
+// Accepts either a plain json body or a multipart form with a "json" field and
+// an optional "img" file to replace the cover.
+func (h *Handler) ReleaseUpdate(c echo.Context) error {
+	idStr := c.Param("id")
+	id, err := pid.DecodeBase32(idStr)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid ID format provided")
+	}
+
+	var data ReleaseUpdateRequest
+	img, err := bindJsonForm(c, &data)
+	if err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	r, err := h.DB.Client.Release.Query().
+		Where(release.IDEQ(id)).
+		WithImage(func(iq *ent.ImageQuery) {
+			iq.Select("id")
+		}).
+		Only(ctx)
+	if ent.IsNotFound(err) {
+		return echo.NewHTTPError(http.StatusNotFound)
+	}
+	if err != nil {
+		return err
+	}
+
+	var t *release.Type
+	if data.Type != nil {
+		parsed, err := database.ParseReleaseType(*data.Type)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "bad release type")
+		}
+		t = &parsed
+	}
+	err = h.checkCredits(ctx, data.Artists, data.Tracks)
+	if err != nil {
+		return err
+	}
+
+	var DBimg *ent.Image
+	if img != nil {
+		if !users.HasScope(c, users.ScopeImagesWrite) {
+			return echo.NewHTTPError(http.StatusForbidden, "missing scope "+string(users.ScopeImagesWrite))
+		}
+		_, claims := users.IsAuth(c)
+		DBimg, err = h.DB.SaveImg(ctx, img, claims.UserId)
+		if err != nil {
+			return err
+		}
+	}
+
+	tx, err := h.DB.Client.Tx(ctx)
+	if err != nil {
+		if DBimg != nil {
+			h.DB.HardDeleteImg(ctx, DBimg.ID)
+		}
+		return err
+	}
+	u := tx.Release.UpdateOneID(id).
+		SetNillableType(t).
+		SetNillableReleaseDate(data.ReleaseDate)
+	if data.Name != nil {
+		u.SetName(strings.TrimSpace(*data.Name))
+	}
+	if DBimg != nil {
+		u.ClearImage().SetImageID(DBimg.ID)
+	}
+	err = u.Exec(ctx)
+	if err == nil && data.Artists != nil {
+		err = setReleaseArtists(ctx, tx, id, data.Artists)
+	}
+	if err == nil && data.Tracks != nil {
+		err = setReleaseTracks(ctx, tx, id, data.Tracks)
+	}
+	if err == nil {
+		err = tx.Commit()
+	} else if rerr := tx.Rollback(); rerr != nil {
+		err = fmt.Errorf("%w: %v", err, rerr)
+	}
+	if err != nil {
+		if DBimg != nil {
+			h.DB.HardDeleteImg(ctx, DBimg.ID)
+		}
+		return err
+	}
+
+	if DBimg != nil && r.Edges.Image != nil {
+		err = h.DB.HardDeleteImg(ctx, r.Edges.Image.ID)
+		if err != nil {
+			slog.Warn("failed to delete replaced release img", "img", r.Edges.Image.ID, "error", err)
+		}
+	}
+	if DBimg != nil {
+		h.warnSimilar(c, DBimg)
+	}
+
+	r, err = queryRelease(h.DB.Client.Release.Query()).
+		Where(release.IDEQ(id)).
+		Only(ctx)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, r)
+}
+
+func (h *Handler) ReleaseDelete(c echo.Context) error {
+	idStr := c.Param("id")
+	id, err := pid.DecodeBase32(idStr)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid ID format provided")
+	}
+
+	ctx := c.Request().Context()
+	r, err := h.DB.Client.Release.Query().
+		Where(release.IDEQ(id)).
+		WithImage(func(iq *ent.ImageQuery) {
+			iq.Select("id")
+		}).
+		Only(ctx)
+	if ent.IsNotFound(err) {
+		return echo.NewHTTPError(http.StatusNotFound)
+	}
+	if err != nil {
+		return err
+	}
+
+	tx, err := h.DB.Client.Tx(ctx)
+	if err != nil {
+		return err
+	}
+	err = setReleaseArtists(ctx, tx, id, nil)
+	if err == nil {
+		err = setReleaseTracks(ctx, tx, id, nil)
+	}
+	if err == nil {
+		err = tx.Release.DeleteOneID(id).Exec(ctx)
+	}
+	if err == nil {
+		err = tx.Commit()
+	} else if rerr := tx.Rollback(); rerr != nil {
+		err = fmt.Errorf("%w: %v", err, rerr)
+	}
+	if err != nil {
+		return err
+	}
+
+	if r.Edges.Image != nil {
+		err = h.DB.HardDeleteImg(ctx, r.Edges.Image.ID)
+		if err != nil {
+			slog.Warn("failed to delete release img", "img", r.Edges.Image.ID, "error", err)
+		}
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// Eager-loads everything needed to render a release
+func queryRelease(rq *ent.ReleaseQuery) *ent.ReleaseQuery {
+	return rq.
+		WithImage(func(iq *ent.ImageQuery) {
+			iq.Select("id").WithProccesedImage(func(piq *ent.ProcessedImageQuery) {
+				piq.Order(ent.Desc(processedimage.FieldWidth))
+			}).WithData()
+		}).
+		WithReleaseAppearance(func(raq *ent.ReleaseAppearanceQuery) {
+			raq.Order(releaseappearance.ByOrder()).WithArtist()
+		}).
+		WithTracks(func(tq *ent.TrackQuery) {
+			tq.Order(track.ByPosition()).
+				WithAppearance(func(taq *ent.TrackAppearanceQuery) {
+					taq.Order(trackappearance.ByOrder()).WithArtist()
+				})
+		})
+}
+
+// Makes sure every credited artist exists and is credited only once before
+// anything is written
+func (h *Handler) checkCredits(ctx context.Context, artists []pid.ID, tracks []ReleaseTrack) error {
+	ids := map[pid.ID]struct{}{}
+	if hasDuplicate(artists) {
+		return echo.NewHTTPError(http.StatusBadRequest, "artist listed twice")
+	}
+	for _, a := range artists {
+		ids[a] = struct{}{}
+	}
+	for i, t := range tracks {
+		if strings.TrimSpace(t.Title) == "" {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("track %d has no title", i+1))
+		}
+		if hasDuplicate(t.Artists) {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("artist listed twice on track %d", i+1))
+		}
+		for _, a := range t.Artists {
+			ids[a] = struct{}{}
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	list := make([]pid.ID, 0, len(ids))
+	for id := range ids {
+		list = append(list, id)
+	}
+	count, err := h.DB.Client.Artist.Query().
+		Where(artist.IDIn(list...)).
+		Count(ctx)
+	if err != nil {
+		return err
+	}
+	if count != len(list) {
+		return echo.NewHTTPError(http.StatusBadRequest, "unknown artist")
+	}
+	return nil
+}
+
+func hasDuplicate(ids []pid.ID) bool {
+	seen := map[pid.ID]bool{}
+	for _, id := range ids {
+		if seen[id] {
+			return true
+		}
+		seen[id] = true
+	}
+	return false
+}
+
+// Replaces the credited artists of a release, keeping the given order
+func setReleaseArtists(ctx context.Context, tx *ent.Tx, releaseId pid.ID, artists []pid.ID) error {
+	_, err := tx.ReleaseAppearance.Delete().
+		Where(releaseappearance.ReleaseID(releaseId)).
+		Exec(ctx)
 	if err != nil {
-		h.DB.HardDeleteImg(c.Request().Context(), DBimg.ID)
 		return err
 	}
+	creates := make([]*ent.ReleaseAppearanceCreate, len(artists))
+	for i, a := range artists {
+		creates[i] = tx.ReleaseAppearance.Create().
+			SetReleaseID(releaseId).
+			SetArtistID(a).
+			SetOrder(i + 1)
+	}
+	return tx.ReleaseAppearance.CreateBulk(creates...).Exec(ctx)
+}
 
+// Replaces the track list of a release, positions follow the slice order
+func setReleaseTracks(ctx context.Context, tx *ent.Tx, releaseId pid.ID, tracks []ReleaseTrack) error {
+	old, err := tx.Track.Query().
+		Where(track.HasReleaseWith(release.IDEQ(releaseId))).
+		IDs(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = tx.TrackAppearance.Delete().
+		Where(trackappearance.TrackIDIn(old...)).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Track.Delete().
+		Where(track.IDIn(old...)).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	for i, t := range tracks {
+		dbTrack, err := tx.Track.Create().
+			SetTitle(strings.TrimSpace(t.Title)).
+			SetPosition(i + 1).
+			SetReleaseID(releaseId).
+			Save(ctx)
+		if err != nil {
+			return err
+		}
+		creates := make([]*ent.TrackAppearanceCreate, len(t.Artists))
+		for j, a := range t.Artists {
+			creates[j] = tx.TrackAppearance.Create().
+				SetTrackID(dbTrack.ID).
+				SetArtistID(a).
+				SetOrder(j + 1)
+		}
+		err = tx.TrackAppearance.CreateBulk(creates...).Exec(ctx)
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
+
+// Decodes the request into v, either from a plain json body or from the
+// "json" field of a multipart form. The optional "img" file is returned.
+func bindJsonForm(c echo.Context, v any) (*multipart.FileHeader, error) {
+	ct := c.Request().Header.Get(echo.HeaderContentType)
+	if !strings.HasPrefix(ct, echo.MIMEMultipartForm) {
+		err := json.NewDecoder(c.Request().Body).Decode(v)
+		if err != nil {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, "invalid json")
+		}
+		return nil, nil
+	}
+
+	f, err := c.MultipartForm()
+	if err != nil {
+		return nil, err
+	}
+	if len(f.Value["json"]) > 0 {
+		err = json.Unmarshal([]byte(f.Value["json"][0]), v)
+		if err != nil {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, "invalid json")
+		}
+	}
+	if len(f.File["img"]) > 0 {
+		return f.File["img"][0], nil
+	}
+	return nil, nil
+}
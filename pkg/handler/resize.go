@@ -0,0 +1,203 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Pineapple217/cvrs/pkg/config"
+	"github.com/Pineapple217/cvrs/pkg/database"
+	"github.com/Pineapple217/cvrs/pkg/ent"
+	"github.com/Pineapple217/cvrs/pkg/pid"
+	"github.com/Pineapple217/cvrs/pkg/worker"
+	"github.com/labstack/echo/v4"
+)
+
+// Quality used when ?q= is missing
+const defaultResizeQuality = 80
+
+var resizeFormats = map[string]string{
+	"webp": "WEBP",
+	"png":  "PNG",
+	"jpg":  "JPG",
+	"jpeg": "JPG",
+}
+
+// Only the resize parameters are read from the request
+func isResizeRequest(c echo.Context) bool {
+	q := c.QueryParams()
+	return q.Has("w") || q.Has("h") || q.Has("fmt") || q.Has("q")
+}
+
+// Parses ?w=&h=&fmt=&q= into a variant. Sizes are rounded up to an allowed
+// size and the quality to a multiple of ten, so there are only so many
+// versions of an image to render and cache. Sizes larger than the original
+// are brought down to it.
+func resizeVariant(c echo.Context, conf config.ImageResize, img *ent.Image) (config.ImageVariant, error) {
+	v := config.ImageVariant{
+		Format:  "WEBP",
+		Quality: defaultResizeQuality,
+	}
+	var err error
+	size := func(name string) (int, error) {
+		s := c.QueryParam(name)
+		if s == "" {
+			return 0, nil
+		}
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 1 {
+			return 0, echo.NewHTTPError(http.StatusBadRequest, name+" must be a positive number")
+		}
+		return conf.Clamp(n), nil
+	}
+	v.Width, err = size("w")
+	if err != nil {
+		return v, err
+	}
+	v.Height, err = size("h")
+	if err != nil {
+		return v, err
+	}
+	if f := c.QueryParam("fmt"); f != "" {
+		format, ok := resizeFormats[strings.ToLower(f)]
+		if !ok {
+			return v, echo.NewHTTPError(http.StatusBadRequest, "fmt must be webp, png or jpg")
+		}
+		v.Format = format
+	}
+	if q := c.QueryParam("q"); q != "" {
+		v.Quality, err = strconv.Atoi(q)
+		if err != nil || v.Quality < 1 || v.Quality > 100 {
+			return v, echo.NewHTTPError(http.StatusBadRequest, "q must be between 1 and 100")
+		}
+		v.Quality = max(10, (v.Quality+5)/10*10)
+	}
+
+	// With both sides the image is cropped to fill them, otherwise it keeps
+	// its aspect ratio within the largest allowed size
+	v.Mode = "fit"
+	switch {
+	case v.Width > 0 && v.Height > 0:
+		v.Mode = "cover-crop"
+	case v.Width > 0:
+		v.Height = conf.Max()
+	case v.Height > 0:
+		v.Width = conf.Max()
+	default:
+		v.Width, v.Height = conf.Max(), conf.Max()
+	}
+
+	// Images are never scaled up, the original is as large as a render gets
+	w, h := img.DimentionWidth, img.DimentionHeight
+	switch {
+	case v.Mode == "fit":
+		v.Width, v.Height = min(v.Width, w), min(v.Height, h)
+	case v.Width <= w && v.Height <= h:
+		// Already fits
+	case w*v.Height < h*v.Width:
+		v.Width, v.Height = w, max(1, v.Height*w/v.Width)
+	default:
+		v.Width, v.Height = max(1, v.Width*h/v.Height), h
+	}
+	return v, nil
+}
+
+// Serves the original image of /api/i/:id resized to ?w= and ?h=, encoded as
+// ?fmt= with quality ?q=. Results are cached on disk and have a strong ETag,
+// the same parameters always give the same bytes, so a matching If-None-Match
+// does not need a render.
+func (h *Handler) ImageResize(c echo.Context) error {
+	id, err := pid.DecodeBase32(c.Param("key"))
+	if err != nil {
+		return echo.ErrNotFound
+	}
+	ctx := c.Request().Context()
+	img, err := h.DB.Client.Image.Get(ctx, id)
+	if ent.IsNotFound(err) {
+		return echo.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	v, err := resizeVariant(c, h.Conf.Images.Resize, img)
+	if err != nil {
+		return err
+	}
+
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s:%s:%d:%d:%s:%d", img.File, v.Mode, v.Width, v.Height, v.Format, v.Quality)))
+	key := hex.EncodeToString(sum[:])
+	header := c.Response().Header()
+	etag := `"` + key[:32] + `"`
+	if ifNoneMatch(c.Request().Header.Get("If-None-Match"), etag) {
+		setResizeHeaders(header, etag, v)
+		return c.NoContent(http.StatusNotModified)
+	}
+
+	if h.resizeCache != nil {
+		if f, ok := h.resizeCache.Open(key); ok {
+			defer f.Close()
+			info, err := f.Stat()
+			if err == nil {
+				setResizeHeaders(header, etag, v)
+				// Handles If-None-Match with the ETag set above
+				http.ServeContent(c.Response(), c.Request(), "", info.ModTime(), f)
+				return nil
+			}
+		}
+	}
+
+	data, err, _ := h.resizes.Do(key, func() (any, error) {
+		// Other requests may be waiting on this render
+		return h.renderResize(context.WithoutCancel(ctx), img, v, key)
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return echo.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	setResizeHeaders(header, etag, v)
+	http.ServeContent(c.Response(), c.Request(), "", time.Now(), bytes.NewReader(data.([]byte)))
+	return nil
+}
+
+func setResizeHeaders(header http.Header, etag string, v config.ImageVariant) {
+	header.Set("Cache-Control", immutableCache)
+	header.Set(echo.HeaderContentType, database.VariantMIME[v.Format])
+	header.Set("ETag", etag)
+}
+
+func (h *Handler) renderResize(ctx context.Context, img *ent.Image, v config.ImageVariant, key string) ([]byte, error) {
+	r, _, err := h.DB.Blobs.Get(ctx, img.File)
+	if err != nil {
+		return nil, err
+	}
+	original, _, err := image.Decode(r)
+	r.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	buf := bytes.Buffer{}
+	err = database.EncodeVariant(&buf, worker.ResizeImg(original, v), v)
+	if err != nil {
+		return nil, err
+	}
+	if h.resizeCache != nil {
+		err = h.resizeCache.Put(key, buf.Bytes())
+		if err != nil {
+			slog.Warn("failed to cache resized image", "img", img.ID, "error", err)
+		}
+	}
+	return buf.Bytes(), nil
+}
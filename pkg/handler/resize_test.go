@@ -0,0 +1,195 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Pineapple217/cvrs/pkg/config"
+	"github.com/Pineapple217/cvrs/pkg/database"
+	"github.com/Pineapple217/cvrs/pkg/ent"
+	entImage "github.com/Pineapple217/cvrs/pkg/ent/image"
+	"github.com/labstack/echo/v4"
+)
+
+func TestResizeVariant(t *testing.T) {
+	var conf config.Images
+	conf.SetDefault()
+	img := &ent.Image{DimentionWidth: 1000, DimentionHeight: 500}
+	small := &ent.Image{DimentionWidth: 100, DimentionHeight: 100}
+
+	tests := []struct {
+		query string
+		img   *ent.Image
+		want  config.ImageVariant
+		// Status of the error, 0 when the query is valid
+		status int
+	}{
+		{query: "", want: config.ImageVariant{Width: 1000, Height: 500, Mode: "fit", Format: "WEBP", Quality: 80}},
+		{query: "w=100", want: config.ImageVariant{Width: 128, Height: 500, Mode: "fit", Format: "WEBP", Quality: 80}},
+		{query: "h=100", want: config.ImageVariant{Width: 1000, Height: 128, Mode: "fit", Format: "WEBP", Quality: 80}},
+		{query: "w=100&h=50", want: config.ImageVariant{Width: 128, Height: 64, Mode: "cover-crop", Format: "WEBP", Quality: 80}},
+		{query: "w=5000", want: config.ImageVariant{Width: 1000, Height: 500, Mode: "fit", Format: "WEBP", Quality: 80}},
+		{query: "w=64&fmt=JPEG&q=84", want: config.ImageVariant{Width: 64, Height: 500, Mode: "fit", Format: "JPG", Quality: 80}},
+		{query: "w=64&fmt=png&q=85", want: config.ImageVariant{Width: 64, Height: 500, Mode: "fit", Format: "PNG", Quality: 90}},
+		{query: "w=64&q=1", want: config.ImageVariant{Width: 64, Height: 500, Mode: "fit", Format: "WEBP", Quality: 10}},
+		{query: "w=64&q=100", want: config.ImageVariant{Width: 64, Height: 500, Mode: "fit", Format: "WEBP", Quality: 100}},
+		// Never larger than the original
+		{query: "w=2048", img: small, want: config.ImageVariant{Width: 100, Height: 100, Mode: "fit", Format: "WEBP", Quality: 80}},
+		{query: "h=2048", img: small, want: config.ImageVariant{Width: 100, Height: 100, Mode: "fit", Format: "WEBP", Quality: 80}},
+		{query: "w=2048&h=1024", img: small, want: config.ImageVariant{Width: 100, Height: 50, Mode: "cover-crop", Format: "WEBP", Quality: 80}},
+		{query: "w=512&h=2048", img: small, want: config.ImageVariant{Width: 25, Height: 100, Mode: "cover-crop", Format: "WEBP", Quality: 80}},
+		{query: "w=64&h=2048", img: small, want: config.ImageVariant{Width: 3, Height: 100, Mode: "cover-crop", Format: "WEBP", Quality: 80}},
+		{query: "w=0", status: http.StatusBadRequest},
+		{query: "h=abc", status: http.StatusBadRequest},
+		{query: "fmt=gif", status: http.StatusBadRequest},
+		{query: "q=0", status: http.StatusBadRequest},
+		{query: "q=101", status: http.StatusBadRequest},
+	}
+	e := echo.New()
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/api/i/x?"+tt.query, nil)
+		c := e.NewContext(req, httptest.NewRecorder())
+		in := img
+		if tt.img != nil {
+			in = tt.img
+		}
+		got, err := resizeVariant(c, conf.Resize, in)
+		var httpErr *echo.HTTPError
+		switch {
+		case tt.status != 0:
+			if !errors.As(err, &httpErr) || httpErr.Code != tt.status {
+				t.Errorf("resizeVariant(%q) error = %v, want status %d", tt.query, err, tt.status)
+			}
+		case err != nil:
+			t.Errorf("resizeVariant(%q) error = %v", tt.query, err)
+		case got != tt.want:
+			t.Errorf("resizeVariant(%q) = %+v, want %+v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func newTestHandler(t *testing.T) *Handler {
+	t.Helper()
+	var conf config.Config
+	conf.SetDefault()
+	conf.Database.DataLocation = t.TempDir()
+	conf.Database.SqliteOptions = fmt.Sprintf("file:%s?mode=memory&cache=shared&_fk=1", strings.ReplaceAll(t.Name(), "/", "_"))
+	conf.Storage.Backend = "fs"
+	db, err := database.NewDatabase(conf.Database, conf.Storage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Client.Close() })
+	h, err := NewHandler(db, conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return h
+}
+
+func TestImageResizeNotModified(t *testing.T) {
+	h := newTestHandler(t)
+	ctx := context.Background()
+	src := image.NewRGBA(image.Rect(0, 0, 64, 48))
+	draw.Draw(src, src.Bounds(), &image.Uniform{color.RGBA{200, 40, 40, 255}}, image.Point{}, draw.Src)
+	var buf bytes.Buffer
+	err := png.Encode(&buf, src)
+	if err == nil {
+		err = h.DB.Blobs.Put(ctx, "original", bytes.NewReader(buf.Bytes()), int64(buf.Len()), "image/png")
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, err := h.DB.Client.User.Create().SetUsername("bob").Save(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	img, err := h.DB.Client.Image.Create().
+		SetFile("original").
+		SetOriginalName("a.png").
+		SetType(entImage.TypePNG).
+		SetDimentionWidth(64).
+		SetDimentionHeight(48).
+		SetSizeBits(uint32(buf.Len())).
+		SetUploader(u).
+		Save(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e := echo.New()
+	get := func(query, ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/i/"+img.ID.String()+"?"+query, nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("key")
+		c.SetParamValues(img.ID.String())
+		err := h.ImageFile(c)
+		if err != nil {
+			t.Fatalf("GET ?%s: %v", query, err)
+		}
+		return rec
+	}
+
+	rec := get("w=32&fmt=png", "")
+	etag := rec.Header().Get("ETag")
+	if rec.Code != http.StatusOK || etag == "" {
+		t.Fatalf("first request: status %d, ETag %q", rec.Code, etag)
+	}
+	if rec.Header().Get(echo.HeaderContentType) != "image/png" {
+		t.Errorf("content type %s", rec.Header().Get(echo.HeaderContentType))
+	}
+	resized, err := png.Decode(rec.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b := resized.Bounds(); b.Dx() != 32 || b.Dy() != 24 {
+		t.Errorf("resized to %dx%d, want 32x24", b.Dx(), b.Dy())
+	}
+
+	rec = get("w=32&fmt=png", etag)
+	if rec.Code != http.StatusNotModified || rec.Body.Len() != 0 {
+		t.Errorf("matching ETag: status %d with %d bytes", rec.Code, rec.Body.Len())
+	}
+	if rec.Header().Get("ETag") != etag || rec.Header().Get("Cache-Control") != immutableCache {
+		t.Errorf("304 headers %v", rec.Header())
+	}
+
+	// Rounded to the same size and quality, so the same ETag
+	rec = get("w=30&fmt=png", etag)
+	if rec.Code != http.StatusNotModified {
+		t.Errorf("equivalent parameters: status %d", rec.Code)
+	}
+	rec = get("w=64&fmt=png", etag)
+	if rec.Code != http.StatusOK || rec.Header().Get("ETag") == etag {
+		t.Errorf("other size: status %d, ETag %s", rec.Code, rec.Header().Get("ETag"))
+	}
+
+	// Larger than the original gives the original size, with the same ETag
+	// as asking for exactly that
+	full := rec.Header().Get("ETag")
+	rec = get("w=2048&fmt=png", "")
+	resized, err = png.Decode(rec.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b := resized.Bounds(); b.Dx() != 64 || b.Dy() != 48 {
+		t.Errorf("upscaled to %dx%d", b.Dx(), b.Dy())
+	}
+	if rec.Header().Get("ETag") != full {
+		t.Errorf("w=2048 ETag %s, want %s", rec.Header().Get("ETag"), full)
+	}
+}
@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Pineapple217/cvrs/pkg/database"
+	"github.com/Pineapple217/cvrs/pkg/ent"
+	"github.com/Pineapple217/cvrs/pkg/ent/artist"
+	"github.com/Pineapple217/cvrs/pkg/ent/processedimage"
+	"github.com/Pineapple217/cvrs/pkg/ent/release"
+	"github.com/Pineapple217/cvrs/pkg/ent/track"
+	"github.com/Pineapple217/cvrs/pkg/pid"
+	"github.com/labstack/echo/v4"
+)
+
+type SearchResult struct {
+	Type    database.SearchKind `json:"type"`
+	Id      pid.ID              `json:"id"`
+	Name    string              `json:"name"`
+	Rank    float64             `json:"rank"`
+	Release *pid.ID             `json:"release,omitempty"`
+	// Smallest variant of the cover, tracks use the one of their release
+	Thumb *ent.ProcessedImage `json:"thumb"`
+}
+
+type SearchResponse struct {
+	Query   string         `json:"query"`
+	Results []SearchResult `json:"results"`
+}
+
+func (h *Handler) Search(c echo.Context) error {
+	q := strings.TrimSpace(c.QueryParam("q"))
+	if q == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "no query provided")
+	}
+	limit, err := strconv.Atoi(c.QueryParam("limit"))
+	if err != nil || limit <= 0 {
+		limit = 20
+	}
+	limit = min(limit, 100)
+	kinds := []database.SearchKind{}
+	if t := c.QueryParam("type"); t != "" {
+		for _, k := range strings.Split(t, ",") {
+			kind := database.SearchKind(k)
+			switch kind {
+			case database.SearchArtist, database.SearchRelease, database.SearchTrack:
+				kinds = append(kinds, kind)
+			default:
+				return echo.NewHTTPError(http.StatusBadRequest, "bad search type")
+			}
+		}
+	}
+
+	ctx := queryCtx(c)
+	hits, err := h.DB.Search(ctx, q, limit, kinds...)
+	if errors.Is(err, database.ErrSearchDisabled) {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, err.Error())
+	}
+	if err != nil {
+		return err
+	}
+
+	ids := map[database.SearchKind][]pid.ID{}
+	for _, hit := range hits {
+		ids[hit.Kind] = append(ids[hit.Kind], hit.Ref)
+	}
+	withThumb := func(iq *ent.ImageQuery) {
+		iq.Select("id").WithProccesedImage(func(piq *ent.ProcessedImageQuery) {
+			piq.Order(ent.Asc(processedimage.FieldWidth))
+		})
+	}
+
+	// Soft-deleted rows are filtered out here, so they drop out of the results
+	found := map[pid.ID]SearchResult{}
+	as, err := h.DB.Client.Artist.Query().
+		Where(artist.IDIn(ids[database.SearchArtist]...)).
+		WithImage(withThumb).
+		All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, a := range as {
+		found[a.ID] = SearchResult{Name: a.Name, Thumb: smallestVariant(a.Edges.Image)}
+	}
+	rs, err := h.DB.Client.Release.Query().
+		Where(release.IDIn(ids[database.SearchRelease]...)).
+		WithImage(withThumb).
+		All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, r := range rs {
+		found[r.ID] = SearchResult{Name: r.Name, Thumb: smallestVariant(r.Edges.Image)}
+	}
+	ts, err := h.DB.Client.Track.Query().
+		Where(track.IDIn(ids[database.SearchTrack]...)).
+		WithRelease(func(rq *ent.ReleaseQuery) {
+			rq.Select("id").WithImage(withThumb)
+		}).
+		All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, t := range ts {
+		res := SearchResult{Name: t.Title}
+		if t.Edges.Release != nil {
+			res.Release = &t.Edges.Release.ID
+			res.Thumb = smallestVariant(t.Edges.Release.Edges.Image)
+		}
+		found[t.ID] = res
+	}
+
+	results := []SearchResult{}
+	for _, hit := range hits {
+		res, ok := found[hit.Ref]
+		if !ok {
+			continue
+		}
+		res.Type = hit.Kind
+		res.Id = hit.Ref
+		res.Rank = hit.Rank
+		results = append(results, res)
+	}
+	return c.JSON(http.StatusOK, SearchResponse{
+		Query:   q,
+		Results: results,
+	})
+}
+
+func smallestVariant(img *ent.Image) *ent.ProcessedImage {
+	if img == nil || len(img.Edges.ProccesedImage) == 0 {
+		return nil
+	}
+	return img.Edges.ProccesedImage[0]
+}
@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Pineapple217/cvrs/pkg/ent"
+	"github.com/Pineapple217/cvrs/pkg/ent/task"
+	"github.com/Pineapple217/cvrs/pkg/pid"
+	"github.com/labstack/echo/v4"
+)
+
+type TasksPage struct {
+	Cursor
+	Tasks []*ent.Task `json:"tasks"`
+}
+
+func (h *Handler) TasksGet(c echo.Context) error {
+	q := h.DB.Client.Task.Query()
+	if status := c.QueryParam("status"); status != "" {
+		s := task.Status(status)
+		if err := task.StatusValidator(s); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "bad task status")
+		}
+		q.Where(task.StatusEQ(s))
+	}
+	ts, cursor, err := paginate(c, c.Request().Context(), q, func(t *ent.Task) pid.ID { return t.ID })
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, TasksPage{
+		Cursor: cursor,
+		Tasks:  ts,
+	})
+}
@@ -0,0 +1,243 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/Pineapple217/cvrs/pkg/ent"
+	"github.com/Pineapple217/cvrs/pkg/pid"
+	"github.com/Pineapple217/cvrs/pkg/users"
+	"github.com/labstack/echo/v4"
+)
+
+// Public view of a user, the password hash never leaves the server
+type UserResponse struct {
+	Id        pid.ID    `json:"id"`
+	Username  string    `json:"username"`
+	IsAdmin   bool      `json:"is_admin"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func newUserResponse(u *ent.User) UserResponse {
+	return UserResponse{
+		Id:        u.ID,
+		Username:  u.Username,
+		IsAdmin:   u.IsAdmin,
+		CreatedAt: u.CreatedAt,
+	}
+}
+
+// Maps the errors of the users package onto HTTP errors
+func userError(err error) error {
+	switch {
+	case errors.Is(err, users.ErrUsernameTaken), errors.Is(err, users.ErrLastAdmin), errors.Is(err, users.ErrHasImages):
+		return echo.NewHTTPError(http.StatusConflict, err.Error())
+	case ent.IsNotFound(err):
+		return echo.NewHTTPError(http.StatusNotFound)
+	}
+	return err
+}
+
+type MeResponse struct {
+	UserResponse
+	Scopes []users.Scope `json:"scopes"`
+}
+
+func (h *Handler) Me(c echo.Context) error {
+	_, claims := users.IsAuth(c)
+	u, err := h.DB.Client.User.Get(c.Request().Context(), claims.UserId)
+	if err != nil {
+		return userError(err)
+	}
+	return c.JSON(http.StatusOK, MeResponse{
+		UserResponse: newUserResponse(u),
+		Scopes:       claims.Scopes,
+	})
+}
+
+type MePasswordRequest struct {
+	CurrentPassword string `json:"currentPassword"`
+	NewPassword     string `json:"newPassword"`
+}
+
+// Changes the password of the current user, every other session is logged out
+func (h *Handler) MePassword(c echo.Context) error {
+	var data MePasswordRequest
+	err := json.NewDecoder(c.Request().Body).Decode(&data)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest)
+	}
+	err = users.ValidatePassword(data.NewPassword)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	ctx := c.Request().Context()
+	_, claims := users.IsAuth(c)
+	u, err := h.DB.Client.User.Get(ctx, claims.UserId)
+	if err != nil {
+		return userError(err)
+	}
+	if !users.CheckPassword(u, data.CurrentPassword) {
+		return echo.NewHTTPError(http.StatusForbidden, "current password is wrong")
+	}
+
+	keep := []pid.ID{}
+	if sessionId, err := claims.SessionId(); err == nil {
+		keep = append(keep, sessionId)
+	}
+	err = users.SetPassword(ctx, h.DB, u, data.NewPassword, keep...)
+	if err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+type UserAddRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	IsAdmin  bool   `json:"isAdmin"`
+}
+
+func (h *Handler) UserAdd(c echo.Context) error {
+	var data UserAddRequest
+	err := json.NewDecoder(c.Request().Body).Decode(&data)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest)
+	}
+	err = users.ValidateUsername(data.Username)
+	if err == nil {
+		err = users.ValidatePassword(data.Password)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	u, err := users.CreateUser(c.Request().Context(), h.DB, data.Username, data.Password, data.IsAdmin)
+	if err != nil {
+		return userError(err)
+	}
+	return c.JSON(http.StatusCreated, newUserResponse(u))
+}
+
+// Fields that are left out stay unchanged
+type UserUpdateRequest struct {
+	Username *string `json:"username"`
+	Password *string `json:"password"`
+	IsAdmin  *bool   `json:"isAdmin"`
+}
+
+func (h *Handler) UserUpdate(c echo.Context) error {
+	idStr := c.Param("id")
+	id, err := pid.DecodeBase32(idStr)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid ID format provided")
+	}
+	var data UserUpdateRequest
+	err = json.NewDecoder(c.Request().Body).Decode(&data)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest)
+	}
+	if data.Username != nil {
+		err = users.ValidateUsername(*data.Username)
+	}
+	if err == nil && data.Password != nil {
+		err = users.ValidatePassword(*data.Password)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	ctx := c.Request().Context()
+	u, err := h.DB.Client.User.Get(ctx, id)
+	if err != nil {
+		return userError(err)
+	}
+	err = users.UpdateUser(ctx, h.DB, u, users.UserUpdate{
+		Username: data.Username,
+		Password: data.Password,
+		IsAdmin:  data.IsAdmin,
+	})
+	if err != nil {
+		return userError(err)
+	}
+
+	u, err = h.DB.Client.User.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, newUserResponse(u))
+}
+
+// Deletes a user, pass ?reassign=<id> to hand its uploaded images to another user
+func (h *Handler) UserDelete(c echo.Context) error {
+	idStr := c.Param("id")
+	id, err := pid.DecodeBase32(idStr)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid ID format provided")
+	}
+
+	ctx := c.Request().Context()
+	u, err := h.DB.Client.User.Get(ctx, id)
+	if err != nil {
+		return userError(err)
+	}
+	var target *ent.User
+	if reassign := c.QueryParam("reassign"); reassign != "" {
+		targetId, err := pid.DecodeBase32(reassign)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid ID format provided")
+		}
+		target, err = h.DB.Client.User.Get(ctx, targetId)
+		if ent.IsNotFound(err) {
+			return echo.NewHTTPError(http.StatusBadRequest, "reassign user not found")
+		}
+		if err != nil {
+			return err
+		}
+		if target.ID == u.ID {
+			return echo.NewHTTPError(http.StatusBadRequest, "can not reassign images to the deleted user")
+		}
+	}
+
+	_, err = users.DeleteUser(ctx, h.DB, u, target)
+	if err != nil {
+		return userError(err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// Clears the failed logins of a user, so a locked out user can log in again
+func (h *Handler) UserUnlock(c echo.Context) error {
+	idStr := c.Param("id")
+	id, err := pid.DecodeBase32(idStr)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid ID format provided")
+	}
+	ctx := c.Request().Context()
+	u, err := h.DB.Client.User.Get(ctx, id)
+	if err != nil {
+		return userError(err)
+	}
+	_, err = users.Unlock(ctx, h.DB, users.UserLockoutKey(u.Username))
+	if err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// Clears the failed logins of an IP address
+func (h *Handler) IpUnlock(c echo.Context) error {
+	ip := c.Param("ip")
+	if net.ParseIP(ip) == nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid IP address")
+	}
+	_, err := users.Unlock(c.Request().Context(), h.DB, users.IpLockoutKey(ip))
+	if err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusNoContent)
+}
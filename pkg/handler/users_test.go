@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	entImage "github.com/Pineapple217/cvrs/pkg/ent/image"
+	"github.com/Pineapple217/cvrs/pkg/ent/session"
+	"github.com/Pineapple217/cvrs/pkg/ent/user"
+	"github.com/Pineapple217/cvrs/pkg/pid"
+	"github.com/Pineapple217/cvrs/pkg/users"
+	"github.com/labstack/echo/v4"
+)
+
+// Calls the handler with a JSON body as the user of the claims and returns
+// the status
+func callUser(t *testing.T, handler echo.HandlerFunc, target, body, id string, claims users.JwtClaims) int {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(id)
+	c.Set("isAuth", true)
+	c.Set("claims", claims)
+	err := handler(c)
+	var httpErr *echo.HTTPError
+	switch {
+	case errors.As(err, &httpErr):
+		return httpErr.Code
+	case err != nil:
+		t.Fatalf("%s: %v", target, err)
+	}
+	return rec.Code
+}
+
+func TestMePassword(t *testing.T) {
+	h := newTestHandler(t)
+	ctx := context.Background()
+	u, err := users.CreateUser(ctx, h.DB, "bob", "secret", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	current, _, err := users.NewSession(ctx, h.DB, h.Conf.Auth, u, "current")
+	if err == nil {
+		_, _, err = users.NewSession(ctx, h.DB, h.Conf.Auth, u, "other")
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims := users.JwtClaims{UserId: u.ID, Username: u.Username}
+	claims.ID = current.ID.String()
+
+	tests := []struct {
+		body   string
+		status int
+	}{
+		{`not json`, http.StatusBadRequest},
+		{`{"currentPassword": "secret", "newPassword": ""}`, http.StatusBadRequest},
+		{`{"currentPassword": "wrong", "newPassword": "changed"}`, http.StatusForbidden},
+		{`{"currentPassword": "secret", "newPassword": "changed"}`, http.StatusNoContent},
+		// Changed, the old password is wrong now
+		{`{"currentPassword": "secret", "newPassword": "again"}`, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		if status := callUser(t, h.MePassword, "/api/me/password", tt.body, "", claims); status != tt.status {
+			t.Errorf("%s: status %d, want %d", tt.body, status, tt.status)
+		}
+	}
+
+	// Only the session that changed the password stays logged in
+	active, err := h.DB.Client.Session.Query().Where(session.RevokedAtIsNil()).IDs(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(active) != 1 || active[0] != current.ID {
+		t.Errorf("active sessions %v, want only %s", active, current.ID)
+	}
+	_, err = users.Authenticate(ctx, h.DB, h.Conf.Auth, "bob", "changed", "10.0.0.1")
+	if err != nil {
+		t.Errorf("login with the new password: %v", err)
+	}
+}
+
+func TestUserAdmin(t *testing.T) {
+	h := newTestHandler(t)
+	ctx := context.Background()
+	admin, err := users.CreateUser(ctx, h.DB, "admin", "secret", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bob, err := users.CreateUser(ctx, h.DB, "bob", "secret", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = h.DB.Client.Image.Create().
+		SetFile("original").
+		SetOriginalName("a.png").
+		SetType(entImage.TypePNG).
+		SetDimentionWidth(32).
+		SetDimentionHeight(32).
+		SetSizeBits(1).
+		SetUploader(bob).
+		Save(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims := users.JwtClaims{UserId: admin.ID, Username: admin.Username, IsAdmin: true}
+	unknown := pid.New().String()
+
+	tests := []struct {
+		name    string
+		handler echo.HandlerFunc
+		target  string
+		body    string
+		id      string
+		status  int
+	}{
+		{"add", h.UserAdd, "/api/users", `{"username": "carol", "password": "secret"}`, "", http.StatusCreated},
+		{"add taken", h.UserAdd, "/api/users", `{"username": "carol", "password": "secret"}`, "", http.StatusConflict},
+		{"add short name", h.UserAdd, "/api/users", `{"username": "ab", "password": "secret"}`, "", http.StatusBadRequest},
+		{"add no password", h.UserAdd, "/api/users", `{"username": "dave"}`, "", http.StatusBadRequest},
+		{"rename taken", h.UserUpdate, "", `{"username": "carol"}`, bob.ID.String(), http.StatusConflict},
+		{"update unknown", h.UserUpdate, "", `{"isAdmin": true}`, unknown, http.StatusNotFound},
+		{"update bad id", h.UserUpdate, "", `{"isAdmin": true}`, "!", http.StatusBadRequest},
+		{"demote last admin", h.UserUpdate, "", `{"isAdmin": false}`, admin.ID.String(), http.StatusConflict},
+		{"delete last admin", h.UserDelete, "", "", admin.ID.String(), http.StatusConflict},
+		{"delete with images", h.UserDelete, "", "", bob.ID.String(), http.StatusConflict},
+		{"reassign to itself", h.UserDelete, "?reassign=" + bob.ID.String(), "", bob.ID.String(), http.StatusBadRequest},
+		{"reassign to unknown", h.UserDelete, "?reassign=" + unknown, "", bob.ID.String(), http.StatusBadRequest},
+		{"reassign", h.UserDelete, "?reassign=" + admin.ID.String(), "", bob.ID.String(), http.StatusNoContent},
+		{"delete unknown", h.UserDelete, "", "", unknown, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		target := tt.target
+		if !strings.HasPrefix(target, "/") {
+			target = "/api/users/" + tt.id + target
+		}
+		if status := callUser(t, tt.handler, target, tt.body, tt.id, claims); status != tt.status {
+			t.Errorf("%s: status %d, want %d", tt.name, status, tt.status)
+		}
+	}
+
+	n, err := h.DB.Client.Image.Query().Where(entImage.HasUploaderWith(user.IDEQ(admin.ID))).Count(ctx)
+	if err != nil || n != 1 {
+		t.Errorf("admin has %d images after the reassign, want 1", n)
+	}
+}
@@ -145,7 +145,8 @@ func TestBase32SortableByRandom(t *testing.T) {
 func FuzzEncodeDecodeBase32Roundtrip(f *testing.F) {
 	f.Add(uint64(1))
 	f.Add(uint64(123456789))
-	f.Fuzz(func(t *testing.T, val ID) {
+	f.Fuzz(func(t *testing.T, v uint64) {
+		val := ID(v >> 1) // sign bit is unused
 		s := EncodeBase32(val)
 		got, err := DecodeBase32(s)
 		if err != nil {
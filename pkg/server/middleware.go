@@ -3,20 +3,26 @@ package server
 import (
 	"log/slog"
 	"strings"
-	"time"
 
+	"github.com/Pineapple217/cvrs/pkg/database"
 	"github.com/Pineapple217/cvrs/pkg/users"
 	"github.com/labstack/echo/v4"
 	echoMw "github.com/labstack/echo/v4/middleware"
+	"golang.org/x/time/rate"
 )
 
-func (s *Server) ApplyMiddleware(dev bool) {
+func (s *Server) ApplyMiddleware(db *database.Database) {
 	slog.Info("Applying middlewares")
 	s.e.Use(echoMw.RateLimiterWithConfig(echoMw.RateLimiterConfig{
 		Store: echoMw.NewRateLimiterMemoryStoreWithConfig(
-			echoMw.RateLimiterMemoryStoreConfig{Rate: 30, Burst: 200, ExpiresIn: 3 * time.Minute},
+			echoMw.RateLimiterMemoryStoreConfig{
+				Rate:      rate.Limit(s.conf.RateLimit.Rate),
+				Burst:     s.conf.RateLimit.Burst,
+				ExpiresIn: s.conf.RateLimit.ExpiresIn,
+			},
 		),
 	}))
+	s.e.Use(echoMw.BodyLimit(s.conf.BodyLimit))
 	s.e.Use(echoMw.RequestLoggerWithConfig(echoMw.RequestLoggerConfig{
 		LogStatus:  true,
 		LogURI:     true,
@@ -34,9 +40,10 @@ func (s *Server) ApplyMiddleware(dev bool) {
 		},
 	}))
 
-	if dev {
+	if len(s.conf.CorsOrigins) > 0 {
 		s.e.Use(echoMw.CORSWithConfig(echoMw.CORSConfig{
-			AllowOrigins: []string{"http://localhost:5173"},
+			AllowOrigins:  s.conf.CorsOrigins,
+			ExposeHeaders: []string{"X-Similar-Images"},
 		}))
 	}
 
@@ -51,9 +58,9 @@ func (s *Server) ApplyMiddleware(dev bool) {
 			return next(c)
 		}
 	})
-	s.e.Use(users.Auth([]byte("adsjfkaweijrfsdjfkla")))
+	s.e.Use(users.Auth(s.auth, db))
 
 	s.e.Use(echoMw.GzipWithConfig(echoMw.GzipConfig{
-		Level: 5,
+		Level: s.conf.GzipLevel,
 	}))
 }
@@ -10,30 +10,54 @@ import (
 	"github.com/labstack/echo/v4"
 )
 
-func (server *Server) RegisterRoutes(hdlr *handler.Handler, imgDir string) {
+func (server *Server) RegisterRoutes(hdlr *handler.Handler) {
 	slog.Info("Registering routes")
 	e := server.e
 
 	// backend
 	api := e.Group("/api")
 
-	img := api.Group("/i")
-	img.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
-		return func(c echo.Context) error {
-			c.Response().Header().Add("Cache-Control", "public, max-age=31536000, immutable")
-			return next(c)
-		}
-	})
-	img.Static("/", imgDir)
+	api.GET("/i/:key", hdlr.ImageFile)
+
+	authed := users.RequireScope()
+	admin := users.RequireScope(users.ScopeAdmin)
 
+	api.GET("/auth/methods", hdlr.AuthMethods)
 	api.POST("/auth/login", hdlr.Login)
-	api.GET("/auth/users", users.CheckAdmin(hdlr.Users))
+	api.GET("/auth/oidc/login", hdlr.OidcLogin)
+	api.GET("/auth/oidc/callback", hdlr.OidcCallback)
+	api.POST("/auth/refresh", hdlr.Refresh)
+	api.POST("/auth/logout", hdlr.Logout, authed)
+	api.GET("/auth/sessions", hdlr.Sessions, authed)
+	api.DELETE("/auth/sessions/:id", hdlr.SessionDelete, authed)
+	api.GET("/auth/users", hdlr.Users, admin)
 
-	api.POST("/artists/add", users.CheckAuth(hdlr.ArtistsAdd))
+	api.GET("/me", hdlr.Me, authed)
+	api.POST("/me/password", hdlr.MePassword, authed)
+	api.POST("/users", hdlr.UserAdd, admin)
+	api.PATCH("/users/:id", hdlr.UserUpdate, admin)
+	api.DELETE("/users/:id", hdlr.UserDelete, admin)
+	api.POST("/users/:id/unlock", hdlr.UserUnlock, admin)
+	api.POST("/ips/:ip/unlock", hdlr.IpUnlock, admin)
+
+	api.POST("/artists/add", hdlr.ArtistsAdd, users.RequireScope(users.ScopeArtistsWrite, users.ScopeImagesWrite))
 	api.GET("/artist/:id", hdlr.ArtistGetId)
+	api.PATCH("/artist/:id", hdlr.ArtistUpdate, users.RequireScope(users.ScopeArtistsWrite))
+	api.DELETE("/artist/:id", hdlr.ArtistDelete, admin)
+	api.POST("/artist/:id/restore", hdlr.ArtistRestore, admin)
 	api.GET("/artists", hdlr.ArtistsGet)
 
-	api.POST("/releases/add", users.CheckAdmin(hdlr.ReleaseAdd))
+	api.POST("/releases/add", hdlr.ReleaseAdd, users.RequireScope(users.ScopeReleasesWrite, users.ScopeImagesWrite))
+	api.GET("/release/:id", hdlr.ReleaseGetId)
+	api.PATCH("/release/:id", hdlr.ReleaseUpdate, users.RequireScope(users.ScopeReleasesWrite))
+	api.DELETE("/release/:id", hdlr.ReleaseDelete, users.RequireScope(users.ScopeReleasesWrite))
+	api.GET("/releases", hdlr.ReleasesGet)
+
+	api.GET("/search", hdlr.Search)
+
+	api.GET("/images", hdlr.ImagesGet, admin)
+	api.GET("/image/:id/similar", hdlr.ImageSimilar, authed)
+	api.GET("/tasks", hdlr.TasksGet, admin)
 
 	// frontend
 	frontend := static.GetFrontend()
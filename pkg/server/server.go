@@ -2,31 +2,46 @@ package server
 
 import (
 	"context"
-	"flag"
 	"log/slog"
 	"net/http"
 	"time"
 
+	"github.com/Pineapple217/cvrs/pkg/config"
 	"github.com/labstack/echo/v4"
 )
 
-var (
-	listen = "0.0.0.0"
-	// listen = "127.0.0.1"
-	port = ":3000"
-)
-
 type Server struct {
-	e *echo.Echo
+	e    *echo.Echo
+	conf config.Server
+	auth config.Auth
 }
 
-func NewServer() *Server {
+func NewServer(conf config.Server, auth config.Auth) *Server {
 	e := echo.New()
 	e.HideBanner = true
 	e.HidePort = true
-	e.Debug = true
+	e.Debug = conf.Debug
+	e.Server.ReadTimeout = conf.ReadTimeout
+	e.Server.WriteTimeout = conf.WriteTimeout
+	e.Server.IdleTimeout = conf.IdleTimeout
+	// Without trusted proxies forwarded headers are ignored, otherwise any
+	// client could pick its own IP and dodge the login lockout
+	e.IPExtractor = echo.ExtractIPDirect()
+	if len(conf.TrustedProxies) > 0 {
+		options := []echo.TrustOption{
+			echo.TrustLoopback(false),
+			echo.TrustLinkLocal(false),
+			echo.TrustPrivateNet(false),
+		}
+		for _, ipNet := range conf.TrustedProxyRanges() {
+			options = append(options, echo.TrustIPRange(ipNet))
+		}
+		e.IPExtractor = echo.ExtractIPFromXFFHeader(options...)
+	}
 	NewServer := &Server{
-		e: e,
+		e:    e,
+		conf: conf,
+		auth: auth,
 	}
 
 	return NewServer
@@ -34,14 +49,13 @@ func NewServer() *Server {
 
 // Starts the server in a new routine
 func (s *Server) Start() {
-	flag.Parse()
 	slog.Info("Starting server")
 	go func() {
-		if err := s.e.Start(listen + port); err != nil && err != http.ErrServerClosed {
+		if err := s.e.Start(s.conf.Address()); err != nil && err != http.ErrServerClosed {
 			slog.Error("Shutting down the server", "error", err.Error())
 		}
 	}()
-	slog.Info("Server started", "bind", listen, "port", port)
+	slog.Info("Server started", "bind", s.conf.Listen, "port", s.conf.Port)
 }
 
 // Tries to the stops the server gracefully
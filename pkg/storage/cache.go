@@ -0,0 +1,151 @@
+package storage
+
+import (
+	"container/list"
+	"os"
+	"path"
+	"slices"
+	"sync"
+	"time"
+)
+
+// DiskCache keeps generated files in a directory and deletes the least
+// recently used ones once they take up more than the size budget. Unlike a
+// BlobStore it may lose files at any time.
+type DiskCache struct {
+	dir     string
+	maxSize int64
+
+	mu   sync.Mutex
+	size int64
+	// Front is the most recently used
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type cacheEntry struct {
+	key  string
+	size int64
+}
+
+// Picks up the files already in dir, ordered by their modification time
+func NewDiskCache(dir string, maxSize int64) (*DiskCache, error) {
+	c := &DiskCache{
+		dir:     dir,
+		maxSize: maxSize,
+		order:   list.New(),
+		entries: map[string]*list.Element{},
+	}
+	dirEntries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	infos := []os.FileInfo{}
+	for _, e := range dirEntries {
+		if checkKey(e.Name()) != nil || !e.Type().IsRegular() {
+			// Leftover temp files
+			os.Remove(path.Join(dir, e.Name()))
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, info)
+	}
+	slices.SortFunc(infos, func(a, b os.FileInfo) int {
+		return b.ModTime().Compare(a.ModTime())
+	})
+	for _, info := range infos {
+		c.entries[info.Name()] = c.order.PushBack(&cacheEntry{key: info.Name(), size: info.Size()})
+		c.size += info.Size()
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.evict()
+	return c, nil
+}
+
+// Opens the cached file and marks it as used, reports false when it is not
+// cached
+func (c *DiskCache) Open(key string) (*os.File, bool) {
+	if checkKey(key) != nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	f, err := os.Open(path.Join(c.dir, key))
+	if err != nil {
+		c.remove(e)
+		return nil, false
+	}
+	c.order.MoveToFront(e)
+	// The order survives a restart
+	now := time.Now()
+	os.Chtimes(f.Name(), now, now)
+	return f, true
+}
+
+// Stores the data under key, then deletes the least recently used files
+// until the cache fits its budget again
+func (c *DiskCache) Put(key string, data []byte) error {
+	err := checkKey(key)
+	if err != nil {
+		return err
+	}
+	tempFile, err := os.CreateTemp(c.dir, ".put_*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tempFile.Name())
+	defer tempFile.Close()
+	_, err = tempFile.Write(data)
+	if err != nil {
+		return err
+	}
+	err = tempFile.Close()
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	err = os.Rename(tempFile.Name(), path.Join(c.dir, key))
+	if err != nil {
+		return err
+	}
+	if e, ok := c.entries[key]; ok {
+		c.size -= e.Value.(*cacheEntry).size
+		c.order.Remove(e)
+	}
+	c.entries[key] = c.order.PushFront(&cacheEntry{key: key, size: int64(len(data))})
+	c.size += int64(len(data))
+	c.evict()
+	return nil
+}
+
+// Total size of the cached files
+func (c *DiskCache) Size() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.size
+}
+
+// Has to be called with the lock held
+func (c *DiskCache) evict() {
+	for c.size > c.maxSize && c.order.Len() > 0 {
+		c.remove(c.order.Back())
+	}
+}
+
+func (c *DiskCache) remove(e *list.Element) {
+	entry := e.Value.(*cacheEntry)
+	os.Remove(path.Join(c.dir, entry.key))
+	c.order.Remove(e)
+	delete(c.entries, entry.key)
+	c.size -= entry.size
+}
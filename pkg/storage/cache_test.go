@@ -0,0 +1,56 @@
+package storage
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"testing"
+)
+
+func TestDiskCache(t *testing.T) {
+	dir := t.TempDir()
+	c, err := NewDiskCache(dir, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"a", "b", "c"} {
+		err = c.Put(key, []byte("1234"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if key == "b" {
+			// a is used, so b is the oldest now
+			f, ok := c.Open("a")
+			if !ok {
+				t.Fatal("a should be cached")
+			}
+			data, _ := io.ReadAll(f)
+			f.Close()
+			if !bytes.Equal(data, []byte("1234")) {
+				t.Errorf("unexpected content %q", data)
+			}
+		}
+	}
+
+	if _, ok := c.Open("b"); ok {
+		t.Error("b should have been evicted")
+	}
+	if _, err := os.Stat(dir + "/b"); !os.IsNotExist(err) {
+		t.Error("file of b should be deleted")
+	}
+	if c.Size() != 8 {
+		t.Errorf("unexpected size %d", c.Size())
+	}
+
+	// Survives a restart, with a smaller budget the oldest goes first
+	c, err = NewDiskCache(dir, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Size() != 4 {
+		t.Errorf("unexpected size after reopening %d", c.Size())
+	}
+	if _, ok := c.Open("."); ok {
+		t.Error("invalid keys are never cached")
+	}
+}
@@ -0,0 +1,117 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+)
+
+// FsStore keeps every blob as a file in a single directory
+type FsStore struct {
+	dir string
+}
+
+func NewFsStore(dir string) *FsStore {
+	return &FsStore{dir: dir}
+}
+
+// Writes to a temporary file first, so a blob is never seen half-written
+func (s *FsStore) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	err := checkKey(key)
+	if err != nil {
+		return err
+	}
+	tempFile, err := os.CreateTemp(s.dir, ".put_*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tempFile.Name())
+	defer tempFile.Close()
+
+	_, err = io.Copy(tempFile, r)
+	if err != nil {
+		return err
+	}
+	err = tempFile.Close()
+	if err != nil {
+		return err
+	}
+	return os.Rename(tempFile.Name(), path.Join(s.dir, key))
+}
+
+// The returned reader is an *os.File, so it can be seeked
+func (s *FsStore) Get(ctx context.Context, key string) (io.ReadCloser, BlobInfo, error) {
+	err := checkKey(key)
+	if err != nil {
+		return nil, BlobInfo{}, err
+	}
+	f, err := os.Open(path.Join(s.dir, key))
+	if err != nil {
+		return nil, BlobInfo{}, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, BlobInfo{}, err
+	}
+	return f, fileBlobInfo(info), nil
+}
+
+func (s *FsStore) Delete(ctx context.Context, key string) error {
+	err := checkKey(key)
+	if err != nil {
+		return err
+	}
+	err = os.Remove(path.Join(s.dir, key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+func (s *FsStore) Stat(ctx context.Context, key string) (BlobInfo, error) {
+	err := checkKey(key)
+	if err != nil {
+		return BlobInfo{}, err
+	}
+	info, err := os.Stat(path.Join(s.dir, key))
+	if err != nil {
+		return BlobInfo{}, err
+	}
+	return fileBlobInfo(info), nil
+}
+
+func (s *FsStore) List(ctx context.Context, prefix string) ([]BlobInfo, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+	blobs := []BlobInfo{}
+	for _, e := range entries {
+		// Skips unfinished puts
+		if !e.Type().IsRegular() || checkKey(e.Name()) != nil || !strings.HasPrefix(e.Name(), prefix) {
+			continue
+		}
+		info, err := e.Info()
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		blobs = append(blobs, fileBlobInfo(info))
+	}
+	return blobs, nil
+}
+
+func fileBlobInfo(info fs.FileInfo) BlobInfo {
+	return BlobInfo{
+		Key:     info.Name(),
+		Size:    info.Size(),
+		ModTime: info.ModTime(),
+	}
+}
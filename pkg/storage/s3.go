@@ -0,0 +1,322 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Pineapple217/cvrs/pkg/config"
+)
+
+const (
+	amzDateFormat   = "20060102T150405Z"
+	unsignedPayload = "UNSIGNED-PAYLOAD"
+)
+
+// S3Store keeps every blob as an object in an S3-compatible bucket. Requests
+// are signed with AWS Signature Version 4.
+type S3Store struct {
+	conf   config.S3
+	bucket *url.URL
+	client *http.Client
+}
+
+func NewS3Store(conf config.S3) (*S3Store, error) {
+	u, err := url.Parse(conf.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	if conf.VirtualHost {
+		u.Host = conf.Bucket + "." + u.Host
+	} else {
+		u.Path += "/" + conf.Bucket
+	}
+	return &S3Store{
+		conf:   conf,
+		bucket: u,
+		client: &http.Client{Timeout: time.Minute},
+	}, nil
+}
+
+func (s *S3Store) objectUrl(key string) *url.URL {
+	u := *s.bucket
+	u.Path += "/" + s.conf.Prefix + key
+	return &u
+}
+
+func (s *S3Store) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	err := checkKey(key)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.objectUrl(key).String(), r)
+	if err != nil {
+		return err
+	}
+	// Without it the request would be chunked, which S3 does not accept
+	req.ContentLength = size
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	res, err := s.do(req)
+	if err != nil {
+		return err
+	}
+	return res.Body.Close()
+}
+
+func (s *S3Store) Get(ctx context.Context, key string) (io.ReadCloser, BlobInfo, error) {
+	err := checkKey(key)
+	if err != nil {
+		return nil, BlobInfo{}, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.objectUrl(key).String(), nil)
+	if err != nil {
+		return nil, BlobInfo{}, err
+	}
+	res, err := s.do(req)
+	if err != nil {
+		return nil, BlobInfo{}, err
+	}
+	return res.Body, headerBlobInfo(key, res), nil
+}
+
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	err := checkKey(key)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, s.objectUrl(key).String(), nil)
+	if err != nil {
+		return err
+	}
+	res, err := s.do(req)
+	if err != nil {
+		return err
+	}
+	return res.Body.Close()
+}
+
+func (s *S3Store) Stat(ctx context.Context, key string) (BlobInfo, error) {
+	err := checkKey(key)
+	if err != nil {
+		return BlobInfo{}, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, s.objectUrl(key).String(), nil)
+	if err != nil {
+		return BlobInfo{}, err
+	}
+	res, err := s.do(req)
+	if err != nil {
+		return BlobInfo{}, err
+	}
+	res.Body.Close()
+	return headerBlobInfo(key, res), nil
+}
+
+type listBucketResult struct {
+	Contents []struct {
+		Key          string    `xml:"Key"`
+		Size         int64     `xml:"Size"`
+		LastModified time.Time `xml:"LastModified"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+func (s *S3Store) List(ctx context.Context, prefix string) ([]BlobInfo, error) {
+	blobs := []BlobInfo{}
+	token := ""
+	for {
+		u := *s.bucket
+		u.Path += "/"
+		q := url.Values{}
+		q.Set("list-type", "2")
+		q.Set("prefix", s.conf.Prefix+prefix)
+		if token != "" {
+			q.Set("continuation-token", token)
+		}
+		u.RawQuery = q.Encode()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+		res, err := s.do(req)
+		if err != nil {
+			return nil, err
+		}
+		var result listBucketResult
+		err = xml.NewDecoder(res.Body).Decode(&result)
+		res.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("s3: invalid list response: %w", err)
+		}
+
+		for _, c := range result.Contents {
+			key := strings.TrimPrefix(c.Key, s.conf.Prefix)
+			// Objects put there by something else
+			if checkKey(key) != nil {
+				continue
+			}
+			blobs = append(blobs, BlobInfo{Key: key, Size: c.Size, ModTime: c.LastModified})
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return blobs, nil
+		}
+		token = result.NextContinuationToken
+	}
+}
+
+// Returns a URL that serves the object until it expires, without credentials
+func (s *S3Store) Presign(ctx context.Context, key string, expires time.Duration) (string, error) {
+	err := checkKey(key)
+	if err != nil {
+		return "", err
+	}
+	now := time.Now().UTC()
+	u := s.objectUrl(key)
+	q := url.Values{}
+	q.Set("X-Amz-Algorithm", "AWS4-HMAC-SHA256")
+	q.Set("X-Amz-Credential", s.conf.AccessKey+"/"+credentialScope(now, s.conf.Region, "s3"))
+	q.Set("X-Amz-Date", now.Format(amzDateFormat))
+	q.Set("X-Amz-Expires", strconv.Itoa(int(expires.Seconds())))
+	q.Set("X-Amz-SignedHeaders", "host")
+	u.RawQuery = canonicalQuery(q)
+
+	canonical := strings.Join([]string{
+		http.MethodGet,
+		u.EscapedPath(),
+		u.RawQuery,
+		"host:" + u.Host + "\n",
+		"host",
+		unsignedPayload,
+	}, "\n")
+	u.RawQuery += "&X-Amz-Signature=" + signature(s.conf.SecretKey, now, s.conf.Region, "s3", canonical)
+	return u.String(), nil
+}
+
+// Signs and sends the request, error responses are turned into errors
+func (s *S3Store) do(req *http.Request) (*http.Response, error) {
+	// Hashing the body would mean reading it twice
+	req.Header.Set("X-Amz-Content-Sha256", unsignedPayload)
+	signRequest(req, unsignedPayload, s.conf.AccessKey, s.conf.SecretKey, s.conf.Region, "s3", time.Now())
+	res, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode < 300 {
+		return res, nil
+	}
+	defer res.Body.Close()
+
+	var s3Err struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	// HEAD responses have no body
+	xml.NewDecoder(io.LimitReader(res.Body, 1<<16)).Decode(&s3Err)
+	if res.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("s3: %s %s: %w", req.Method, req.URL.Path, fs.ErrNotExist)
+	}
+	if s3Err.Code == "" {
+		s3Err.Code = res.Status
+	}
+	return nil, fmt.Errorf("s3: %s %s: %s %s", req.Method, req.URL.Path, s3Err.Code, s3Err.Message)
+}
+
+func headerBlobInfo(key string, res *http.Response) BlobInfo {
+	modTime, _ := http.ParseTime(res.Header.Get("Last-Modified"))
+	return BlobInfo{
+		Key:         key,
+		Size:        res.ContentLength,
+		ModTime:     modTime,
+		ContentType: res.Header.Get("Content-Type"),
+	}
+}
+
+// Adds the Authorization header for AWS Signature Version 4. The host and
+// every X-Amz-* header are signed.
+func signRequest(req *http.Request, payloadHash, accessKey, secretKey, region, service string, t time.Time) {
+	t = t.UTC()
+	req.Header.Set("X-Amz-Date", t.Format(amzDateFormat))
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		name = strings.ToLower(name)
+		if strings.HasPrefix(name, "x-amz-") {
+			headers[name] = strings.TrimSpace(strings.Join(values, ","))
+		}
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	canonicalHeaders := ""
+	for _, name := range names {
+		canonicalHeaders += name + ":" + headers[name] + "\n"
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	canonical := strings.Join([]string{
+		req.Method,
+		path,
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		accessKey, credentialScope(t, region, service), signedHeaders,
+		signature(secretKey, t, region, service, canonical),
+	))
+}
+
+func credentialScope(t time.Time, region, service string) string {
+	return t.UTC().Format("20060102") + "/" + region + "/" + service + "/aws4_request"
+}
+
+func signature(secretKey string, t time.Time, region, service, canonicalRequest string) string {
+	hash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		t.UTC().Format(amzDateFormat),
+		credentialScope(t, region, service),
+		hex.EncodeToString(hash[:]),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+secretKey), t.UTC().Format("20060102"))
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, service)
+	key = hmacSHA256(key, "aws4_request")
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// Sorted and with spaces as %20, url.Values.Encode uses +
+func canonicalQuery(q url.Values) string {
+	return strings.ReplaceAll(q.Encode(), "+", "%20")
+}
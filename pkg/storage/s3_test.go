@@ -0,0 +1,193 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Pineapple217/cvrs/pkg/config"
+)
+
+// From the AWS Signature Version 4 test suite, get-vanilla
+func TestSignRequest(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "https://example.amazonaws.com/", nil)
+	req.Host = ""
+	signed := time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC)
+	emptyHash := "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	signRequest(req, emptyHash, "AKIDEXAMPLE", "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", "us-east-1", "service", signed)
+
+	want := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, " +
+		"SignedHeaders=host;x-amz-date, " +
+		"Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31"
+	if got := req.Header.Get("Authorization"); got != want {
+		t.Errorf("unexpected authorization\n got: %s\nwant: %s", got, want)
+	}
+}
+
+// In-memory stand-in for MinIO, with path-style buckets
+type mockS3 struct {
+	*httptest.Server
+	mu      sync.Mutex
+	objects map[string][]byte
+	types   map[string]string
+}
+
+func newMockS3(t *testing.T) *mockS3 {
+	m := &mockS3{objects: map[string][]byte{}, types: map[string]string{}}
+	m.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+		if bucket != "covers" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.URL.Query().Has("X-Amz-Signature") {
+			if r.Method != http.MethodGet {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+		} else if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=access/") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		switch {
+		case r.Method == http.MethodGet && key == "":
+			type content struct {
+				Key          string
+				Size         int
+				LastModified time.Time
+			}
+			result := struct {
+				XMLName  xml.Name `xml:"ListBucketResult"`
+				Contents []content
+			}{}
+			keys := []string{}
+			for k := range m.objects {
+				if strings.HasPrefix(k, r.URL.Query().Get("prefix")) {
+					keys = append(keys, k)
+				}
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				result.Contents = append(result.Contents, content{k, len(m.objects[k]), time.Now()})
+			}
+			xml.NewEncoder(w).Encode(result)
+		case r.Method == http.MethodPut:
+			if r.ContentLength < 0 {
+				w.WriteHeader(http.StatusLengthRequired)
+				return
+			}
+			data, _ := io.ReadAll(r.Body)
+			m.objects[key] = data
+			m.types[key] = r.Header.Get("Content-Type")
+		case r.Method == http.MethodGet || r.Method == http.MethodHead:
+			data, ok := m.objects[key]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				io.WriteString(w, "<Error><Code>NoSuchKey</Code></Error>")
+				return
+			}
+			w.Header().Set("Content-Type", m.types[key])
+			w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+			w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+			w.Write(data)
+		case r.Method == http.MethodDelete:
+			delete(m.objects, key)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	t.Cleanup(m.Close)
+	return m
+}
+
+func TestS3Store(t *testing.T) {
+	mock := newMockS3(t)
+	s, err := NewS3Store(config.S3{
+		Endpoint:  mock.URL,
+		Region:    "us-east-1",
+		Bucket:    "covers",
+		Prefix:    "img/",
+		AccessKey: "access",
+		SecretKey: "secret",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	data := []byte("not really a png")
+	err = s.Put(ctx, "1M56PQDXRQ03H", bytes.NewReader(data), int64(len(data)), "image/png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := mock.objects["img/1M56PQDXRQ03H"]; !ok {
+		t.Fatal("object was not stored under the prefix")
+	}
+
+	r, info, err := s.Get(ctx, "1M56PQDXRQ03H")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, _ := io.ReadAll(r)
+	r.Close()
+	if !bytes.Equal(got, data) || info.Size != int64(len(data)) || info.ContentType != "image/png" {
+		t.Errorf("unexpected blob %q %+v", got, info)
+	}
+
+	info, err = s.Stat(ctx, "1M56PQDXRQ03H")
+	if err != nil || info.Size != int64(len(data)) {
+		t.Errorf("unexpected stat %+v %v", info, err)
+	}
+
+	blobs, err := s.List(ctx, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blobs) != 1 || blobs[0].Key != "1M56PQDXRQ03H" {
+		t.Errorf("unexpected list %+v", blobs)
+	}
+
+	url, err := s.Presign(ctx, "1M56PQDXRQ03H", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		t.Errorf("presigned URL returned %d", res.StatusCode)
+	}
+
+	err = s.Delete(ctx, "1M56PQDXRQ03H")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.Stat(ctx, "1M56PQDXRQ03H")
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected not exist after delete, got %v", err)
+	}
+	_, _, err = s.Get(ctx, "1M56PQDXRQ03H")
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected not exist after delete, got %v", err)
+	}
+
+	err = s.Put(ctx, "../escape", bytes.NewReader(data), int64(len(data)), "")
+	if !errors.Is(err, fs.ErrInvalid) {
+		t.Errorf("expected invalid key, got %v", err)
+	}
+}
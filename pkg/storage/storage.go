@@ -0,0 +1,45 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"strings"
+	"time"
+)
+
+// BlobStore holds the image files, keyed by their pid.ID. Missing blobs are
+// reported with an error wrapping fs.ErrNotExist.
+type BlobStore interface {
+	// Stores the blob, replacing any blob with the same key
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// The caller has to close the returned reader
+	Get(ctx context.Context, key string) (io.ReadCloser, BlobInfo, error)
+	// Deleting a missing blob is not an error
+	Delete(ctx context.Context, key string) error
+	Stat(ctx context.Context, key string) (BlobInfo, error)
+	List(ctx context.Context, prefix string) ([]BlobInfo, error)
+}
+
+// Presigner is implemented by stores that can hand out URLs which serve a blob
+// without going through cvrs
+type Presigner interface {
+	Presign(ctx context.Context, key string, expires time.Duration) (string, error)
+}
+
+type BlobInfo struct {
+	Key     string
+	Size    int64
+	ModTime time.Time
+	// Empty when the store does not keep it
+	ContentType string
+}
+
+// Keys are flat names, so they map onto a single directory or object prefix
+func checkKey(key string) error {
+	if key == "" || key == "." || key == ".." || strings.HasPrefix(key, ".") || strings.ContainsAny(key, `/\`) {
+		return fmt.Errorf("invalid key %q: %w", key, fs.ErrInvalid)
+	}
+	return nil
+}
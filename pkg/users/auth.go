@@ -1,29 +1,43 @@
 package users
 
 import (
+	"errors"
 	"log/slog"
 	"net/http"
 	"strings"
 	"time"
 
+	"github.com/Pineapple217/cvrs/pkg/config"
+	"github.com/Pineapple217/cvrs/pkg/database"
 	"github.com/Pineapple217/cvrs/pkg/ent"
+	"github.com/Pineapple217/cvrs/pkg/ent/session"
 	"github.com/Pineapple217/cvrs/pkg/pid"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/labstack/echo/v4"
 )
 
-const SECRET = "adsjfkaweijrfsdjfkla"
-const DUR = 24
+// Limits how often a session is written to just to bump last_used_at
+const lastUsedInterval = 5 * time.Minute
 
 type JwtClaims struct {
 	Username string `json:"usn"`
 	UserId   pid.ID `json:"uid"`
 	IsAdmin  bool   `json:"adm"`
+	// Filled in by Auth, never part of the token itself
+	Scopes []Scope `json:"-"`
 	jwt.RegisteredClaims
 }
 
-func CreateJWT(user *ent.User) (string, error) {
-	expiration := time.Hour * time.Duration(DUR)
+// SessionId returns the session the token was issued for, API tokens have none
+func (c JwtClaims) SessionId() (pid.ID, error) {
+	if c.ID == "" {
+		return 0, errors.New("no session")
+	}
+	return pid.DecodeBase32(c.ID)
+}
+
+func CreateJWT(conf config.Auth, user *ent.User, sessionId pid.ID) (string, error) {
+	expiration := conf.TokenLifetime
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, JwtClaims{
 		Username: user.Username,
@@ -33,10 +47,11 @@ func CreateJWT(user *ent.User) (string, error) {
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(expiration)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			Issuer:    "cvrs",
+			ID:        sessionId.String(),
 		},
 	})
 
-	tokenString, err := token.SignedString([]byte(SECRET))
+	tokenString, err := token.SignedString([]byte(conf.Secret))
 	if err != nil {
 		return "", err
 	}
@@ -44,7 +59,15 @@ func CreateJWT(user *ent.User) (string, error) {
 	return tokenString, err
 }
 
-func Auth(secret []byte) echo.MiddlewareFunc {
+// Accepts both access tokens of a session and personal API tokens. Only
+// tokens of sessions that are still active are accepted. The admin flag is
+// taken from the database, so a demotion takes effect immediately.
+func Auth(conf config.Auth, db *database.Database) echo.MiddlewareFunc {
+	// Tokens signed with a previous secret stay valid until they expire
+	keys := jwt.VerificationKeySet{}
+	for _, k := range conf.VerificationKeys() {
+		keys.Keys = append(keys.Keys, k)
+	}
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			authHeader := c.Request().Header.Get("Authorization")
@@ -53,17 +76,15 @@ func Auth(secret []byte) echo.MiddlewareFunc {
 				return next(c)
 			}
 			tokenString := strings.TrimPrefix(authHeader, "Bearer ")
-			claims := &JwtClaims{}
-			token, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
-				return secret, nil
-			})
-			if err != nil {
-				slog.Debug("failed to parse jwt", "err", err)
-				return echo.NewHTTPError(http.StatusUnauthorized, "Invalid or expired token")
+			var claims *JwtClaims
+			var err error
+			if isApiToken(tokenString) {
+				claims, err = authApiToken(c, db, tokenString)
+			} else {
+				claims, err = authSession(c, db, keys, tokenString)
 			}
-			if !token.Valid {
-				slog.Debug("Invalid jwt token")
-				return echo.NewHTTPError(http.StatusUnauthorized, "Invalid or expired token")
+			if err != nil {
+				return err
 			}
 			c.Set("isAuth", true)
 			c.Set("claims", *claims)
@@ -72,6 +93,73 @@ func Auth(secret []byte) echo.MiddlewareFunc {
 	}
 }
 
+func authSession(c echo.Context, db *database.Database, keys jwt.VerificationKeySet, tokenString string) (*JwtClaims, error) {
+	claims := &JwtClaims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
+		return keys, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	if err != nil {
+		slog.Debug("failed to parse jwt", "err", err)
+		return nil, echo.NewHTTPError(http.StatusUnauthorized, "Invalid or expired token")
+	}
+	if !token.Valid {
+		slog.Debug("Invalid jwt token")
+		return nil, echo.NewHTTPError(http.StatusUnauthorized, "Invalid or expired token")
+	}
+	sessionId, err := claims.SessionId()
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusUnauthorized, "Invalid or expired token")
+	}
+	s, err := db.Client.Session.Query().
+		Where(
+			session.IDEQ(sessionId),
+			session.RevokedAtIsNil(),
+			session.ExpiresAtGT(time.Now()),
+		).
+		WithUser().
+		Only(c.Request().Context())
+	if ent.IsNotFound(err) {
+		return nil, echo.NewHTTPError(http.StatusUnauthorized, "Session revoked")
+	}
+	if err != nil {
+		return nil, err
+	}
+	claims.IsAdmin = s.Edges.User.IsAdmin
+	claims.Username = s.Edges.User.Username
+	claims.Scopes = UserScopes(s.Edges.User)
+	if time.Since(s.LastUsedAt) > lastUsedInterval {
+		err = s.Update().SetLastUsedAt(time.Now()).Exec(c.Request().Context())
+		if err != nil {
+			slog.Warn("failed to update session", "session", s.ID, "error", err)
+		}
+	}
+	return claims, nil
+}
+
+func authApiToken(c echo.Context, db *database.Database, tokenString string) (*JwtClaims, error) {
+	t, err := lookupApiToken(c.Request().Context(), db, tokenString)
+	if ent.IsNotFound(err) {
+		return nil, echo.NewHTTPError(http.StatusUnauthorized, "Invalid or expired token")
+	}
+	if err != nil {
+		return nil, err
+	}
+	u := t.Edges.User
+	claims := &JwtClaims{
+		Username: u.Username,
+		UserId:   u.ID,
+		Scopes:   effectiveScopes(u, t.Scopes),
+	}
+	claims.IsAdmin = hasScopes(claims.Scopes, ScopeAdmin)
+	if t.LastUsedAt == nil || time.Since(*t.LastUsedAt) > lastUsedInterval {
+		err = t.Update().SetLastUsedAt(time.Now()).Exec(c.Request().Context())
+		if err != nil {
+			slog.Warn("failed to update api token", "token", t.ID, "error", err)
+		}
+	}
+	return claims, nil
+}
+
 func IsAuth(c echo.Context) (bool, JwtClaims) {
 	isAuth, ok := c.Get("isAuth").(bool)
 	if !ok || !isAuth {
@@ -84,25 +172,25 @@ func IsAuth(c echo.Context) (bool, JwtClaims) {
 	return true, claims
 }
 
-func CheckAuth(next echo.HandlerFunc) echo.HandlerFunc {
-	return func(c echo.Context) error {
-		IsAuth, _ := IsAuth(c)
-		if !IsAuth {
-			return echo.NewHTTPError(http.StatusUnauthorized)
-		}
-		return next(c)
-	}
+// HasScope reports if the request is authenticated with all of the given scopes
+func HasScope(c echo.Context, scopes ...Scope) bool {
+	isAuth, claims := IsAuth(c)
+	return isAuth && hasScopes(claims.Scopes, scopes...)
 }
 
-func CheckAdmin(next echo.HandlerFunc) echo.HandlerFunc {
-	return func(c echo.Context) error {
-		IsAuth, claims := IsAuth(c)
-		if !IsAuth {
-			return echo.NewHTTPError(http.StatusUnauthorized)
-		}
-		if !claims.IsAdmin {
-			return echo.NewHTTPError(http.StatusForbidden)
+// RequireScope only lets requests through that are authenticated and hold all
+// of the given scopes. Without scopes any authenticated request is allowed.
+func RequireScope(scopes ...Scope) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			isAuth, claims := IsAuth(c)
+			if !isAuth {
+				return echo.NewHTTPError(http.StatusUnauthorized)
+			}
+			if !hasScopes(claims.Scopes, scopes...) {
+				return echo.NewHTTPError(http.StatusForbidden)
+			}
+			return next(c)
 		}
-		return next(c)
 	}
 }
@@ -0,0 +1,232 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Pineapple217/cvrs/pkg/config"
+	"github.com/Pineapple217/cvrs/pkg/database"
+	"github.com/Pineapple217/cvrs/pkg/pid"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+)
+
+func testAuthConfig() config.Auth {
+	return config.Auth{
+		Secret:          "a-secret-that-is-long-enough-1234",
+		TokenLifetime:   time.Hour,
+		RefreshLifetime: 24 * time.Hour,
+	}
+}
+
+// Runs a request with the token through Auth and returns the claims it set
+func authenticate(conf config.Auth, db *database.Database, token string) (JwtClaims, error) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	c := echo.New().NewContext(req, httptest.NewRecorder())
+	var claims JwtClaims
+	err := Auth(conf, db)(func(c echo.Context) error {
+		_, claims = IsAuth(c)
+		return nil
+	})(c)
+	return claims, err
+}
+
+func isStatus(err error, status int) bool {
+	var httpErr *echo.HTTPError
+	return errors.As(err, &httpErr) && httpErr.Code == status
+}
+
+func TestAuthSession(t *testing.T) {
+	db := newTestDatabase(t)
+	ctx := context.Background()
+	conf := testAuthConfig()
+	u, err := CreateUser(ctx, db, "bob", "secret", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newToken := func(conf config.Auth) (pid.ID, string) {
+		s, _, err := NewSession(ctx, db, conf, u, "test")
+		if err != nil {
+			t.Fatal(err)
+		}
+		token, err := CreateJWT(conf, u, s.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s.ID, token
+	}
+
+	_, valid := newToken(conf)
+	revokedId, revoked := newToken(conf)
+	err = RevokeSession(ctx, db, revokedId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	endedId, ended := newToken(conf)
+	err = db.Client.Session.UpdateOneID(endedId).SetExpiresAt(time.Now().Add(-time.Minute)).Exec(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expiredConf := conf
+	expiredConf.TokenLifetime = -time.Minute
+	_, expired := newToken(expiredConf)
+	unknown, err := CreateJWT(conf, u, pid.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherConf := conf
+	otherConf.Secret = "another-secret-that-is-long-enough"
+	_, forged := newToken(otherConf)
+
+	tests := []struct {
+		name  string
+		token string
+		ok    bool
+	}{
+		{"valid", valid, true},
+		{"revoked session", revoked, false},
+		{"expired session", ended, false},
+		{"expired token", expired, false},
+		{"unknown session", unknown, false},
+		{"other secret", forged, false},
+		{"garbage", "not.a.token", false},
+	}
+	for _, tt := range tests {
+		claims, err := authenticate(conf, db, tt.token)
+		switch {
+		case tt.ok && err != nil:
+			t.Errorf("%s: %v", tt.name, err)
+		case tt.ok && claims.UserId != u.ID:
+			t.Errorf("%s: authenticated as %s", tt.name, claims.UserId)
+		case !tt.ok && !isStatus(err, http.StatusUnauthorized):
+			t.Errorf("%s: expected 401, got %v", tt.name, err)
+		}
+	}
+}
+
+func TestRefreshSession(t *testing.T) {
+	db := newTestDatabase(t)
+	ctx := context.Background()
+	conf := testAuthConfig()
+	u, err := CreateUser(ctx, db, "bob", "secret", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, first, err := NewSession(ctx, db, conf, u, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	stored, err := db.Client.Session.Get(ctx, s.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(stored.RefreshHash) == first {
+		t.Error("refresh token is stored in plain text")
+	}
+
+	refreshed, second, err := RefreshSession(ctx, db, conf, first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if refreshed.ID != s.ID || second == first {
+		t.Errorf("refreshed session %s with the same token", refreshed.ID)
+	}
+	// Rotated, the first token can not be used again
+	_, _, err = RefreshSession(ctx, db, conf, first)
+	if !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Errorf("reused refresh token: expected ErrInvalidRefreshToken, got %v", err)
+	}
+
+	err = RevokeSession(ctx, db, s.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, err = RefreshSession(ctx, db, conf, second)
+	if !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Errorf("revoked session: expected ErrInvalidRefreshToken, got %v", err)
+	}
+	_, _, err = RefreshSession(ctx, db, conf, "unknown")
+	if !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Errorf("unknown token: expected ErrInvalidRefreshToken, got %v", err)
+	}
+}
+
+func TestAuthRotatedSecret(t *testing.T) {
+	db := newTestDatabase(t)
+	ctx := context.Background()
+	u, err := CreateUser(ctx, db, "bob", "secret", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, _, err := NewSession(ctx, db, testAuthConfig(), u, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	const (
+		older    = "older-secret-that-is-long-enough"
+		previous = "previous-secret-that-is-long-enough"
+		current  = "current-secret-that-is-long-enough"
+	)
+	signed := func(secret string) string {
+		conf := testAuthConfig()
+		conf.Secret = secret
+		token, err := CreateJWT(conf, u, s.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token
+	}
+	rotated := testAuthConfig()
+	rotated.Secret = current
+	rotated.PreviousSecrets = []string{previous, older}
+	dropped := testAuthConfig()
+	dropped.Secret = current
+	dropped.PreviousSecrets = []string{previous}
+
+	tests := []struct {
+		name   string
+		secret string
+		conf   config.Auth
+		ok     bool
+	}{
+		{"current secret", current, rotated, true},
+		{"previous secret", previous, rotated, true},
+		{"older secret", older, rotated, true},
+		{"dropped secret", older, dropped, false},
+		{"unknown secret", "unknown-secret-that-is-long-enough", rotated, false},
+	}
+	for _, tt := range tests {
+		_, err := authenticate(tt.conf, db, signed(tt.secret))
+		switch {
+		case tt.ok && err != nil:
+			t.Errorf("%s: %v", tt.name, err)
+		case !tt.ok && !isStatus(err, http.StatusUnauthorized):
+			t.Errorf("%s: expected 401, got %v", tt.name, err)
+		}
+	}
+
+	// Only the signing method of CreateJWT is accepted, even with a known key
+	claims := JwtClaims{UserId: u.ID, RegisteredClaims: jwt.RegisteredClaims{
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		ID:        s.ID.String(),
+	}}
+	for _, method := range []jwt.SigningMethod{jwt.SigningMethodHS384, jwt.SigningMethodNone} {
+		var key any = []byte(current)
+		if method == jwt.SigningMethodNone {
+			key = jwt.UnsafeAllowNoneSignatureType
+		}
+		token, err := jwt.NewWithClaims(method, claims).SignedString(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = authenticate(rotated, db, token)
+		if !isStatus(err, http.StatusUnauthorized) {
+			t.Errorf("%s: expected 401, got %v", method.Alg(), err)
+		}
+	}
+}
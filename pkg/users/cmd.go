@@ -1,12 +1,26 @@
 package users
 
 import (
+	"bufio"
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/Pineapple217/cvrs/pkg/config"
 	"github.com/Pineapple217/cvrs/pkg/database"
+	"github.com/Pineapple217/cvrs/pkg/ent"
+	"github.com/Pineapple217/cvrs/pkg/ent/apitoken"
+	"github.com/Pineapple217/cvrs/pkg/ent/user"
+	"github.com/Pineapple217/cvrs/pkg/pid"
 	"github.com/spf13/cobra"
-	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/term"
 )
 
 func GetCmd() *cobra.Command {
@@ -19,34 +33,371 @@ func GetCmd() *cobra.Command {
 
 	addUserCmd := &cobra.Command{
 		Use:   "add",
-		Short: "Add a new user",
+		Short: "Add a new user, prompts for the password when -p is omitted",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			conf, err := config.Load()
+			err := ValidateUsername(username)
 			if err != nil {
 				return err
 			}
-			db, err := database.NewDatabase(conf.Database)
+			if password == "" {
+				password, err = readPassword(true)
+				if err != nil {
+					return err
+				}
+			}
+			db, err := loadDatabase()
 			if err != nil {
 				return err
 			}
-			bytes, err := bcrypt.GenerateFromPassword([]byte(password), 10)
+			u, err := CreateUser(cmd.Context(), db, username, password, isAdmin)
 			if err != nil {
 				return err
 			}
-			_, err = db.Client.User.
-				Create().
-				SetUsername(username).
-				SetPassword(bytes).
-				SetIsAdmin(isAdmin).
-				Save(context.Background())
-			return err
+			slog.Info("Added user", "id", u.ID, "username", u.Username, "admin", u.IsAdmin)
+			return nil
 		},
 	}
 	addUserCmd.Flags().StringVarP(&username, "username", "u", "", "Username for the new user")
-	addUserCmd.Flags().StringVarP(&password, "password", "p", "", "Password for the new user")
+	addUserCmd.Flags().StringVarP(&password, "password", "p", "", "Password for the new user, ends up in your shell history")
 	addUserCmd.Flags().BoolVar(&isAdmin, "admin", false, "Set the user as admin")
+	addUserCmd.MarkFlagRequired("username")
 	addUserCmd.SilenceUsage = true
 
-	userCmd.AddCommand(addUserCmd)
+	var asJson bool
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List all users",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, err := loadDatabase()
+			if err != nil {
+				return err
+			}
+			us, err := db.Client.User.Query().
+				Select(
+					user.FieldID,
+					user.FieldUsername,
+					user.FieldIsAdmin,
+					user.FieldCreatedAt,
+				).
+				Order(ent.Asc(user.FieldUsername)).
+				All(cmd.Context())
+			if err != nil {
+				return err
+			}
+			if asJson {
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				return enc.Encode(us)
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "ID\tUSERNAME\tADMIN\tCREATED")
+			for _, u := range us {
+				fmt.Fprintf(w, "%s\t%s\t%t\t%s\n",
+					u.ID, u.Username, u.IsAdmin, u.CreatedAt.Format(time.DateTime))
+			}
+			return w.Flush()
+		},
+	}
+	listCmd.Flags().BoolVar(&asJson, "json", false, "Output as JSON")
+	listCmd.SilenceUsage = true
+
+	passwdCmd := &cobra.Command{
+		Use:   "passwd <username>",
+		Short: "Change the password of a user, read from stdin when it is not a terminal",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, err := loadDatabase()
+			if err != nil {
+				return err
+			}
+			u, err := findUser(cmd.Context(), db, args[0])
+			if err != nil {
+				return err
+			}
+			password, err := readPassword(true)
+			if err != nil {
+				return err
+			}
+			// Whoever knew the old password should not stay logged in
+			err = SetPassword(cmd.Context(), db, u, password)
+			if err != nil {
+				return err
+			}
+			slog.Info("Changed password", "username", u.Username)
+			return nil
+		},
+	}
+	passwdCmd.SilenceUsage = true
+
+	promoteCmd := &cobra.Command{
+		Use:   "promote <username>",
+		Short: "Make a user admin",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return setAdmin(cmd.Context(), args[0], true)
+		},
+	}
+	promoteCmd.SilenceUsage = true
+
+	demoteCmd := &cobra.Command{
+		Use:   "demote <username>",
+		Short: "Take away the admin rights of a user",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return setAdmin(cmd.Context(), args[0], false)
+		},
+	}
+	demoteCmd.SilenceUsage = true
+
+	var reassign string
+	deleteCmd := &cobra.Command{
+		Use:   "delete <username>",
+		Short: "Delete a user together with its sessions and API tokens",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, err := loadDatabase()
+			if err != nil {
+				return err
+			}
+			u, err := findUser(cmd.Context(), db, args[0])
+			if err != nil {
+				return err
+			}
+			var target *ent.User
+			if reassign != "" {
+				target, err = findUser(cmd.Context(), db, reassign)
+				if err != nil {
+					return err
+				}
+			}
+			n, err := DeleteUser(cmd.Context(), db, u, target)
+			if errors.Is(err, ErrHasImages) {
+				return fmt.Errorf("%w, use --reassign to hand them to another user", err)
+			}
+			if err != nil {
+				return err
+			}
+			slog.Info("Deleted user", "username", u.Username, "reassigned_images", n)
+			return nil
+		},
+	}
+	deleteCmd.Flags().StringVar(&reassign, "reassign", "", "Give the uploaded images of the user to this user")
+	deleteCmd.SilenceUsage = true
+
+	var isIp bool
+	unlockCmd := &cobra.Command{
+		Use:   "unlock <username>",
+		Short: "Clear the failed logins of a locked out user or IP",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, err := loadDatabase()
+			if err != nil {
+				return err
+			}
+			key := UserLockoutKey(args[0])
+			if isIp {
+				key = IpLockoutKey(args[0])
+			}
+			found, err := Unlock(cmd.Context(), db, key)
+			if err != nil {
+				return err
+			}
+			if !found {
+				slog.Info("Nothing to unlock", "name", args[0])
+				return nil
+			}
+			slog.Info("Unlocked", "name", args[0])
+			return nil
+		},
+	}
+	unlockCmd.Flags().BoolVar(&isIp, "ip", false, "Unlock an IP address instead of a username")
+	unlockCmd.SilenceUsage = true
+
+	userCmd.AddCommand(addUserCmd, listCmd, passwdCmd, promoteCmd, demoteCmd, deleteCmd, unlockCmd)
+	userCmd.AddCommand(getTokenCmd())
 	return userCmd
 }
+
+func findUser(ctx context.Context, db *database.Database, username string) (*ent.User, error) {
+	u, err := db.Client.User.Query().
+		Where(user.Username(username)).
+		Only(ctx)
+	if ent.IsNotFound(err) {
+		return nil, fmt.Errorf("user %q not found", username)
+	}
+	return u, err
+}
+
+func setAdmin(ctx context.Context, username string, isAdmin bool) error {
+	db, err := loadDatabase()
+	if err != nil {
+		return err
+	}
+	u, err := findUser(ctx, db, username)
+	if err != nil {
+		return err
+	}
+	err = SetAdmin(ctx, db, u, isAdmin)
+	if err != nil {
+		return err
+	}
+	slog.Info("Updated user", "username", u.Username, "admin", isAdmin)
+	return nil
+}
+
+// Prompts for a password on a terminal, otherwise the first line of stdin is
+// used so it can be piped in from a secret store.
+func readPassword(confirm bool) (string, error) {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return "", err
+		}
+		return strings.TrimRight(line, "\r\n"), nil
+	}
+
+	fmt.Fprint(os.Stderr, "Password: ")
+	password, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", err
+	}
+	if confirm {
+		fmt.Fprint(os.Stderr, "Repeat password: ")
+		again, err := term.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", err
+		}
+		if string(again) != string(password) {
+			return "", errors.New("passwords do not match")
+		}
+	}
+	return string(password), nil
+}
+
+func loadDatabase() (*database.Database, error) {
+	conf, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	return database.NewDatabase(conf.Database, conf.Storage)
+}
+
+func getTokenCmd() *cobra.Command {
+	tokenCmd := &cobra.Command{
+		Use:   "token",
+		Short: "Manage personal API tokens",
+	}
+	var username, name string
+	var scopes []string
+	var expires time.Duration
+
+	createCmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create an API token, it is only shown once",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			parsed := []Scope{}
+			for _, s := range scopes {
+				scope, err := ParseScope(s)
+				if err != nil {
+					return err
+				}
+				parsed = append(parsed, scope)
+			}
+			db, err := loadDatabase()
+			if err != nil {
+				return err
+			}
+			u, err := findUser(cmd.Context(), db, username)
+			if err != nil {
+				return err
+			}
+			t, token, err := NewApiToken(cmd.Context(), db, u, name, parsed, expires)
+			if err != nil {
+				return err
+			}
+			slog.Info("Created API token", "id", t.ID, "user", u.Username, "scopes", t.Scopes)
+			fmt.Println(token)
+			return nil
+		},
+	}
+	createCmd.Flags().StringVarP(&username, "username", "u", "", "Owner of the token")
+	createCmd.Flags().StringVarP(&name, "name", "n", "", "Name to recognize the token by")
+	createCmd.Flags().StringSliceVarP(&scopes, "scope", "s", nil, fmt.Sprintf("Scopes of the token %v", Scopes))
+	createCmd.Flags().DurationVar(&expires, "expires", 0, "Lifetime of the token, 0 never expires")
+	createCmd.MarkFlagRequired("username")
+	createCmd.MarkFlagRequired("name")
+	createCmd.MarkFlagRequired("scope")
+	createCmd.SilenceUsage = true
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List API tokens",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, err := loadDatabase()
+			if err != nil {
+				return err
+			}
+			q := db.Client.ApiToken.Query().
+				WithUser().
+				Order(ent.Asc(apitoken.FieldID))
+			if username != "" {
+				q.Where(apitoken.HasUserWith(user.Username(username)))
+			}
+			ts, err := q.All(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "ID\tUSER\tNAME\tSCOPES\tLAST USED\tEXPIRES")
+			for _, t := range ts {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+					t.ID, t.Edges.User.Username, t.Name, strings.Join(t.Scopes, ","),
+					formatTime(t.LastUsedAt, "never"), formatTime(t.ExpiresAt, "never"))
+			}
+			return w.Flush()
+		},
+	}
+	listCmd.Flags().StringVarP(&username, "username", "u", "", "Only list the tokens of this user")
+	listCmd.SilenceUsage = true
+
+	revokeCmd := &cobra.Command{
+		Use:   "revoke <id>",
+		Short: "Revoke an API token",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := pid.DecodeBase32(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid token ID: %w", err)
+			}
+			db, err := loadDatabase()
+			if err != nil {
+				return err
+			}
+			err = db.Client.ApiToken.DeleteOneID(id).Exec(cmd.Context())
+			if ent.IsNotFound(err) {
+				return fmt.Errorf("token %s not found", id)
+			}
+			if err != nil {
+				return err
+			}
+			slog.Info("Revoked API token", "id", id)
+			return nil
+		},
+	}
+	revokeCmd.SilenceUsage = true
+
+	tokenCmd.AddCommand(createCmd, listCmd, revokeCmd)
+	return tokenCmd
+}
+
+func formatTime(t *time.Time, zero string) string {
+	if t == nil {
+		return zero
+	}
+	return t.Format(time.DateTime)
+}
@@ -0,0 +1,197 @@
+package users
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/Pineapple217/cvrs/pkg/config"
+	"github.com/Pineapple217/cvrs/pkg/database"
+	"github.com/Pineapple217/cvrs/pkg/ent"
+	"github.com/Pineapple217/cvrs/pkg/ent/lockout"
+	"github.com/Pineapple217/cvrs/pkg/ent/user"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var ErrBadCredentials = errors.New("failed to authenticate")
+
+// LockedError is returned while a username or IP is locked out
+type LockedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LockedError) Error() string {
+	return fmt.Sprintf("too many failed logins, try again in %s", e.RetryAfter.Round(time.Second))
+}
+
+// Failures count against every username, not only the existing ones, so a
+// lockout does not reveal which accounts exist. The name is hashed to bound
+// the key of whatever was typed in.
+func UserLockoutKey(username string) string {
+	sum := sha256.Sum256([]byte(username))
+	return "user:" + hex.EncodeToString(sum[:16])
+}
+
+func IpLockoutKey(ip string) string {
+	// The same address can be written in more than one way
+	if parsed := net.ParseIP(ip); parsed != nil {
+		ip = parsed.String()
+	}
+	return "ip:" + ip
+}
+
+var dummyHash = sync.OnceValue(func() []byte {
+	hash, _ := bcrypt.GenerateFromPassword([]byte("not a real password"), bcryptCost)
+	return hash
+})
+
+// Authenticate checks the credentials while keeping track of failed attempts
+// for both the username and the IP. Locked out logins are refused without
+// looking at the password. Unknown usernames still go through bcrypt, so the
+// response time does not reveal which accounts exist.
+func Authenticate(ctx context.Context, db *database.Database, conf config.Auth, username, password, ip string) (*ent.User, error) {
+	userKey, ipKey := UserLockoutKey(username), IpLockoutKey(ip)
+	err := checkLockout(ctx, db, userKey, ipKey)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := db.Client.User.Query().
+		Where(user.UsernameEQ(username)).
+		Only(ctx)
+	if err != nil && !ent.IsNotFound(err) {
+		return nil, err
+	}
+	// Users that only use single sign-on have no password
+	hasPassword := u != nil && len(u.Password) > 0
+	hash := dummyHash()
+	if hasPassword {
+		hash = u.Password
+	}
+	if bcrypt.CompareHashAndPassword(hash, []byte(password)) != nil || !hasPassword {
+		err = recordFailure(ctx, db, conf, userKey, ipKey)
+		if err != nil {
+			return nil, err
+		}
+		return nil, ErrBadCredentials
+	}
+
+	// Only the account is cleared, otherwise logging in to your own account
+	// would reset the counter of an IP that is guessing other accounts
+	_, err = db.Client.Lockout.Delete().
+		Where(lockout.Key(userKey)).
+		Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+func checkLockout(ctx context.Context, db *database.Database, keys ...string) error {
+	locks, err := db.Client.Lockout.Query().
+		Where(
+			lockout.KeyIn(keys...),
+			lockout.LockedUntilGT(time.Now()),
+		).
+		All(ctx)
+	if err != nil {
+		return err
+	}
+	var retryAfter time.Duration
+	for _, l := range locks {
+		retryAfter = max(retryAfter, time.Until(*l.LockedUntil))
+	}
+	if retryAfter > 0 {
+		return &LockedError{RetryAfter: retryAfter}
+	}
+	return nil
+}
+
+// Counts a failed login, once the limit is reached every failure doubles the
+// lockout. A key without failures for the max lockout duration starts over.
+// The counting happens in one transaction, so concurrent failures can not
+// overwrite each other.
+func recordFailure(ctx context.Context, db *database.Database, conf config.Auth, keys ...string) error {
+	now := time.Now()
+	stale := now.Add(-conf.MaxLockoutDuration)
+	tx, err := db.Client.Tx(ctx)
+	if err != nil {
+		return err
+	}
+	// Keys that are no longer locked and have no recent failures are useless
+	_, err = tx.Lockout.Delete().
+		Where(
+			lockout.LastFailureAtLT(stale),
+			lockout.Or(lockout.LockedUntilIsNil(), lockout.LockedUntilLT(now)),
+		).
+		Exec(ctx)
+	for _, key := range keys {
+		if err != nil {
+			break
+		}
+		err = tx.Lockout.Create().
+			SetKey(key).
+			SetLastFailureAt(now).
+			Exec(ctx)
+		if ent.IsConstraintError(err) {
+			err = nil
+		}
+		if err == nil {
+			_, err = tx.Lockout.Update().
+				Where(lockout.Key(key), lockout.LastFailureAtLT(stale)).
+				SetFailures(0).
+				Save(ctx)
+		}
+		if err == nil {
+			_, err = tx.Lockout.Update().
+				Where(lockout.Key(key)).
+				AddFailures(1).
+				SetLastFailureAt(now).
+				Save(ctx)
+		}
+		var l *ent.Lockout
+		if err == nil {
+			l, err = tx.Lockout.Query().
+				Where(lockout.Key(key)).
+				Only(ctx)
+		}
+		if err == nil {
+			if over := l.Failures - conf.MaxLoginAttempts; over >= 0 {
+				err = l.Update().
+					SetLockedUntil(now.Add(lockoutDuration(conf, over))).
+					Exec(ctx)
+			}
+		}
+	}
+	if err == nil {
+		err = tx.Commit()
+	} else if rerr := tx.Rollback(); rerr != nil {
+		err = fmt.Errorf("%w: %v", err, rerr)
+	}
+	return err
+}
+
+func lockoutDuration(conf config.Auth, over int) time.Duration {
+	d := conf.LockoutDuration
+	for range over {
+		d *= 2
+		if d >= conf.MaxLockoutDuration {
+			return conf.MaxLockoutDuration
+		}
+	}
+	return d
+}
+
+// Unlock clears the failed logins of a lockout key. It reports if the key had
+// any failures.
+func Unlock(ctx context.Context, db *database.Database, key string) (bool, error) {
+	n, err := db.Client.Lockout.Delete().
+		Where(lockout.Key(key)).
+		Exec(ctx)
+	return n > 0, err
+}
@@ -0,0 +1,148 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Pineapple217/cvrs/pkg/config"
+	"github.com/Pineapple217/cvrs/pkg/ent/lockout"
+)
+
+func TestLockout(t *testing.T) {
+	db := newTestDatabase(t)
+	ctx := context.Background()
+	conf := config.Auth{
+		MaxLoginAttempts:   3,
+		LockoutDuration:    time.Minute,
+		MaxLockoutDuration: time.Hour,
+	}
+	_, err := CreateUser(ctx, db, "bob", "secret", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	failures := func(key string) int {
+		l, err := db.Client.Lockout.Query().Where(lockout.Key(key)).Only(ctx)
+		if err != nil {
+			return 0
+		}
+		return l.Failures
+	}
+
+	for i := 1; i < conf.MaxLoginAttempts; i++ {
+		_, err = Authenticate(ctx, db, conf, "bob", "wrong", "10.0.0.1")
+		if !errors.Is(err, ErrBadCredentials) {
+			t.Fatalf("attempt %d: expected ErrBadCredentials, got %v", i, err)
+		}
+		if n := failures(UserLockoutKey("bob")); n != i {
+			t.Errorf("attempt %d: user counter is %d", i, n)
+		}
+	}
+
+	// Unknown usernames are counted and locked like real ones, so the
+	// lockout does not tell them apart
+	for i := range conf.MaxLoginAttempts {
+		_, err = Authenticate(ctx, db, conf, "nobody", "wrong", fmt.Sprintf("10.1.0.%d", i))
+		if !errors.Is(err, ErrBadCredentials) {
+			t.Fatalf("attempt %d: expected ErrBadCredentials, got %v", i, err)
+		}
+	}
+	if n := failures(UserLockoutKey("nobody")); n != conf.MaxLoginAttempts {
+		t.Errorf("unknown username has %d failures", n)
+	}
+	_, err = Authenticate(ctx, db, conf, "nobody", "wrong", "10.1.0.99")
+	var unknownLocked *LockedError
+	if !errors.As(err, &unknownLocked) {
+		t.Errorf("expected unknown username to be locked, got %v", err)
+	}
+
+	// The last allowed attempt locks the account, even with the right password
+	_, err = Authenticate(ctx, db, conf, "bob", "wrong", "10.0.0.3")
+	if !errors.Is(err, ErrBadCredentials) {
+		t.Fatalf("expected ErrBadCredentials, got %v", err)
+	}
+	_, err = Authenticate(ctx, db, conf, "bob", "secret", "10.0.0.4")
+	var locked *LockedError
+	if !errors.As(err, &locked) {
+		t.Fatalf("expected LockedError, got %v", err)
+	}
+	if locked.RetryAfter <= 0 || locked.RetryAfter > conf.LockoutDuration {
+		t.Errorf("retry after %s, want at most %s", locked.RetryAfter, conf.LockoutDuration)
+	}
+
+	ok, err := Unlock(ctx, db, UserLockoutKey("bob"))
+	if err != nil || !ok {
+		t.Fatalf("Unlock = %v, %v", ok, err)
+	}
+	u, err := Authenticate(ctx, db, conf, "bob", "secret", "10.0.0.4")
+	if err != nil {
+		t.Fatalf("login after unlock: %v", err)
+	}
+	if u.Username != "bob" {
+		t.Errorf("logged in as %s", u.Username)
+	}
+}
+
+func TestLockoutDuration(t *testing.T) {
+	conf := config.Auth{LockoutDuration: time.Minute, MaxLockoutDuration: 5 * time.Minute}
+	tests := []struct {
+		over int
+		want time.Duration
+	}{
+		{0, time.Minute},
+		{1, 2 * time.Minute},
+		{2, 4 * time.Minute},
+		{3, 5 * time.Minute},
+		{10, 5 * time.Minute},
+	}
+	for _, tt := range tests {
+		if got := lockoutDuration(conf, tt.over); got != tt.want {
+			t.Errorf("lockoutDuration(%d) = %s, want %s", tt.over, got, tt.want)
+		}
+	}
+}
+
+func TestLockoutRenameAndIp(t *testing.T) {
+	db := newTestDatabase(t)
+	ctx := context.Background()
+	conf := config.Auth{
+		MaxLoginAttempts:   1,
+		LockoutDuration:    time.Minute,
+		MaxLockoutDuration: time.Hour,
+	}
+	u, err := CreateUser(ctx, db, "bob", "secret", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Locks bob, the name alice and both IPs
+	for _, login := range [][2]string{{"bob", "10.0.0.1"}, {"alice", "::ffff:10.0.0.2"}} {
+		_, err = Authenticate(ctx, db, conf, login[0], "wrong", login[1])
+		if !errors.Is(err, ErrBadCredentials) {
+			t.Fatalf("expected ErrBadCredentials, got %v", err)
+		}
+	}
+
+	name := "alice"
+	err = UpdateUser(ctx, db, u, UserUpdate{Username: &name})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{UserLockoutKey("bob"), UserLockoutKey("alice")} {
+		if n, _ := db.Client.Lockout.Query().Where(lockout.Key(key)).Count(ctx); n != 0 {
+			t.Errorf("lockout %s kept after the rename", key)
+		}
+	}
+
+	// Written differently, the same address
+	ok, err := Unlock(ctx, db, IpLockoutKey("10.0.0.2"))
+	if err != nil || !ok {
+		t.Errorf("Unlock ip = %v, %v", ok, err)
+	}
+	_, err = Authenticate(ctx, db, conf, "alice", "secret", "10.0.0.2")
+	if err != nil {
+		t.Errorf("login after unlocking the ip: %v", err)
+	}
+}
@@ -0,0 +1,224 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/Pineapple217/cvrs/pkg/config"
+	"github.com/Pineapple217/cvrs/pkg/database"
+	"github.com/Pineapple217/cvrs/pkg/ent"
+	"github.com/Pineapple217/cvrs/pkg/ent/user"
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/oauth2"
+)
+
+var ErrOidcUsername = errors.New("the username from the identity provider can not be used")
+
+// Identity is what cvrs needs to know about a user that logged in through oidc
+type Identity struct {
+	// Issuer and subject, unique for every account at every provider
+	Subject  string
+	Username string
+	// Nil when no admin claim is configured
+	IsAdmin *bool
+}
+
+type Oidc struct {
+	conf config.Oidc
+
+	mu       sync.Mutex
+	provider *oidc.Provider
+}
+
+func NewOidc(conf config.Oidc) *Oidc {
+	return &Oidc{conf: conf}
+}
+
+// Discovery happens on first use instead of at startup, so cvrs still starts
+// while the provider is unreachable.
+func (o *Oidc) init(ctx context.Context) (*oidc.Provider, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.provider != nil {
+		return o.provider, nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	p, err := oidc.NewProvider(ctx, o.conf.Issuer)
+	if err != nil {
+		return nil, fmt.Errorf("oidc discovery failed: %w", err)
+	}
+	o.provider = p
+	return p, nil
+}
+
+func (o *Oidc) oauth2Config(p *oidc.Provider) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     o.conf.ClientId,
+		ClientSecret: o.conf.ClientSecret,
+		RedirectURL:  o.conf.RedirectUrl,
+		Endpoint:     p.Endpoint(),
+		Scopes:       o.conf.Scopes,
+	}
+}
+
+// AuthCodeURL returns the URL of the provider's login page. State, nonce and
+// the PKCE verifier have to be kept by the caller to finish the login.
+func (o *Oidc) AuthCodeURL(ctx context.Context, state, nonce, verifier string) (string, error) {
+	p, err := o.init(ctx)
+	if err != nil {
+		return "", err
+	}
+	return o.oauth2Config(p).AuthCodeURL(state,
+		oidc.Nonce(nonce),
+		oauth2.S256ChallengeOption(verifier),
+	), nil
+}
+
+// Exchange trades the code from the callback for a verified ID token
+func (o *Oidc) Exchange(ctx context.Context, code, nonce, verifier string) (Identity, error) {
+	p, err := o.init(ctx)
+	if err != nil {
+		return Identity{}, err
+	}
+	token, err := o.oauth2Config(p).Exchange(ctx, code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return Identity{}, fmt.Errorf("failed to exchange code: %w", err)
+	}
+	rawIdToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return Identity{}, errors.New("no id_token in token response")
+	}
+	idToken, err := p.Verifier(&oidc.Config{ClientID: o.conf.ClientId}).Verify(ctx, rawIdToken)
+	if err != nil {
+		return Identity{}, fmt.Errorf("invalid id_token: %w", err)
+	}
+	if idToken.Nonce != nonce {
+		return Identity{}, errors.New("id_token nonce does not match")
+	}
+
+	claims := map[string]any{}
+	err = idToken.Claims(&claims)
+	if err != nil {
+		return Identity{}, err
+	}
+	username, _ := claims[o.conf.UsernameClaim].(string)
+	if username == "" {
+		return Identity{}, fmt.Errorf("id_token has no %s claim", o.conf.UsernameClaim)
+	}
+	id := Identity{
+		Subject:  idToken.Issuer + "|" + idToken.Subject,
+		Username: username,
+	}
+	if o.conf.AdminClaim != "" {
+		isAdmin := claimMatches(claims[o.conf.AdminClaim], o.conf.AdminValue)
+		id.IsAdmin = &isAdmin
+	}
+	return id, nil
+}
+
+func claimMatches(claim any, value string) bool {
+	switch v := claim.(type) {
+	case bool:
+		return v
+	case string:
+		return v == value
+	case []any:
+		return slices.ContainsFunc(v, func(e any) bool {
+			s, ok := e.(string)
+			return ok && s == value
+		})
+	}
+	return false
+}
+
+// ProvisionUser returns the user linked to the identity, creating it on its
+// first login. The admin flag follows the provider on every login.
+func ProvisionUser(ctx context.Context, db *database.Database, id Identity) (*ent.User, error) {
+	u, err := db.Client.User.Query().
+		Where(user.OidcSubject(id.Subject)).
+		Only(ctx)
+	if ent.IsNotFound(err) {
+		err = ValidateUsername(id.Username)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrOidcUsername, err)
+		}
+		q := db.Client.User.Create().
+			SetUsername(id.Username).
+			SetOidcSubject(id.Subject)
+		if id.IsAdmin != nil {
+			q.SetIsAdmin(*id.IsAdmin)
+		}
+		u, err = q.Save(ctx)
+		// Linking to an existing local account by name would let anyone that
+		// can pick their username at the provider take over that account
+		if ent.IsConstraintError(err) {
+			return nil, fmt.Errorf("%w: it is taken by an account that does not use single sign-on", ErrOidcUsername)
+		}
+		return u, err
+	}
+	if err != nil {
+		return nil, err
+	}
+	if id.IsAdmin != nil && *id.IsAdmin != u.IsAdmin {
+		u, err = u.Update().SetIsAdmin(*id.IsAdmin).Save(ctx)
+	}
+	return u, err
+}
+
+const (
+	oidcStateLifetime = 10 * time.Minute
+	oidcStateAudience = "cvrs-oidc-state"
+)
+
+// OidcState ties the callback to the browser that started the login. It is
+// kept in a cookie, signed like the access tokens.
+type OidcState struct {
+	State    string `json:"sta"`
+	Nonce    string `json:"non"`
+	Verifier string `json:"ver"`
+	// Frontend path to return to after logging in
+	Redirect string `json:"red"`
+	jwt.RegisteredClaims
+}
+
+func NewOidcState(conf config.Auth, redirect string) (OidcState, string, error) {
+	state, _, err := newRefreshToken()
+	if err != nil {
+		return OidcState{}, "", err
+	}
+	nonce, _, err := newRefreshToken()
+	if err != nil {
+		return OidcState{}, "", err
+	}
+	s := OidcState{
+		State:    state,
+		Nonce:    nonce,
+		Verifier: oauth2.GenerateVerifier(),
+		Redirect: redirect,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(oidcStateLifetime)),
+			Issuer:    "cvrs",
+			// Keeps it from being mistaken for an access token and vice versa
+			Audience: jwt.ClaimStrings{oidcStateAudience},
+		},
+	}
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, s).SignedString([]byte(conf.Secret))
+	if err != nil {
+		return OidcState{}, "", err
+	}
+	return s, signed, nil
+}
+
+func ParseOidcState(conf config.Auth, signed string) (OidcState, error) {
+	s := OidcState{}
+	_, err := jwt.ParseWithClaims(signed, &s, func(t *jwt.Token) (interface{}, error) {
+		return []byte(conf.Secret), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithIssuer("cvrs"), jwt.WithAudience(oidcStateAudience))
+	return s, err
+}
@@ -0,0 +1,204 @@
+package users
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/Pineapple217/cvrs/pkg/config"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Minimal OpenID provider that hands out an ID token for a single code
+type mockIssuer struct {
+	*httptest.Server
+	key       *rsa.PrivateKey
+	challenge string
+	nonce     string
+	claims    jwt.MapClaims
+}
+
+func newMockIssuer(t *testing.T) *mockIssuer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := &mockIssuer{key: key}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"issuer":                                m.URL,
+			"authorization_endpoint":                m.URL + "/auth",
+			"token_endpoint":                        m.URL + "/token",
+			"jwks_uri":                              m.URL + "/keys",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"alg": "RS256",
+				"use": "sig",
+				"kid": "test",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		sum := sha256.Sum256([]byte(r.Form.Get("code_verifier")))
+		if r.Form.Get("code") != "good-code" || base64.RawURLEncoding.EncodeToString(sum[:]) != m.challenge {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		claims := jwt.MapClaims{
+			"iss":   m.URL,
+			"sub":   "1234",
+			"aud":   "cvrs",
+			"exp":   time.Now().Add(time.Minute).Unix(),
+			"iat":   time.Now().Unix(),
+			"nonce": m.nonce,
+		}
+		for k, v := range m.claims {
+			claims[k] = v
+		}
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		token.Header["kid"] = "test"
+		idToken, err := token.SignedString(key)
+		if err != nil {
+			t.Error(err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"access_token": "access",
+			"token_type":   "Bearer",
+			"expires_in":   60,
+			"id_token":     idToken,
+		})
+	})
+	m.Server = httptest.NewServer(mux)
+	t.Cleanup(m.Close)
+	return m
+}
+
+func TestOidcExchange(t *testing.T) {
+	issuer := newMockIssuer(t)
+	o := NewOidc(config.Oidc{
+		Issuer:        issuer.URL,
+		ClientId:      "cvrs",
+		ClientSecret:  "secret",
+		RedirectUrl:   "http://localhost:3000/api/auth/oidc/callback",
+		Scopes:        []string{"openid"},
+		UsernameClaim: "preferred_username",
+		AdminClaim:    "groups",
+		AdminValue:    "cvrs-admins",
+	})
+	ctx := context.Background()
+
+	login := func(t *testing.T) (nonce, verifier string) {
+		s, _, err := NewOidcState(config.Auth{Secret: "0123456789abcdef"}, "/")
+		if err != nil {
+			t.Fatal(err)
+		}
+		authUrl, err := o.AuthCodeURL(ctx, s.State, s.Nonce, s.Verifier)
+		if err != nil {
+			t.Fatal(err)
+		}
+		u, err := url.Parse(authUrl)
+		if err != nil {
+			t.Fatal(err)
+		}
+		q := u.Query()
+		if q.Get("state") != s.State || q.Get("nonce") != s.Nonce || q.Get("code_challenge_method") != "S256" {
+			t.Fatalf("unexpected auth URL %s", authUrl)
+		}
+		issuer.challenge = q.Get("code_challenge")
+		issuer.nonce = q.Get("nonce")
+		return s.Nonce, s.Verifier
+	}
+
+	t.Run("admin", func(t *testing.T) {
+		issuer.claims = jwt.MapClaims{"preferred_username": "alice", "groups": []string{"users", "cvrs-admins"}}
+		nonce, verifier := login(t)
+		id, err := o.Exchange(ctx, "good-code", nonce, verifier)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id.Subject != issuer.URL+"|1234" || id.Username != "alice" {
+			t.Errorf("unexpected identity %+v", id)
+		}
+		if id.IsAdmin == nil || !*id.IsAdmin {
+			t.Errorf("expected admin")
+		}
+	})
+
+	t.Run("not admin", func(t *testing.T) {
+		issuer.claims = jwt.MapClaims{"preferred_username": "bob", "groups": []string{"users"}}
+		nonce, verifier := login(t)
+		id, err := o.Exchange(ctx, "good-code", nonce, verifier)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id.IsAdmin == nil || *id.IsAdmin {
+			t.Errorf("expected no admin")
+		}
+	})
+
+	t.Run("bad nonce", func(t *testing.T) {
+		issuer.claims = jwt.MapClaims{"preferred_username": "alice"}
+		_, verifier := login(t)
+		_, err := o.Exchange(ctx, "good-code", "other", verifier)
+		if err == nil {
+			t.Error("expected error for a nonce mismatch")
+		}
+	})
+
+	t.Run("bad verifier", func(t *testing.T) {
+		issuer.claims = jwt.MapClaims{"preferred_username": "alice"}
+		nonce, _ := login(t)
+		_, err := o.Exchange(ctx, "good-code", nonce, "wrong")
+		if err == nil {
+			t.Error("expected error for a wrong PKCE verifier")
+		}
+	})
+
+	t.Run("no username", func(t *testing.T) {
+		issuer.claims = jwt.MapClaims{}
+		nonce, verifier := login(t)
+		_, err := o.Exchange(ctx, "good-code", nonce, verifier)
+		if err == nil {
+			t.Error("expected error for a missing username claim")
+		}
+	})
+}
+
+func TestOidcState(t *testing.T) {
+	conf := config.Auth{Secret: "0123456789abcdef"}
+	s, signed, err := NewOidcState(conf, "/artists")
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := ParseOidcState(conf, signed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.State != s.State || parsed.Verifier != s.Verifier || parsed.Redirect != "/artists" {
+		t.Errorf("state did not survive a round trip: %+v", parsed)
+	}
+	_, err = ParseOidcState(config.Auth{Secret: "another secret!!"}, signed)
+	if err == nil {
+		t.Error("expected error for a state signed with another secret")
+	}
+}
@@ -0,0 +1,123 @@
+package users
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"time"
+
+	"github.com/Pineapple217/cvrs/pkg/config"
+	"github.com/Pineapple217/cvrs/pkg/database"
+	"github.com/Pineapple217/cvrs/pkg/ent"
+	"github.com/Pineapple217/cvrs/pkg/ent/session"
+	"github.com/Pineapple217/cvrs/pkg/ent/user"
+	"github.com/Pineapple217/cvrs/pkg/pid"
+)
+
+const maxDeviceLen = 128
+
+var ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+
+func newRefreshToken() (string, []byte, error) {
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", nil, err
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+	return token, hashToken(token), nil
+}
+
+func hashToken(token string) []byte {
+	h := sha256.Sum256([]byte(token))
+	return h[:]
+}
+
+// NewSession starts a session for the user and returns it together with its
+// refresh token. Only a hash of the refresh token is stored.
+func NewSession(ctx context.Context, db *database.Database, conf config.Auth, u *ent.User, device string) (*ent.Session, string, error) {
+	token, hash, err := newRefreshToken()
+	if err != nil {
+		return nil, "", err
+	}
+	if len(device) > maxDeviceLen {
+		device = device[:maxDeviceLen]
+	}
+	s, err := db.Client.Session.Create().
+		SetUser(u).
+		SetDevice(device).
+		SetRefreshHash(hash).
+		SetExpiresAt(time.Now().Add(conf.RefreshLifetime)).
+		Save(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	return s, token, nil
+}
+
+// RefreshSession exchanges a refresh token for a new one. The old token stops
+// working right away. The returned session has its user loaded.
+func RefreshSession(ctx context.Context, db *database.Database, conf config.Auth, refreshToken string) (*ent.Session, string, error) {
+	s, err := db.Client.Session.Query().
+		Where(
+			session.RefreshHash(hashToken(refreshToken)),
+			session.RevokedAtIsNil(),
+			session.ExpiresAtGT(time.Now()),
+		).
+		WithUser().
+		Only(ctx)
+	if ent.IsNotFound(err) {
+		return nil, "", ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	token, hash, err := newRefreshToken()
+	if err != nil {
+		return nil, "", err
+	}
+	// Matching on the old hash makes sure a token can only be used once, even
+	// with concurrent requests
+	n, err := db.Client.Session.Update().
+		Where(
+			session.IDEQ(s.ID),
+			session.RefreshHash(s.RefreshHash),
+		).
+		SetRefreshHash(hash).
+		SetLastUsedAt(time.Now()).
+		Save(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	if n == 0 {
+		return nil, "", ErrInvalidRefreshToken
+	}
+	return s, token, nil
+}
+
+func RevokeSession(ctx context.Context, db *database.Database, id pid.ID) error {
+	return db.Client.Session.Update().
+		Where(session.IDEQ(id), session.RevokedAtIsNil()).
+		SetRevokedAt(time.Now()).
+		Exec(ctx)
+}
+
+// Revokes every active session of a user except for the ones in keep, e.g.
+// after a password change
+func RevokeUserSessions(ctx context.Context, db *database.Database, userId pid.ID, keep ...pid.ID) error {
+	return revokeUserSessions(ctx, db.Client, userId, keep...)
+}
+
+func revokeUserSessions(ctx context.Context, client *ent.Client, userId pid.ID, keep ...pid.ID) error {
+	return client.Session.Update().
+		Where(
+			session.HasUserWith(user.IDEQ(userId)),
+			session.RevokedAtIsNil(),
+			session.IDNotIn(keep...),
+		).
+		SetRevokedAt(time.Now()).
+		Exec(ctx)
+}
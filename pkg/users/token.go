@@ -0,0 +1,131 @@
+package users
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Pineapple217/cvrs/pkg/database"
+	"github.com/Pineapple217/cvrs/pkg/ent"
+	"github.com/Pineapple217/cvrs/pkg/ent/apitoken"
+)
+
+type Scope string
+
+const (
+	ScopeArtistsWrite  Scope = "artists:write"
+	ScopeReleasesWrite Scope = "releases:write"
+	ScopeImagesWrite   Scope = "images:write"
+	// Grants every other scope as well
+	ScopeAdmin Scope = "admin"
+)
+
+var Scopes = []Scope{ScopeArtistsWrite, ScopeReleasesWrite, ScopeImagesWrite, ScopeAdmin}
+
+// Makes API tokens recognizable, both for Auth and for secret scanners
+const apiTokenPrefix = "cvrs_"
+
+func ParseScope(s string) (Scope, error) {
+	scope := Scope(s)
+	if !slices.Contains(Scopes, scope) {
+		return "", fmt.Errorf("unknown scope %q", s)
+	}
+	return scope, nil
+}
+
+// UserScopes returns every scope the user is allowed to hold. Editing releases
+// has always been reserved for admins.
+func UserScopes(u *ent.User) []Scope {
+	if u.IsAdmin {
+		return Scopes
+	}
+	return []Scope{ScopeArtistsWrite, ScopeImagesWrite}
+}
+
+// Limits the scopes of a token to what its user is currently allowed, so a
+// demotion also takes away the admin scopes of the user's tokens.
+func effectiveScopes(u *ent.User, scopes []string) []Scope {
+	allowed := UserScopes(u)
+	effective := []Scope{}
+	for _, s := range scopes {
+		if slices.Contains(allowed, Scope(s)) {
+			effective = append(effective, Scope(s))
+		}
+	}
+	return effective
+}
+
+func hasScopes(held []Scope, required ...Scope) bool {
+	if slices.Contains(held, ScopeAdmin) {
+		return true
+	}
+	for _, s := range required {
+		if !slices.Contains(held, s) {
+			return false
+		}
+	}
+	return true
+}
+
+func isApiToken(token string) bool {
+	return strings.HasPrefix(token, apiTokenPrefix)
+}
+
+// NewApiToken creates a personal API token for the user and returns it together
+// with the token itself, which is not stored and can not be shown again. A zero
+// lifetime creates a token that never expires.
+func NewApiToken(ctx context.Context, db *database.Database, u *ent.User, name string, scopes []Scope, lifetime time.Duration) (*ent.ApiToken, string, error) {
+	if len(scopes) == 0 {
+		return nil, "", errors.New("a token needs at least one scope")
+	}
+	allowed := UserScopes(u)
+	strScopes := []string{}
+	for _, s := range scopes {
+		if !slices.Contains(allowed, s) {
+			return nil, "", fmt.Errorf("user %s can not be granted scope %s", u.Username, s)
+		}
+		if !slices.Contains(strScopes, string(s)) {
+			strScopes = append(strScopes, string(s))
+		}
+	}
+
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	if err != nil {
+		return nil, "", err
+	}
+	token := apiTokenPrefix + base64.RawURLEncoding.EncodeToString(b)
+
+	q := db.Client.ApiToken.Create().
+		SetUser(u).
+		SetName(name).
+		SetTokenHash(hashToken(token)).
+		SetScopes(strScopes)
+	if lifetime > 0 {
+		q.SetExpiresAt(time.Now().Add(lifetime))
+	}
+	t, err := q.Save(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	return t, token, nil
+}
+
+// Finds the active API token with its user
+func lookupApiToken(ctx context.Context, db *database.Database, token string) (*ent.ApiToken, error) {
+	return db.Client.ApiToken.Query().
+		Where(
+			apitoken.TokenHash(hashToken(token)),
+			apitoken.Or(
+				apitoken.ExpiresAtIsNil(),
+				apitoken.ExpiresAtGT(time.Now()),
+			),
+		).
+		WithUser().
+		Only(ctx)
+}
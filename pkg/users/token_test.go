@@ -0,0 +1,91 @@
+package users
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Pineapple217/cvrs/pkg/ent/apitoken"
+	"github.com/labstack/echo/v4"
+)
+
+func TestAuthApiToken(t *testing.T) {
+	db := newTestDatabase(t)
+	ctx := context.Background()
+	conf := testAuthConfig()
+	u, err := CreateUser(ctx, db, "bob", "secret", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, valid, err := NewApiToken(ctx, db, u, "valid", []Scope{ScopeArtistsWrite}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(valid, apiTokenPrefix) {
+		t.Errorf("token %s has no prefix", valid)
+	}
+	n, err := db.Client.ApiToken.Query().Where(apitoken.TokenHash([]byte(valid))).Count(ctx)
+	if err != nil || n != 0 {
+		t.Errorf("token is stored in plain text")
+	}
+	expiredToken, expired, err := NewApiToken(ctx, db, u, "expired", []Scope{ScopeArtistsWrite}, time.Hour)
+	if err == nil {
+		err = expiredToken.Update().SetExpiresAt(time.Now().Add(-time.Minute)).Exec(ctx)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, admin, err := NewApiToken(ctx, db, u, "admin", []Scope{ScopeAdmin}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		token  string
+		scopes []Scope
+		status int
+	}{
+		{"valid", valid, []Scope{ScopeArtistsWrite}, http.StatusOK},
+		{"no scopes required", valid, nil, http.StatusOK},
+		{"missing scope", valid, []Scope{ScopeImagesWrite}, http.StatusForbidden},
+		{"one of two scopes", valid, []Scope{ScopeArtistsWrite, ScopeImagesWrite}, http.StatusForbidden},
+		{"admin grants all", admin, []Scope{ScopeReleasesWrite, ScopeImagesWrite}, http.StatusOK},
+		{"expired", expired, nil, http.StatusUnauthorized},
+		{"unknown", apiTokenPrefix + "unknown", nil, http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", "Bearer "+tt.token)
+		c := echo.New().NewContext(req, httptest.NewRecorder())
+		err := Auth(conf, db)(RequireScope(tt.scopes...)(func(c echo.Context) error {
+			return c.NoContent(http.StatusOK)
+		}))(c)
+		switch {
+		case tt.status == http.StatusOK && err != nil:
+			t.Errorf("%s: %v", tt.name, err)
+		case tt.status != http.StatusOK && !isStatus(err, tt.status):
+			t.Errorf("%s: expected %d, got %v", tt.name, tt.status, err)
+		}
+	}
+
+	// Demoted, the admin token keeps none of its scopes
+	_, err = CreateUser(ctx, db, "alice", "secret", true)
+	if err == nil {
+		err = SetAdmin(ctx, db, u, false)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims, err := authenticate(conf, db, admin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claims.IsAdmin || len(claims.Scopes) != 0 {
+		t.Errorf("demoted token has scopes %v", claims.Scopes)
+	}
+}
@@ -0,0 +1,277 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Pineapple217/cvrs/pkg/database"
+	"github.com/Pineapple217/cvrs/pkg/ent"
+	"github.com/Pineapple217/cvrs/pkg/ent/apitoken"
+	"github.com/Pineapple217/cvrs/pkg/ent/image"
+	"github.com/Pineapple217/cvrs/pkg/ent/lockout"
+	"github.com/Pineapple217/cvrs/pkg/ent/schema"
+	"github.com/Pineapple217/cvrs/pkg/ent/session"
+	"github.com/Pineapple217/cvrs/pkg/ent/user"
+	"github.com/Pineapple217/cvrs/pkg/pid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Keep in sync with the limits of the username field in the User schema
+const (
+	minUsernameLen = 3
+	maxUsernameLen = 32
+)
+
+const bcryptCost = 10
+
+var (
+	ErrUsernameTaken = errors.New("username is already taken")
+	ErrLastAdmin     = errors.New("can not remove the last admin")
+	ErrHasImages     = errors.New("user has uploaded images")
+)
+
+// ValidateUsername checks the username against the schema limits, so users get
+// a readable error instead of an ent validator error.
+func ValidateUsername(username string) error {
+	if username != strings.TrimSpace(username) {
+		return errors.New("username can not start or end with whitespace")
+	}
+	// Bytes, like the length limits on the schema
+	l := len(username)
+	if l < minUsernameLen || l > maxUsernameLen {
+		return fmt.Errorf("username must be between %d and %d bytes long", minUsernameLen, maxUsernameLen)
+	}
+	return nil
+}
+
+func ValidatePassword(password string) error {
+	if password == "" {
+		return errors.New("password can not be empty")
+	}
+	// bcrypt only looks at the first 72 bytes
+	if len(password) > 72 {
+		return errors.New("password can not be longer than 72 bytes")
+	}
+	return nil
+}
+
+func HashPassword(password string) ([]byte, error) {
+	err := ValidatePassword(password)
+	if err != nil {
+		return nil, err
+	}
+	return bcrypt.GenerateFromPassword([]byte(password), bcryptCost)
+}
+
+func CheckPassword(u *ent.User, password string) bool {
+	return bcrypt.CompareHashAndPassword(u.Password, []byte(password)) == nil
+}
+
+func CreateUser(ctx context.Context, db *database.Database, username, password string, isAdmin bool) (*ent.User, error) {
+	err := ValidateUsername(username)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := HashPassword(password)
+	if err != nil {
+		return nil, err
+	}
+	u, err := db.Client.User.Create().
+		SetUsername(username).
+		SetPassword(hash).
+		SetIsAdmin(isAdmin).
+		Save(ctx)
+	if ent.IsConstraintError(err) {
+		return nil, ErrUsernameTaken
+	}
+	return u, err
+}
+
+func SetUsername(ctx context.Context, db *database.Database, u *ent.User, username string) error {
+	err := ValidateUsername(username)
+	if err != nil {
+		return err
+	}
+	err = db.Client.User.UpdateOneID(u.ID).SetUsername(username).Exec(ctx)
+	if ent.IsConstraintError(err) {
+		return ErrUsernameTaken
+	}
+	return err
+}
+
+// SetPassword changes the password and ends every session of the user, except
+// for the ones in keep.
+func SetPassword(ctx context.Context, db *database.Database, u *ent.User, password string, keep ...pid.ID) error {
+	hash, err := HashPassword(password)
+	if err != nil {
+		return err
+	}
+	err = db.Client.User.UpdateOneID(u.ID).SetPassword(hash).Exec(ctx)
+	if err != nil {
+		return err
+	}
+	return RevokeUserSessions(ctx, db, u.ID, keep...)
+}
+
+// Fails with ErrLastAdmin when nobody else would be left to administrate
+func checkOtherAdmin(ctx context.Context, db *database.Database, u *ent.User) error {
+	if !u.IsAdmin {
+		return nil
+	}
+	others, err := db.Client.User.Query().
+		Where(user.IsAdmin(true), user.IDNEQ(u.ID)).
+		Exist(ctx)
+	if err != nil {
+		return err
+	}
+	if !others {
+		return ErrLastAdmin
+	}
+	return nil
+}
+
+// Changes to a user, nil fields stay unchanged
+type UserUpdate struct {
+	Username *string
+	Password *string
+	IsAdmin  *bool
+}
+
+// UpdateUser checks every change before it writes any of them, then applies
+// them in one transaction. A new password ends every session of the user and
+// a new name clears the lockouts of both names.
+func UpdateUser(ctx context.Context, db *database.Database, u *ent.User, upd UserUpdate) error {
+	if upd.Username != nil {
+		err := ValidateUsername(*upd.Username)
+		if err != nil {
+			return err
+		}
+		taken, err := db.Client.User.Query().
+			Where(user.UsernameEQ(*upd.Username), user.IDNEQ(u.ID)).
+			Exist(ctx)
+		if err != nil {
+			return err
+		}
+		if taken {
+			return ErrUsernameTaken
+		}
+	}
+	if upd.IsAdmin != nil && !*upd.IsAdmin {
+		err := checkOtherAdmin(ctx, db, u)
+		if err != nil {
+			return err
+		}
+	}
+	var hash []byte
+	if upd.Password != nil {
+		var err error
+		hash, err = HashPassword(*upd.Password)
+		if err != nil {
+			return err
+		}
+	}
+
+	tx, err := db.Client.Tx(ctx)
+	if err != nil {
+		return err
+	}
+	uu := tx.User.UpdateOneID(u.ID)
+	if upd.Username != nil {
+		uu.SetUsername(*upd.Username)
+	}
+	if upd.IsAdmin != nil {
+		uu.SetIsAdmin(*upd.IsAdmin)
+	}
+	if hash != nil {
+		uu.SetPassword(hash)
+	}
+	err = uu.Exec(ctx)
+	if ent.IsConstraintError(err) {
+		err = ErrUsernameTaken
+	}
+	if err == nil && hash != nil {
+		err = revokeUserSessions(ctx, tx.Client(), u.ID)
+	}
+	// The lockout is keyed on the name, failures of the old name would stay
+	// behind and ones of the new name would lock out the renamed user
+	if err == nil && upd.Username != nil && *upd.Username != u.Username {
+		_, err = tx.Lockout.Delete().
+			Where(lockout.KeyIn(UserLockoutKey(u.Username), UserLockoutKey(*upd.Username))).
+			Exec(ctx)
+	}
+	if err == nil {
+		err = tx.Commit()
+	} else if rerr := tx.Rollback(); rerr != nil {
+		err = fmt.Errorf("%w: %v", err, rerr)
+	}
+	return err
+}
+
+func SetAdmin(ctx context.Context, db *database.Database, u *ent.User, isAdmin bool) error {
+	if !isAdmin {
+		err := checkOtherAdmin(ctx, db, u)
+		if err != nil {
+			return err
+		}
+	}
+	return db.Client.User.UpdateOneID(u.ID).SetIsAdmin(isAdmin).Exec(ctx)
+}
+
+// DeleteUser removes the user with its sessions and API tokens. Images require
+// an uploader, so a user that uploaded images can only be deleted when they are
+// handed over to target. Returns the amount of reassigned images.
+func DeleteUser(ctx context.Context, db *database.Database, u *ent.User, target *ent.User) (int, error) {
+	err := checkOtherAdmin(ctx, db, u)
+	if err != nil {
+		return 0, err
+	}
+	if target != nil && target.ID == u.ID {
+		return 0, errors.New("can not reassign images to the user that gets deleted")
+	}
+	// Soft-deleted images still reference their uploader
+	ctx = schema.SkipSoftDelete(ctx)
+	imgCount, err := db.Client.Image.Query().
+		Where(image.HasUploaderWith(user.IDEQ(u.ID))).
+		Count(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if imgCount > 0 && target == nil {
+		return 0, fmt.Errorf("%w: %d images", ErrHasImages, imgCount)
+	}
+
+	tx, err := db.Client.Tx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if imgCount > 0 {
+		_, err = tx.Image.Update().
+			Where(image.HasUploaderWith(user.IDEQ(u.ID))).
+			SetUploaderID(target.ID).
+			Save(ctx)
+	}
+	if err == nil {
+		_, err = tx.Session.Delete().
+			Where(session.HasUserWith(user.IDEQ(u.ID))).
+			Exec(ctx)
+	}
+	if err == nil {
+		_, err = tx.ApiToken.Delete().
+			Where(apitoken.HasUserWith(user.IDEQ(u.ID))).
+			Exec(ctx)
+	}
+	if err == nil {
+		err = tx.User.DeleteOneID(u.ID).Exec(ctx)
+	}
+	if err == nil {
+		err = tx.Commit()
+	} else if rerr := tx.Rollback(); rerr != nil {
+		err = fmt.Errorf("%w: %v", err, rerr)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return imgCount, nil
+}
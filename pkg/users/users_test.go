@@ -0,0 +1,94 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Pineapple217/cvrs/pkg/config"
+	"github.com/Pineapple217/cvrs/pkg/database"
+)
+
+func TestValidateUsername(t *testing.T) {
+	tests := []struct {
+		in string
+		ok bool
+	}{
+		{"bob", true},
+		{"ab", false},
+		{" bob", false},
+		{strings.Repeat("a", 32), true},
+		{strings.Repeat("a", 33), false},
+		// 17 characters but 34 bytes, over the limit of the schema
+		{strings.Repeat("ä", 17), false},
+		{strings.Repeat("ä", 16), true},
+	}
+	for _, tt := range tests {
+		if err := ValidateUsername(tt.in); (err == nil) != tt.ok {
+			t.Errorf("ValidateUsername(%q) = %v, want ok %v", tt.in, err, tt.ok)
+		}
+	}
+}
+
+// Database on an in-memory sqlite, with the fs blob store in a temp dir
+func newTestDatabase(t *testing.T) *database.Database {
+	t.Helper()
+	name := strings.NewReplacer("/", "_", " ", "_").Replace(t.Name())
+	db, err := database.NewDatabase(config.Database{
+		DataLocation:  t.TempDir(),
+		SqliteOptions: fmt.Sprintf("file:%s?mode=memory&cache=shared&_fk=1", name),
+	}, config.Storage{Backend: "fs"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Client.Close() })
+	return db
+}
+
+func TestUpdateUser(t *testing.T) {
+	db := newTestDatabase(t)
+	ctx := context.Background()
+	admin, err := CreateUser(ctx, db, "admin", "secret", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = CreateUser(ctx, db, "bob", "secret", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	name, password, no := "alice", "changed", false
+	// Demoting the last admin fails, so the other changes may not stick either
+	err = UpdateUser(ctx, db, admin, UserUpdate{Username: &name, Password: &password, IsAdmin: &no})
+	if !errors.Is(err, ErrLastAdmin) {
+		t.Fatalf("expected ErrLastAdmin, got %v", err)
+	}
+	u, err := db.Client.User.Get(ctx, admin.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Username != "admin" || !u.IsAdmin || !CheckPassword(u, "secret") {
+		t.Errorf("failed update was partly applied: %s admin=%v", u.Username, u.IsAdmin)
+	}
+
+	taken := "bob"
+	err = UpdateUser(ctx, db, admin, UserUpdate{Username: &taken, Password: &password})
+	if !errors.Is(err, ErrUsernameTaken) {
+		t.Fatalf("expected ErrUsernameTaken, got %v", err)
+	}
+	u, _ = db.Client.User.Get(ctx, admin.ID)
+	if !CheckPassword(u, "secret") {
+		t.Error("password changed by a failed update")
+	}
+
+	err = UpdateUser(ctx, db, admin, UserUpdate{Username: &name, Password: &password})
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, _ = db.Client.User.Get(ctx, admin.ID)
+	if u.Username != "alice" || !CheckPassword(u, "changed") {
+		t.Errorf("update was not applied: %s", u.Username)
+	}
+}
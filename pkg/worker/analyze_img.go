@@ -0,0 +1,30 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	"github.com/Pineapple217/cvrs/pkg/database"
+	"github.com/Pineapple217/cvrs/pkg/ent"
+)
+
+func AnalyzeImg(t *ent.Task, db *database.Database, ctx context.Context) error {
+	var ta database.TaskAnalyzeImg
+	err := json.Unmarshal(t.Payload, &ta)
+	if err != nil {
+		return err
+	}
+
+	i, err := db.Client.Image.Get(ctx, ta.ImageId)
+	if err != nil {
+		return err
+	}
+	err = db.AnalyzeImg(ctx, i)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("done analyzing img", "img", ta.ImageId)
+	return nil
+}
@@ -0,0 +1,37 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	"github.com/Pineapple217/cvrs/pkg/config"
+	"github.com/Pineapple217/cvrs/pkg/database"
+	"github.com/Pineapple217/cvrs/pkg/ent"
+)
+
+func Backup(t *ent.Task, db *database.Database, ctx context.Context, conf config.Backup) error {
+	var tb database.TaskBackup
+	err := json.Unmarshal(t.Payload, &tb)
+	if err != nil {
+		return err
+	}
+
+	file, err := db.CreateScheduledBackup(ctx, tb.Incremental, conf.FullEvery)
+	if err != nil {
+		return err
+	}
+	slog.Info("Created backup", "file", file)
+	if !tb.Prune {
+		return nil
+	}
+
+	pruned, err := db.PruneBackups(conf)
+	if err != nil {
+		return err
+	}
+	if len(pruned) > 0 {
+		slog.Info("Pruned old backups", "count", len(pruned))
+	}
+	return nil
+}
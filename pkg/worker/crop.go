@@ -0,0 +1,101 @@
+package worker
+
+import (
+	"image"
+
+	"github.com/anthonynsimon/bild/effect"
+	"github.com/anthonynsimon/bild/transform"
+)
+
+// Longest side of the copy the edges are detected on
+const smartCropSample = 256
+
+// Size of the largest area of the image with the aspect ratio of width by
+// height
+func cropSize(bounds image.Rectangle, width, height int) (int, int) {
+	w, h := bounds.Dx(), bounds.Dy()
+	if w*height > h*width {
+		return max(1, h*width/height), h
+	}
+	return w, max(1, w*height/width)
+}
+
+// Crops the image to the aspect ratio of width by height around its center
+func CropCenter(img image.Image, width, height int) image.Image {
+	bounds := img.Bounds()
+	w, h := cropSize(bounds, width, height)
+	if w == bounds.Dx() && h == bounds.Dy() {
+		return img
+	}
+	start := bounds.Min.Add(image.Pt((bounds.Dx()-w)/2, (bounds.Dy()-h)/2))
+	return transform.Crop(img, image.Rectangle{start, start.Add(image.Pt(w, h))})
+}
+
+// Crops the image to the aspect ratio of width by height, the crop is placed
+// where the image has the most edges. Edges are detected with a Sobel filter
+// on a smaller copy.
+func SmartCrop(img image.Image, width, height int) image.Image {
+	bounds := img.Bounds()
+	w, h := cropSize(bounds, width, height)
+	if w == bounds.Dx() && h == bounds.Dy() {
+		return img
+	}
+
+	scale := float64(smartCropSample) / float64(max(bounds.Dx(), bounds.Dy()))
+	if scale > 1 {
+		scale = 1
+	}
+	sw := max(1, int(float64(bounds.Dx())*scale))
+	sh := max(1, int(float64(bounds.Dy())*scale))
+	edges := effect.Sobel(transform.Resize(img, sw, sh, transform.Linear))
+
+	// Only one axis has room to move, the energy of every row or column along
+	// it is summed
+	horizontal := w < bounds.Dx()
+	length := sh
+	if horizontal {
+		length = sw
+	}
+	energy := make([]int, length+1)
+	for y := range sh {
+		for x := range sw {
+			i := y
+			if horizontal {
+				i = x
+			}
+			energy[i+1] += int(edges.Pix[edges.PixOffset(x, y)])
+		}
+	}
+	for i := range length {
+		energy[i+1] += energy[i]
+	}
+
+	window := min(length, max(1, int(float64(h)*scale)))
+	if horizontal {
+		window = min(length, max(1, int(float64(w)*scale)))
+	}
+	// Ties go to the crop closest to the center
+	center := (length - window) / 2
+	best := center
+	for start := 0; start+window <= length; start++ {
+		sum := energy[start+window] - energy[start]
+		bestSum := energy[best+window] - energy[best]
+		if sum > bestSum || (sum == bestSum && abs(start-center) < abs(best-center)) {
+			best = start
+		}
+	}
+
+	offset := image.Pt(0, min(bounds.Dy()-h, int(float64(best)/scale)))
+	if horizontal {
+		offset = image.Pt(min(bounds.Dx()-w, int(float64(best)/scale)), 0)
+	}
+	start := bounds.Min.Add(offset)
+	return transform.Crop(img, image.Rectangle{start, start.Add(image.Pt(w, h))})
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
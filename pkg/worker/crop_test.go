@@ -0,0 +1,93 @@
+package worker
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/Pineapple217/cvrs/pkg/config"
+)
+
+// Flat gray image with a checkerboard in the given area
+func busyImage(w, h int, busy image.Rectangle) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := range h {
+		for x := range w {
+			c := color.RGBA{128, 128, 128, 255}
+			if (image.Point{x, y}).In(busy) && (x/4+y/4)%2 == 0 {
+				c = color.RGBA{255, 255, 255, 255}
+			}
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestCropCenter(t *testing.T) {
+	for _, tc := range []struct {
+		w, h, width, height int
+		want                image.Point
+	}{
+		{400, 200, 1, 1, image.Pt(200, 200)},
+		{200, 400, 1, 1, image.Pt(200, 200)},
+		{400, 400, 2, 1, image.Pt(400, 200)},
+		{300, 200, 3, 2, image.Pt(300, 200)},
+	} {
+		got := CropCenter(image.NewRGBA(image.Rect(0, 0, tc.w, tc.h)), tc.width, tc.height).Bounds().Size()
+		if got != tc.want {
+			t.Errorf("%dx%d cropped to %d:%d is %v, want %v", tc.w, tc.h, tc.width, tc.height, got, tc.want)
+		}
+	}
+}
+
+func whitePixels(img image.Image) int {
+	n := 0
+	b := img.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if r, _, _, _ := img.At(x, y).RGBA(); r == 0xffff {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+func TestSmartCrop(t *testing.T) {
+	// The busy part is near an edge, a center crop would miss it
+	for _, img := range []image.Image{
+		busyImage(600, 200, image.Rect(440, 40, 560, 160)),
+		busyImage(200, 600, image.Rect(40, 20, 160, 140)),
+	} {
+		cropped := SmartCrop(img, 1, 1)
+		if cropped.Bounds().Size() != image.Pt(200, 200) {
+			t.Fatalf("unexpected size %v", cropped.Bounds().Size())
+		}
+		if got, want := whitePixels(cropped), whitePixels(img); got != want {
+			t.Errorf("crop has %d of the %d busy pixels", got, want)
+		}
+		if whitePixels(CropCenter(img, 1, 1)) != 0 {
+			t.Errorf("center crop should miss the busy part")
+		}
+	}
+}
+
+func TestResizeImgFit(t *testing.T) {
+	for _, tc := range []struct {
+		w, h, width, height int
+		want                image.Point
+	}{
+		{400, 200, 100, 100, image.Pt(100, 50)},
+		{200, 400, 100, 100, image.Pt(50, 100)},
+		{400, 200, 100, 3000, image.Pt(100, 50)},
+		// Never scaled up
+		{100, 100, 2048, 2048, image.Pt(100, 100)},
+		{100, 50, 3000, 200, image.Pt(100, 50)},
+	} {
+		v := config.ImageVariant{Width: tc.width, Height: tc.height, Mode: "fit"}
+		got := ResizeImg(image.NewRGBA(image.Rect(0, 0, tc.w, tc.h)), v).Bounds().Size()
+		if got != tc.want {
+			t.Errorf("%dx%d fit in %dx%d is %v, want %v", tc.w, tc.h, tc.width, tc.height, got, tc.want)
+		}
+	}
+}
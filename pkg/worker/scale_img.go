@@ -3,67 +3,104 @@ package worker
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"image"
 	"log/slog"
-	"path"
 
+	"github.com/Pineapple217/cvrs/pkg/config"
 	"github.com/Pineapple217/cvrs/pkg/database"
 	"github.com/Pineapple217/cvrs/pkg/ent"
-	"github.com/anthonynsimon/bild/imgio"
+	entImage "github.com/Pineapple217/cvrs/pkg/ent/image"
+	"github.com/Pineapple217/cvrs/pkg/ent/schema"
 	"github.com/anthonynsimon/bild/transform"
 )
 
-var sizes = []int{64, 265, 1024}
-
-func ScaleImg(t *ent.Task, db *database.Database, ctx context.Context) error {
+func ScaleImg(t *ent.Task, db *database.Database, ctx context.Context, conf config.Images) error {
 	var ti database.TaskScaleImg
 	err := json.Unmarshal(t.Payload, &ti)
 	if err != nil {
 		return err
 	}
 
+	variants := conf.Variants
+	if len(ti.Variants) > 0 {
+		variants = []config.ImageVariant{}
+		for _, name := range ti.Variants {
+			v, ok := conf.Variant(name)
+			if !ok {
+				return fmt.Errorf("variant %s is not configured", name)
+			}
+			variants = append(variants, v)
+		}
+	}
+
+	// Soft-deleted images can be restored, so they are kept up to date as well
+	ctx = schema.SkipSoftDelete(ctx)
 	i, err := db.Client.Image.Get(ctx, ti.ImageId)
 	if err != nil {
 		return err
 	}
-	img, err := imgio.Open(path.Join(db.Conf.DataLocation, database.IMG_DIR, i.File))
+	r, _, err := db.Blobs.Get(ctx, i.File)
 	if err != nil {
 		return err
 	}
-
-	if i.DimentionHeight != i.DimentionWidth {
-		img = CropToSquare(img)
+	img, _, err := image.Decode(r)
+	r.Close()
+	if err != nil {
+		return err
 	}
 
 	imgs := []image.Image{}
-	for _, size := range sizes {
-		smallImg := transform.Resize(img, size, size, transform.Lanczos)
-		imgs = append(imgs, smallImg)
+	for _, v := range variants {
+		imgs = append(imgs, ResizeImg(img, v))
 	}
-	dbImgs, err := db.SaveProcessedImgs(ctx, i.ID, imgs)
+
+	// Images with the same file get the same variants
+	sources, err := db.Client.Image.Query().
+		Where(entImage.FileEQ(i.File)).
+		All(ctx)
 	if err != nil {
 		return err
 	}
-	_ = dbImgs
+	if len(sources) == 0 {
+		sources = []*ent.Image{i}
+	}
+	_, err = db.SaveProcessedImgs(ctx, sources, variants, imgs)
+	if err != nil {
+		return err
+	}
+	for _, source := range sources {
+		_, err = db.RemoveStaleVariants(ctx, source.ID, conf)
+		if err != nil {
+			return err
+		}
+	}
 
-	slog.Info("done scaling img", "img", ti.ImageId)
+	slog.Info("done scaling img", "img", ti.ImageId, "variants", len(variants))
 	return nil
 }
 
-func CropToSquare(img image.Image) image.Image {
-	bounds := img.Bounds()
-	width := bounds.Dx()
-	height := bounds.Dy()
-
-	var size int
-	if width < height {
-		size = width
-	} else {
-		size = height
+// Scales the image to the size of the variant, depending on the mode the
+// image is cropped first
+func ResizeImg(img image.Image, v config.ImageVariant) image.Image {
+	switch v.Mode {
+	case "fit":
+		bounds := img.Bounds()
+		width, height := v.Width, v.Height
+		if bounds.Dx()*v.Height > bounds.Dy()*v.Width {
+			height = max(1, bounds.Dy()*v.Width/bounds.Dx())
+		} else {
+			width = max(1, bounds.Dx()*v.Height/bounds.Dy())
+		}
+		// Scaling up only adds bytes
+		if width >= bounds.Dx() {
+			return img
+		}
+		return transform.Resize(img, width, height, transform.Lanczos)
+	case "smart-crop":
+		img = SmartCrop(img, v.Width, v.Height)
+	default:
+		img = CropCenter(img, v.Width, v.Height)
 	}
-
-	startX := (width - size) / 2
-	startY := (height - size) / 2
-
-	return transform.Crop(img, image.Rect(startX, startY, startX+size, startY+size))
+	return transform.Resize(img, v.Width, v.Height, transform.Lanczos)
 }
@@ -3,6 +3,7 @@ package worker
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"log/slog"
 	"sync"
@@ -17,6 +18,8 @@ import (
 
 type Workforce struct {
 	db      *database.Database
+	backup  config.Backup
+	images  config.Images
 	workers []*Worker
 	wg      sync.WaitGroup
 	tasks   chan *ent.Task
@@ -26,13 +29,15 @@ type Workforce struct {
 
 type Worker struct {
 	db     *database.Database
+	backup config.Backup
+	images config.Images
 	id     string
 	logger *slog.Logger
 	ctx    context.Context
 	cancel context.CancelFunc
 }
 
-func NewWorkforce(conf config.Workforce, db *database.Database) *Workforce {
+func NewWorkforce(conf config.Workforce, backup config.Backup, images config.Images, db *database.Database) *Workforce {
 	ctx, cancel := context.WithCancel(context.Background())
 	ws := []*Worker{}
 
@@ -45,6 +50,8 @@ func NewWorkforce(conf config.Workforce, db *database.Database) *Workforce {
 			ctx:    workerCtx,
 			cancel: workerCancel,
 			db:     db,
+			backup: backup,
+			images: images,
 			id:     name,
 		})
 	}
@@ -53,6 +60,8 @@ func NewWorkforce(conf config.Workforce, db *database.Database) *Workforce {
 		workers: ws,
 		tasks:   make(chan *ent.Task, 20),
 		db:      db,
+		backup:  backup,
+		images:  images,
 		wg:      sync.WaitGroup{},
 		ctx:     ctx,
 		cancel:  cancel,
@@ -78,6 +87,9 @@ func (wf *Workforce) Start() error {
 		w.Start(&wf.wg, wf.tasks)
 	}
 	go wf.Fetcher()
+	if wf.backup.Interval > 0 {
+		go wf.Scheduler()
+	}
 
 	return nil
 }
@@ -156,6 +168,62 @@ func (wf *Workforce) Fetcher() {
 	}
 }
 
+// Queues a backup task once the interval has passed since the last one. The
+// last task is looked up in the database, so restarts do not reset the clock.
+func (wf *Workforce) Scheduler() {
+	wf.wg.Add(1)
+	defer wf.wg.Done()
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+	for {
+		err := wf.scheduleBackup()
+		if err != nil {
+			slog.Warn("failed to schedule backup", "error", err)
+		}
+		select {
+		case <-wf.ctx.Done():
+			slog.Info("stopped scheduler")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (wf *Workforce) scheduleBackup() error {
+	last, err := wf.db.Client.Task.Query().
+		Where(task.TypeEQ(task.TypeBackup)).
+		Order(ent.Desc(task.FieldCreatedAt)).
+		First(wf.ctx)
+	if err != nil && !ent.IsNotFound(err) {
+		return err
+	}
+	if last != nil {
+		if last.Status == task.StatusPending || last.Status == task.StatusWorking {
+			return nil
+		}
+		if time.Since(last.CreatedAt) < wf.backup.Interval {
+			return nil
+		}
+	}
+
+	payload, err := json.Marshal(database.TaskBackup{
+		Prune:       true,
+		Incremental: wf.backup.Incremental,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = wf.db.Client.Task.Create().
+		SetType(task.TypeBackup).
+		SetPayload(payload).
+		Save(wf.ctx)
+	if err != nil {
+		return err
+	}
+	slog.Info("scheduled backup")
+	return nil
+}
+
 func (w *Worker) Start(wg *sync.WaitGroup, tasks chan *ent.Task) {
 	go func() {
 		defer wg.Done()
@@ -196,7 +264,19 @@ func (w *Worker) Stop() {
 func (w *Worker) proccesTask(t *ent.Task) error {
 	switch t.Type {
 	case task.TypeScaleImg:
-		err := ScaleImg(t, w.db, w.ctx)
+		err := ScaleImg(t, w.db, w.ctx, w.images)
+		if err != nil {
+			return err
+		}
+		return w.db.Client.Task.UpdateOne(t).SetStatus(task.StatusDone).Exec(w.ctx)
+	case task.TypeAnalyzeImg:
+		err := AnalyzeImg(t, w.db, w.ctx)
+		if err != nil {
+			return err
+		}
+		return w.db.Client.Task.UpdateOne(t).SetStatus(task.StatusDone).Exec(w.ctx)
+	case task.TypeBackup:
+		err := Backup(t, w.db, w.ctx, w.backup)
 		if err != nil {
 			return err
 		}
@@ -13,6 +13,7 @@ from concurrent.futures import ThreadPoolExecutor
 TAGS = ["hip-hop", "indie+rock", "drum+and+bass", "pop+punk", "electronic"]
 BASE_URL = "https://www.last.fm/"
 MAX_PAGE = 10
+# cvrs user token create -u <user> -n scrape -s artists:write,images:write
 TOKEN = os.getenv("CVRS_TOKEN")
 BACKEND_URL = "http://localhost:3000/api"
 ARTISTS_ADD_URL = BACKEND_URL + "/artists/add"